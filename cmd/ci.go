@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/nekoman-hq/neko-cli/pkg/ci"
+	"github.com/nekoman-hq/neko-cli/pkg/log"
+)
+
+// ciSystem is the CI system neko runs in, empty outside CI. Resolved by detectCI before the theme is applied.
+var ciSystem string
+
+// detectCI resolves the CI system. In CI neko never prompts unless --interactive is given, and
+// uses the plain theme if the CI log viewer does not render colors and no theme is chosen.
+func detectCI() {
+	ciSystem = ci.Detect(os.Getenv)
+	if ciSystem == "" || !verbose {
+		return
+	}
+
+	colors := "kept"
+	if ciDisablesColors() {
+		colors = "off"
+	}
+	_, _ = fmt.Fprintf(os.Stderr, "Detected CI environment: %s (prompts: %v, colors: %s, set %s=false to ignore)\n",
+		ciSystem, isInteractive(), colors, ci.Env)
+}
+
+// ciDisablesColors reports whether CI turns colors off, an explicit --theme or NEKO_THEME wins
+func ciDisablesColors() bool {
+	return ciSystem != "" && !ci.RendersColors(ciSystem) && themeName == "" && os.Getenv(log.ThemeEnv) == ""
+}
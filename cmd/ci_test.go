@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/nekoman-hq/neko-cli/pkg/ci"
+	"github.com/nekoman-hq/neko-cli/pkg/log"
+)
+
+// clearCIEnv hides the CI environment the tests themselves may run in
+func clearCIEnv(t *testing.T) {
+	t.Helper()
+	for _, key := range []string{"CI", "GITHUB_ACTIONS", "GITLAB_CI", ci.Env, log.ThemeEnv} {
+		t.Setenv(key, "")
+	}
+}
+
+func TestDetectCI(t *testing.T) {
+	tests := []struct {
+		name string
+		env  map[string]string
+		want string
+	}{
+		{"local", nil, ""},
+		{"github actions", map[string]string{"GITHUB_ACTIONS": "true"}, ci.GitHubActions},
+		{"ignored", map[string]string{"CI": "true", ci.Env: "false"}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clearCIEnv(t)
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+			withFlag(t, &ciSystem, "unset")
+			withFlag(t, &verbose, false)
+
+			detectCI()
+			if ciSystem != tt.want {
+				t.Errorf("ciSystem = %q, want %q", ciSystem, tt.want)
+			}
+		})
+	}
+}
+
+func TestCIDisablesColors(t *testing.T) {
+	tests := []struct {
+		name     string
+		system   string
+		theme    string
+		themeEnv string
+		want     bool
+	}{
+		{"local", "", "", "", false},
+		{"generic CI", ci.Generic, "", "", true},
+		{"github actions renders colors", ci.GitHubActions, "", "", false},
+		{"gitlab ci renders colors", ci.GitLabCI, "", "", false},
+		{"--theme wins", ci.Generic, "default", "", false},
+		{"NEKO_THEME wins", ci.Generic, "", "default", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(log.ThemeEnv, tt.themeEnv)
+			withFlag(t, &ciSystem, tt.system)
+			withFlag(t, &themeName, tt.theme)
+
+			if got := ciDisablesColors(); got != tt.want {
+				t.Errorf("ciDisablesColors() = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
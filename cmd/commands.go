@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"strings"
+	"time"
+
+	"github.com/nekoman-hq/neko-cli/pkg/plugin"
+	"github.com/nekoman-hq/neko-cli/pkg/renderer"
+	"github.com/nekoman-hq/neko-cli/pkg/version"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+var commandsCmd = &cobra.Command{
+	Use:   "commands",
+	Short: "List all CLI commands with their flags and arguments",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		resp := &plugin.Response{
+			Status: "success",
+			Metadata: plugin.ResponseMetadata{
+				Plugin:    "cli",
+				Version:   version.Version,
+				Command:   "commands",
+				Timestamp: time.Now(),
+			},
+			Data: map[string]any{
+				"items": collectCommands(rootCmd, renderer.OutputFormat(outputFormat) == renderer.FormatJSON),
+			},
+		}
+
+		opts := renderer.RenderOptions{
+			Format:   renderer.OutputFormat(outputFormat),
+			Template: outputTmpl,
+			Describe: describe,
+			Compact:  compactJSON,
+		}
+		return renderer.RenderWithOptions(resp, opts)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(commandsCmd)
+}
+
+// collectCommands walks the command tree below root and describes every available command.
+// The long help text is only included when withLong is set, as it breaks table layouts
+func collectCommands(root *cobra.Command, withLong bool) []map[string]any {
+	var items []map[string]any
+
+	for _, c := range root.Commands() {
+		if !c.IsAvailableCommand() {
+			continue
+		}
+
+		items = append(items, describeCommand(c, withLong))
+		items = append(items, collectCommands(c, withLong)...)
+	}
+
+	return items
+}
+
+// describeCommand builds the machine-readable description of a single command
+func describeCommand(c *cobra.Command, withLong bool) map[string]any {
+	flags := make([]any, 0)
+	c.NonInheritedFlags().VisitAll(func(f *pflag.Flag) {
+		if f.Hidden || f.Name == "help" {
+			return
+		}
+		flags = append(flags, map[string]any{
+			"name":      f.Name,
+			"shorthand": f.Shorthand,
+			"type":      f.Value.Type(),
+			"default":   f.DefValue,
+			"usage":     f.Usage,
+		})
+	})
+
+	args := make([]any, 0)
+	for _, a := range strings.Fields(c.Use)[1:] {
+		args = append(args, a)
+	}
+
+	item := map[string]any{
+		"name":  strings.TrimPrefix(c.CommandPath(), c.Root().Name()+" "),
+		"short": c.Short,
+		"args":  args,
+		"flags": flags,
+	}
+	if withLong {
+		item["long"] = c.Long
+	}
+
+	return item
+}
@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func testCommandTree() *cobra.Command {
+	root := &cobra.Command{Use: "neko"}
+	root.PersistentFlags().Bool("verbose", false, "Verbose output")
+
+	plugin := &cobra.Command{Use: "plugin", Short: "Manage plugins"}
+	install := &cobra.Command{Use: "install <name> [version]", Short: "Install a plugin", Long: "Installs a plugin.", Run: func(*cobra.Command, []string) {}}
+	install.Flags().StringP("registry", "r", "", "Registry URL")
+	install.Flags().Bool("secret", false, "Hidden flag")
+	_ = install.Flags().MarkHidden("secret")
+	hidden := &cobra.Command{Use: "debug", Hidden: true, Run: func(*cobra.Command, []string) {}}
+
+	plugin.AddCommand(install, hidden)
+	root.AddCommand(plugin)
+	return root
+}
+
+func TestCollectCommands(t *testing.T) {
+	items := collectCommands(testCommandTree(), false)
+
+	var names []string
+	for _, item := range items {
+		names = append(names, item["name"].(string))
+	}
+	if want := []string{"plugin", "plugin install"}; !slices.Equal(names, want) {
+		t.Fatalf("commands = %v, want %v without hidden commands", names, want)
+	}
+
+	install := items[1]
+	if args := install["args"].([]any); len(args) != 2 || args[0] != "<name>" || args[1] != "[version]" {
+		t.Errorf("args = %v, want <name> [version]", args)
+	}
+	flags := install["flags"].([]any)
+	if len(flags) != 1 {
+		t.Fatalf("flags = %v, want only --registry without hidden, help and inherited flags", flags)
+	}
+	flag := flags[0].(map[string]any)
+	if flag["name"] != "registry" || flag["shorthand"] != "r" || flag["type"] != "string" {
+		t.Errorf("flag = %v", flag)
+	}
+	if _, ok := install["long"]; ok {
+		t.Error("long help included for table output")
+	}
+}
+
+func TestCollectCommandsWithLong(t *testing.T) {
+	items := collectCommands(testCommandTree(), true)
+	if got := items[1]["long"]; got != "Installs a plugin." {
+		t.Errorf("long = %q", got)
+	}
+	if args := items[0]["args"].([]any); len(args) != 0 {
+		t.Errorf("args of a command without arguments = %v, want an empty list", args)
+	}
+}
@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"errors"
+	"time"
+
+	"github.com/nekoman-hq/neko-cli/pkg/config"
+	"github.com/nekoman-hq/neko-cli/pkg/plugin"
+	"github.com/nekoman-hq/neko-cli/pkg/renderer"
+	"github.com/nekoman-hq/neko-cli/pkg/version"
+	config2 "github.com/nekoman-hq/neko-cli/plugin/release/pkg/config"
+	"github.com/nekoman-hq/neko-cli/plugin/release/pkg/release"
+	"github.com/spf13/cobra"
+)
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Show the environment fingerprint and check the requirements of the configured release system",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		fp := version.GatherFingerprint(version.DefaultProbe)
+		checks := releaseChecks()
+
+		resp := &plugin.Response{
+			Status: "success",
+			Metadata: plugin.ResponseMetadata{
+				Plugin:    "cli",
+				Version:   version.Version,
+				Command:   "doctor",
+				Timestamp: time.Now(),
+			},
+			Data: map[string]any{
+				"sections": []map[string]any{
+					{"title": "Environment", "items": fingerprintItems(fp), "column_order": []string{"name", "version", "path"}},
+					{"title": "Release system", "items": checks, "column_order": []string{"system", "status", "detail"}},
+				},
+				"fingerprint": fp,
+				"checks":      checks,
+			},
+			RendererHint: "table",
+		}
+
+		opts := renderer.RenderOptions{
+			Format:   renderer.OutputFormat(outputFormat),
+			Template: outputTmpl,
+			Describe: describe,
+			Compact:  compactJSON,
+		}
+		return renderer.RenderWithOptions(resp, opts)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(doctorCmd)
+}
+
+// fingerprintItems flattens the fingerprint into table rows
+func fingerprintItems(fp version.Fingerprint) []map[string]any {
+	items := []map[string]any{
+		{"name": "neko-cli", "version": fp.CLIVersion + " (" + fp.Commit + ")", "path": fp.Executable},
+		{"name": "os/arch", "version": fp.OS + "/" + fp.Arch, "path": ""},
+		{"name": "github-token", "version": fp.Token, "path": ""},
+	}
+
+	for _, t := range fp.Tools {
+		v := t.Version
+		if !t.Found {
+			v = "not found"
+		}
+		items = append(items, map[string]any{
+			"name":    t.Name,
+			"version": v,
+			"path":    t.Path,
+		})
+	}
+	return items
+}
+
+// releaseChecks validates the release systems configured in the current directory as checklist rows
+func releaseChecks() []map[string]any {
+	project, err := config.LoadProject()
+	if errors.Is(err, config.ErrNoProject) {
+		return []map[string]any{
+			{"system": "-", "status": "skipped", "detail": config.FileName + " not found, run 'neko release init' first"},
+		}
+	}
+	if err != nil {
+		return []map[string]any{
+			{"system": "-", "status": release.CheckFailed, "detail": err.Error()},
+		}
+	}
+
+	items := make([]map[string]any, 0)
+	if config.LegacyExists() {
+		items = append(items, map[string]any{
+			"system": "-",
+			"status": release.CheckFailed,
+			"detail": "old " + config.LegacyFileName + " found, run 'neko release migrate-config' to move it into " + config.FileName,
+		})
+	}
+	if project.Legacy() {
+		return items
+	}
+
+	cfg, err := config2.LoadConfig()
+	if err != nil {
+		return append(items, map[string]any{"system": "-", "status": release.CheckFailed, "detail": err.Error()})
+	}
+
+	for _, c := range release.CheckTools(cfg) {
+		items = append(items, map[string]any{
+			"system": c.System,
+			"status": c.Status,
+			"detail": c.Detail,
+		})
+	}
+	return items
+}
@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/nekoman-hq/neko-cli/pkg/config"
+	config2 "github.com/nekoman-hq/neko-cli/plugin/release/pkg/config"
+	"github.com/nekoman-hq/neko-cli/plugin/release/pkg/release"
+)
+
+const goreleaserConfig = `{"project-type": "backend", "release-system": "goreleaser", "version": "1.0.0"}`
+
+func TestReleaseChecks(t *testing.T) {
+	tests := []struct {
+		name       string
+		config     string
+		legacy     string
+		wantSystem string
+		wantStatus string
+	}{
+		{"no config", "", "", "-", "skipped"},
+		{"invalid config", "{", "", "-", release.CheckFailed},
+		{"configured system", goreleaserConfig, "", "goreleaser", ""},
+		{"legacy config", "", goreleaserConfig, "-", release.CheckFailed},
+		{"legacy config left over", goreleaserConfig, goreleaserConfig, "-", release.CheckFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Chdir(t.TempDir())
+			for name, content := range map[string]string{config2.FileName: tt.config, config.LegacyFileName: tt.legacy} {
+				if content == "" {
+					continue
+				}
+				if err := os.WriteFile(name, []byte(content), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			checks := releaseChecks()
+			if len(checks) == 0 || checks[0]["system"] != tt.wantSystem {
+				t.Fatalf("releaseChecks = %v, want a row for %s", checks, tt.wantSystem)
+			}
+			if tt.wantStatus != "" && checks[0]["status"] != tt.wantStatus {
+				t.Errorf("status = %v, want %s", checks[0]["status"], tt.wantStatus)
+			}
+			if tt.legacy != "" && !strings.Contains(checks[0]["detail"].(string), "migrate-config") {
+				t.Errorf("detail = %v, want the migrate-config hint", checks[0]["detail"])
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+)
+
+var (
+	interactive    bool
+	nonInteractive bool
+)
+
+// isInteractive reports whether neko may prompt the user, see resolveInteractive.
+// In CI a terminal is not trusted, e.g. runners allocating a pseudo-TTY nobody answers.
+func isInteractive() bool {
+	return resolveInteractive(interactive, nonInteractive || assumeYes, terminalAttached() && ciSystem == "")
+}
+
+// resolveInteractive applies the overrides to the detected terminal.
+// Turning prompts off wins, since --yes and --non-interactive must never block a CI run.
+func resolveInteractive(forceOn, forceOff, detected bool) bool {
+	if forceOff {
+		return false
+	}
+	if forceOn {
+		return true
+	}
+	return detected
+}
+
+// terminalAttached reports whether stdin and stderr are terminals, prompts are written to stderr
+func terminalAttached() bool {
+	for _, f := range []*os.File{os.Stdin, os.Stderr} {
+		info, err := f.Stat()
+		if err != nil || info.Mode()&os.ModeCharDevice == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// confirm asks a yes/no question on stderr, anything but y or yes declines
+func confirm(question string) bool {
+	_, _ = fmt.Fprintf(os.Stderr, "%s [y/N] ", question)
+	answer, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && answer == "" {
+		return false
+	}
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return true
+	default:
+		return false
+	}
+}
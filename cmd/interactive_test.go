@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"os"
+	"testing"
+)
+
+func TestResolveInteractive(t *testing.T) {
+	tests := []struct {
+		name               string
+		forceOn, forceOff  bool
+		detected, expected bool
+	}{
+		{"terminal", false, false, true, true},
+		{"no terminal", false, false, false, false},
+		{"--interactive without terminal", true, false, false, true},
+		{"--non-interactive on a terminal", false, true, true, false},
+		{"turning prompts off wins", true, true, true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := resolveInteractive(tt.forceOn, tt.forceOff, tt.detected); got != tt.expected {
+				t.Errorf("resolveInteractive(%t, %t, %t) = %t, want %t", tt.forceOn, tt.forceOff, tt.detected, got, tt.expected)
+			}
+		})
+	}
+}
+
+// Tests run without a terminal, so only --interactive turns prompts on
+func TestIsInteractive(t *testing.T) {
+	tests := []struct {
+		name           string
+		interactive    bool
+		nonInteractive bool
+		yes            bool
+		ci             string
+		want           bool
+	}{
+		{name: "no terminal", want: false},
+		{name: "--interactive", interactive: true, want: true},
+		{name: "--interactive in CI", interactive: true, ci: "github-actions", want: true},
+		{name: "--yes wins over --interactive", interactive: true, yes: true, want: false},
+		{name: "--non-interactive", nonInteractive: true, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			withFlag(t, &interactive, tt.interactive)
+			withFlag(t, &nonInteractive, tt.nonInteractive)
+			withFlag(t, &assumeYes, tt.yes)
+			withFlag(t, &ciSystem, tt.ci)
+
+			if got := isInteractive(); got != tt.want {
+				t.Errorf("isInteractive() = %t, want %t", got, tt.want)
+			}
+			if got := requestContext().Interactive; got != tt.want {
+				t.Errorf("Context.Interactive = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConfirm(t *testing.T) {
+	tests := []struct {
+		answer string
+		want   bool
+	}{
+		{"y\n", true},
+		{"YES\n", true},
+		{" yes \n", true},
+		{"yes", true},
+		{"n\n", false},
+		{"\n", false},
+		{"yep\n", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.answer, func(t *testing.T) {
+			r, w, err := os.Pipe()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := w.WriteString(tt.answer); err != nil {
+				t.Fatal(err)
+			}
+			_ = w.Close()
+			withFlag(t, &os.Stdin, r)
+
+			if got := confirm("Proceed?"); got != tt.want {
+				t.Errorf("confirm with answer %q = %t, want %t", tt.answer, got, tt.want)
+			}
+		})
+	}
+}
@@ -3,17 +3,24 @@ package cmd
 import (
 	"archive/tar"
 	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"os"
 	"path/filepath"
 	"runtime"
 	"strings"
+	"sync"
 
 	"github.com/nekoman-hq/neko-cli/pkg/dispatcher"
+	"github.com/nekoman-hq/neko-cli/pkg/errors"
 	"github.com/nekoman-hq/neko-cli/pkg/plugin"
+	"github.com/nekoman-hq/neko-cli/pkg/renderer"
+	"github.com/nekoman-hq/neko-cli/plugin/release/pkg/git/github"
 	"github.com/spf13/cobra"
 	"golang.org/x/text/cases"
 	"golang.org/x/text/language"
@@ -21,11 +28,22 @@ import (
 
 const (
 	defaultPluginRegistry = "https://api.github.com/repos/nekoman-hq/neko-cli/releases"
+
+	// maxParallelInstalls bounds the number of concurrent plugin downloads
+	maxParallelInstalls = 4
 )
 
 var pluginCmd = &cobra.Command{
 	Use:   "plugin",
 	Short: "Manage neko plugins",
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		registry, err := resolveRegistry(registryFlag, os.Getenv("NEKO_PLUGIN_REGISTRY"))
+		if err != nil {
+			return err
+		}
+		pluginRegistry = registry
+		return nil
+	},
 }
 
 var pluginListCmd = &cobra.Command{
@@ -41,9 +59,9 @@ var pluginAvailableCmd = &cobra.Command{
 }
 
 var pluginInstallCmd = &cobra.Command{
-	Use:   "install [plugin-name]",
-	Short: "Install a plugin from the registry",
-	Args:  cobra.ExactArgs(1),
+	Use:   "install [plugin-name...]",
+	Short: "Install one or more plugins from the registry",
+	Args:  cobra.MinimumNArgs(1),
 	RunE:  runPluginInstall,
 }
 
@@ -56,6 +74,11 @@ var pluginUninstallCmd = &cobra.Command{
 
 var (
 	installVersion string
+	registryFlag   string
+	pruneConfig    bool
+
+	// pluginRegistry is the releases API all registry calls are made against
+	pluginRegistry = defaultPluginRegistry
 )
 
 func init() {
@@ -66,12 +89,33 @@ func init() {
 	pluginCmd.AddCommand(pluginUninstallCmd)
 
 	pluginInstallCmd.Flags().StringVar(&installVersion, "version", "latest", "Version to install")
+	pluginUninstallCmd.Flags().BoolVar(&pruneConfig, "prune", false, "Also remove the config files the plugin wrote into the current project (requires --yes)")
+	pluginCmd.PersistentFlags().StringVar(&registryFlag, "registry", "", "Plugin registry releases API URL (overrides NEKO_PLUGIN_REGISTRY)")
+}
+
+// resolveRegistry returns the registry URL to use, preferring the flag over the env variable.
+// Falls back to the official registry if neither is set.
+func resolveRegistry(flagValue, envValue string) (string, error) {
+	registry := flagValue
+	if registry == "" {
+		registry = envValue
+	}
+	if registry == "" {
+		return defaultPluginRegistry, nil
+	}
+
+	u, err := url.Parse(registry)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return "", fmt.Errorf("invalid plugin registry URL '%s': must be an absolute http(s) URL", registry)
+	}
+
+	return strings.TrimSuffix(registry, "/"), nil
 }
 
 func runPluginList(cmd *cobra.Command, args []string) error {
 	d := dispatcher.NewDispatcher(pluginDir)
 
-	manifests, err := d.ListPlugins()
+	manifests, warnings, err := d.ScanPlugins()
 	if err != nil {
 		if os.IsNotExist(err) {
 			fmt.Println("No plugins installed.")
@@ -81,7 +125,7 @@ func runPluginList(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to list plugins: %w", err)
 	}
 
-	if len(manifests) == 0 {
+	if len(manifests) == 0 && len(warnings) == 0 {
 		fmt.Println("No plugins installed.")
 		fmt.Println("Use 'neko plugin available' to see available plugins.")
 		return nil
@@ -91,6 +135,14 @@ func runPluginList(cmd *cobra.Command, args []string) error {
 	for _, m := range manifests {
 		fmt.Printf("%-15s %-10s %-40s %s\n", m.Name, m.Version, truncate(m.Description, 40), m.Author)
 	}
+	for _, w := range warnings {
+		fmt.Printf("%-15s %-10s %-40s %s\n", w.Plugin, "-", "("+w.Reason()+")", "-")
+	}
+
+	for _, w := range warnings {
+		errors.Warning("Skipping plugin "+w.Plugin, fmt.Sprintf("%s. Reinstall it with 'neko plugin install %s'",
+			w.Err.Error(), w.Plugin))
+	}
 
 	return nil
 }
@@ -132,11 +184,7 @@ func runPluginAvailable(cmd *cobra.Command, args []string) error {
 }
 
 func runPluginInstall(cmd *cobra.Command, args []string) error {
-	pluginName := args[0]
-
-	fmt.Printf("Installing plugin '%s'...\n", pluginName)
-
-	// Determine version to install
+	// Determine version to install, resolved once for all plugins
 	version := installVersion
 	if version == "latest" {
 		latestVersion, err := getLatestVersion()
@@ -146,6 +194,67 @@ func runPluginInstall(cmd *cobra.Command, args []string) error {
 		version = latestVersion
 	}
 
+	results := installPlugins(args, version, installPlugin)
+
+	fmt.Println()
+	fmt.Printf("%-15s %-10s %s\n", "NAME", "STATUS", "ERROR")
+	for _, r := range results {
+		status, errMsg := "installed", ""
+		if r.Err != nil {
+			status, errMsg = "failed", r.Err.Error()
+		}
+		fmt.Printf("%-15s %-10s %s\n", r.Name, status, errMsg)
+	}
+
+	if failed := countFailedInstalls(results); failed > 0 {
+		return fmt.Errorf("%d of %d plugins failed to install", failed, len(results))
+	}
+	return nil
+}
+
+// installResult holds the outcome of a single plugin installation
+type installResult struct {
+	Name string
+	Err  error
+}
+
+// installPlugins installs all plugins concurrently (bounded by maxParallelInstalls).
+// Individual failures do not abort the batch; results keep the order of names.
+func installPlugins(names []string, version string, install func(name, version string) error) []installResult {
+	results := make([]installResult, len(names))
+	sem := make(chan struct{}, maxParallelInstalls)
+
+	var wg sync.WaitGroup
+	for i, name := range names {
+		wg.Add(1)
+		go func(i int, name string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			results[i] = installResult{Name: name, Err: install(name, version)}
+		}(i, name)
+	}
+	wg.Wait()
+
+	return results
+}
+
+// countFailedInstalls returns the number of failed installations
+func countFailedInstalls(results []installResult) int {
+	failed := 0
+	for _, r := range results {
+		if r.Err != nil {
+			failed++
+		}
+	}
+	return failed
+}
+
+// installPlugin downloads and installs a single plugin in the given version
+func installPlugin(pluginName, version string) error {
+	fmt.Printf("Installing plugin '%s'...\n", pluginName)
+
 	// Build download URL
 	downloadURL, err := getPluginDownloadURL(pluginName, version)
 	if err != nil {
@@ -153,10 +262,21 @@ func runPluginInstall(cmd *cobra.Command, args []string) error {
 	}
 
 	// Download and extract
-	if err := downloadAndInstallPlugin(pluginName, downloadURL); err != nil {
+	archiveSum, binarySum, err := downloadAndInstallPlugin(pluginName, downloadURL)
+	if err != nil {
 		return fmt.Errorf("failed to install plugin: %w", err)
 	}
 
+	record := installRecord{
+		Version:       version,
+		Asset:         pluginAssetName(pluginName),
+		ArchiveSHA256: archiveSum,
+		BinarySHA256:  binarySum,
+	}
+	if err := writeInstallRecord(pluginName, record); err != nil {
+		return fmt.Errorf("failed to record installation: %w", err)
+	}
+
 	fmt.Printf("Plugin '%s' installed successfully!\n", pluginName)
 	return nil
 }
@@ -169,14 +289,64 @@ func runPluginUninstall(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("plugin '%s' is not installed", pluginName)
 	}
 
+	// The manifest is gone after the uninstall, so the files to prune are resolved first
+	var prune []string
+	if pruneConfig {
+		files, err := pluginConfigFiles(pluginName)
+		if err != nil {
+			return err
+		}
+		if len(files) > 0 && !assumeYes {
+			plan := plugin.Plan{
+				Summary:  fmt.Sprintf("Uninstall plugin '%s' and remove its config files", pluginName),
+				Files:    files,
+				Commands: []string{"rm -r " + installPath},
+			}
+			if err := renderer.RenderPlan(plan); err != nil {
+				return err
+			}
+			if !isInteractive() || !confirm("Proceed?") {
+				return fmt.Errorf("--prune deletes %d file(s) from the current project, re-run with --yes to confirm", len(files))
+			}
+		}
+		prune = files
+	}
+
 	if err := os.RemoveAll(installPath); err != nil {
 		return fmt.Errorf("failed to uninstall plugin: %w", err)
 	}
 
 	fmt.Printf("Plugin '%s' uninstalled successfully!\n", pluginName)
+
+	for _, file := range prune {
+		if err := os.Remove(file); err != nil {
+			return fmt.Errorf("failed to remove %s: %w", file, err)
+		}
+		fmt.Printf("Removed %s\n", file)
+	}
 	return nil
 }
 
+// pluginConfigFiles returns the config files declared in the plugin manifest that exist in the current project.
+// Declared paths must stay inside the project, a manifest must not be able to delete arbitrary files.
+func pluginConfigFiles(pluginName string) ([]string, error) {
+	manifest, err := GetInstalledPluginManifest(pluginName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest of plugin '%s': %w", pluginName, err)
+	}
+
+	files := make([]string, 0, len(manifest.ConfigFiles))
+	for _, file := range manifest.ConfigFiles {
+		if !filepath.IsLocal(file) {
+			return nil, fmt.Errorf("plugin '%s' declares config file %s outside of the project", pluginName, file)
+		}
+		if info, err := os.Stat(file); err == nil && !info.IsDir() {
+			files = append(files, file)
+		}
+	}
+	return files, nil
+}
+
 // AvailablePlugin represents a plugin available in the registry
 type AvailablePlugin struct {
 	Name    string `json:"name"`
@@ -190,7 +360,7 @@ func fetchAvailablePlugins() ([]AvailablePlugin, error) {
 	}
 
 	// Get release assets
-	url := fmt.Sprintf("%s/tags/%s", defaultPluginRegistry, latestVersion)
+	url := fmt.Sprintf("%s/tags/%s", pluginRegistry, latestVersion)
 
 	resp, err := httpGetWithAuth(url)
 	if err != nil {
@@ -239,7 +409,7 @@ func fetchAvailablePlugins() ([]AvailablePlugin, error) {
 }
 
 func getLatestVersion() (string, error) {
-	url := fmt.Sprintf("%s/latest", defaultPluginRegistry)
+	url := fmt.Sprintf("%s/latest", pluginRegistry)
 
 	resp, err := httpGetWithAuth(url)
 	if err != nil {
@@ -264,8 +434,14 @@ func getLatestVersion() (string, error) {
 	return release.TagName, nil
 }
 
-func getPluginDownloadURL(pluginName, version string) (string, error) {
-	osName := runtime.GOOS
+// pluginAssetName returns the release asset of the plugin for the current platform
+func pluginAssetName(pluginName string) string {
+	osName, archName := assetPlatform()
+	return fmt.Sprintf("plugin-%s_%s_%s.tar.gz", pluginName, osName, archName)
+}
+
+// assetPlatform returns the OS and arch names as used in the goreleaser asset names
+func assetPlatform() (string, string) {
 	arch := runtime.GOARCH
 
 	// Map arch names to match goreleaser output
@@ -276,11 +452,14 @@ func getPluginDownloadURL(pluginName, version string) (string, error) {
 
 	// Capitalize OS name
 	caser := cases.Title(language.English)
-	osName = caser.String(osName)
+	return caser.String(runtime.GOOS), archName
+}
 
-	assetName := fmt.Sprintf("plugin-%s_%s_%s.tar.gz", pluginName, osName, archName)
+func getPluginDownloadURL(pluginName, version string) (string, error) {
+	osName, archName := assetPlatform()
+	assetName := pluginAssetName(pluginName)
 
-	url := fmt.Sprintf("%s/tags/%s", defaultPluginRegistry, version)
+	url := fmt.Sprintf("%s/tags/%s", pluginRegistry, version)
 	resp, err := httpGetWithAuth(url)
 	if err != nil {
 		return "", err
@@ -310,40 +489,54 @@ func getPluginDownloadURL(pluginName, version string) (string, error) {
 	return "", fmt.Errorf("plugin '%s' not found for %s/%s in version %s", pluginName, osName, archName, version)
 }
 
-func downloadAndInstallPlugin(pluginName, downloadURL string) error {
-	resp, err := httpGetWithAuth(downloadURL)
+// downloadAndInstallPlugin downloads the plugin archive and extracts it into the plugin dir.
+// Returns the SHA-256 checksums of the archive and the plugin binary.
+func downloadAndInstallPlugin(pluginName, downloadURL string) (string, string, error) {
+	archive, err := downloadPluginArchive(pluginName, downloadURL)
 	if err != nil {
-		return err
+		return "", "", err
 	}
-	defer func(Body io.ReadCloser) {
-		_ = Body.Close()
-	}(resp.Body)
 
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("failed to download plugin: %s", resp.Status)
+	archiveSum, binarySum, err := extractPlugin(pluginName, archive)
+	// A corrupt archive must not be resumed, a retry downloads it again
+	_ = os.Remove(archive)
+	return archiveSum, binarySum, err
+}
+
+// extractPlugin extracts the downloaded archive into the plugin dir
+func extractPlugin(pluginName, archive string) (string, string, error) {
+	f, err := os.Open(archive)
+	if err != nil {
+		return "", "", err
 	}
+	defer func(f *os.File) {
+		_ = f.Close()
+	}(f)
 
 	// Remove existing plugin directory if it exists
 	installPath := filepath.Join(pluginDir, pluginName)
 	if err = os.RemoveAll(installPath); err != nil {
-		return fmt.Errorf("failed to remove existing plugin: %w", err)
+		return "", "", fmt.Errorf("failed to remove existing plugin: %w", err)
 	}
 
 	// Create plugin directory
 	if err = os.MkdirAll(installPath, 0755); err != nil {
-		return err
+		return "", "", err
 	}
 
-	// Extract tar.gz
-	gzr, err := gzip.NewReader(resp.Body)
+	// Extract tar.gz while hashing the archive
+	archiveHash := sha256.New()
+	gzr, err := gzip.NewReader(io.TeeReader(f, archiveHash))
 	if err != nil {
-		return err
+		return "", "", err
 	}
 	defer func(gzr *gzip.Reader) {
 		_ = gzr.Close()
 	}(gzr)
 
 	tr := tar.NewReader(gzr)
+	binaryHash := sha256.New()
+	binaryName := fmt.Sprintf("plugin-%s", pluginName)
 
 	for {
 		header, err := tr.Next()
@@ -351,7 +544,7 @@ func downloadAndInstallPlugin(pluginName, downloadURL string) error {
 			break
 		}
 		if err != nil {
-			return err
+			return "", "", err
 		}
 
 		// Skip empty names or current directory entries
@@ -372,38 +565,83 @@ func downloadAndInstallPlugin(pluginName, downloadURL string) error {
 		case tar.TypeReg:
 			// Ensure parent directory exists
 			if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
-				return err
+				return "", "", err
 			}
 			f, err := os.OpenFile(target, os.O_CREATE|os.O_RDWR|os.O_TRUNC, os.FileMode(header.Mode))
 			if err != nil {
-				return fmt.Errorf("failed to create file %s: %w", target, err)
+				return "", "", fmt.Errorf("failed to create file %s: %w", target, err)
 			}
-			if _, err = io.Copy(f, tr); err != nil {
+			var w io.Writer = f
+			if baseName == binaryName {
+				w = io.MultiWriter(f, binaryHash)
+			}
+			if _, err = io.Copy(w, tr); err != nil {
 				_ = f.Close()
-				return err
+				return "", "", err
 			}
 			if err = f.Close(); err != nil {
-				return err
+				return "", "", err
 			}
 		}
 	}
 
-	return nil
+	// Drain the trailing archive bytes so the checksum covers the whole file
+	if _, err := io.Copy(io.Discard, gzr); err != nil {
+		return "", "", err
+	}
+	if _, err := io.Copy(archiveHash, f); err != nil {
+		return "", "", err
+	}
+
+	return hex.EncodeToString(archiveHash.Sum(nil)), hex.EncodeToString(binaryHash.Sum(nil)), nil
 }
 
 func httpGetWithAuth(url string) (*http.Response, error) {
+	req, err := newAuthRequest(url)
+	if err != nil {
+		return nil, err
+	}
+	return doRequest(req)
+}
+
+// newAuthRequest creates a GET request carrying the GitHub token if available (for private repos)
+func newAuthRequest(url string) (*http.Request, error) {
 	req, err := http.NewRequest("GET", url, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	// Add GitHub token if available (for private repos)
 	if token := os.Getenv("GITHUB_TOKEN"); token != "" {
 		req.Header.Set("Authorization", "token "+token)
 	}
 	req.Header.Set("Accept", "application/vnd.github.v3+json")
+	return req, nil
+}
 
-	return http.DefaultClient.Do(req)
+// doRequest sends the request and reports the GitHub rate limit of the response
+func doRequest(req *http.Request) (*http.Response, error) {
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	reportRateLimit(resp)
+	return resp, nil
+}
+
+// reportRateLimit prints the GitHub API rate limit in verbose mode and warns when it runs low
+func reportRateLimit(resp *http.Response) {
+	rl, ok := github.ParseRateLimit(resp.Header)
+	if !ok {
+		return
+	}
+
+	if rl.Low() {
+		_, _ = fmt.Fprintf(os.Stderr, "Warning: GitHub API rate limit is running low: %s\n", rl)
+		return
+	}
+	if verbose {
+		_, _ = fmt.Fprintf(os.Stderr, "GitHub API rate limit: %s\n", rl)
+	}
 }
 
 func truncate(s string, maxLen int) string {
@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// partSuffix marks an unfinished plugin download that is resumed by the next install
+const partSuffix = ".part"
+
+// downloadPluginArchive downloads the plugin archive into the plugin dir and returns its path.
+// The archive is written to a .part file first; if a previous download was interrupted,
+// the remaining bytes are requested with a Range header instead of starting over.
+func downloadPluginArchive(pluginName, downloadURL string) (string, error) {
+	if err := os.MkdirAll(pluginDir, 0755); err != nil {
+		return "", err
+	}
+
+	// The URL contains the version, so a stale part of another version is never resumed
+	urlHash := sha256.Sum256([]byte(downloadURL))
+	archive := filepath.Join(pluginDir, fmt.Sprintf("%s-%s.tar.gz", pluginName, hex.EncodeToString(urlHash[:6])))
+	part := archive + partSuffix
+
+	if err := resumeDownload(downloadURL, part); err != nil {
+		return "", err
+	}
+	if err := os.Rename(part, archive); err != nil {
+		return "", err
+	}
+	return archive, nil
+}
+
+// resumeDownload appends the missing bytes of the download to part and verifies the final size
+func resumeDownload(downloadURL, part string) error {
+	var offset int64
+	if info, err := os.Stat(part); err == nil {
+		offset = info.Size()
+	}
+
+	req, err := newAuthRequest(downloadURL)
+	if err != nil {
+		return err
+	}
+	if offset > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	}
+
+	resp, err := doRequest(req)
+	if err != nil {
+		return err
+	}
+	defer func(Body io.ReadCloser) {
+		_ = Body.Close()
+	}(resp.Body)
+
+	flags := os.O_CREATE | os.O_WRONLY
+	var total int64
+	switch resp.StatusCode {
+	case http.StatusPartialContent:
+		start, size, err := parseContentRange(resp.Header.Get("Content-Range"))
+		if err != nil || start != offset {
+			_ = os.Remove(part)
+			return fmt.Errorf("failed to resume download, run the install again to start over: unexpected Content-Range %q",
+				resp.Header.Get("Content-Range"))
+		}
+		flags |= os.O_APPEND
+		total = size
+		fmt.Printf("Resuming download at %d of %d bytes\n", offset, total)
+	case http.StatusOK:
+		// The server ignored the Range header, the download starts over
+		flags |= os.O_TRUNC
+		total = resp.ContentLength
+	case http.StatusRequestedRangeNotSatisfiable:
+		// The part is as large as or larger than the archive, it cannot be trusted
+		_ = os.Remove(part)
+		return resumeDownload(downloadURL, part)
+	default:
+		return fmt.Errorf("failed to download plugin: %s", resp.Status)
+	}
+
+	f, err := os.OpenFile(part, flags, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, resp.Body); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("download interrupted, run the install again to resume: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	info, err := os.Stat(part)
+	if err != nil {
+		return err
+	}
+	if total >= 0 && info.Size() != total {
+		if info.Size() > total {
+			_ = os.Remove(part)
+		}
+		return fmt.Errorf("download incomplete: got %d of %d bytes, run the install again to resume", info.Size(), total)
+	}
+	return nil
+}
+
+// parseContentRange parses a "bytes <start>-<end>/<size>" header.
+// An unknown size "*" is returned as -1.
+func parseContentRange(header string) (start, size int64, err error) {
+	spec, ok := strings.CutPrefix(header, "bytes ")
+	if !ok {
+		return 0, 0, fmt.Errorf("invalid Content-Range %q", header)
+	}
+	rng, total, ok := strings.Cut(spec, "/")
+	if !ok {
+		return 0, 0, fmt.Errorf("invalid Content-Range %q", header)
+	}
+	first, _, ok := strings.Cut(rng, "-")
+	if !ok {
+		return 0, 0, fmt.Errorf("invalid Content-Range %q", header)
+	}
+
+	if start, err = strconv.ParseInt(first, 10, 64); err != nil {
+		return 0, 0, fmt.Errorf("invalid Content-Range %q", header)
+	}
+	if total == "*" {
+		return start, -1, nil
+	}
+	if size, err = strconv.ParseInt(total, 10, 64); err != nil {
+		return 0, 0, fmt.Errorf("invalid Content-Range %q", header)
+	}
+	return start, size, nil
+}
@@ -0,0 +1,190 @@
+package cmd
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// archiveContent stands in for a plugin archive, large enough to be cut in half
+var archiveContent = bytes.Repeat([]byte("neko-plugin-archive\n"), 512)
+
+func TestParseContentRange(t *testing.T) {
+	tests := []struct {
+		header    string
+		wantStart int64
+		wantSize  int64
+		wantErr   bool
+	}{
+		{"bytes 100-199/200", 100, 200, false},
+		{"bytes 0-99/*", 0, -1, false},
+		{"bytes */200", 0, 0, true},
+		{"items 0-99/200", 0, 0, true},
+		{"bytes 0-99", 0, 0, true},
+		{"bytes a-99/200", 0, 0, true},
+		{"bytes 0-99/many", 0, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			start, size, err := parseContentRange(tt.header)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseContentRange error = %v, want error %t", err, tt.wantErr)
+			}
+			if start != tt.wantStart || size != tt.wantSize {
+				t.Errorf("parseContentRange = %d, %d, want %d, %d", start, size, tt.wantStart, tt.wantSize)
+			}
+		})
+	}
+}
+
+// serveArchive serves archiveContent with Range support and records the requested ranges
+func serveArchive(t *testing.T, handler http.HandlerFunc) (url string, ranges *[]string) {
+	t.Helper()
+	ranges = new([]string)
+	if handler == nil {
+		handler = func(w http.ResponseWriter, r *http.Request) {
+			http.ServeContent(w, r, "plugin.tar.gz", time.Time{}, bytes.NewReader(archiveContent))
+		}
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*ranges = append(*ranges, r.Header.Get("Range"))
+		handler(w, r)
+	}))
+	t.Cleanup(srv.Close)
+	return srv.URL + "/neko-release.tar.gz", ranges
+}
+
+func TestResumeDownload(t *testing.T) {
+	half := len(archiveContent) / 2
+	tests := []struct {
+		name       string
+		part       []byte
+		handler    http.HandlerFunc
+		wantRanges []string
+		wantErr    string
+		wantPart   bool
+	}{
+		{name: "fresh download", wantRanges: []string{""}},
+		{name: "resume", part: archiveContent[:half], wantRanges: []string{"bytes=5120-"}},
+		{
+			name: "range ignored",
+			part: []byte("stale"),
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write(archiveContent)
+			},
+			wantRanges: []string{"bytes=5-"},
+		},
+		{
+			name:       "part larger than the archive",
+			part:       append(bytes.Clone(archiveContent), "garbage"...),
+			wantRanges: []string{"bytes=10247-", ""},
+		},
+		{
+			name: "unexpected content range",
+			part: archiveContent[:half],
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Range", "bytes 0-10239/10240")
+				w.WriteHeader(http.StatusPartialContent)
+				_, _ = w.Write(archiveContent)
+			},
+			wantRanges: []string{"bytes=5120-"},
+			wantErr:    "unexpected Content-Range",
+		},
+		{
+			name: "server error keeps the part",
+			part: archiveContent[:half],
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				http.Error(w, "boom", http.StatusInternalServerError)
+			},
+			wantRanges: []string{"bytes=5120-"},
+			wantErr:    "500",
+			wantPart:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			url, ranges := serveArchive(t, tt.handler)
+			part := filepath.Join(t.TempDir(), "neko-release.tar.gz"+partSuffix)
+			if tt.part != nil {
+				if err := os.WriteFile(part, tt.part, 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			err := resumeDownload(url, part)
+			if strings.Join(*ranges, ",") != strings.Join(tt.wantRanges, ",") {
+				t.Errorf("requested ranges %q, want %q", *ranges, tt.wantRanges)
+			}
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("resumeDownload = %v, want %q", err, tt.wantErr)
+				}
+				if _, err := os.Stat(part); (err == nil) != tt.wantPart {
+					t.Errorf("part file kept = %t, want %t", err == nil, tt.wantPart)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := os.ReadFile(part)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(got, archiveContent) {
+				t.Errorf("downloaded %d bytes, want the %d bytes of the archive", len(got), len(archiveContent))
+			}
+		})
+	}
+}
+
+// An interrupted download keeps its part file and the next install only fetches the rest
+func TestDownloadPluginArchiveResumesPartialDownload(t *testing.T) {
+	withPluginDir(t)
+
+	var requests atomic.Int32
+	url, ranges := serveArchive(t, func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) == 1 {
+			// Announce the whole archive, send half of it and drop the connection
+			w.Header().Set("Content-Length", "10240")
+			_, _ = w.Write(archiveContent[:4096])
+			w.(http.Flusher).Flush()
+			panic(http.ErrAbortHandler)
+		}
+		http.ServeContent(w, r, "plugin.tar.gz", time.Time{}, bytes.NewReader(archiveContent))
+	})
+
+	if _, err := downloadPluginArchive("release", url); err == nil || !strings.Contains(err.Error(), "resume") {
+		t.Fatalf("interrupted download = %v, want a resumable error", err)
+	}
+	parts, _ := filepath.Glob(filepath.Join(pluginDir, "release-*.tar.gz"+partSuffix))
+	if len(parts) != 1 {
+		t.Fatalf("part files = %q, want the interrupted download", parts)
+	}
+
+	archive, err := downloadPluginArchive("release", url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := (*ranges)[1]; got != "bytes=4096-" {
+		t.Errorf("resumed with Range %q, want bytes=4096-", got)
+	}
+	got, err := os.ReadFile(archive)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, archiveContent) {
+		t.Errorf("resumed archive has %d bytes, want %d", len(got), len(archiveContent))
+	}
+	if _, err := os.Stat(parts[0]); !os.IsNotExist(err) {
+		t.Errorf("part file %s was not renamed: %v", parts[0], err)
+	}
+}
@@ -2,9 +2,11 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/nekoman-hq/neko-cli/pkg/dispatcher"
 	"github.com/nekoman-hq/neko-cli/pkg/plugin"
@@ -14,8 +16,12 @@ import (
 )
 
 func init() {
-	rootCmd.PersistentFlags().StringVar(&outputFormat, "output", "table", "Output format (table, json, wide)")
+	rootCmd.PersistentFlags().StringVar(&outputFormat, "output", "table", "Output format (table, json, wide, template)")
+	rootCmd.PersistentFlags().BoolVar(&compactJSON, "compact", false, "With --output json, print the response on a single line")
+	rootCmd.PersistentFlags().StringVar(&outputTmpl, "template", "", "Go text/template for --output template, executed against the response data")
 	rootCmd.PersistentFlags().BoolVar(&describe, "describe", false, "Include execution logs and metadata in output")
+	rootCmd.PersistentFlags().StringVar(&logLevel, "log-level", "", "Only show describe logs at or above this level (verbose, info, warn, error)")
+	rootCmd.PersistentFlags().StringSliceVar(&logCategories, "log-category", nil, "Only show describe logs of these categories (e.g. exec,guard)")
 
 	// Detect plugin directory
 	home, _ := os.UserHomeDir()
@@ -26,6 +32,9 @@ func init() {
 	}
 }
 
+// errPluginFailed makes neko exit nonzero when a plugin responds with an error status
+var errPluginFailed = errors.New("plugin command failed")
+
 // CreatePluginCommand creates a cobra.Command for the given plugin manifest
 func CreatePluginCommand(manifest plugin.Manifest) *cobra.Command {
 	// Main command for every plugin e.g., "release", "deploy"
@@ -48,9 +57,21 @@ func CreatePluginCommand(manifest plugin.Manifest) *cobra.Command {
 
 // createSubCommand creates a cobra.Command for the given plugin command with flags
 func createSubCommand(pluginName string, pluginCmd plugin.Command) *cobra.Command {
+	// Flags set in the user's defaults file are not required
+	defaults := flagDefaults(pluginCmd.Name)
+	flags := make([]plugin.Flag, len(pluginCmd.Flags))
+	for i, flag := range pluginCmd.Flags {
+		if _, ok := defaults[flag.Name]; ok {
+			flag.Required = false
+		}
+		flags[i] = flag
+	}
+	pluginCmd.Flags = flags
+
 	subCmd := &cobra.Command{
-		Use:   pluginCmd.Name,
-		Short: pluginCmd.Description,
+		Use:     pluginCmd.Name,
+		Short:   pluginCmd.Description,
+		Example: "  " + strings.Join(pluginCmd.Examples(pluginName), "\n  "),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return executePlugin(pluginName, cmd, args)
 		},
@@ -64,6 +85,19 @@ func createSubCommand(pluginName string, pluginCmd plugin.Command) *cobra.Comman
 	return subCmd
 }
 
+// flagDefaults returns the default flag values of the command from the user's defaults file
+func flagDefaults(command string) map[string]any {
+	path, err := plugin.DefaultsPath()
+	if err != nil {
+		return nil
+	}
+	defaults, err := plugin.LoadFlagDefaults(path, command)
+	if err != nil {
+		return nil
+	}
+	return defaults
+}
+
 // addFlagToCommand adds a flag to the command based on the flag definition
 func addFlagToCommand(cmd *cobra.Command, flag plugin.Flag) {
 	switch flag.Type {
@@ -104,17 +138,17 @@ func addFlagToCommand(cmd *cobra.Command, flag plugin.Flag) {
 
 // executePlugin dispatches the command to the plugin and renders the response
 func executePlugin(pluginName string, cmd *cobra.Command, args []string) error {
+	if !renderer.ValidLogLevel(logLevel) {
+		return fmt.Errorf("invalid --log-level %q: must be one of verbose, info, warn, error", logLevel)
+	}
+
 	d := dispatcher.NewDispatcher(pluginDir)
 
 	req := plugin.Request{
 		Command: cmd.Name(),
 		Args:    args,
 		Flags:   extractFlags(cmd),
-		Context: plugin.Context{
-			WorkingDir: mustGetwd(),
-			User:       os.Getenv("USER"),
-			Verbose:    verbose,
-		},
+		Context: requestContext(),
 	}
 
 	ctx := context.Background()
@@ -125,9 +159,41 @@ func executePlugin(pluginName string, cmd *cobra.Command, args []string) error {
 
 	opts := renderer.RenderOptions{
 		Format:   renderer.OutputFormat(outputFormat),
+		Template: outputTmpl,
 		Describe: describe,
+		Compact:  compactJSON,
+		LogFilter: renderer.LogFilter{
+			MinLevel:   logLevel,
+			Categories: logCategories,
+		},
 	}
-	return renderer.RenderWithOptions(resp, opts)
+	if err := renderer.RenderWithOptions(resp, opts); err != nil {
+		return err
+	}
+
+	// Plugins cannot prompt, so neko asks and repeats the command confirmed
+	if needsConfirmation(resp) && req.Context.Interactive && confirm("Proceed?") {
+		req.Context.AssumeYes = true
+		if resp, err = d.Dispatch(ctx, pluginName, req); err != nil {
+			return fmt.Errorf("failed to execute plugin: %w", err)
+		}
+		if err := renderer.RenderWithOptions(resp, opts); err != nil {
+			return err
+		}
+	}
+
+	// The error is already rendered, only the exit code is left to signal it
+	if resp.Status == "error" {
+		cmd.SilenceUsage = true
+		cmd.SilenceErrors = true
+		return errPluginFailed
+	}
+	return nil
+}
+
+// needsConfirmation reports whether the plugin refused a destructive action without --yes
+func needsConfirmation(resp *plugin.Response) bool {
+	return resp.Status == "error" && resp.Error != nil && resp.Error.Code == "CONFIRMATION_REQUIRED"
 }
 
 // extractFlags extracts the flags from the cobra.Command into a map
@@ -155,6 +221,18 @@ func extractFlags(cmd *cobra.Command) map[string]any {
 	return flags
 }
 
+// requestContext builds the execution context passed to plugins from the global flags
+func requestContext() plugin.Context {
+	return plugin.Context{
+		WorkingDir:  mustGetwd(),
+		User:        os.Getenv("USER"),
+		Verbose:     verbose,
+		AssumeYes:   assumeYes,
+		Interactive: isInteractive(),
+		DryRun:      dryRun,
+	}
+}
+
 // mustGetwd returns the current working directory or an empty string on error
 func mustGetwd() string {
 	wd, _ := os.Getwd()
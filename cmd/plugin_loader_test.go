@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nekoman-hq/neko-cli/pkg/plugin"
+	"github.com/spf13/cobra"
+)
+
+// Flags with a value in the defaults file must not be required by cobra, the plugin merges them in
+func TestCreateSubCommandDefaultsNotRequired(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	if err := os.MkdirAll(filepath.Join(dir, "neko"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	defaults := `{"init": {"project-type": "backend"}}`
+	if err := os.WriteFile(filepath.Join(dir, "neko", plugin.DefaultsFileName), []byte(defaults), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	pluginCmd := plugin.Command{
+		Name: "init",
+		Flags: []plugin.Flag{
+			{Name: "project-type", Type: "string", Required: true},
+			{Name: "release-system", Type: "string", Required: true},
+		},
+	}
+	subCmd := createSubCommand("release", pluginCmd)
+
+	tests := []struct {
+		flag string
+		want bool
+	}{
+		{"project-type", false},
+		{"release-system", true},
+	}
+	for _, tt := range tests {
+		f := subCmd.Flags().Lookup(tt.flag)
+		if f == nil {
+			t.Fatalf("flag --%s is missing", tt.flag)
+		}
+		_, required := f.Annotations[cobra.BashCompOneRequiredFlag]
+		if required != tt.want {
+			t.Errorf("--%s required = %t, want %t", tt.flag, required, tt.want)
+		}
+	}
+	// The defaulted flag is an optional example, the required one is in every example
+	want := "  neko release init --release-system <string>\n  neko release init --release-system <string> --project-type <string>"
+	if subCmd.Example != want {
+		t.Errorf("Example = %q, want %q", subCmd.Example, want)
+	}
+	// The manifest is shared, createSubCommand must not change it
+	if !pluginCmd.Flags[0].Required {
+		t.Error("createSubCommand changed the manifest flags")
+	}
+}
+
+// A plugin flag named like a global flag shadows it, e.g. a local --dry-run never reaches Context.DryRun
+func TestReleaseManifestKeepsGlobalFlags(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("..", "plugin", "release", "manifest.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var manifest plugin.Manifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, command := range manifest.Commands {
+		for _, flag := range command.Flags {
+			if rootCmd.PersistentFlags().Lookup(flag.Name) != nil {
+				t.Errorf("release %s declares --%s, which shadows the global flag", command.Name, flag.Name)
+			}
+		}
+	}
+
+	releaseCmd := CreatePluginCommand(manifest)
+	rootCmd.AddCommand(releaseCmd)
+	t.Cleanup(func() { rootCmd.RemoveCommand(releaseCmd) })
+	withFlag(t, &dryRun, false)
+
+	for _, name := range []string{"patch", "minor", "major"} {
+		sub, _, err := rootCmd.Find([]string{"release", name})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := sub.ParseFlags([]string{"--dry-run"}); err != nil {
+			t.Fatal(err)
+		}
+		if !requestContext().DryRun {
+			t.Errorf("release %s --dry-run did not set Context.DryRun", name)
+		}
+		dryRun = false
+	}
+}
@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/nekoman-hq/neko-cli/pkg/dispatcher"
+	"github.com/nekoman-hq/neko-cli/pkg/plugin"
+	"github.com/nekoman-hq/neko-cli/pkg/renderer"
+	"github.com/nekoman-hq/neko-cli/pkg/version"
+	"github.com/spf13/cobra"
+)
+
+var pluginRunCmd = &cobra.Command{
+	Use:   "run [command] [args...]",
+	Short: "Run a command (e.g. validate) in every installed plugin that provides it",
+	Args:  cobra.MinimumNArgs(1),
+	RunE:  runPluginRun,
+}
+
+var runConcurrency int
+
+func init() {
+	pluginCmd.AddCommand(pluginRunCmd)
+	pluginRunCmd.Flags().IntVar(&runConcurrency, "concurrency", dispatcher.DefaultMaxConcurrency, "Maximum number of plugins running at the same time")
+}
+
+func runPluginRun(cmd *cobra.Command, args []string) error {
+	if runConcurrency < 1 {
+		return fmt.Errorf("--concurrency must be at least 1, got %d", runConcurrency)
+	}
+
+	d := dispatcher.NewDispatcher(pluginDir)
+	manifests, err := d.ListPlugins()
+	if err != nil {
+		return fmt.Errorf("failed to list plugins: %w", err)
+	}
+
+	command := args[0]
+	names := pluginsProviding(manifests, command)
+	if len(names) == 0 {
+		return fmt.Errorf("no installed plugin provides the command '%s'", command)
+	}
+
+	req := plugin.Request{
+		Command: command,
+		Args:    args[1:],
+		Flags:   map[string]any{},
+		Context: requestContext(),
+	}
+
+	results := d.DispatchAll(context.Background(), names, req, dispatcher.DispatchOptions{
+		MaxConcurrency: runConcurrency,
+		MinInterval:    dispatcher.DefaultMinInterval,
+	})
+
+	items := make([]map[string]any, 0, len(results))
+	failed := 0
+	for _, r := range results {
+		status, message := "success", ""
+		switch {
+		case r.Err != nil:
+			status, message = "error", r.Err.Error()
+		case r.Response.Status == "error" && r.Response.Error != nil:
+			status, message = "error", r.Response.Error.Message
+		case r.Response.Status != "":
+			status = r.Response.Status
+		}
+		if status == "error" {
+			failed++
+		}
+		items = append(items, map[string]any{
+			"plugin": r.Plugin,
+			"status": status,
+			"error":  message,
+		})
+	}
+
+	resp := &plugin.Response{
+		Status: "success",
+		Metadata: plugin.ResponseMetadata{
+			Plugin:    "cli",
+			Version:   version.Version,
+			Command:   "plugin run " + command,
+			Timestamp: time.Now(),
+		},
+		Data: map[string]any{
+			"items": items,
+		},
+		RendererHint: "table",
+		ColumnOrder:  []string{"plugin", "status", "error"},
+	}
+
+	opts := renderer.RenderOptions{
+		Format:   renderer.OutputFormat(outputFormat),
+		Template: outputTmpl,
+		Describe: describe,
+		Compact:  compactJSON,
+	}
+	if err := renderer.RenderWithOptions(resp, opts); err != nil {
+		return err
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d plugins failed", failed, len(results))
+	}
+	return nil
+}
+
+// pluginsProviding returns the names of the plugins whose manifest declares the command
+func pluginsProviding(manifests []plugin.Manifest, command string) []string {
+	var names []string
+	for _, m := range manifests {
+		if slices.ContainsFunc(m.Commands, func(c plugin.Command) bool { return c.Name == command }) {
+			names = append(names, m.Name)
+		}
+	}
+	return names
+}
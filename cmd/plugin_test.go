@@ -0,0 +1,304 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/nekoman-hq/neko-cli/pkg/plugin"
+)
+
+func TestInstallPlugins(t *testing.T) {
+	names := []string{"release", "deploy", "missing", "monitor", "broken"}
+	failing := map[string]bool{"missing": true, "broken": true}
+
+	var mu sync.Mutex
+	installed := make(map[string]string)
+	var running, peak atomic.Int32
+	install := func(name, version string) error {
+		n := running.Add(1)
+		defer running.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+
+		if failing[name] {
+			return fmt.Errorf("no asset for %s", name)
+		}
+		mu.Lock()
+		installed[name] = version
+		mu.Unlock()
+		return nil
+	}
+
+	results := installPlugins(names, "v1.2.0", install)
+
+	if len(results) != len(names) {
+		t.Fatalf("got %d results, want %d", len(results), len(names))
+	}
+	for i, r := range results {
+		if r.Name != names[i] {
+			t.Errorf("result %d is %s, want the order of the arguments (%s)", i, r.Name, names[i])
+		}
+		if failed := r.Err != nil; failed != failing[r.Name] {
+			t.Errorf("%s: error = %v, want failed = %t", r.Name, r.Err, failing[r.Name])
+		}
+	}
+	if got := countFailedInstalls(results); got != 2 {
+		t.Errorf("countFailedInstalls = %d, want 2", got)
+	}
+	if len(installed) != 3 || installed["deploy"] != "v1.2.0" {
+		t.Errorf("installed = %v, want the three working plugins in v1.2.0", installed)
+	}
+	if p := peak.Load(); p < 2 || p > maxParallelInstalls {
+		t.Errorf("%d installs ran at once, want between 2 and %d", p, maxParallelInstalls)
+	}
+}
+
+func TestResolveRegistry(t *testing.T) {
+	tests := []struct {
+		name, flag, env string
+		want            string
+		wantErr         bool
+	}{
+		{name: "default", want: defaultPluginRegistry},
+		{name: "env", env: "https://ghe.example.com/api/v3/repos/acme/neko/releases", want: "https://ghe.example.com/api/v3/repos/acme/neko/releases"},
+		{name: "flag overrides env", flag: "http://localhost:8080/releases/", env: "https://ghe.example.com/releases", want: "http://localhost:8080/releases"},
+		{name: "relative", flag: "/releases", wantErr: true},
+		{name: "unsupported scheme", env: "ftp://example.com/releases", wantErr: true},
+		{name: "missing host", flag: "https:///releases", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resolveRegistry(tt.flag, tt.env)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("resolveRegistry(%q, %q) error = %v, want error %t", tt.flag, tt.env, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("resolveRegistry(%q, %q) = %q, want %q", tt.flag, tt.env, got, tt.want)
+			}
+		})
+	}
+}
+
+// fakeRegistry serves a releases API and points pluginRegistry at it
+func fakeRegistry(t *testing.T, handler http.HandlerFunc) {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	registry, err := resolveRegistry(srv.URL+"/repos/acme/neko/releases/", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	previous := pluginRegistry
+	pluginRegistry = registry
+	t.Cleanup(func() { pluginRegistry = previous })
+}
+
+func TestRegistryURLs(t *testing.T) {
+	asset := pluginAssetName("release")
+	var paths []string
+	fakeRegistry(t, func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		switch r.URL.Path {
+		case "/repos/acme/neko/releases/latest":
+			_, _ = fmt.Fprint(w, `{"tag_name": "v1.2.0"}`)
+		case "/repos/acme/neko/releases/tags/v1.2.0":
+			_, _ = fmt.Fprintf(w, `{"assets": [{"name": %q, "browser_download_url": "https://dl.example.com/plugin.tar.gz"}]}`, asset)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+
+	version, err := getLatestVersion()
+	if err != nil || version != "v1.2.0" {
+		t.Fatalf("getLatestVersion = %q, %v", version, err)
+	}
+	url, err := getPluginDownloadURL("release", version)
+	if err != nil || url != "https://dl.example.com/plugin.tar.gz" {
+		t.Fatalf("getPluginDownloadURL = %q, %v", url, err)
+	}
+	if _, err := getPluginDownloadURL("deploy", version); err == nil {
+		t.Error("getPluginDownloadURL found a plugin without asset")
+	}
+
+	want := []string{"/repos/acme/neko/releases/latest", "/repos/acme/neko/releases/tags/v1.2.0", "/repos/acme/neko/releases/tags/v1.2.0"}
+	if !slices.Equal(paths, want) {
+		t.Errorf("requested %v, want %v", paths, want)
+	}
+}
+
+// withFlag sets a package flag variable for the test
+func withFlag[T any](t *testing.T, flag *T, value T) {
+	t.Helper()
+	previous := *flag
+	*flag = value
+	t.Cleanup(func() { *flag = previous })
+}
+
+// captureStdout returns what fn prints to stdout
+func captureStdout(t *testing.T, fn func()) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	fn()
+	os.Stdout = stdout
+	_ = w.Close()
+
+	out, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(out)
+}
+
+func TestRunPluginUninstallPrune(t *testing.T) {
+	tests := []struct {
+		name        string
+		configFiles []string
+		prune       bool
+		yes         bool
+		wantErr     string
+		wantRemoved bool
+		wantPruned  bool
+	}{
+		{name: "without prune", configFiles: []string{".release.neko.json"}, wantRemoved: true},
+		{name: "prune unconfirmed", configFiles: []string{".release.neko.json"}, prune: true, wantErr: "--yes"},
+		{name: "prune confirmed", configFiles: []string{".release.neko.json", ".neko/config.lock", "missing.json"}, prune: true, yes: true, wantRemoved: true, wantPruned: true},
+		{name: "outside of the project", configFiles: []string{"../.release.neko.json"}, prune: true, yes: true, wantErr: "outside of the project"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			withPluginDir(t)
+			withFlag(t, &pruneConfig, tt.prune)
+			withFlag(t, &assumeYes, tt.yes)
+			withFlag(t, &nonInteractive, true)
+
+			manifest, err := json.Marshal(plugin.Manifest{Name: "release", ConfigFiles: tt.configFiles})
+			if err != nil {
+				t.Fatal(err)
+			}
+			installPath := filepath.Join(pluginDir, "release")
+			if err := os.MkdirAll(installPath, 0755); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(filepath.Join(installPath, "manifest.json"), manifest, 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			project := t.TempDir()
+			t.Chdir(project)
+			projectFiles := []string{".release.neko.json", filepath.Join(".neko", "config.lock"), "go.mod"}
+			for _, file := range projectFiles {
+				if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(file, []byte("{}"), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			err = runPluginUninstall(pluginUninstallCmd, []string{"release"})
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("runPluginUninstall = %v, want %q", err, tt.wantErr)
+				}
+			} else if err != nil {
+				t.Fatal(err)
+			}
+
+			if _, err := os.Stat(installPath); os.IsNotExist(err) != tt.wantRemoved {
+				t.Errorf("plugin removed = %t, want %t", os.IsNotExist(err), tt.wantRemoved)
+			}
+			for _, file := range projectFiles[:2] {
+				if _, err := os.Stat(file); os.IsNotExist(err) != tt.wantPruned {
+					t.Errorf("%s removed = %t, want %t", file, os.IsNotExist(err), tt.wantPruned)
+				}
+			}
+			if _, err := os.Stat("go.mod"); err != nil {
+				t.Errorf("prune removed an undeclared file: %v", err)
+			}
+		})
+	}
+}
+
+func TestRunPluginList(t *testing.T) {
+	tests := []struct {
+		name      string
+		manifests map[string]string
+		want      []string
+	}{
+		{
+			name: "no plugins",
+			want: []string{"No plugins installed."},
+		},
+		{
+			name:      "valid manifest",
+			manifests: map[string]string{"release": `{"name": "release", "version": "1.0.0", "description": "Releases", "author": "neko"}`},
+			want:      []string{"NAME", "release         1.0.0      Releases"},
+		},
+		{
+			name: "missing and corrupt manifests are listed",
+			manifests: map[string]string{
+				"release": `{"name": "release", "version": "1.0.0"}`,
+				"broken":  `{"name": `,
+				"empty":   "",
+			},
+			want: []string{
+				"release         1.0.0",
+				"broken          -          (manifest unreadable)",
+				"empty           -          (manifest missing)",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			withPluginDir(t)
+			for name, manifest := range tt.manifests {
+				dir := filepath.Join(pluginDir, name)
+				if err := os.MkdirAll(dir, 0755); err != nil {
+					t.Fatal(err)
+				}
+				if manifest == "" {
+					continue
+				}
+				if err := os.WriteFile(filepath.Join(dir, "manifest.json"), []byte(manifest), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			var err error
+			out := captureStdout(t, func() { err = runPluginList(pluginListCmd, nil) })
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, line := range tt.want {
+				if !strings.Contains(out, line) {
+					t.Errorf("output does not contain %q:\n%s", line, out)
+				}
+			}
+		})
+	}
+}
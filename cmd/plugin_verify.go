@@ -0,0 +1,259 @@
+package cmd
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/nekoman-hq/neko-cli/pkg/dispatcher"
+	"github.com/spf13/cobra"
+)
+
+// installRecordFile is written next to the plugin binary on install
+const installRecordFile = "install.json"
+
+// Verification states reported by neko plugin verify
+const (
+	verifyOK      = "OK"
+	verifyCorrupt = "CORRUPT"
+	verifyUnknown = "UNKNOWN"
+)
+
+var pluginVerifyCmd = &cobra.Command{
+	Use:   "verify [plugin-name...]",
+	Short: "Verify installed plugins against the registry checksums",
+	RunE:  runPluginVerify,
+}
+
+var (
+	verifyAll    bool
+	verifyRepair bool
+)
+
+func init() {
+	pluginCmd.AddCommand(pluginVerifyCmd)
+	pluginVerifyCmd.Flags().BoolVar(&verifyAll, "all", false, "Verify all installed plugins")
+	pluginVerifyCmd.Flags().BoolVar(&verifyRepair, "repair", false, "Reinstall corrupt plugins")
+}
+
+// installRecord describes the release asset a plugin was installed from
+type installRecord struct {
+	Version       string `json:"version"`
+	Asset         string `json:"asset"`
+	ArchiveSHA256 string `json:"archive_sha256"`
+	BinarySHA256  string `json:"binary_sha256"`
+}
+
+// verifyResult holds the verification outcome of a single plugin
+type verifyResult struct {
+	Name   string
+	Status string
+	Reason string
+	// Version is the release to reinstall on repair
+	Version string
+}
+
+func runPluginVerify(cmd *cobra.Command, args []string) error {
+	names := args
+	if verifyAll {
+		manifests, err := dispatcher.NewDispatcher(pluginDir).ListPlugins()
+		if err != nil {
+			return fmt.Errorf("failed to list plugins: %w", err)
+		}
+		names = nil
+		for _, m := range manifests {
+			names = append(names, m.Name)
+		}
+	}
+	if len(names) == 0 {
+		return fmt.Errorf("specify plugin names or use --all")
+	}
+
+	checksums := make(map[string]map[string]string)
+	lookup := func(version string) (map[string]string, error) {
+		if sums, ok := checksums[version]; ok {
+			return sums, nil
+		}
+		sums, err := fetchChecksums(version)
+		if err != nil {
+			return nil, err
+		}
+		checksums[version] = sums
+		return sums, nil
+	}
+
+	fmt.Printf("%-15s %-10s %s\n", "NAME", "STATUS", "DETAILS")
+	corrupt := 0
+	for _, name := range names {
+		r := verifyPlugin(name, lookup)
+		if r.Status == verifyCorrupt && verifyRepair {
+			if err := installPlugin(name, r.Version); err != nil {
+				r.Reason = fmt.Sprintf("%s; repair failed: %s", r.Reason, err.Error())
+			} else {
+				r.Status, r.Reason = verifyOK, "repaired"
+			}
+		}
+		if r.Status == verifyCorrupt {
+			corrupt++
+		}
+		fmt.Printf("%-15s %-10s %s\n", r.Name, r.Status, r.Reason)
+	}
+
+	if corrupt > 0 {
+		return fmt.Errorf("%d of %d plugins are corrupt (re-run with --repair to reinstall them)", corrupt, len(names))
+	}
+	return nil
+}
+
+// verifyPlugin compares the installed binary with the checksum recorded on install
+// and the recorded archive checksum with the registry's checksums file
+func verifyPlugin(name string, checksums func(version string) (map[string]string, error)) verifyResult {
+	r := verifyResult{Name: name}
+
+	binary := filepath.Join(pluginDir, name, fmt.Sprintf("plugin-%s", name))
+	if _, err := os.Stat(binary); err != nil {
+		r.Status, r.Reason = verifyUnknown, "not installed"
+		return r
+	}
+
+	record, err := readInstallRecord(name)
+	if err != nil {
+		r.Status, r.Reason = verifyUnknown, "no install record, reinstall the plugin to enable verification"
+		return r
+	}
+	r.Version = record.Version
+
+	sums, err := checksums(record.Version)
+	if err != nil {
+		r.Status, r.Reason = verifyUnknown, err.Error()
+		return r
+	}
+	expected, ok := sums[record.Asset]
+	if !ok {
+		r.Status, r.Reason = verifyUnknown, fmt.Sprintf("%s not listed in checksums of %s", record.Asset, record.Version)
+		return r
+	}
+	if !strings.EqualFold(expected, record.ArchiveSHA256) {
+		r.Status, r.Reason = verifyCorrupt, fmt.Sprintf("archive checksum differs from registry (%s)", record.Version)
+		return r
+	}
+
+	actual, err := fileSHA256(binary)
+	if err != nil {
+		r.Status, r.Reason = verifyUnknown, err.Error()
+		return r
+	}
+	if actual != record.BinarySHA256 {
+		r.Status, r.Reason = verifyCorrupt, "binary was modified after install"
+		return r
+	}
+
+	r.Status, r.Reason = verifyOK, record.Version
+	return r
+}
+
+func writeInstallRecord(pluginName string, record installRecord) error {
+	data, err := json.MarshalIndent(record, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(pluginDir, pluginName, installRecordFile), data, 0644)
+}
+
+func readInstallRecord(pluginName string) (*installRecord, error) {
+	data, err := os.ReadFile(filepath.Join(pluginDir, pluginName, installRecordFile))
+	if err != nil {
+		return nil, err
+	}
+
+	var record installRecord
+	if err := json.Unmarshal(data, &record); err != nil {
+		return nil, err
+	}
+	return &record, nil
+}
+
+func fileSHA256(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer func(f *os.File) {
+		_ = f.Close()
+	}(f)
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// fetchChecksums downloads the goreleaser checksums file of the release and maps asset names to checksums
+func fetchChecksums(version string) (map[string]string, error) {
+	url := fmt.Sprintf("%s/tags/%s", pluginRegistry, version)
+	resp, err := httpGetWithAuth(url)
+	if err != nil {
+		return nil, err
+	}
+	defer func(Body io.ReadCloser) {
+		_ = Body.Close()
+	}(resp.Body)
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch release %s: %s", version, resp.Status)
+	}
+
+	var release struct {
+		Assets []struct {
+			Name               string `json:"name"`
+			BrowserDownloadURL string `json:"browser_download_url"`
+		} `json:"assets"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&release); err != nil {
+		return nil, err
+	}
+
+	for _, asset := range release.Assets {
+		if strings.HasSuffix(asset.Name, "checksums.txt") {
+			return downloadChecksums(asset.BrowserDownloadURL)
+		}
+	}
+	return nil, fmt.Errorf("release %s has no checksums file", version)
+}
+
+func downloadChecksums(url string) (map[string]string, error) {
+	resp, err := httpGetWithAuth(url)
+	if err != nil {
+		return nil, err
+	}
+	defer func(Body io.ReadCloser) {
+		_ = Body.Close()
+	}(resp.Body)
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to download checksums: %s", resp.Status)
+	}
+	return parseChecksums(resp.Body)
+}
+
+// parseChecksums parses "<sha256>  <file>" lines as written by goreleaser and sha256sum
+func parseChecksums(r io.Reader) (map[string]string, error) {
+	sums := make(map[string]string)
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 {
+			continue
+		}
+		sums[strings.TrimPrefix(fields[1], "*")] = fields[0]
+	}
+	return sums, scanner.Err()
+}
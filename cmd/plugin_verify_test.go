@@ -0,0 +1,207 @@
+package cmd
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// withPluginDir points pluginDir at a temporary directory
+func withPluginDir(t *testing.T) {
+	t.Helper()
+	previous := pluginDir
+	pluginDir = t.TempDir()
+	t.Cleanup(func() { pluginDir = previous })
+}
+
+func sha256Hex(data string) string {
+	sum := sha256.Sum256([]byte(data))
+	return hex.EncodeToString(sum[:])
+}
+
+// installFakePlugin writes a plugin binary and the install record of a clean install
+func installFakePlugin(t *testing.T, name, binary string) installRecord {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Join(pluginDir, name), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(pluginDir, name, "plugin-"+name), []byte(binary), 0755); err != nil {
+		t.Fatal(err)
+	}
+	record := installRecord{
+		Version:       "v1.2.0",
+		Asset:         pluginAssetName(name),
+		ArchiveSHA256: sha256Hex("archive of " + name),
+		BinarySHA256:  sha256Hex(binary),
+	}
+	if err := writeInstallRecord(name, record); err != nil {
+		t.Fatal(err)
+	}
+	return record
+}
+
+func TestParseChecksums(t *testing.T) {
+	input := strings.Join([]string{
+		"aaa  neko_release_linux_amd64.tar.gz",
+		"bbb *neko_release_darwin_arm64.tar.gz",
+		"",
+		"not a checksum line",
+		"ccc  checksums.txt",
+	}, "\n")
+
+	sums, err := parseChecksums(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{
+		"neko_release_linux_amd64.tar.gz":  "aaa",
+		"neko_release_darwin_arm64.tar.gz": "bbb",
+		"checksums.txt":                    "ccc",
+	}
+	if !maps.Equal(sums, want) {
+		t.Errorf("parseChecksums = %v, want %v", sums, want)
+	}
+}
+
+func TestVerifyPlugin(t *testing.T) {
+	tests := []struct {
+		name       string
+		setup      func(t *testing.T) map[string]string
+		lookupErr  error
+		wantStatus string
+		wantReason string
+	}{
+		{
+			name: "intact",
+			setup: func(t *testing.T) map[string]string {
+				r := installFakePlugin(t, "release", "binary")
+				return map[string]string{r.Asset: r.ArchiveSHA256}
+			},
+			wantStatus: verifyOK,
+			wantReason: "v1.2.0",
+		},
+		{
+			name: "checksum case differs",
+			setup: func(t *testing.T) map[string]string {
+				r := installFakePlugin(t, "release", "binary")
+				return map[string]string{r.Asset: strings.ToUpper(r.ArchiveSHA256)}
+			},
+			wantStatus: verifyOK,
+		},
+		{
+			name: "corrupted binary",
+			setup: func(t *testing.T) map[string]string {
+				r := installFakePlugin(t, "release", "binary")
+				if err := os.WriteFile(filepath.Join(pluginDir, "release", "plugin-release"), []byte("tampered"), 0755); err != nil {
+					t.Fatal(err)
+				}
+				return map[string]string{r.Asset: r.ArchiveSHA256}
+			},
+			wantStatus: verifyCorrupt,
+			wantReason: "binary was modified after install",
+		},
+		{
+			name: "archive differs from registry",
+			setup: func(t *testing.T) map[string]string {
+				r := installFakePlugin(t, "release", "binary")
+				return map[string]string{r.Asset: sha256Hex("another archive")}
+			},
+			wantStatus: verifyCorrupt,
+			wantReason: "archive checksum differs",
+		},
+		{
+			name:       "not installed",
+			setup:      func(t *testing.T) map[string]string { return nil },
+			wantStatus: verifyUnknown,
+			wantReason: "not installed",
+		},
+		{
+			name: "no install record",
+			setup: func(t *testing.T) map[string]string {
+				installFakePlugin(t, "release", "binary")
+				if err := os.Remove(filepath.Join(pluginDir, "release", installRecordFile)); err != nil {
+					t.Fatal(err)
+				}
+				return nil
+			},
+			wantStatus: verifyUnknown,
+			wantReason: "no install record",
+		},
+		{
+			name: "asset not listed",
+			setup: func(t *testing.T) map[string]string {
+				installFakePlugin(t, "release", "binary")
+				return map[string]string{"other.tar.gz": "aaa"}
+			},
+			wantStatus: verifyUnknown,
+			wantReason: "not listed in checksums of v1.2.0",
+		},
+		{
+			name: "registry unreachable",
+			setup: func(t *testing.T) map[string]string {
+				installFakePlugin(t, "release", "binary")
+				return nil
+			},
+			lookupErr:  errors.New("connection refused"),
+			wantStatus: verifyUnknown,
+			wantReason: "connection refused",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			withPluginDir(t)
+			sums := tt.setup(t)
+
+			r := verifyPlugin("release", func(string) (map[string]string, error) {
+				return sums, tt.lookupErr
+			})
+			if r.Status != tt.wantStatus || !strings.Contains(r.Reason, tt.wantReason) {
+				t.Errorf("verifyPlugin = %s (%s), want %s (%s)", r.Status, r.Reason, tt.wantStatus, tt.wantReason)
+			}
+		})
+	}
+}
+
+// neko plugin verify fetches the checksums from the registry and fails on a corrupted binary
+func TestRunPluginVerifyCorruptBinary(t *testing.T) {
+	withPluginDir(t)
+	intact := installFakePlugin(t, "release", "binary")
+	corrupt := installFakePlugin(t, "deploy", "binary")
+	if err := os.WriteFile(filepath.Join(pluginDir, "deploy", "plugin-deploy"), []byte("tampered"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	requests := 0
+	fakeRegistry(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		switch r.URL.Path {
+		case "/repos/acme/neko/releases/tags/v1.2.0":
+			_, _ = fmt.Fprintf(w, `{"assets": [{"name": "neko_1.2.0_checksums.txt", "browser_download_url": "http://%s/checksums.txt"}]}`, r.Host)
+		case "/checksums.txt":
+			_, _ = fmt.Fprintf(w, "%s  %s\n%s  %s\n", intact.ArchiveSHA256, intact.Asset, corrupt.ArchiveSHA256, corrupt.Asset)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+
+	previousAll, previousRepair := verifyAll, verifyRepair
+	verifyAll, verifyRepair = false, false
+	t.Cleanup(func() { verifyAll, verifyRepair = previousAll, previousRepair })
+
+	err := runPluginVerify(pluginVerifyCmd, []string{"release", "deploy"})
+	if err == nil || !strings.Contains(err.Error(), "1 of 2 plugins are corrupt") {
+		t.Errorf("runPluginVerify = %v, want one corrupt plugin", err)
+	}
+	// Both plugins share a release, the checksums are fetched once
+	if requests != 2 {
+		t.Errorf("the registry got %d requests, want 2", requests)
+	}
+}
@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/nekoman-hq/neko-cli/pkg/dispatcher"
+	"github.com/spf13/cobra"
+)
+
+var pluginWhichCmd = &cobra.Command{
+	Use:   "which [plugin-name]",
+	Short: "Print the binary and manifest path neko resolves for a plugin",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runPluginWhich,
+}
+
+func init() {
+	pluginCmd.AddCommand(pluginWhichCmd)
+}
+
+func runPluginWhich(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	d := dispatcher.NewDispatcher(pluginDir)
+
+	binary := d.BinaryPath(name)
+	binaryState := fileState(binary)
+	fmt.Printf("%-10s %s (%s)\n", "BINARY", binary, binaryState)
+
+	manifest := d.ManifestPath(name)
+	fmt.Printf("%-10s %s (%s)\n", "MANIFEST", manifest, fileState(manifest))
+
+	if binaryState == "missing" {
+		return fmt.Errorf("plugin '%s' is not installed", name)
+	}
+	return nil
+}
+
+// fileState describes whether the file exists and, for binaries, whether it is executable
+func fileState(path string) string {
+	info, err := os.Stat(path)
+	switch {
+	case os.IsNotExist(err):
+		return "missing"
+	case err != nil:
+		return err.Error()
+	case info.IsDir():
+		return "directory"
+	case info.Mode()&0111 != 0:
+		return "exists, executable"
+	default:
+		return "exists"
+	}
+}
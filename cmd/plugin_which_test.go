@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFileState(t *testing.T) {
+	dir := t.TempDir()
+	executable := filepath.Join(dir, "plugin-release")
+	plain := filepath.Join(dir, "manifest.json")
+	if err := os.WriteFile(executable, []byte("#!/bin/sh\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(plain, []byte("{}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		path string
+		want string
+	}{
+		{executable, "exists, executable"},
+		{plain, "exists"},
+		{dir, "directory"},
+		{filepath.Join(dir, "missing"), "missing"},
+	}
+
+	for _, tt := range tests {
+		t.Run(filepath.Base(tt.path), func(t *testing.T) {
+			if got := fileState(tt.path); got != tt.want {
+				t.Errorf("fileState(%s) = %q, want %q", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRunPluginWhich(t *testing.T) {
+	tests := []struct {
+		name         string
+		binary       os.FileMode
+		manifest     bool
+		wantBinary   string
+		wantManifest string
+		wantErr      bool
+	}{
+		{"installed", 0755, true, "exists, executable", "exists", false},
+		{"not executable", 0644, true, "exists", "exists", false},
+		{"manifest missing", 0755, false, "exists, executable", "missing", false},
+		{"not installed", 0, false, "missing", "missing", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			withPluginDir(t)
+			dir := filepath.Join(pluginDir, "release")
+			if err := os.MkdirAll(dir, 0755); err != nil {
+				t.Fatal(err)
+			}
+			if tt.binary != 0 {
+				if err := os.WriteFile(filepath.Join(dir, "plugin-release"), []byte("#!/bin/sh\n"), tt.binary); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if tt.manifest {
+				if err := os.WriteFile(filepath.Join(dir, "manifest.json"), []byte("{}"), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			var err error
+			out := captureStdout(t, func() { err = runPluginWhich(pluginWhichCmd, []string{"release"}) })
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("runPluginWhich error = %v, want error %t", err, tt.wantErr)
+			}
+			want := "BINARY     " + filepath.Join(dir, "plugin-release") + " (" + tt.wantBinary + ")\n" +
+				"MANIFEST   " + filepath.Join(dir, "manifest.json") + " (" + tt.wantManifest + ")\n"
+			if out != want {
+				t.Errorf("output =\n%s\nwant\n%s", out, want)
+			}
+		})
+	}
+}
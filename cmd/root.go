@@ -4,14 +4,26 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/nekoman-hq/neko-cli/pkg/crash"
+	"github.com/nekoman-hq/neko-cli/pkg/errors"
+	"github.com/nekoman-hq/neko-cli/pkg/log"
+	"github.com/nekoman-hq/neko-cli/pkg/version"
 	"github.com/spf13/cobra"
 )
 
 var (
 	verbose      bool
 	outputFormat string
+	outputTmpl   string
+	compactJSON  bool
+	themeName    string
 	pluginDir    string
 	describe     bool
+	assumeYes    bool
+	dryRun       bool
+
+	logLevel      string
+	logCategories []string
 )
 
 var rootCmd = &cobra.Command{
@@ -20,13 +32,60 @@ var rootCmd = &cobra.Command{
 }
 
 func Execute() {
+	defer recoverCrash()
+
 	if err := rootCmd.Execute(); err != nil {
 		os.Exit(1)
 	}
 }
 
+// applyTheme activates the color theme chosen by --theme, NEKO_THEME or the user's theme file.
+// CI systems whose logs do not render colors get the plain theme.
+func applyTheme() {
+	name := themeName
+	if ciDisablesColors() {
+		name = log.ThemeNone
+	}
+	theme, err := log.LoadTheme(name)
+	if err != nil {
+		errors.Warning("Ignoring color theme", err.Error())
+	}
+	log.SetTheme(theme)
+}
+
+// checkLogTimestamp warns about an invalid log timezone, which falls back to the local one
+func checkLogTimestamp() {
+	if _, err := log.ParseTimestampFormat(os.Getenv(log.TimeFormatEnv), os.Getenv(log.TimezoneEnv)); err != nil {
+		errors.Warning("Ignoring log timezone", err.Error())
+	}
+}
+
+// recoverCrash writes a crash report for a panic and exits, see crash.Capture
+func recoverCrash() {
+	r := recover()
+	if r == nil {
+		return
+	}
+
+	program := fmt.Sprintf("neko %s (%s)", version.Version, version.Commit)
+	path, err := crash.Capture(r, program, "neko", os.Args[1:])
+	message := fmt.Sprintf("%v\nCrash report written to %s", r, path)
+	if err != nil {
+		message = fmt.Sprintf("%v\nCould not write crash report: %s", r, err.Error())
+	}
+	errors.Fatal("neko crashed unexpectedly", message, "PANIC")
+}
+
 func init() {
 	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "Verbose output")
+	rootCmd.PersistentFlags().BoolVarP(&assumeYes, "yes", "y", false, "Automatically confirm all prompts (implies non-interactive)")
+	rootCmd.PersistentFlags().BoolVar(&interactive, "interactive", false, "Prompt for confirmations even if no terminal is detected")
+	rootCmd.PersistentFlags().BoolVar(&nonInteractive, "non-interactive", false, "Never prompt, even if a terminal is detected")
+	rootCmd.MarkFlagsMutuallyExclusive("interactive", "non-interactive")
+	rootCmd.PersistentFlags().BoolVar(&dryRun, "dry-run", false, "Log mutating git and GitHub operations (commit, tag, push, delete, reset) instead of running them")
+	rootCmd.PersistentFlags().StringVar(&themeName, "theme", "", "Color theme: default, none or the path of a theme file (overrides NEKO_THEME)")
+
+	cobra.OnInitialize(detectCI, applyTheme, checkLogTimestamp)
 
 	// Load plugins during initialization
 	if err := InitializePlugins(); err != nil {
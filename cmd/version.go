@@ -16,6 +16,10 @@ var versionCmd = &cobra.Command{
 	Use:   "version",
 	Short: "Show current version of the cli and the repository",
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := git.EnsureInstalled(); err != nil {
+			return err
+		}
+
 		repoInfo, _ := git.Current()
 		err := version.Latest(repoInfo)
 		if err != nil {
@@ -0,0 +1,44 @@
+// Package ci detects whether neko runs in a CI environment
+package ci
+
+import "strings"
+
+// Env overrides the detection: NEKO_CI=false ignores the CI environment, NEKO_CI=true forces CI mode
+const Env = "NEKO_CI"
+
+// Detected CI systems
+const (
+	GitHubActions = "github-actions"
+	GitLabCI      = "gitlab-ci"
+	Generic       = "ci"
+)
+
+// Detect returns the CI system from the environment looked up by env, empty outside CI
+func Detect(env func(string) string) string {
+	override := strings.ToLower(strings.TrimSpace(env(Env)))
+	if isFalse(override) {
+		return ""
+	}
+
+	switch {
+	case env("GITHUB_ACTIONS") == "true":
+		return GitHubActions
+	case env("GITLAB_CI") == "true":
+		return GitLabCI
+	case env("CI") != "" && !isFalse(strings.ToLower(env("CI"))):
+		return Generic
+	case override == "true" || override == "1":
+		return Generic
+	default:
+		return ""
+	}
+}
+
+// RendersColors reports whether the log viewer of the CI system renders ANSI colors
+func RendersColors(system string) bool {
+	return system == GitHubActions || system == GitLabCI
+}
+
+func isFalse(value string) bool {
+	return value == "false" || value == "0"
+}
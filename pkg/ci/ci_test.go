@@ -0,0 +1,52 @@
+package ci
+
+import "testing"
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		name string
+		env  map[string]string
+		want string
+	}{
+		{"local", nil, ""},
+		{"github actions", map[string]string{"GITHUB_ACTIONS": "true", "CI": "true"}, GitHubActions},
+		{"gitlab ci", map[string]string{"GITLAB_CI": "true", "CI": "true"}, GitLabCI},
+		{"generic", map[string]string{"CI": "true"}, Generic},
+		{"generic without value check", map[string]string{"CI": "1"}, Generic},
+		{"CI=false", map[string]string{"CI": "false"}, ""},
+		{"CI=0", map[string]string{"CI": "0"}, ""},
+		{"NEKO_CI=false ignores CI", map[string]string{"GITHUB_ACTIONS": "true", "NEKO_CI": "false"}, ""},
+		{"NEKO_CI=0 ignores CI", map[string]string{"CI": "true", "NEKO_CI": " 0 "}, ""},
+		{"NEKO_CI=true forces CI", map[string]string{"NEKO_CI": "TRUE"}, Generic},
+		{"NEKO_CI=1 forces CI", map[string]string{"NEKO_CI": "1"}, Generic},
+		{"NEKO_CI=true keeps the system", map[string]string{"GITLAB_CI": "true", "NEKO_CI": "true"}, GitLabCI},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Detect(func(key string) string { return tt.env[key] }); got != tt.want {
+				t.Errorf("Detect = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRendersColors(t *testing.T) {
+	tests := []struct {
+		system string
+		want   bool
+	}{
+		{GitHubActions, true},
+		{GitLabCI, true},
+		{Generic, false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.system, func(t *testing.T) {
+			if got := RendersColors(tt.system); got != tt.want {
+				t.Errorf("RendersColors(%q) = %t, want %t", tt.system, got, tt.want)
+			}
+		})
+	}
+}
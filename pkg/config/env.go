@@ -10,21 +10,59 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"os/exec"
+	"strings"
 
 	"github.com/nekoman-hq/neko-cli/pkg/log"
 )
 
-// GetPAT retrieves the GitHub Personal Access Token from the environment.
-// returns error if the token is not set.
+// ErrMissingPAT is returned by GetPAT when no GitHub token could be resolved
+var ErrMissingPAT = errors.New("environment Variable Missing")
+
+const patHelp = `A GitHub Personal Access Token is required.
+Provide it in one of the following ways:
+  - Set it with: export GITHUB_TOKEN=your_token_here
+  - Log in with the GitHub CLI: gh auth login (neko falls back to 'gh auth token')
+Tokens are not read from the neko config (.release.neko.json), so they are never committed with the project.
+The token needs the 'repo' scope ('public_repo' is sufficient for public repositories).
+See https://docs.github.com/en/authentication/keeping-your-account-and-data-secure/managing-your-personal-access-tokens`
+
+// GetPAT retrieves the GitHub Personal Access Token.
+// GITHUB_TOKEN takes precedence, the GitHub CLI is used as a fallback.
+// With NEKO_GITHUB_AUTH=app a GitHub App installation token is used instead.
+// returns ErrMissingPAT if no token is available.
 func GetPAT() (string, error) {
+	if os.Getenv(AuthModeEnv) == AuthModeApp {
+		return gitHubAppToken()
+	}
+
 	log.PluginV(log.Config, fmt.Sprintf("Looking up required env variable: %s",
 		log.ColorText(log.ColorGreen, "GITHUB_TOKEN"),
 	))
 	token, ok := os.LookupEnv("GITHUB_TOKEN")
-	if !ok || token == "" {
-		return "", errors.New(
-			"environment Variable Missing: \nA GitHub Personal Access Token (GITHUB_TOKEN) is required.\nSet it with: export GITHUB_TOKEN=your_token_here",
-		)
+	if ok && token != "" {
+		return token, nil
+	}
+
+	if token := ghAuthToken(); token != "" {
+		return token, nil
+	}
+
+	return "", fmt.Errorf("%w: \n%s", ErrMissingPAT, patHelp)
+}
+
+// ghAuthToken returns the token of the GitHub CLI or an empty string if unavailable
+func ghAuthToken() string {
+	if _, err := exec.LookPath("gh"); err != nil {
+		return ""
+	}
+
+	log.PluginV(log.Config, fmt.Sprintf("GITHUB_TOKEN not set, trying %s",
+		log.ColorText(log.ColorGreen, "gh auth token"),
+	))
+	output, err := exec.Command("gh", "auth", "token").Output()
+	if err != nil {
+		return ""
 	}
-	return token, nil
+	return strings.TrimSpace(string(output))
 }
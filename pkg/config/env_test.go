@@ -0,0 +1,47 @@
+package config
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// withoutGitHubCLI hides gh so GetPAT cannot fall back to 'gh auth token'
+func withoutGitHubCLI(t *testing.T) {
+	t.Helper()
+	t.Setenv("PATH", t.TempDir())
+	t.Setenv(AuthModeEnv, "")
+}
+
+func TestGetPATMissingToken(t *testing.T) {
+	withoutGitHubCLI(t)
+	t.Setenv("GITHUB_TOKEN", "")
+
+	token, err := GetPAT()
+	if !errors.Is(err, ErrMissingPAT) {
+		t.Fatalf("GetPAT() error = %v, want ErrMissingPAT", err)
+	}
+	if token != "" {
+		t.Errorf("GetPAT() returned token %q with the error", token)
+	}
+
+	// The message must tell new users every way to provide the token
+	for _, want := range []string{"GITHUB_TOKEN", "gh auth login", "not read from the neko config", "'repo' scope"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error does not mention %q:\n%s", want, err)
+		}
+	}
+}
+
+func TestGetPATFromEnvironment(t *testing.T) {
+	withoutGitHubCLI(t)
+	t.Setenv("GITHUB_TOKEN", "ghp_test")
+
+	token, err := GetPAT()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token != "ghp_test" {
+		t.Errorf("GetPAT() = %q, want the GITHUB_TOKEN value", token)
+	}
+}
@@ -0,0 +1,209 @@
+package config
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nekoman-hq/neko-cli/pkg/log"
+)
+
+// Auth modes selected via NEKO_GITHUB_AUTH
+const (
+	AuthModeEnv   = "NEKO_GITHUB_AUTH"
+	AuthModeToken = "token" // GITHUB_TOKEN or the GitHub CLI (default)
+	AuthModeApp   = "app"   // GitHub App installation token
+)
+
+const appHelp = `GitHub App authentication (NEKO_GITHUB_AUTH=app) requires:
+  - GITHUB_APP_ID: the App ID
+  - GITHUB_APP_INSTALLATION_ID: the installation ID of the App in your organization
+  - GITHUB_APP_PRIVATE_KEY (PEM content) or GITHUB_APP_PRIVATE_KEY_PATH (path to the .pem file)
+See https://docs.github.com/en/apps/creating-github-apps/authenticating-with-a-github-app/authenticating-as-a-github-app-installation`
+
+// GitHubApp holds the credentials to request installation tokens for a GitHub App
+type GitHubApp struct {
+	AppID          string
+	InstallationID string
+	PrivateKey     *rsa.PrivateKey
+}
+
+// installationToken is a short-lived token, cached until shortly before it expires
+type installationToken struct {
+	Token     string
+	ExpiresAt time.Time
+}
+
+// tokenRefreshMargin renews cached tokens before they expire mid-release
+const tokenRefreshMargin = time.Minute
+
+var (
+	appTokenMu sync.Mutex
+	appToken   installationToken
+)
+
+// exchangeToken requests an installation token, replaceable to avoid the network call
+var exchangeToken = requestInstallationToken
+
+// GitHubAppFromEnv reads the GitHub App credentials from the environment
+func GitHubAppFromEnv() (*GitHubApp, error) {
+	app := &GitHubApp{
+		AppID:          os.Getenv("GITHUB_APP_ID"),
+		InstallationID: os.Getenv("GITHUB_APP_INSTALLATION_ID"),
+	}
+	if app.AppID == "" || app.InstallationID == "" {
+		return nil, fmt.Errorf("%w: \n%s", ErrMissingPAT, appHelp)
+	}
+
+	key := os.Getenv("GITHUB_APP_PRIVATE_KEY")
+	if path := os.Getenv("GITHUB_APP_PRIVATE_KEY_PATH"); key == "" && path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read GitHub App private key: %w", err)
+		}
+		key = string(data)
+	}
+	if key == "" {
+		return nil, fmt.Errorf("%w: \n%s", ErrMissingPAT, appHelp)
+	}
+
+	privateKey, err := ParsePrivateKey([]byte(key))
+	if err != nil {
+		return nil, err
+	}
+	app.PrivateKey = privateKey
+	return app, nil
+}
+
+// ParsePrivateKey parses the PEM encoded RSA key GitHub generates for an App (PKCS#1 or PKCS#8)
+func ParsePrivateKey(data []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("GitHub App private key is not PEM encoded")
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse GitHub App private key: %w", err)
+	}
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("GitHub App private key must be an RSA key")
+	}
+	return key, nil
+}
+
+// JWT returns the RS256 signed token the App authenticates with.
+// iat is backdated a minute against clock drift, GitHub accepts at most 10 minutes of lifetime.
+func (a *GitHubApp) JWT(now time.Time) (string, error) {
+	header, err := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT"})
+	if err != nil {
+		return "", err
+	}
+	claims, err := json.Marshal(map[string]any{
+		"iat": now.Add(-time.Minute).Unix(),
+		"exp": now.Add(9 * time.Minute).Unix(),
+		"iss": a.AppID,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	unsigned := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(claims)
+	digest := sha256.Sum256([]byte(unsigned))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, a.PrivateKey, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", fmt.Errorf("failed to sign GitHub App JWT: %w", err)
+	}
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// InstallationToken returns a cached installation token or exchanges a new JWT for one
+func (a *GitHubApp) InstallationToken(now time.Time) (string, error) {
+	appTokenMu.Lock()
+	defer appTokenMu.Unlock()
+
+	if appToken.Token != "" && now.Before(appToken.ExpiresAt.Add(-tokenRefreshMargin)) {
+		log.PluginV(log.Config, "Using cached GitHub App installation token")
+		return appToken.Token, nil
+	}
+
+	jwt, err := a.JWT(now)
+	if err != nil {
+		return "", err
+	}
+
+	token, err := exchangeToken(a.InstallationID, jwt)
+	if err != nil {
+		return "", err
+	}
+	appToken = token
+	return token.Token, nil
+}
+
+// requestInstallationToken exchanges the App JWT for an installation token
+func requestInstallationToken(installationID, jwt string) (installationToken, error) {
+	url := fmt.Sprintf("https://api.github.com/app/installations/%s/access_tokens", installationID)
+
+	log.PluginV(log.Config, fmt.Sprintf("Requesting GitHub App installation token: %s",
+		log.ColorText(log.ColorGreen, "POST "+url),
+	))
+
+	req, err := http.NewRequest("POST", url, nil)
+	if err != nil {
+		return installationToken{}, fmt.Errorf("request Creation Failed: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+jwt)
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("User-Agent", "neko-cli")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return installationToken{}, fmt.Errorf("API Request Failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return installationToken{}, fmt.Errorf("response Read Failed: %w", err)
+	}
+	if resp.StatusCode != http.StatusCreated {
+		return installationToken{}, fmt.Errorf(
+			"GitHub App token exchange returned status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)),
+		)
+	}
+
+	var payload struct {
+		Token     string    `json:"token"`
+		ExpiresAt time.Time `json:"expires_at"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return installationToken{}, fmt.Errorf("JSON Parse Failed: %w", err)
+	}
+	return installationToken{Token: payload.Token, ExpiresAt: payload.ExpiresAt}, nil
+}
+
+// gitHubAppToken returns an installation token for the App configured in the environment
+func gitHubAppToken() (string, error) {
+	app, err := GitHubAppFromEnv()
+	if err != nil {
+		return "", err
+	}
+	return app.InstallationToken(time.Now())
+}
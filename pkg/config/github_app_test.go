@@ -0,0 +1,265 @@
+package config
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// testKey is generated once, RSA key generation is slow
+var testKey = sync.OnceValue(func() *rsa.PrivateKey {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		panic(err)
+	}
+	return key
+})
+
+func pemEncode(blockType string, der []byte) string {
+	return string(pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}))
+}
+
+// withTokenExchange replaces the token exchange and resets the cached token
+func withTokenExchange(t *testing.T, exchange func(installationID, jwt string) (installationToken, error)) {
+	t.Helper()
+	original := exchangeToken
+	exchangeToken = exchange
+	appToken = installationToken{}
+	t.Cleanup(func() {
+		exchangeToken = original
+		appToken = installationToken{}
+	})
+}
+
+func TestParsePrivateKey(t *testing.T) {
+	pkcs8, err := x509.MarshalPKCS8PrivateKey(testKey())
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecDER, err := x509.MarshalPKCS8PrivateKey(ecKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		data    string
+		wantErr string
+	}{
+		{"pkcs1", pemEncode("RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(testKey())), ""},
+		{"pkcs8", pemEncode("PRIVATE KEY", pkcs8), ""},
+		{"not pem", "-----not a key-----", "not PEM encoded"},
+		{"garbage", pemEncode("PRIVATE KEY", []byte("garbage")), "failed to parse"},
+		{"ecdsa", pemEncode("PRIVATE KEY", ecDER), "must be an RSA key"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, err := ParsePrivateKey([]byte(tt.data))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("ParsePrivateKey error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !key.Equal(testKey()) {
+				t.Error("ParsePrivateKey returned a different key")
+			}
+		})
+	}
+}
+
+func TestJWT(t *testing.T) {
+	app := &GitHubApp{AppID: "12345", PrivateKey: testKey()}
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	jwt, err := app.JWT(now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	parts := strings.Split(jwt, ".")
+	if len(parts) != 3 {
+		t.Fatalf("JWT has %d parts, want 3", len(parts))
+	}
+
+	decode := func(part string, v any) {
+		t.Helper()
+		data, err := base64.RawURLEncoding.DecodeString(part)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := json.Unmarshal(data, v); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var header map[string]string
+	decode(parts[0], &header)
+	if header["alg"] != "RS256" || header["typ"] != "JWT" {
+		t.Errorf("header = %v", header)
+	}
+
+	var claims struct {
+		IssuedAt  int64  `json:"iat"`
+		ExpiresAt int64  `json:"exp"`
+		Issuer    string `json:"iss"`
+	}
+	decode(parts[1], &claims)
+	if claims.Issuer != "12345" {
+		t.Errorf("iss = %q, want the App ID", claims.Issuer)
+	}
+	if want := now.Add(-time.Minute).Unix(); claims.IssuedAt != want {
+		t.Errorf("iat = %d, want %d", claims.IssuedAt, want)
+	}
+	if lifetime := time.Duration(claims.ExpiresAt-claims.IssuedAt) * time.Second; lifetime > 10*time.Minute {
+		t.Errorf("lifetime = %s, GitHub accepts at most 10m", lifetime)
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		t.Fatal(err)
+	}
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	if err := rsa.VerifyPKCS1v15(&testKey().PublicKey, crypto.SHA256, digest[:], signature); err != nil {
+		t.Errorf("signature does not verify: %v", err)
+	}
+}
+
+func TestGitHubAppFromEnv(t *testing.T) {
+	key := pemEncode("RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(testKey()))
+	keyPath := filepath.Join(t.TempDir(), "app.pem")
+	if err := os.WriteFile(keyPath, []byte(key), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name           string
+		appID          string
+		installationID string
+		key            string
+		keyPath        string
+		wantErr        string
+	}{
+		{"inline key", "1", "2", key, "", ""},
+		{"key file", "1", "2", "", keyPath, ""},
+		{"missing app id", "", "2", key, "", "GITHUB_APP_ID"},
+		{"missing installation id", "1", "", key, "", "GITHUB_APP_INSTALLATION_ID"},
+		{"missing key", "1", "2", "", "", "GITHUB_APP_PRIVATE_KEY"},
+		{"unreadable key file", "1", "2", "", filepath.Join(t.TempDir(), "missing.pem"), "failed to read"},
+		{"invalid key", "1", "2", "not a key", "", "not PEM encoded"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("GITHUB_APP_ID", tt.appID)
+			t.Setenv("GITHUB_APP_INSTALLATION_ID", tt.installationID)
+			t.Setenv("GITHUB_APP_PRIVATE_KEY", tt.key)
+			t.Setenv("GITHUB_APP_PRIVATE_KEY_PATH", tt.keyPath)
+
+			app, err := GitHubAppFromEnv()
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("GitHubAppFromEnv error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if app.AppID != "1" || app.InstallationID != "2" || !app.PrivateKey.Equal(testKey()) {
+				t.Errorf("app = %+v", app)
+			}
+		})
+	}
+}
+
+func TestInstallationTokenCache(t *testing.T) {
+	app := &GitHubApp{AppID: "1", InstallationID: "42", PrivateKey: testKey()}
+	now := time.Date(2026, 1, 2, 3, 0, 0, 0, time.UTC)
+
+	var exchanges int
+	withTokenExchange(t, func(installationID, jwt string) (installationToken, error) {
+		exchanges++
+		if installationID != "42" || strings.Count(jwt, ".") != 2 {
+			t.Errorf("exchange(%q, %q)", installationID, jwt)
+		}
+		return installationToken{Token: "ghs_" + string(rune('0'+exchanges)), ExpiresAt: now.Add(time.Hour)}, nil
+	})
+
+	tests := []struct {
+		name      string
+		now       time.Time
+		want      string
+		exchanges int
+	}{
+		{"first call exchanges", now, "ghs_1", 1},
+		{"cached", now.Add(30 * time.Minute), "ghs_1", 1},
+		{"within the refresh margin", now.Add(time.Hour - tokenRefreshMargin/2), "ghs_2", 2},
+	}
+
+	for _, tt := range tests {
+		token, err := app.InstallationToken(tt.now)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if token != tt.want || exchanges != tt.exchanges {
+			t.Errorf("%s: token = %q after %d exchanges, want %q after %d", tt.name, token, exchanges, tt.want, tt.exchanges)
+		}
+	}
+}
+
+func TestInstallationTokenExchangeFailure(t *testing.T) {
+	app := &GitHubApp{AppID: "1", InstallationID: "42", PrivateKey: testKey()}
+	withTokenExchange(t, func(string, string) (installationToken, error) {
+		return installationToken{}, errors.New("status 401")
+	})
+
+	if token, err := app.InstallationToken(time.Now()); err == nil || token != "" {
+		t.Errorf("InstallationToken = %q, %v, want the exchange error", token, err)
+	}
+	if appToken.Token != "" {
+		t.Error("a failed exchange was cached")
+	}
+}
+
+func TestGetPATGitHubApp(t *testing.T) {
+	withoutGitHubCLI(t)
+	t.Setenv(AuthModeEnv, AuthModeApp)
+	t.Setenv("GITHUB_TOKEN", "ghp_ignored")
+	t.Setenv("GITHUB_APP_ID", "1")
+	t.Setenv("GITHUB_APP_INSTALLATION_ID", "42")
+	t.Setenv("GITHUB_APP_PRIVATE_KEY", pemEncode("RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(testKey())))
+	t.Setenv("GITHUB_APP_PRIVATE_KEY_PATH", "")
+	withTokenExchange(t, func(string, string) (installationToken, error) {
+		return installationToken{Token: "ghs_app", ExpiresAt: time.Now().Add(time.Hour)}, nil
+	})
+
+	token, err := GetPAT()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token != "ghs_app" {
+		t.Errorf("GetPAT() = %q, want the installation token", token)
+	}
+}
@@ -0,0 +1,68 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// FileName is the canonical neko config, read by the CLI and the release plugin
+const FileName = ".release.neko.json"
+
+// LegacyFileName is the config of older neko versions, 'neko release migrate-config' moves it into FileName
+const LegacyFileName = ".neko.json"
+
+// ErrNoProject is returned by LoadProject if neither the canonical nor the legacy config exists
+var ErrNoProject = errors.New("no neko configuration found")
+
+// Project holds the fields of the canonical config the CLI reads.
+// The release plugin reads the same file with its full schema, unknown fields are ignored here.
+type Project struct {
+	ProjectName   string `json:"project-name,omitempty"`
+	ProjectOwner  string `json:"project-owner,omitempty"`
+	ProjectType   string `json:"project-type"`
+	ReleaseSystem string `json:"release-system"`
+	Version       string `json:"version"`
+
+	// Path is the file the project was read from
+	Path string `json:"-"`
+}
+
+// Legacy reports whether the project was read from the legacy .neko.json
+func (p *Project) Legacy() bool {
+	return p.Path == LegacyFileName
+}
+
+// LoadProject reads the canonical config, falling back to the legacy .neko.json if it does not exist
+func LoadProject() (*Project, error) {
+	for _, path := range []string{FileName, LegacyFileName} {
+		project, err := LoadProjectFrom(path)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		return project, err
+	}
+	return nil, fmt.Errorf("%w: run 'neko release init' first", ErrNoProject)
+}
+
+// LoadProjectFrom reads the project fields of the config at the given path
+func LoadProjectFrom(path string) (*Project, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var project Project
+	if err := json.Unmarshal(data, &project); err != nil {
+		return nil, fmt.Errorf("configuration parse error in %s: %w", path, err)
+	}
+	project.Path = path
+	return &project, nil
+}
+
+// LegacyExists reports whether an old .neko.json is left in the current directory
+func LegacyExists() bool {
+	_, err := os.Stat(LegacyFileName)
+	return err == nil
+}
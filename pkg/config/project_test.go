@@ -0,0 +1,102 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"testing"
+)
+
+// canonicalConfig carries plugin-only fields the CLI ignores
+const canonicalConfig = `{
+  "project-name": "neko-cli",
+  "project-owner": "nekoman-hq",
+  "project-type": "backend",
+  "release-system": "goreleaser",
+  "version": "1.2.0",
+  "release-args": ["--skip=announce"],
+  "gitmoji": {":rocket:": "feat"}
+}`
+
+func TestLoadProject(t *testing.T) {
+	tests := []struct {
+		name      string
+		canonical string
+		legacy    string
+		want      Project
+		wantErr   error
+	}{
+		{
+			name:      "canonical",
+			canonical: canonicalConfig,
+			want: Project{
+				ProjectName: "neko-cli", ProjectOwner: "nekoman-hq", ProjectType: "backend",
+				ReleaseSystem: "goreleaser", Version: "1.2.0", Path: FileName,
+			},
+		},
+		{
+			name:   "legacy fallback",
+			legacy: `{"project-type": "frontend", "release-system": "release-it", "version": "0.3.0"}`,
+			want:   Project{ProjectType: "frontend", ReleaseSystem: "release-it", Version: "0.3.0", Path: LegacyFileName},
+		},
+		{
+			name:      "canonical wins over legacy",
+			canonical: canonicalConfig,
+			legacy:    `{"project-type": "frontend", "release-system": "release-it", "version": "0.3.0"}`,
+			want: Project{
+				ProjectName: "neko-cli", ProjectOwner: "nekoman-hq", ProjectType: "backend",
+				ReleaseSystem: "goreleaser", Version: "1.2.0", Path: FileName,
+			},
+		},
+		{name: "none", wantErr: ErrNoProject},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Chdir(t.TempDir())
+			writeConfig(t, FileName, tt.canonical)
+			writeConfig(t, LegacyFileName, tt.legacy)
+
+			project, err := LoadProject()
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("LoadProject error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if *project != tt.want {
+				t.Errorf("LoadProject = %+v, want %+v", *project, tt.want)
+			}
+			if project.Legacy() != (tt.want.Path == LegacyFileName) {
+				t.Errorf("Legacy() = %t for %s", project.Legacy(), project.Path)
+			}
+			if LegacyExists() != (tt.legacy != "") {
+				t.Errorf("LegacyExists() = %t", LegacyExists())
+			}
+		})
+	}
+}
+
+func TestLoadProjectInvalid(t *testing.T) {
+	t.Chdir(t.TempDir())
+	writeConfig(t, FileName, "{")
+	writeConfig(t, LegacyFileName, canonicalConfig)
+
+	// A broken canonical config must not silently fall back to the legacy file
+	if project, err := LoadProject(); err == nil || errors.Is(err, ErrNoProject) {
+		t.Errorf("LoadProject = %+v, %v, want a parse error", project, err)
+	}
+}
+
+// writeConfig writes the config file unless content is empty
+func writeConfig(t *testing.T, name, content string) {
+	t.Helper()
+	if content == "" {
+		return
+	}
+	if err := os.WriteFile(name, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -9,9 +9,10 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"regexp"
 	"strings"
-	"time"
 
+	"github.com/nekoman-hq/neko-cli/pkg/log"
 	"github.com/nekoman-hq/neko-cli/pkg/plugin"
 )
 
@@ -32,6 +33,7 @@ func (d *Dispatcher) Dispatch(ctx context.Context, pluginName string, req plugin
 		return nil, fmt.Errorf("plugin not found: %w", err)
 	}
 
+	req.Context.ProtocolVersion = plugin.ProtocolVersion
 	reqJSON, err := json.Marshal(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
@@ -50,7 +52,8 @@ func (d *Dispatcher) Dispatch(ctx context.Context, pluginName string, req plugin
 			var resp plugin.Response
 			if jsonErr := json.Unmarshal(stdout.Bytes(), &resp); jsonErr == nil {
 				// Valid response found, parse logs and return it
-				resp.Logs = parseLogOutput(stderr.String())
+				resp.Logs, resp.Progress = parseLogOutput(stderr.String())
+				checkProtocol(pluginName, &resp)
 				return &resp, nil
 			}
 		}
@@ -62,20 +65,32 @@ func (d *Dispatcher) Dispatch(ctx context.Context, pluginName string, req plugin
 		return nil, fmt.Errorf("failed to parse plugin response: %w\nOutput: %s", err, stdout.String())
 	}
 
-	// Parse stderr as structured logs
-	resp.Logs = parseLogOutput(stderr.String())
+	// Parse stderr as structured logs and progress events
+	resp.Logs, resp.Progress = parseLogOutput(stderr.String())
+	checkProtocol(pluginName, &resp)
 
 	return &resp, nil
 }
 
-// parseLogOutput converts stderr lines into structured log entries
-// Expected format: "15:04:05 [category] message" or plain text
-func parseLogOutput(stderr string) []plugin.LogEntry {
+// checkProtocol adds a warning to the response if the plugin speaks another protocol version
+func checkProtocol(pluginName string, resp *plugin.Response) {
+	if err := plugin.CheckProtocol(pluginName, resp.Metadata.ProtocolVersion); err != nil {
+		resp.Warnings = append(resp.Warnings, plugin.ResponseError{
+			Code:    "PROTOCOL_MISMATCH",
+			Message: err.Error(),
+		})
+	}
+}
+
+// parseLogOutput converts stderr lines into structured log entries and progress events
+// Expected format: "<timestamp> [category] message", "PROGRESS {...}" or plain text
+func parseLogOutput(stderr string) ([]plugin.LogEntry, []plugin.ProgressEvent) {
 	if stderr == "" {
-		return nil
+		return nil, nil
 	}
 
 	var logs []plugin.LogEntry
+	var progress []plugin.ProgressEvent
 	scanner := bufio.NewScanner(strings.NewReader(stderr))
 
 	for scanner.Scan() {
@@ -84,33 +99,54 @@ func parseLogOutput(stderr string) []plugin.LogEntry {
 			continue
 		}
 
+		if event, ok := parseProgressLine(line); ok {
+			progress = append(progress, event)
+			continue
+		}
+
 		entry := parseLogLine(line)
 		logs = append(logs, entry)
 	}
 
-	return logs
+	return logs, progress
 }
 
-// parseLogLine attempts to parse a structured log line
-func parseLogLine(line string) plugin.LogEntry {
-	// Try to parse: "15:04:05 [category] message"
-	parts := strings.SplitN(line, " ", 3)
+// parseProgressLine parses a "PROGRESS {...}" line emitted by log.PluginProgress
+func parseProgressLine(line string) (plugin.ProgressEvent, bool) {
+	payload, found := strings.CutPrefix(line, plugin.ProgressMarker+" ")
+	if !found {
+		return plugin.ProgressEvent{}, false
+	}
 
-	if len(parts) >= 3 && strings.HasPrefix(parts[1], "[") && strings.HasSuffix(parts[1], "]") {
-		category := strings.Trim(parts[1], "[]")
-		level := inferLogLevel(parts[2])
+	var event plugin.ProgressEvent
+	if err := json.Unmarshal([]byte(payload), &event); err != nil {
+		return plugin.ProgressEvent{}, false
+	}
+	return event, true
+}
 
+// logLinePattern matches "<timestamp> [category] message". The timestamp is everything before the
+// first category, so any layout configured with NEKO_LOG_TIME_FORMAT parses, even one containing spaces.
+// The category may be wrapped in color codes.
+var logLinePattern = regexp.MustCompile(`^(.+?) (?:\x1b\[[0-9;]*m)?\[([^\]\s]+)\](?:\x1b\[[0-9;]*m)? (.*)$`)
+
+// ansiEscape matches color codes, which would hide the verbose marker from inferLogLevel
+var ansiEscape = regexp.MustCompile("\x1b\\[[0-9;]*m")
+
+// parseLogLine attempts to parse a structured log line
+func parseLogLine(line string) plugin.LogEntry {
+	if m := logLinePattern.FindStringSubmatch(line); m != nil {
 		return plugin.LogEntry{
-			Timestamp: parts[0],
-			Level:     level,
-			Category:  category,
-			Message:   parts[2],
+			Timestamp: m[1],
+			Level:     inferLogLevel(ansiEscape.ReplaceAllString(m[3], "")),
+			Category:  m[2],
+			Message:   m[3],
 		}
 	}
 
 	// Fallback: plain text log
 	return plugin.LogEntry{
-		Timestamp: time.Now().Format("15:04:05"),
+		Timestamp: log.Timestamp(),
 		Level:     "info",
 		Category:  "plugin",
 		Message:   line,
@@ -132,39 +168,80 @@ func inferLogLevel(msg string) string {
 	return "info"
 }
 
+// BinaryPath returns the path of the binary executed for the plugin, whether it exists or not
+func (d *Dispatcher) BinaryPath(name string) string {
+	return filepath.Join(d.pluginDir, name, fmt.Sprintf("plugin-%s", name))
+}
+
+// ManifestPath returns the path of the plugin's manifest, whether it exists or not
+func (d *Dispatcher) ManifestPath(name string) string {
+	return filepath.Join(d.pluginDir, name, "manifest.json")
+}
+
 func (d *Dispatcher) findPlugin(name string) (string, error) {
-	pluginPath := filepath.Join(d.pluginDir, name, fmt.Sprintf("plugin-%s", name))
+	pluginPath := d.BinaryPath(name)
 	if _, err := os.Stat(pluginPath); os.IsNotExist(err) {
 		return "", fmt.Errorf("plugin '%s' not found at %s", name, pluginPath)
 	}
 	return pluginPath, nil
 }
 
+// ManifestWarning describes an installed plugin whose manifest could not be loaded
+type ManifestWarning struct {
+	Plugin string
+	Path   string
+	Err    error
+}
+
+// Reason is a short description of the problem for listings
+func (w ManifestWarning) Reason() string {
+	if os.IsNotExist(w.Err) {
+		return "manifest missing"
+	}
+	return "manifest unreadable"
+}
+
+func (w ManifestWarning) Error() string {
+	return fmt.Sprintf("plugin '%s': %s", w.Plugin, w.Err.Error())
+}
+
+// ListPlugins returns the manifests of all installed plugins, skipping plugins without a readable manifest
 func (d *Dispatcher) ListPlugins() ([]plugin.Manifest, error) {
+	manifests, _, err := d.ScanPlugins()
+	return manifests, err
+}
+
+// ScanPlugins returns the manifests of all installed plugins and a warning
+// for every plugin directory whose manifest is missing or cannot be parsed
+func (d *Dispatcher) ScanPlugins() ([]plugin.Manifest, []ManifestWarning, error) {
 	entries, err := os.ReadDir(d.pluginDir)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	var manifests []plugin.Manifest
+	var warnings []ManifestWarning
 	for _, entry := range entries {
 		if !entry.IsDir() {
 			continue
 		}
 
-		manifestPath := filepath.Join(d.pluginDir, entry.Name(), "manifest.json")
+		manifestPath := d.ManifestPath(entry.Name())
 		data, err := os.ReadFile(manifestPath)
 		if err != nil {
+			warnings = append(warnings, ManifestWarning{Plugin: entry.Name(), Path: manifestPath, Err: err})
 			continue
 		}
 
 		var manifest plugin.Manifest
 		if err := json.Unmarshal(data, &manifest); err != nil {
+			warnings = append(warnings, ManifestWarning{Plugin: entry.Name(), Path: manifestPath,
+				Err: fmt.Errorf("invalid manifest %s: %w", manifestPath, err)})
 			continue
 		}
 
 		manifests = append(manifests, manifest)
 	}
 
-	return manifests, nil
+	return manifests, warnings, nil
 }
@@ -0,0 +1,110 @@
+package dispatcher
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/nekoman-hq/neko-cli/pkg/plugin"
+)
+
+const (
+	// DefaultMaxConcurrency is used if DispatchOptions.MaxConcurrency is not set
+	DefaultMaxConcurrency = 4
+
+	// DefaultMinInterval spaces plugin starts to stay below GitHub's secondary rate limits
+	DefaultMinInterval = 250 * time.Millisecond
+)
+
+// DispatchOptions configures DispatchAll
+type DispatchOptions struct {
+	// MaxConcurrency bounds the number of plugins running at the same time
+	MaxConcurrency int
+	// MinInterval is the minimum time between two plugin starts, shared by all workers
+	MinInterval time.Duration
+}
+
+// DispatchResult is the outcome of dispatching a request to a single plugin
+type DispatchResult struct {
+	Plugin   string
+	Response *plugin.Response
+	Err      error
+}
+
+// DispatchAll sends the same request to all plugins concurrently.
+// Individual failures do not abort the batch; results keep the order of pluginNames.
+func (d *Dispatcher) DispatchAll(ctx context.Context, pluginNames []string, req plugin.Request, opts DispatchOptions) []DispatchResult {
+	return dispatchAll(ctx, pluginNames, opts, func(ctx context.Context, name string) (*plugin.Response, error) {
+		return d.Dispatch(ctx, name, req)
+	})
+}
+
+func dispatchAll(
+	ctx context.Context,
+	names []string,
+	opts DispatchOptions,
+	dispatch func(ctx context.Context, name string) (*plugin.Response, error),
+) []DispatchResult {
+	concurrency := opts.MaxConcurrency
+	if concurrency <= 0 {
+		concurrency = DefaultMaxConcurrency
+	}
+	limiter := &rateLimiter{interval: opts.MinInterval}
+
+	results := make([]DispatchResult, len(names))
+	sem := make(chan struct{}, concurrency)
+
+	var wg sync.WaitGroup
+	for i, name := range names {
+		wg.Add(1)
+		go func(i int, name string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			if err := limiter.Wait(ctx); err != nil {
+				results[i] = DispatchResult{Plugin: name, Err: err}
+				return
+			}
+
+			resp, err := dispatch(ctx, name)
+			results[i] = DispatchResult{Plugin: name, Response: resp, Err: err}
+		}(i, name)
+	}
+	wg.Wait()
+
+	return results
+}
+
+// rateLimiter hands out start slots at least interval apart
+type rateLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	next     time.Time
+}
+
+// Wait blocks until the next start slot or until ctx is done
+func (l *rateLimiter) Wait(ctx context.Context) error {
+	if l.interval <= 0 {
+		return ctx.Err()
+	}
+
+	l.mu.Lock()
+	now := time.Now()
+	slot := l.next
+	if slot.Before(now) {
+		slot = now
+	}
+	l.next = slot.Add(l.interval)
+	l.mu.Unlock()
+
+	timer := time.NewTimer(slot.Sub(now))
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,128 @@
+package dispatcher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/nekoman-hq/neko-cli/pkg/plugin"
+)
+
+// countingDispatch records the peak number of concurrent dispatches
+type countingDispatch struct {
+	running atomic.Int32
+	peak    atomic.Int32
+}
+
+func (c *countingDispatch) dispatch(_ context.Context, name string) (*plugin.Response, error) {
+	n := c.running.Add(1)
+	defer c.running.Add(-1)
+	for {
+		peak := c.peak.Load()
+		if n <= peak || c.peak.CompareAndSwap(peak, n) {
+			break
+		}
+	}
+
+	time.Sleep(10 * time.Millisecond)
+	if name == "broken" {
+		return nil, errors.New("plugin execution failed")
+	}
+	return &plugin.Response{Status: "success", Metadata: plugin.ResponseMetadata{Plugin: name}}, nil
+}
+
+func TestDispatchAllConcurrency(t *testing.T) {
+	names := make([]string, 12)
+	for i := range names {
+		names[i] = fmt.Sprintf("plugin-%d", i)
+	}
+
+	tests := []struct {
+		maxConcurrency int
+		wantPeak       int32
+	}{
+		{1, 1},
+		{3, 3},
+		{0, DefaultMaxConcurrency},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("max %d", tt.maxConcurrency), func(t *testing.T) {
+			c := &countingDispatch{}
+			results := dispatchAll(context.Background(), names, DispatchOptions{MaxConcurrency: tt.maxConcurrency}, c.dispatch)
+
+			if peak := c.peak.Load(); peak > tt.wantPeak {
+				t.Errorf("%d plugins ran at once, want at most %d", peak, tt.wantPeak)
+			}
+			for i, r := range results {
+				if r.Plugin != names[i] || r.Err != nil || r.Response.Metadata.Plugin != names[i] {
+					t.Errorf("result %d = %+v, want the response of %s", i, r, names[i])
+				}
+			}
+		})
+	}
+}
+
+func TestDispatchAllKeepsGoing(t *testing.T) {
+	c := &countingDispatch{}
+	results := dispatchAll(context.Background(), []string{"release", "broken", "docs"}, DispatchOptions{MaxConcurrency: 2}, c.dispatch)
+
+	if results[1].Err == nil || results[1].Plugin != "broken" {
+		t.Errorf("result of the broken plugin = %+v, want its error", results[1])
+	}
+	if results[0].Err != nil || results[2].Err != nil {
+		t.Errorf("a failing plugin aborted the others: %+v", results)
+	}
+}
+
+func TestRateLimiter(t *testing.T) {
+	const interval = 20 * time.Millisecond
+	l := &rateLimiter{interval: interval}
+
+	var mu sync.Mutex
+	var starts []time.Time
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := l.Wait(context.Background()); err != nil {
+				t.Error(err)
+			}
+			mu.Lock()
+			starts = append(starts, time.Now())
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	first, last := starts[0], starts[0]
+	for _, s := range starts {
+		if s.Before(first) {
+			first = s
+		}
+		if s.After(last) {
+			last = s
+		}
+	}
+	if spread := last.Sub(first); spread < 3*interval-5*time.Millisecond {
+		t.Errorf("4 starts spread over %v, want at least %v", spread, 3*interval)
+	}
+}
+
+func TestRateLimiterCancelled(t *testing.T) {
+	l := &rateLimiter{interval: time.Hour}
+	if err := l.Wait(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := l.Wait(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("Wait with a cancelled context = %v, want context.Canceled", err)
+	}
+}
@@ -0,0 +1,254 @@
+package dispatcher
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nekoman-hq/neko-cli/pkg/log"
+	"github.com/nekoman-hq/neko-cli/pkg/plugin"
+)
+
+func TestParseLogOutputProgress(t *testing.T) {
+	stderr := "09:41:37 [init] Starting release\n" +
+		`PROGRESS {"timestamp":"09:41:37","step":"Pre-flight checks","current":1,"total":5}` + "\n" +
+		"\n" +
+		"plain text from a tool\n" +
+		`  PROGRESS {"timestamp":"09:41:38","step":"Version guard","current":2,"total":5}  ` + "\n" +
+		"PROGRESS not json\n" +
+		"PROGRESS:{}\n" +
+		"09:41:39 [release] Release failed\n"
+
+	logs, progress := parseLogOutput(stderr)
+
+	wantProgress := []plugin.ProgressEvent{
+		{Timestamp: "09:41:37", Step: "Pre-flight checks", Current: 1, Total: 5},
+		{Timestamp: "09:41:38", Step: "Version guard", Current: 2, Total: 5},
+	}
+	if !slices.Equal(progress, wantProgress) {
+		t.Errorf("progress = %+v, want %+v", progress, wantProgress)
+	}
+
+	// Malformed progress lines stay visible as plain logs
+	wantMessages := []string{"Starting release", "plain text from a tool", "PROGRESS not json", "PROGRESS:{}", "Release failed"}
+	var messages []string
+	for _, l := range logs {
+		messages = append(messages, l.Message)
+	}
+	if !slices.Equal(messages, wantMessages) {
+		t.Errorf("log messages = %q, want %q", messages, wantMessages)
+	}
+	if logs[4].Level != "error" || logs[4].Category != "release" {
+		t.Errorf("last log = %+v, want an error in release", logs[4])
+	}
+}
+
+func TestParseLogOutputEmpty(t *testing.T) {
+	logs, progress := parseLogOutput("")
+	if logs != nil || progress != nil {
+		t.Errorf("parseLogOutput(\"\") = %v, %v, want nil", logs, progress)
+	}
+}
+
+// What log.PluginProgress writes must parse back into the same event
+func TestPluginProgressRoundTrip(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stderr := os.Stderr
+	os.Stderr = w
+	log.PluginPrint(log.Exec, "Releasing")
+	log.PluginProgress("Release", 4, 5)
+	os.Stderr = stderr
+	_ = w.Close()
+
+	out, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	logs, progress := parseLogOutput(string(out))
+	if len(logs) != 1 {
+		t.Errorf("logs = %+v, want the release log only", logs)
+	}
+	if len(progress) != 1 {
+		t.Fatalf("progress = %+v, want one event", progress)
+	}
+	if e := progress[0]; e.Step != "Release" || e.Current != 4 || e.Total != 5 || e.Timestamp == "" {
+		t.Errorf("event = %+v, want Release 4/5 with a timestamp", e)
+	}
+}
+
+// stubPlugin installs a shell script plugin that saves its request to requestFile and prints response
+func stubPlugin(t *testing.T, dir, name, response string, exitCode int) (requestFile string) {
+	t.Helper()
+	requestFile = filepath.Join(t.TempDir(), "request.json")
+	script := fmt.Sprintf("#!/bin/sh\ncat > '%s'\necho '%s'\nexit %d\n", requestFile, response, exitCode)
+	if err := os.MkdirAll(filepath.Join(dir, name), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, name, "plugin-"+name), []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	return requestFile
+}
+
+func TestDispatchProtocolVersion(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("stub plugins are shell scripts")
+	}
+	tests := []struct {
+		name        string
+		version     int
+		exitCode    int
+		wantWarning bool
+	}{
+		{"unversioned plugin", 0, 0, false},
+		{"same version", plugin.ProtocolVersion, 0, false},
+		{"newer plugin", plugin.ProtocolVersion + 1, 0, true},
+		{"newer plugin failing", plugin.ProtocolVersion + 1, 1, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			response := fmt.Sprintf(`{"status": "success", "metadata": {"plugin": "stub", "protocol_version": %d}}`, tt.version)
+			requestFile := stubPlugin(t, dir, "stub", response, tt.exitCode)
+
+			resp, err := NewDispatcher(dir).Dispatch(context.Background(), "stub", plugin.Request{Command: "run"})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			data, err := os.ReadFile(requestFile)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var req plugin.Request
+			if err := json.Unmarshal(data, &req); err != nil {
+				t.Fatal(err)
+			}
+			if req.Context.ProtocolVersion != plugin.ProtocolVersion {
+				t.Errorf("sent protocol version %d, want %d", req.Context.ProtocolVersion, plugin.ProtocolVersion)
+			}
+
+			warned := slices.ContainsFunc(resp.Warnings, func(w plugin.ResponseError) bool { return w.Code == "PROTOCOL_MISMATCH" })
+			if warned != tt.wantWarning {
+				t.Errorf("PROTOCOL_MISMATCH warning = %t, want %t: %+v", warned, tt.wantWarning, resp.Warnings)
+			}
+		})
+	}
+}
+
+// A log line written with any configured timestamp format parses back into the same time
+func TestParseLogLineTimestampRoundTrip(t *testing.T) {
+	at := time.Date(2026, 3, 1, 11, 4, 5, 123456789, time.UTC)
+
+	tests := []struct {
+		format   string
+		timezone string
+		category string
+		// want is the time the timestamp parses to, zero for layouts without a date
+		want time.Time
+	}{
+		{"", "UTC", "[exec]", time.Time{}},
+		{"datetime", "UTC", "[exec]", at.Truncate(time.Second)},
+		{"rfc3339", "Europe/Berlin", "[exec]", at.Truncate(time.Second)},
+		{"rfc3339nano", "UTC", "[exec]", at},
+		{"Mon Jan 2 15:04:05 MST 2006", "UTC", "[exec]", at.Truncate(time.Second)},
+		{"datetime", "UTC", "\x1b[92m[exec]\x1b[0m", at.Truncate(time.Second)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format+" "+tt.timezone+" "+tt.category, func(t *testing.T) {
+			f, err := log.ParseTimestampFormat(tt.format, tt.timezone)
+			if err != nil {
+				t.Skip("no timezone database:", err)
+			}
+			timestamp := f.Format(at)
+
+			entry := parseLogLine(timestamp + " " + tt.category + " Release failed")
+			if entry.Timestamp != timestamp || entry.Category != "exec" || entry.Message != "Release failed" || entry.Level != "error" {
+				t.Fatalf("parseLogLine = %+v, want %q [exec] Release failed", entry, timestamp)
+			}
+
+			parsed, err := time.ParseInLocation(f.Layout, entry.Timestamp, f.Location)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.want.IsZero() {
+				if parsed.Format(time.TimeOnly) != at.Format(time.TimeOnly) {
+					t.Errorf("parsed %s, want the clock time of %s", parsed, at)
+				}
+			} else if !parsed.Equal(tt.want) {
+				t.Errorf("parsed %s, want %s", parsed, tt.want)
+			}
+		})
+	}
+}
+
+func TestScanPlugins(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, manifest string) {
+		if err := os.MkdirAll(filepath.Join(dir, name), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if manifest != "" {
+			if err := os.WriteFile(filepath.Join(dir, name, "manifest.json"), []byte(manifest), 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	write("release", `{"name": "release", "version": "1.0.0"}`)
+	write("broken", `{"name": `)
+	write("empty", "")
+	if err := os.WriteFile(filepath.Join(dir, "README"), []byte("not a plugin"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	manifests, warnings, err := NewDispatcher(dir).ScanPlugins()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(manifests) != 1 || manifests[0].Name != "release" {
+		t.Errorf("manifests = %+v, want only release", manifests)
+	}
+
+	tests := []struct {
+		plugin     string
+		wantReason string
+	}{
+		{"broken", "manifest unreadable"},
+		{"empty", "manifest missing"},
+	}
+	if len(warnings) != len(tests) {
+		t.Fatalf("warnings = %+v, want %d", warnings, len(tests))
+	}
+	for i, tt := range tests {
+		t.Run(tt.plugin, func(t *testing.T) {
+			w := warnings[i]
+			if w.Plugin != tt.plugin || w.Reason() != tt.wantReason {
+				t.Errorf("warning = %+v (%s), want %s with %s", w, w.Reason(), tt.plugin, tt.wantReason)
+			}
+			if w.Path != filepath.Join(dir, tt.plugin, "manifest.json") {
+				t.Errorf("Path = %s, want the manifest of %s", w.Path, tt.plugin)
+			}
+			if !strings.HasPrefix(w.Error(), "plugin '"+tt.plugin+"': ") {
+				t.Errorf("Error() = %q, want it to name the plugin", w.Error())
+			}
+		})
+	}
+
+	if listed, err := NewDispatcher(dir).ListPlugins(); err != nil || len(listed) != 1 {
+		t.Errorf("ListPlugins = %+v, %v, want the valid manifest only", listed, err)
+	}
+}
@@ -47,7 +47,7 @@ func PrintError(err CLIError) {
 		color = log.ColorRed
 	}
 
-	_, _ = fmt.Fprintf(os.Stderr, "%s%s%s", color, log.ColorBold, prefix)
+	_, _ = fmt.Fprintf(os.Stderr, "%s%s%s", color, log.ColorBold, log.Sanitize(prefix))
 	if err.Title != "" {
 		_, _ = fmt.Fprintf(os.Stderr, ": %s", err.Title)
 	}
@@ -21,19 +21,29 @@ var (
 	PluginVersion = "1.0.0"
 )
 
+// warnings collects the warnings recorded by WriteWarning during the plugin run
+var warnings []plugin.ResponseError
+
+// Warnings returns the warnings recorded so far, plugins attach them to their response
+func Warnings() []plugin.ResponseError {
+	return warnings
+}
+
 // WriteError writes an error response to stdout and exits
 func WriteError(code, message string) {
 	resp := plugin.Response{
 		Status: "error",
 		Metadata: plugin.ResponseMetadata{
-			Plugin:    PluginName,
-			Version:   PluginVersion,
-			Timestamp: time.Now(),
+			Plugin:          PluginName,
+			Version:         PluginVersion,
+			Timestamp:       time.Now(),
+			ProtocolVersion: plugin.ProtocolVersion,
 		},
 		Error: &plugin.ResponseError{
 			Code:    code,
 			Message: message,
 		},
+		Warnings: warnings,
 	}
 	_ = json.NewEncoder(os.Stdout).Encode(resp)
 	os.Exit(1)
@@ -44,28 +54,32 @@ func WriteErrorWithDetails(code, message string, details map[string]any) {
 	resp := plugin.Response{
 		Status: "error",
 		Metadata: plugin.ResponseMetadata{
-			Plugin:    PluginName,
-			Version:   PluginVersion,
-			Timestamp: time.Now(),
+			Plugin:          PluginName,
+			Version:         PluginVersion,
+			Timestamp:       time.Now(),
+			ProtocolVersion: plugin.ProtocolVersion,
 		},
 		Error: &plugin.ResponseError{
 			Code:    code,
 			Message: message,
 			Details: details,
 		},
+		Warnings: warnings,
 	}
 	_ = json.NewEncoder(os.Stdout).Encode(resp)
 	os.Exit(1)
 }
 
-// WriteWarning writes a warning response to stdout (does not exit)
+// WriteWarning records a warning for the response of the plugin (does not exit)
 func WriteWarning(code, message string) *plugin.Response {
+	warnings = append(warnings, plugin.ResponseError{Code: code, Message: message})
 	return &plugin.Response{
 		Status: "warning",
 		Metadata: plugin.ResponseMetadata{
-			Plugin:    PluginName,
-			Version:   PluginVersion,
-			Timestamp: time.Now(),
+			Plugin:          PluginName,
+			Version:         PluginVersion,
+			Timestamp:       time.Now(),
+			ProtocolVersion: plugin.ProtocolVersion,
 		},
 		Error: &plugin.ResponseError{
 			Code:    code,
@@ -79,9 +93,10 @@ func NewErrorResponse(code, message string) *plugin.Response {
 	return &plugin.Response{
 		Status: "error",
 		Metadata: plugin.ResponseMetadata{
-			Plugin:    PluginName,
-			Version:   PluginVersion,
-			Timestamp: time.Now(),
+			Plugin:          PluginName,
+			Version:         PluginVersion,
+			Timestamp:       time.Now(),
+			ProtocolVersion: plugin.ProtocolVersion,
 		},
 		Error: &plugin.ResponseError{
 			Code:    code,
@@ -95,9 +110,10 @@ func NewErrorResponseWithDetails(code, message string, details map[string]any) *
 	return &plugin.Response{
 		Status: "error",
 		Metadata: plugin.ResponseMetadata{
-			Plugin:    PluginName,
-			Version:   PluginVersion,
-			Timestamp: time.Now(),
+			Plugin:          PluginName,
+			Version:         PluginVersion,
+			Timestamp:       time.Now(),
+			ProtocolVersion: plugin.ProtocolVersion,
 		},
 		Error: &plugin.ResponseError{
 			Code:    code,
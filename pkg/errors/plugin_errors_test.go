@@ -0,0 +1,82 @@
+package errors
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"slices"
+	"testing"
+
+	"github.com/nekoman-hq/neko-cli/pkg/plugin"
+)
+
+// resetWarnings drops the recorded warnings after the test
+func resetWarnings(t *testing.T) {
+	t.Helper()
+	warnings = nil
+	t.Cleanup(func() { warnings = nil })
+}
+
+func TestWriteWarning(t *testing.T) {
+	resetWarnings(t)
+
+	resp := WriteWarning("CONFIG_UPDATE_FAILED", "could not write the version")
+	if resp.Status != "warning" {
+		t.Errorf("status = %s, want warning", resp.Status)
+	}
+	WriteWarning("TAG_DIVERGED", "v1.2.0 differs on origin")
+
+	want := []plugin.ResponseError{
+		{Code: "CONFIG_UPDATE_FAILED", Message: "could not write the version"},
+		{Code: "TAG_DIVERGED", Message: "v1.2.0 differs on origin"},
+	}
+	if got := Warnings(); !slices.EqualFunc(got, want, func(a, b plugin.ResponseError) bool {
+		return a.Code == b.Code && a.Message == b.Message
+	}) {
+		t.Errorf("Warnings() = %+v, want %+v", got, want)
+	}
+}
+
+// WriteError exits, so it runs in a subprocess re-executing this test
+func TestWriteErrorIncludesWarnings(t *testing.T) {
+	if os.Getenv("NEKO_TEST_WRITE_ERROR") == "1" {
+		WriteWarning("TAG_DIVERGED", "v1.2.0 differs on origin")
+		WriteError("RELEASE_FAILED", "push rejected")
+		return
+	}
+
+	cmd := exec.Command(os.Args[0], "-test.run=^TestWriteErrorIncludesWarnings$")
+	cmd.Env = append(os.Environ(), "NEKO_TEST_WRITE_ERROR=1")
+	out, err := cmd.Output()
+	if exitErr, ok := err.(*exec.ExitError); !ok || exitErr.ExitCode() != 1 {
+		t.Fatalf("WriteError exited with %v, want exit status 1", err)
+	}
+
+	var resp plugin.Response
+	if err := json.Unmarshal(out, &resp); err != nil {
+		t.Fatalf("WriteError wrote %q: %v", out, err)
+	}
+	if resp.Error == nil || resp.Error.Code != "RELEASE_FAILED" {
+		t.Errorf("error = %+v, want RELEASE_FAILED", resp.Error)
+	}
+	if len(resp.Warnings) != 1 || resp.Warnings[0].Code != "TAG_DIVERGED" {
+		t.Errorf("warnings = %+v, want the recorded warning", resp.Warnings)
+	}
+	if resp.Metadata.ProtocolVersion != plugin.ProtocolVersion {
+		t.Errorf("protocol version = %d, want %d", resp.Metadata.ProtocolVersion, plugin.ProtocolVersion)
+	}
+}
+
+func TestErrorResponsesReportProtocol(t *testing.T) {
+	resetWarnings(t)
+	responses := map[string]*plugin.Response{
+		"NewErrorResponse":            NewErrorResponse("FAILED", "failed"),
+		"NewErrorResponseWithDetails": NewErrorResponseWithDetails("FAILED", "failed", nil),
+		"WriteWarning":                WriteWarning("WARNED", "warned"),
+	}
+	for name, resp := range responses {
+		if resp.Metadata.ProtocolVersion != plugin.ProtocolVersion {
+			t.Errorf("%s protocol version = %d, want %d", name, resp.Metadata.ProtocolVersion, plugin.ProtocolVersion)
+		}
+	}
+}
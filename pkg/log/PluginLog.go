@@ -1,9 +1,11 @@
 package log
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
-	"time"
+
+	"github.com/nekoman-hq/neko-cli/pkg/plugin"
 )
 
 /*
@@ -24,8 +26,8 @@ func PluginPrint(cat Category, msg string, args ...any) {
 
 	prefix := fmt.Sprintf("[%s]", cat)
 	coloredPrefix := ColorText(color, prefix)
-	timestamp := time.Now().Format("15:04:05")
-	fullMsg := fmt.Sprintf(msg, args...)
+	timestamp := Timestamp()
+	fullMsg := Sanitize(fmt.Sprintf(msg, args...))
 
 	// Write to stderr so dispatcher can capture it
 	_, _ = fmt.Fprintf(os.Stderr, "%s %s %s\n", timestamp, coloredPrefix, fullMsg)
@@ -42,3 +44,19 @@ func PluginV(cat Category, msg string, args ...any) {
 
 	PluginPrint(cat, enhancedMsg, args...)
 }
+
+// PluginProgress writes a progress event to stderr.
+// The dispatcher collects these into the response instead of the logs.
+func PluginProgress(step string, current, total int) {
+	event, err := json.Marshal(plugin.ProgressEvent{
+		Timestamp: Timestamp(),
+		Step:      step,
+		Current:   current,
+		Total:     total,
+	})
+	if err != nil {
+		return
+	}
+
+	_, _ = fmt.Fprintf(os.Stderr, "%s %s\n", plugin.ProgressMarker, event)
+}
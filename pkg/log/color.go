@@ -27,6 +27,10 @@ const (
 	ColorBrightWhite  = "\033[97m"
 )
 
+// ColorText wraps text in the color, unless the active theme disables coloring
 func ColorText(color, text string) string {
+	if !ColorsEnabled() || color == "" {
+		return text
+	}
 	return color + text + ColorReset
 }
@@ -11,7 +11,6 @@ For logs in the core tool
 
 import (
 	"fmt"
-	"time"
 )
 
 func Print(cat Category, msg string, args ...any) {
@@ -22,8 +21,8 @@ func Print(cat Category, msg string, args ...any) {
 
 	prefix := fmt.Sprintf("[%s]", cat)
 	coloredPrefix := ColorText(color, prefix)
-	timestamp := time.Now().Format("15:04:05")
-	fullMsg := fmt.Sprintf(msg, args...)
+	timestamp := Timestamp()
+	fullMsg := Sanitize(fmt.Sprintf(msg, args...))
 	fmt.Printf("%s %s %s\n", timestamp, coloredPrefix, fullMsg)
 }
 
@@ -0,0 +1,232 @@
+package log
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/nekoman-hq/neko-cli/pkg/plugin"
+)
+
+// ThemeEnv selects the theme if --theme is not given, e.g. NEKO_THEME=none
+const ThemeEnv = "NEKO_THEME"
+
+// ThemeFileName is the user-level theme file in the neko config directory,
+// e.g. {"success": "blue", "failure": "bright-yellow", "statuses": {"deployed": "green"}}
+const ThemeFileName = "theme.json"
+
+// Built-in theme names
+const (
+	ThemeDefault = "default"
+	ThemeNone    = "none"
+)
+
+// Theme assigns colors to the kinds of values neko highlights
+type Theme struct {
+	Success  string // success/ok statuses and true
+	Failure  string // error/failed statuses and false
+	Pending  string // pending/waiting/queued statuses
+	Inactive string // cancelled/skipped statuses
+	Neutral  string // neutral statuses
+	Version  string
+	Name     string
+	Warn     string
+	Error    string
+
+	// Statuses colors further status values, keyed by the lowercase status
+	Statuses map[string]string
+
+	// Plain disables coloring altogether
+	Plain bool
+}
+
+// DefaultTheme holds the colors neko always used
+var DefaultTheme = Theme{
+	Success:  ColorGreen,
+	Failure:  ColorRed,
+	Pending:  ColorYellow,
+	Inactive: ColorBrightBlack,
+	Neutral:  ColorCyan,
+	Version:  ColorPurple,
+	Name:     ColorBrightWhite,
+	Warn:     ColorYellow,
+	Error:    ColorRed,
+}
+
+// NoTheme disables coloring
+var NoTheme = Theme{Plain: true}
+
+var activeTheme = DefaultTheme
+
+// ActiveTheme returns the theme used for colored output
+func ActiveTheme() Theme {
+	return activeTheme
+}
+
+// SetTheme replaces the theme used for colored output
+func SetTheme(t Theme) {
+	activeTheme = t
+}
+
+// StatusColor returns the color of a status value like "success" or "in_progress", ignoring case.
+// Statuses of the theme file win over the built-in ones. Returns false for unknown statuses.
+func (t Theme) StatusColor(status string) (string, bool) {
+	status = strings.ToLower(status)
+	if color, ok := t.Statuses[status]; ok {
+		return color, true
+	}
+
+	switch status {
+	case "success", "running", "active", "ready", "healthy", "ok", "valid", "completed":
+		return t.Success, true
+	case "error", "failed", "failure", "terminated", "unhealthy", "invalid":
+		return t.Failure, true
+	case "pending", "waiting", "unknown", "queued", "in_progress", "in-progress":
+		return t.Pending, true
+	case "cancelled", "canceled", "skipped":
+		return t.Inactive, true
+	case "neutral":
+		return t.Neutral, true
+	}
+	return "", false
+}
+
+// ColorsEnabled reports whether the active theme colors output
+func ColorsEnabled() bool {
+	return !activeTheme.Plain
+}
+
+// colorNames are the color names usable in a theme file
+var colorNames = map[string]string{
+	"none":          "",
+	"bold":          ColorBold,
+	"red":           ColorRed,
+	"green":         ColorGreen,
+	"yellow":        ColorYellow,
+	"blue":          ColorBlue,
+	"purple":        ColorPurple,
+	"cyan":          ColorCyan,
+	"bright-black":  ColorBrightBlack,
+	"bright-red":    ColorBrightRed,
+	"bright-green":  ColorBrightGreen,
+	"bright-yellow": ColorBrightYellow,
+	"bright-blue":   ColorBrightBlue,
+	"bright-purple": ColorBrightPurple,
+	"bright-cyan":   ColorBrightCyan,
+	"bright-white":  ColorBrightWhite,
+}
+
+// LoadTheme resolves a theme by name: "default", "none" or the path of a theme file.
+// An empty name falls back to NEKO_THEME, then to the user's theme file if it exists, then to the default theme.
+func LoadTheme(name string) (Theme, error) {
+	if name == "" {
+		name = os.Getenv(ThemeEnv)
+	}
+
+	switch name {
+	case ThemeDefault:
+		return DefaultTheme, nil
+	case ThemeNone:
+		return NoTheme, nil
+	case "":
+		dir, err := plugin.ConfigDir()
+		if err != nil {
+			return DefaultTheme, nil
+		}
+		path := filepath.Join(dir, ThemeFileName)
+		if _, err := os.Stat(path); err != nil {
+			return DefaultTheme, nil
+		}
+		return LoadThemeFile(path)
+	default:
+		if _, err := os.Stat(name); os.IsNotExist(err) {
+			return DefaultTheme, fmt.Errorf("unknown theme %q, use %s, %s or the path of a theme file", name, ThemeDefault, ThemeNone)
+		}
+		return LoadThemeFile(name)
+	}
+}
+
+// LoadThemeFile reads a theme file mapping success, failure, pending, inactive, neutral, version, name, warn and error
+// to color names, and "statuses" mapping further status values to color names. Missing entries keep the default color.
+func LoadThemeFile(path string) (Theme, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return DefaultTheme, fmt.Errorf("failed to read theme: %w", err)
+	}
+	return ParseTheme(data)
+}
+
+// ParseTheme parses the JSON of a theme file on top of the default theme
+func ParseTheme(data []byte) (Theme, error) {
+	var entries map[string]json.RawMessage
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return DefaultTheme, fmt.Errorf("invalid theme: %w", err)
+	}
+
+	theme := DefaultTheme
+	fields := map[string]*string{
+		"success":  &theme.Success,
+		"failure":  &theme.Failure,
+		"pending":  &theme.Pending,
+		"inactive": &theme.Inactive,
+		"neutral":  &theme.Neutral,
+		"version":  &theme.Version,
+		"name":     &theme.Name,
+		"warn":     &theme.Warn,
+		"error":    &theme.Error,
+	}
+	for key, raw := range entries {
+		if key == "statuses" {
+			statuses, err := parseStatusColors(raw)
+			if err != nil {
+				return DefaultTheme, err
+			}
+			theme.Statuses = statuses
+			continue
+		}
+
+		field, ok := fields[key]
+		if !ok {
+			return DefaultTheme, fmt.Errorf("invalid theme: unknown key %q", key)
+		}
+		code, err := parseColor(raw, key)
+		if err != nil {
+			return DefaultTheme, err
+		}
+		*field = code
+	}
+	return theme, nil
+}
+
+// parseStatusColors parses the "statuses" object of a theme file, keyed by the lowercase status
+func parseStatusColors(raw json.RawMessage) (map[string]string, error) {
+	var entries map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &entries); err != nil {
+		return nil, fmt.Errorf("invalid theme: statuses must map status values to colors: %w", err)
+	}
+
+	statuses := make(map[string]string, len(entries))
+	for status, color := range entries {
+		code, err := parseColor(color, "status "+status)
+		if err != nil {
+			return nil, err
+		}
+		statuses[strings.ToLower(status)] = code
+	}
+	return statuses, nil
+}
+
+// parseColor resolves a color name of a theme file to its escape code
+func parseColor(raw json.RawMessage, key string) (string, error) {
+	var color string
+	if err := json.Unmarshal(raw, &color); err != nil {
+		return "", fmt.Errorf("invalid theme: color of %s must be a string", key)
+	}
+	code, ok := colorNames[strings.ToLower(color)]
+	if !ok {
+		return "", fmt.Errorf("invalid theme: unknown color %q for %s", color, key)
+	}
+	return code, nil
+}
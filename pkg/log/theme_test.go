@@ -0,0 +1,161 @@
+package log
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// withTheme activates the theme for the test
+func withTheme(t *testing.T, theme Theme) {
+	t.Helper()
+	previous := ActiveTheme()
+	SetTheme(theme)
+	t.Cleanup(func() { SetTheme(previous) })
+}
+
+func TestParseTheme(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		check   func(Theme) bool
+		wantErr string
+	}{
+		{"empty keeps the default", `{}`, func(th Theme) bool { return th.Success == ColorGreen && th.Error == ColorRed }, ""},
+		{"overrides", `{"success": "blue", "failure": "Bright-Yellow"}`, func(th Theme) bool {
+			return th.Success == ColorBlue && th.Failure == ColorBrightYellow && th.Pending == ColorYellow
+		}, ""},
+		{"none color", `{"name": "none"}`, func(th Theme) bool { return th.Name == "" }, ""},
+		{"inactive and neutral", `{"inactive": "bright-white", "neutral": "purple"}`, func(th Theme) bool {
+			return th.Inactive == ColorBrightWhite && th.Neutral == ColorPurple
+		}, ""},
+		{"statuses", `{"statuses": {"Deployed": "green"}}`, func(th Theme) bool { return th.Statuses["deployed"] == ColorGreen }, ""},
+		{"unknown key", `{"sucess": "blue"}`, nil, `unknown key "sucess"`},
+		{"unknown color", `{"success": "mauve"}`, nil, `unknown color "mauve"`},
+		{"color not a string", `{"success": 1}`, nil, "must be a string"},
+		{"status color unknown", `{"statuses": {"deployed": "mauve"}}`, nil, `unknown color "mauve" for status deployed`},
+		{"statuses not an object", `{"statuses": ["green"]}`, nil, "statuses must map"},
+		{"invalid JSON", `{`, nil, "invalid theme"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			theme, err := ParseTheme([]byte(tt.data))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("ParseTheme error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !tt.check(theme) {
+				t.Errorf("ParseTheme(%s) = %+v", tt.data, theme)
+			}
+		})
+	}
+}
+
+func TestStatusColor(t *testing.T) {
+	theme := DefaultTheme
+	theme.Statuses = map[string]string{"deployed": ColorBlue, "failed": ColorPurple}
+
+	tests := []struct {
+		status string
+		want   string
+		known  bool
+	}{
+		{"SUCCESS", ColorGreen, true},
+		{"completed", ColorGreen, true},
+		{"failure", ColorRed, true},
+		{"queued", ColorYellow, true},
+		{"in_progress", ColorYellow, true},
+		{"In-Progress", ColorYellow, true},
+		{"cancelled", ColorBrightBlack, true},
+		{"canceled", ColorBrightBlack, true},
+		{"skipped", ColorBrightBlack, true},
+		{"neutral", ColorCyan, true},
+		{"deployed", ColorBlue, true},
+		{"failed", ColorPurple, true},
+		{"sleeping", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.status, func(t *testing.T) {
+			color, known := theme.StatusColor(tt.status)
+			if color != tt.want || known != tt.known {
+				t.Errorf("StatusColor(%q) = %q, %t, want %q, %t", tt.status, color, known, tt.want, tt.known)
+			}
+		})
+	}
+}
+
+func TestLoadTheme(t *testing.T) {
+	themeFile := filepath.Join(t.TempDir(), "blue.json")
+	if err := os.WriteFile(themeFile, []byte(`{"success": "blue"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		flag      string
+		env       string
+		userTheme string
+		want      string // color of Success
+		wantPlain bool
+		wantErr   bool
+	}{
+		{"default", "", "", "", ColorGreen, false, false},
+		{"none flag", ThemeNone, "", "", "", true, false},
+		{"none env", "", ThemeNone, "", "", true, false},
+		{"flag wins over env", ThemeDefault, ThemeNone, "", ColorGreen, false, false},
+		{"theme file", themeFile, "", "", ColorBlue, false, false},
+		{"user theme file", "", "", `{"success": "cyan"}`, ColorCyan, false, false},
+		{"env wins over the user theme file", "", ThemeNone, `{"success": "cyan"}`, "", true, false},
+		{"invalid user theme file", "", "", `{`, ColorGreen, false, true},
+		{"unknown theme", "solarized", "", "", ColorGreen, false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := t.TempDir()
+			t.Setenv("XDG_CONFIG_HOME", config)
+			t.Setenv(ThemeEnv, tt.env)
+			if tt.userTheme != "" {
+				if err := os.MkdirAll(filepath.Join(config, "neko"), 0755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(filepath.Join(config, "neko", ThemeFileName), []byte(tt.userTheme), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			theme, err := LoadTheme(tt.flag)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LoadTheme(%q) error = %v, want error %t", tt.flag, err, tt.wantErr)
+			}
+			if theme.Plain != tt.wantPlain || theme.Success != tt.want {
+				t.Errorf("LoadTheme(%q) = %+v", tt.flag, theme)
+			}
+		})
+	}
+}
+
+func TestColorTextNoTheme(t *testing.T) {
+	if got := ColorText(ColorRed, "failed"); got != ColorRed+"failed"+ColorReset {
+		t.Errorf("ColorText with the default theme = %q", got)
+	}
+	if got := ColorText("", "plain"); got != "plain" {
+		t.Errorf("ColorText without a color = %q", got)
+	}
+
+	withTheme(t, NoTheme)
+	if ColorsEnabled() {
+		t.Error("ColorsEnabled with the none theme")
+	}
+	if got := ColorText(ColorRed, "failed"); got != "failed" {
+		t.Errorf("ColorText with the none theme = %q", got)
+	}
+}
@@ -0,0 +1,77 @@
+package log
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// TimeFormatEnv sets the layout of log timestamps, e.g. NEKO_LOG_TIME_FORMAT=rfc3339
+	TimeFormatEnv = "NEKO_LOG_TIME_FORMAT"
+	// TimezoneEnv sets the timezone of log timestamps, e.g. NEKO_LOG_TIMEZONE=UTC
+	TimezoneEnv = "NEKO_LOG_TIMEZONE"
+)
+
+// DefaultTimeFormat is the layout of log timestamps if none is configured
+const DefaultTimeFormat = "15:04:05"
+
+// timeFormats are the named layouts accepted besides Go layouts
+var timeFormats = map[string]string{
+	"time":        DefaultTimeFormat,
+	"datetime":    time.DateTime,
+	"rfc3339":     time.RFC3339,
+	"rfc3339nano": time.RFC3339Nano,
+}
+
+// TimestampFormat is the layout and timezone of log timestamps
+type TimestampFormat struct {
+	Layout   string
+	Location *time.Location
+}
+
+// ParseTimestampFormat resolves a named or Go layout and a timezone ("local", "UTC" or an IANA name).
+// Empty values fall back to DefaultTimeFormat and the local timezone.
+func ParseTimestampFormat(format, timezone string) (TimestampFormat, error) {
+	f := TimestampFormat{Layout: DefaultTimeFormat, Location: time.Local}
+
+	if format != "" {
+		if layout, ok := timeFormats[strings.ToLower(format)]; ok {
+			f.Layout = layout
+		} else {
+			f.Layout = format
+		}
+	}
+
+	switch strings.ToLower(timezone) {
+	case "", "local":
+	case "utc":
+		f.Location = time.UTC
+	default:
+		loc, err := time.LoadLocation(timezone)
+		if err != nil {
+			return f, fmt.Errorf("invalid %s %q: %w", TimezoneEnv, timezone, err)
+		}
+		f.Location = loc
+	}
+	return f, nil
+}
+
+// Format formats t in the layout and timezone
+func (f TimestampFormat) Format(t time.Time) string {
+	return t.In(f.Location).Format(f.Layout)
+}
+
+// timestampFormat is read from the environment once, plugins inherit it from neko.
+// An invalid timezone falls back to the local one, neko warns about it on startup.
+var timestampFormat = sync.OnceValue(func() TimestampFormat {
+	f, _ := ParseTimestampFormat(os.Getenv(TimeFormatEnv), os.Getenv(TimezoneEnv))
+	return f
+})
+
+// Timestamp returns the current time formatted for log lines
+func Timestamp() string {
+	return timestampFormat().Format(time.Now())
+}
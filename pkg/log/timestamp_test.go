@@ -0,0 +1,49 @@
+package log
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseTimestampFormat(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skip("no timezone database:", err)
+	}
+	at := time.Date(2026, 3, 1, 11, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		format   string
+		timezone string
+		want     string
+		wantErr  bool
+	}{
+		{name: "default", timezone: "UTC", want: "11:04:05"},
+		{name: "time", format: "time", timezone: "utc", want: "11:04:05"},
+		{name: "datetime", format: "datetime", timezone: "UTC", want: "2026-03-01 11:04:05"},
+		{name: "named formats ignore case", format: "RFC3339", timezone: "UTC", want: "2026-03-01T11:04:05Z"},
+		{name: "go layout", format: "Jan 2 15:04", timezone: "UTC", want: "Mar 1 11:04"},
+		{name: "iana timezone", format: "rfc3339", timezone: "Europe/Berlin", want: "2026-03-01T12:04:05+01:00"},
+		{name: "invalid timezone", timezone: "Mars/Olympus", want: at.In(time.Local).Format(DefaultTimeFormat), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := ParseTimestampFormat(tt.format, tt.timezone)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseTimestampFormat(%q, %q) error = %v, want error %t", tt.format, tt.timezone, err, tt.wantErr)
+			}
+			if got := f.Format(at); got != tt.want {
+				t.Errorf("Format = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if f, _ := ParseTimestampFormat("", "local"); f.Location != time.Local {
+		t.Errorf("local timezone = %v, want time.Local", f.Location)
+	}
+	if f, _ := ParseTimestampFormat("", "Europe/Berlin"); f.Location.String() != berlin.String() {
+		t.Errorf("timezone = %v, want %v", f.Location, berlin)
+	}
+}
@@ -0,0 +1,98 @@
+package log
+
+import (
+	"os"
+	"strings"
+	"sync"
+)
+
+// unicodeMu guards the unicode state, SetUnicode may race with logging from other goroutines
+var (
+	unicodeMu       sync.Mutex
+	unicodeOverride *bool
+	unicodeDetected *bool
+)
+
+// SupportsUnicode reports whether the terminal can display unicode glyphs.
+// NEKO_ASCII or TERM=dumb force ASCII, otherwise the first set locale variable
+// (LC_ALL, LC_CTYPE, LANG) has to be UTF-8. Without any locale unicode is assumed.
+// The environment is only read on the first call.
+func SupportsUnicode() bool {
+	unicodeMu.Lock()
+	defer unicodeMu.Unlock()
+
+	if unicodeOverride != nil {
+		return *unicodeOverride
+	}
+	if unicodeDetected == nil {
+		detected := detectUnicode(os.Getenv)
+		unicodeDetected = &detected
+	}
+	return *unicodeDetected
+}
+
+// SetUnicode overrides the detected unicode support
+func SetUnicode(enabled bool) {
+	unicodeMu.Lock()
+	defer unicodeMu.Unlock()
+	unicodeOverride = &enabled
+}
+
+// ResetUnicode drops the override and detects the unicode support from the environment again on the next call
+func ResetUnicode() {
+	unicodeMu.Lock()
+	defer unicodeMu.Unlock()
+	unicodeOverride, unicodeDetected = nil, nil
+}
+
+func detectUnicode(getenv func(string) string) bool {
+	if getenv("NEKO_ASCII") != "" || getenv("TERM") == "dumb" {
+		return false
+	}
+
+	for _, key := range []string{"LC_ALL", "LC_CTYPE", "LANG"} {
+		if locale := strings.ToLower(getenv(key)); locale != "" {
+			return strings.Contains(locale, "utf-8") || strings.Contains(locale, "utf8")
+		}
+	}
+	return true
+}
+
+// Glyph returns the unicode glyph if supported, the ASCII fallback otherwise
+func Glyph(unicode, ascii string) string {
+	if SupportsUnicode() {
+		return unicode
+	}
+	return ascii
+}
+
+// asciiGlyphs maps the glyphs used across neko to ASCII
+var asciiGlyphs = strings.NewReplacer(
+	"\uF00C", "[ok]",
+	"\uF178", "->",
+	"\u26A0", "[!]",
+	"✓", "[ok]",
+	"✗", "[x]",
+	"•", "*",
+	"━", "=",
+	"┌─", "+-",
+	"├─", "+-",
+	"└─", "`-",
+	"│", "|",
+	// Nerd font icons have no ASCII counterpart
+	"\uF02B", "",
+	"\uF1D3", "",
+	"\uF133", "",
+	"\uF007", "",
+	"\uF09B", "",
+	"\uF12A", "",
+	"\uF0C1", "",
+)
+
+// Sanitize replaces known glyphs with ASCII if the terminal does not support unicode
+func Sanitize(s string) string {
+	if SupportsUnicode() {
+		return s
+	}
+	return asciiGlyphs.Replace(s)
+}
@@ -0,0 +1,95 @@
+package log
+
+import (
+	"sync"
+	"testing"
+)
+
+// unicodeEnv sets the variables read by detectUnicode, unset ones are cleared
+func unicodeEnv(t *testing.T, env map[string]string) {
+	t.Helper()
+	for _, key := range []string{"NEKO_ASCII", "TERM", "LC_ALL", "LC_CTYPE", "LANG"} {
+		t.Setenv(key, env[key])
+	}
+	ResetUnicode()
+	t.Cleanup(ResetUnicode)
+}
+
+var unicodeEnvTests = []struct {
+	name string
+	env  map[string]string
+	want bool
+}{
+	{"no locale", map[string]string{}, true},
+	{"utf-8 LANG", map[string]string{"LANG": "en_US.UTF-8"}, true},
+	{"utf8 LANG", map[string]string{"LANG": "de_AT.utf8"}, true},
+	{"C locale", map[string]string{"LANG": "C"}, false},
+	{"LC_ALL wins over LANG", map[string]string{"LC_ALL": "POSIX", "LANG": "en_US.UTF-8"}, false},
+	{"LC_ALL utf-8", map[string]string{"LC_ALL": "en_US.UTF-8", "LANG": "C"}, true},
+	{"LC_CTYPE before LANG", map[string]string{"LC_CTYPE": "C", "LANG": "en_US.UTF-8"}, false},
+	{"NEKO_ASCII", map[string]string{"NEKO_ASCII": "1", "LANG": "en_US.UTF-8"}, false},
+	{"dumb terminal", map[string]string{"TERM": "dumb", "LANG": "en_US.UTF-8"}, false},
+}
+
+func TestDetectUnicode(t *testing.T) {
+	for _, tt := range unicodeEnvTests {
+		t.Run(tt.name, func(t *testing.T) {
+			getenv := func(key string) string { return tt.env[key] }
+			if got := detectUnicode(getenv); got != tt.want {
+				t.Errorf("detectUnicode(%v) = %t, want %t", tt.env, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSanitizeFollowsEnvironment(t *testing.T) {
+	const msg = "\uF00C Released v1.2.0 \uF178 v1.3.0"
+	for _, tt := range unicodeEnvTests {
+		t.Run(tt.name, func(t *testing.T) {
+			unicodeEnv(t, tt.env)
+
+			want := "[ok] Released v1.2.0 -> v1.3.0"
+			if tt.want {
+				want = msg
+			}
+			if got := Sanitize(msg); got != want {
+				t.Errorf("Sanitize = %q, want %q", got, want)
+			}
+			if got := Glyph("\u2713", "ok"); (got == "\u2713") != tt.want {
+				t.Errorf("Glyph = %q with unicode %t", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetUnicodeOverridesEnvironment(t *testing.T) {
+	unicodeEnv(t, map[string]string{"NEKO_ASCII": "1"})
+
+	SetUnicode(true)
+	if !SupportsUnicode() {
+		t.Error("SetUnicode(true) did not override NEKO_ASCII")
+	}
+	ResetUnicode()
+	if SupportsUnicode() {
+		t.Error("ResetUnicode did not restore the detected ASCII mode")
+	}
+}
+
+// Run with -race, the renderer may switch the icon set while plugins log
+func TestSetUnicodeConcurrent(t *testing.T) {
+	t.Cleanup(ResetUnicode)
+
+	var wg sync.WaitGroup
+	for i := range 8 {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			SetUnicode(i%2 == 0)
+		}()
+		go func() {
+			defer wg.Done()
+			_ = Sanitize("\uF00C done")
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,85 @@
+package plugin
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// DefaultsFileName is the user-level file holding default flag values per command, e.g.
+// {"init": {"project-type": "backend", "release-system": "goreleaser"}}
+const DefaultsFileName = "defaults.json"
+
+// unsafeDefaults are never taken from the defaults file since they confirm destructive actions
+var unsafeDefaults = map[string]bool{
+	"yes":   true,
+	"force": true,
+}
+
+// ConfigDir returns the user-level neko config directory ($XDG_CONFIG_HOME/neko or ~/.config/neko)
+func ConfigDir() (string, error) {
+	if dir := os.Getenv("XDG_CONFIG_HOME"); dir != "" {
+		return filepath.Join(dir, "neko"), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".config", "neko"), nil
+}
+
+// DefaultsPath returns the path of the defaults file in the ConfigDir
+func DefaultsPath() (string, error) {
+	dir, err := ConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, DefaultsFileName), nil
+}
+
+// LoadFlagDefaults reads the default flag values of the command from the defaults file.
+// A missing file yields no defaults.
+func LoadFlagDefaults(path, command string) (map[string]any, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var defaults map[string]map[string]any
+	if err := json.Unmarshal(data, &defaults); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", path, err)
+	}
+	return defaults[command], nil
+}
+
+// MergeFlags returns the explicit flags on top of the defaults. Explicit flags always win.
+func MergeFlags(explicit, defaults map[string]any) map[string]any {
+	merged := make(map[string]any, len(explicit)+len(defaults))
+	for k, v := range defaults {
+		if !unsafeDefaults[k] {
+			merged[k] = v
+		}
+	}
+	for k, v := range explicit {
+		merged[k] = v
+	}
+	return merged
+}
+
+// ApplyFlagDefaults merges the user's default flag values for the request command under its flags
+func (r *Request) ApplyFlagDefaults() error {
+	path, err := DefaultsPath()
+	if err != nil {
+		return err
+	}
+	defaults, err := LoadFlagDefaults(path, r.Command)
+	if err != nil {
+		return err
+	}
+	r.Flags = MergeFlags(r.Flags, defaults)
+	return nil
+}
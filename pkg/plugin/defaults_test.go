@@ -0,0 +1,143 @@
+package plugin
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// writeDefaults writes a defaults file into a temporary XDG config dir
+func writeDefaults(t *testing.T, content string) string {
+	t.Helper()
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	path := filepath.Join(dir, "neko", DefaultsFileName)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestConfigDir(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", "/xdg")
+	if dir, err := ConfigDir(); err != nil || dir != filepath.Join("/xdg", "neko") {
+		t.Errorf("ConfigDir = %q, %v with XDG_CONFIG_HOME", dir, err)
+	}
+
+	t.Setenv("XDG_CONFIG_HOME", "")
+	t.Setenv("HOME", "/home/neko")
+	if dir, err := ConfigDir(); err != nil || dir != filepath.Join("/home/neko", ".config", "neko") {
+		t.Errorf("ConfigDir = %q, %v without XDG_CONFIG_HOME", dir, err)
+	}
+}
+
+func TestLoadFlagDefaults(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		command string
+		want    map[string]any
+		wantErr bool
+	}{
+		{"command", `{"init": {"project-type": "backend"}}`, "init", map[string]any{"project-type": "backend"}, false},
+		{"other command", `{"init": {"project-type": "backend"}}`, "minor", nil, false},
+		{"typed values", `{"minor": {"dry-run": true, "max-commits": 5}}`, "minor", map[string]any{"dry-run": true, "max-commits": float64(5)}, false},
+		{"invalid json", `{"init": `, "init", nil, true},
+		{"wrong shape", `{"init": "backend"}`, "init", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeDefaults(t, tt.content)
+
+			got, err := LoadFlagDefaults(path, tt.command)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LoadFlagDefaults error = %v, want error %t", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("LoadFlagDefaults = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadFlagDefaultsMissingFile(t *testing.T) {
+	got, err := LoadFlagDefaults(filepath.Join(t.TempDir(), DefaultsFileName), "init")
+	if err != nil || got != nil {
+		t.Errorf("LoadFlagDefaults = %v, %v, want no defaults without a file", got, err)
+	}
+}
+
+func TestMergeFlags(t *testing.T) {
+	tests := []struct {
+		name     string
+		explicit map[string]any
+		defaults map[string]any
+		want     map[string]any
+	}{
+		{
+			name:     "defaults fill missing flags",
+			explicit: map[string]any{"version": "1.0.0"},
+			defaults: map[string]any{"project-type": "backend"},
+			want:     map[string]any{"version": "1.0.0", "project-type": "backend"},
+		},
+		{
+			name:     "explicit flags win",
+			explicit: map[string]any{"project-type": "frontend", "dry-run": false},
+			defaults: map[string]any{"project-type": "backend", "dry-run": true},
+			want:     map[string]any{"project-type": "frontend", "dry-run": false},
+		},
+		{
+			name:     "unsafe defaults are dropped",
+			defaults: map[string]any{"yes": true, "force": true, "commit": true},
+			want:     map[string]any{"commit": true},
+		},
+		{
+			name:     "explicit unsafe flags are kept",
+			explicit: map[string]any{"force": true},
+			defaults: map[string]any{"force": false},
+			want:     map[string]any{"force": true},
+		},
+		{
+			name: "nothing",
+			want: map[string]any{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MergeFlags(tt.explicit, tt.defaults); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("MergeFlags = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyFlagDefaults(t *testing.T) {
+	writeDefaults(t, `{"init": {"project-type": "backend", "release-system": "goreleaser", "force": true}}`)
+
+	req := Request{Command: "init", Flags: map[string]any{"release-system": "jreleaser"}}
+	if err := req.ApplyFlagDefaults(); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]any{"project-type": "backend", "release-system": "jreleaser"}
+	if !reflect.DeepEqual(req.Flags, want) {
+		t.Errorf("Flags = %v, want %v", req.Flags, want)
+	}
+}
+
+func TestApplyFlagDefaultsInvalidFile(t *testing.T) {
+	writeDefaults(t, "not json")
+
+	req := Request{Command: "init", Flags: map[string]any{"project-type": "frontend"}}
+	if err := req.ApplyFlagDefaults(); err == nil {
+		t.Error("ApplyFlagDefaults accepted an invalid defaults file")
+	}
+	if req.Flags["project-type"] != "frontend" {
+		t.Errorf("Flags = %v, the explicit flags changed on error", req.Flags)
+	}
+}
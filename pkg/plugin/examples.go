@@ -0,0 +1,36 @@
+package plugin
+
+import "strings"
+
+// Examples returns example invocations of the command derived from its flags, e.g. for --help.
+// The first example passes only the required flags, every optional flag gets an example of its own.
+// Values are shown as placeholders of the flag type like --title <string>.
+func (c Command) Examples(pluginName string) []string {
+	base := []string{"neko", pluginName, c.Name}
+	for _, flag := range c.Flags {
+		if flag.Required {
+			base = append(base, flag.Usage())
+		}
+	}
+
+	minimal := strings.Join(base, " ")
+	examples := []string{minimal}
+	for _, flag := range c.Flags {
+		if !flag.Required {
+			examples = append(examples, minimal+" "+flag.Usage())
+		}
+	}
+	return examples
+}
+
+// Usage returns the flag as it is passed on the command line, with a placeholder for its value
+func (f Flag) Usage() string {
+	switch f.Type {
+	case "bool":
+		return "--" + f.Name
+	case "int":
+		return "--" + f.Name + " <int>"
+	default:
+		return "--" + f.Name + " <string>"
+	}
+}
@@ -0,0 +1,66 @@
+package plugin
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestFlagUsage(t *testing.T) {
+	tests := []struct {
+		flag Flag
+		want string
+	}{
+		{Flag{Name: "yes", Type: "bool"}, "--yes"},
+		{Flag{Name: "limit", Type: "int"}, "--limit <int>"},
+		{Flag{Name: "title", Type: "string"}, "--title <string>"},
+		{Flag{Name: "tag"}, "--tag <string>"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.flag.Name, func(t *testing.T) {
+			if got := tt.flag.Usage(); got != tt.want {
+				t.Errorf("Usage = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExamples(t *testing.T) {
+	tests := []struct {
+		name  string
+		flags []Flag
+		want  []string
+	}{
+		{"no flags", nil, []string{"neko release amend"}},
+		{
+			name: "required only",
+			flags: []Flag{
+				{Name: "title", Type: "string", Required: true},
+				{Name: "limit", Type: "int", Required: true},
+			},
+			want: []string{"neko release amend --title <string> --limit <int>"},
+		},
+		{
+			name: "required and optional",
+			flags: []Flag{
+				{Name: "yes", Type: "bool"},
+				{Name: "title", Type: "string", Required: true},
+				{Name: "limit", Type: "int"},
+			},
+			want: []string{
+				"neko release amend --title <string>",
+				"neko release amend --title <string> --yes",
+				"neko release amend --title <string> --limit <int>",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := Command{Name: "amend", Flags: tt.flags}
+			if got := cmd.Examples("release"); !slices.Equal(got, tt.want) {
+				t.Errorf("Examples = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
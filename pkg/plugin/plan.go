@@ -0,0 +1,26 @@
+package plugin
+
+// PlanKey is the response data key holding the Plan of an action that requires confirmation
+const PlanKey = "plan"
+
+// Plan describes what a destructive action is about to do, so confirmation flows can show
+// a consistent summary before the user re-runs with --yes
+type Plan struct {
+	// Summary is a one-line description of the action, e.g. "Delete tag v1.2.0"
+	Summary string `json:"summary"`
+
+	// From and To describe a version transition, both are optional
+	From string `json:"from,omitempty"`
+	To   string `json:"to,omitempty"`
+
+	// Remote is the remote or repository the action touches, empty if it stays local
+	Remote string `json:"remote,omitempty"`
+
+	Files    []string `json:"files,omitempty"`
+	Commands []string `json:"commands,omitempty"`
+}
+
+// ConfirmationData returns response data carrying the plan, for CONFIRMATION_REQUIRED responses
+func (p Plan) ConfirmationData() map[string]any {
+	return map[string]any{PlanKey: p}
+}
@@ -0,0 +1,22 @@
+package plugin
+
+import "fmt"
+
+// ProtocolVersion is the version of the request and response protocol between neko and its plugins.
+// Bump it whenever Request or Response change incompatibly.
+const ProtocolVersion = 1
+
+// CheckProtocol returns an error if a plugin speaks another protocol version than neko.
+// Plugins built before the protocol was versioned report 0 and are not checked.
+func CheckProtocol(pluginName string, pluginVersion int) error {
+	switch {
+	case pluginVersion == 0 || pluginVersion == ProtocolVersion:
+		return nil
+	case pluginVersion > ProtocolVersion:
+		return fmt.Errorf("plugin %s speaks protocol version %d but neko only supports %d, update neko",
+			pluginName, pluginVersion, ProtocolVersion)
+	default:
+		return fmt.Errorf("plugin %s speaks protocol version %d but neko expects %d, update the plugin with 'neko plugin install %s'",
+			pluginName, pluginVersion, ProtocolVersion, pluginName)
+	}
+}
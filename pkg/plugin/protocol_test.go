@@ -0,0 +1,34 @@
+package plugin
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCheckProtocol(t *testing.T) {
+	tests := []struct {
+		name    string
+		version int
+		wantErr string
+	}{
+		{"unversioned plugin", 0, ""},
+		{"same version", ProtocolVersion, ""},
+		{"newer plugin", ProtocolVersion + 1, "update neko"},
+		{"older plugin", -1, "neko plugin install release"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckProtocol("release", tt.version)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("CheckProtocol(%d) = %v, want nil", tt.version, err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("CheckProtocol(%d) = %v, want %q", tt.version, err, tt.wantErr)
+			}
+		})
+	}
+}
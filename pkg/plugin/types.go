@@ -10,11 +10,31 @@ type Request struct {
 	Context Context        `json:"context"`
 }
 
+// Confirmed reports whether the user explicitly confirmed a destructive action.
+// Plugins cannot prompt since stdin carries the request, so confirmation is passed
+// via the global --yes flag (Context.AssumeYes) or a command-level yes flag.
+func (r Request) Confirmed() bool {
+	if r.Context.AssumeYes {
+		return true
+	}
+	yes, _ := r.Flags["yes"].(bool)
+	return yes
+}
+
 // Context contains execution context information
 type Context struct {
 	WorkingDir string `json:"working_dir"`
 	User       string `json:"user"`
 	Verbose    bool   `json:"verbose"`
+	// AssumeYes auto-confirms all confirmations and implies non-interactive mode
+	AssumeYes bool `json:"assume_yes"`
+	// Interactive reports whether neko prompts for confirmations, resolved from --interactive,
+	// --non-interactive and the detected terminal. Plugins cannot prompt themselves, neko asks for them.
+	Interactive bool `json:"interactive,omitempty"`
+	// DryRun logs mutating git and GitHub operations instead of running them
+	DryRun bool `json:"dry_run,omitempty"`
+	// ProtocolVersion is the protocol version of the calling neko, see ProtocolVersion
+	ProtocolVersion int `json:"protocol_version,omitempty"`
 }
 
 // Response is the output from the Plugin
@@ -24,7 +44,23 @@ type Response struct {
 	Data         map[string]any   `json:"data,omitempty"`
 	Error        *ResponseError   `json:"error,omitempty"`
 	RendererHint string           `json:"renderer_hint,omitempty"`
-	Logs         []LogEntry       `json:"logs,omitempty"`
+	// ColumnOrder pins the order of table columns (or key-value keys). Unlisted columns follow in default order
+	ColumnOrder []string        `json:"column_order,omitempty"`
+	Logs        []LogEntry      `json:"logs,omitempty"`
+	Progress    []ProgressEvent `json:"progress,omitempty"`
+	// Warnings are non-fatal issues of the command, rendered in their own section
+	Warnings []ResponseError `json:"warnings,omitempty"`
+}
+
+// ProgressMarker prefixes progress lines on stderr, followed by a JSON encoded ProgressEvent
+const ProgressMarker = "PROGRESS"
+
+// ProgressEvent reports the progress of a long-running plugin command
+type ProgressEvent struct {
+	Timestamp string `json:"timestamp"`
+	Step      string `json:"step"`
+	Current   int    `json:"current"`
+	Total     int    `json:"total"`
 }
 
 type LogEntry struct {
@@ -39,6 +75,9 @@ type ResponseMetadata struct {
 	Plugin    string    `json:"plugin"`
 	Version   string    `json:"version"`
 	Command   string    `json:"command"`
+
+	// ProtocolVersion is the protocol version the plugin speaks, see ProtocolVersion
+	ProtocolVersion int `json:"protocol_version,omitempty"`
 }
 
 type ResponseError struct {
@@ -64,6 +103,10 @@ type Manifest struct {
 	Author        string    `json:"author"`
 	Commands      []Command `json:"commands"`
 	RendererTypes []string  `json:"renderer_types"`
+
+	// ConfigFiles are the files the plugin writes into a project, relative to the project root.
+	// neko plugin uninstall --prune removes them.
+	ConfigFiles []string `json:"config_files,omitempty"`
 }
 
 // Command describes a plugin command
@@ -0,0 +1,45 @@
+package plugin
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestRequestConfirmed(t *testing.T) {
+	tests := []struct {
+		name string
+		req  Request
+		want bool
+	}{
+		{"nothing", Request{}, false},
+		{"global --yes", Request{Context: Context{AssumeYes: true}}, true},
+		{"command yes flag", Request{Flags: map[string]any{"yes": true}}, true},
+		{"yes flag false", Request{Flags: map[string]any{"yes": false}}, false},
+		{"yes flag not a bool", Request{Flags: map[string]any{"yes": "true"}}, false},
+		{"global --yes overrides the flag", Request{Flags: map[string]any{"yes": false}, Context: Context{AssumeYes: true}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.req.Confirmed(); got != tt.want {
+				t.Errorf("Confirmed() = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
+
+// The plugin decodes the request neko encodes, --yes must survive the round trip
+func TestRequestAssumeYesRoundTrip(t *testing.T) {
+	data, err := json.Marshal(Request{Command: "undo-last-tag", Context: Context{AssumeYes: true}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var req Request
+	if err := json.Unmarshal(data, &req); err != nil {
+		t.Fatal(err)
+	}
+	if !req.Confirmed() {
+		t.Errorf("decoded request %s is not confirmed", data)
+	}
+}
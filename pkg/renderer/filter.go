@@ -0,0 +1,51 @@
+package renderer
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/nekoman-hq/neko-cli/pkg/plugin"
+)
+
+// LogFilter restricts the execution logs shown in the describe view
+type LogFilter struct {
+	MinLevel   string   // verbose, info, warn or error; empty shows every level
+	Categories []string // empty shows every category
+}
+
+// logLevelRank orders the log levels from least to most severe
+var logLevelRank = map[string]int{
+	"verbose": 0,
+	"info":    1,
+	"warn":    2,
+	"error":   3,
+}
+
+// ValidLogLevel reports whether level can be used as a minimum log level
+func ValidLogLevel(level string) bool {
+	if level == "" {
+		return true
+	}
+	_, ok := logLevelRank[strings.ToLower(level)]
+	return ok
+}
+
+// FilterLogs returns the entries at or above the minimum level that match one of the categories
+func FilterLogs(logs []plugin.LogEntry, f LogFilter) []plugin.LogEntry {
+	if f.MinLevel == "" && len(f.Categories) == 0 {
+		return logs
+	}
+
+	minRank := logLevelRank[strings.ToLower(f.MinLevel)]
+	filtered := make([]plugin.LogEntry, 0, len(logs))
+	for _, entry := range logs {
+		if rank, ok := logLevelRank[entry.Level]; ok && rank < minRank {
+			continue
+		}
+		if len(f.Categories) > 0 && !slices.Contains(f.Categories, entry.Category) {
+			continue
+		}
+		filtered = append(filtered, entry)
+	}
+	return filtered
+}
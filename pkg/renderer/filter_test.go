@@ -0,0 +1,53 @@
+package renderer
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/nekoman-hq/neko-cli/pkg/plugin"
+)
+
+func TestFilterLogs(t *testing.T) {
+	logs := []plugin.LogEntry{
+		{Level: "verbose", Category: "exec", Message: "git describe"},
+		{Level: "info", Category: "init", Message: "Starting release"},
+		{Level: "warn", Category: "guard", Message: "Tag diverged"},
+		{Level: "error", Category: "exec", Message: "Release failed"},
+		{Level: "info", Category: "plugin", Message: "tool output"},
+		{Level: "trace", Category: "exec", Message: "unknown level"},
+	}
+
+	tests := []struct {
+		name   string
+		filter LogFilter
+		want   []string
+	}{
+		{"no filter", LogFilter{}, []string{"git describe", "Starting release", "Tag diverged", "Release failed", "tool output", "unknown level"}},
+		{"warn and above", LogFilter{MinLevel: "warn"}, []string{"Tag diverged", "Release failed", "unknown level"}},
+		{"level is case insensitive", LogFilter{MinLevel: "ERROR"}, []string{"Release failed", "unknown level"}},
+		{"category", LogFilter{Categories: []string{"exec"}}, []string{"git describe", "Release failed", "unknown level"}},
+		{"several categories", LogFilter{Categories: []string{"init", "guard"}}, []string{"Starting release", "Tag diverged"}},
+		{"level and category", LogFilter{MinLevel: "info", Categories: []string{"exec", "plugin"}}, []string{"Release failed", "tool output", "unknown level"}},
+		{"nothing matches", LogFilter{Categories: []string{"config"}}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, entry := range FilterLogs(logs, tt.filter) {
+				got = append(got, entry.Message)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("FilterLogs = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidLogLevel(t *testing.T) {
+	for level, want := range map[string]bool{"": true, "verbose": true, "Warn": true, "error": true, "debug": false} {
+		if got := ValidLogLevel(level); got != want {
+			t.Errorf("ValidLogLevel(%q) = %t, want %t", level, got, want)
+		}
+	}
+}
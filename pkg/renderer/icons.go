@@ -0,0 +1,84 @@
+package renderer
+
+import "github.com/nekoman-hq/neko-cli/pkg/log"
+
+// iconSet holds the glyphs used by the execution log section
+type iconSet struct {
+	levels     map[string]string
+	categories map[string]string
+	// level is used for unknown log levels
+	level string
+}
+
+var unicodeIcons = iconSet{
+	levels: map[string]string{
+		"error":   "✗",
+		"warn":    "⚠",
+		"verbose": "›",
+		"info":    "•",
+	},
+	categories: map[string]string{
+		string(log.Init):      "✦",
+		string(log.Config):    "⚙",
+		string(log.Preflight): "✈",
+		string(log.Guard):     "⛨",
+		string(log.Exec):      "▶",
+	},
+	level: "•",
+}
+
+var asciiIcons = iconSet{
+	levels: map[string]string{
+		"error":   "x",
+		"warn":    "!",
+		"verbose": ">",
+		"info":    "*",
+	},
+	categories: map[string]string{
+		string(log.Init):      "+",
+		string(log.Config):    "~",
+		string(log.Preflight): "^",
+		string(log.Guard):     "#",
+		string(log.Exec):      "$",
+	},
+	level: "*",
+}
+
+// customCategoryIcons are registered by SetCategoryIcons and take precedence over the icon sets
+var customCategoryIcons = map[string]string{}
+
+// activeIcons returns the icon set matching the terminal's unicode support
+func activeIcons() iconSet {
+	if log.SupportsUnicode() {
+		return unicodeIcons
+	}
+	return asciiIcons
+}
+
+// UseASCIIIcons forces the plain ASCII icon set (or the unicode set if ascii is false)
+func UseASCIIIcons(ascii bool) {
+	log.SetUnicode(!ascii)
+}
+
+// SetCategoryIcons registers custom icons for log categories, e.g. plugin specific categories
+func SetCategoryIcons(categoryIcons map[string]string) {
+	for category, icon := range categoryIcons {
+		customCategoryIcons[category] = icon
+	}
+}
+
+func getLogLevelIcon(level string) string {
+	icons := activeIcons()
+	if icon, ok := icons.levels[level]; ok {
+		return icon + " "
+	}
+	return icons.level + " "
+}
+
+// getCategoryIcon returns the icon of a log category or an empty string if it has none
+func getCategoryIcon(category string) string {
+	if icon, ok := customCategoryIcons[category]; ok {
+		return icon
+	}
+	return activeIcons().categories[category]
+}
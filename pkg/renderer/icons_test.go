@@ -0,0 +1,126 @@
+package renderer
+
+import (
+	"testing"
+
+	"github.com/nekoman-hq/neko-cli/pkg/log"
+)
+
+func TestCategoryIcons(t *testing.T) {
+	t.Cleanup(log.ResetUnicode)
+
+	tests := []struct {
+		ascii    bool
+		category log.Category
+		want     string
+	}{
+		{false, log.Init, "✦"},
+		{false, log.Config, "⚙"},
+		{false, log.Preflight, "✈"},
+		{false, log.Guard, "⛨"},
+		{false, log.Exec, "▶"},
+		{false, "plugin", ""},
+		{true, log.Init, "+"},
+		{true, log.Config, "~"},
+		{true, log.Preflight, "^"},
+		{true, log.Guard, "#"},
+		{true, log.Exec, "$"},
+		{true, "plugin", ""},
+	}
+
+	for _, tt := range tests {
+		UseASCIIIcons(tt.ascii)
+		if got := getCategoryIcon(string(tt.category)); got != tt.want {
+			t.Errorf("getCategoryIcon(%s) with ascii %t = %q, want %q", tt.category, tt.ascii, got, tt.want)
+		}
+	}
+}
+
+// Every category needs a distinct icon in both sets, or the log section cannot tell them apart
+func TestCategoryIconsDistinct(t *testing.T) {
+	for name, icons := range map[string]iconSet{"unicode": unicodeIcons, "ascii": asciiIcons} {
+		seen := map[string]string{}
+		for category, icon := range icons.categories {
+			if other, ok := seen[icon]; ok {
+				t.Errorf("%s icon %q is used by %s and %s", name, icon, other, category)
+			}
+			seen[icon] = category
+		}
+	}
+}
+
+func TestSetCategoryIcons(t *testing.T) {
+	t.Cleanup(func() {
+		delete(customCategoryIcons, "deploy")
+		delete(customCategoryIcons, string(log.Exec))
+		log.ResetUnicode()
+	})
+	SetCategoryIcons(map[string]string{"deploy": "D", string(log.Exec): "E"})
+
+	for _, ascii := range []bool{false, true} {
+		UseASCIIIcons(ascii)
+		if got := getCategoryIcon("deploy"); got != "D" {
+			t.Errorf("custom category icon = %q, want D", got)
+		}
+		if got := getCategoryIcon(string(log.Exec)); got != "E" {
+			t.Errorf("overridden exec icon = %q, want E", got)
+		}
+	}
+}
+
+func TestLogLevelIcons(t *testing.T) {
+	t.Cleanup(log.ResetUnicode)
+
+	tests := []struct {
+		ascii bool
+		level string
+		want  string
+	}{
+		{false, "error", "✗ "},
+		{false, "verbose", "› "},
+		{false, "debug", "• "},
+		{true, "warn", "! "},
+		{true, "verbose", "> "},
+		{true, "debug", "* "},
+	}
+
+	for _, tt := range tests {
+		UseASCIIIcons(tt.ascii)
+		if got := getLogLevelIcon(tt.level); got != tt.want {
+			t.Errorf("getLogLevelIcon(%s) with ascii %t = %q, want %q", tt.level, tt.ascii, got, tt.want)
+		}
+	}
+}
+
+func TestActiveIconsFollowEnvironment(t *testing.T) {
+	tests := []struct {
+		name  string
+		env   map[string]string
+		ascii bool
+	}{
+		{"utf-8 locale", map[string]string{"LANG": "en_US.UTF-8"}, false},
+		{"C locale", map[string]string{"LC_ALL": "C", "LANG": "en_US.UTF-8"}, true},
+		{"NEKO_ASCII", map[string]string{"NEKO_ASCII": "1", "LC_ALL": "en_US.UTF-8"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, key := range []string{"NEKO_ASCII", "TERM", "LC_ALL", "LC_CTYPE", "LANG"} {
+				t.Setenv(key, tt.env[key])
+			}
+			log.ResetUnicode()
+			t.Cleanup(log.ResetUnicode)
+
+			want := unicodeIcons
+			if tt.ascii {
+				want = asciiIcons
+			}
+			if got := activeIcons(); got.level != want.level || got.categories[string(log.Exec)] != want.categories[string(log.Exec)] {
+				t.Errorf("activeIcons() = %+v, want the ascii set %t", got, tt.ascii)
+			}
+			if got, sanitized := log.Sanitize("\u2501\u2501\u2501 Output \u2501\u2501\u2501"), "=== Output ==="; (got == sanitized) != tt.ascii {
+				t.Errorf("Sanitize = %q with ascii %t", got, tt.ascii)
+			}
+		})
+	}
+}
@@ -0,0 +1,21 @@
+package renderer
+
+import (
+	"io"
+	"regexp"
+)
+
+// ansiEscape matches SGR escape sequences, e.g. colors baked into plugin logs
+var ansiEscape = regexp.MustCompile("\x1b\\[[0-9;]*m")
+
+// plainWriter strips colors before writing, used with the none theme
+type plainWriter struct {
+	w io.Writer
+}
+
+func (p plainWriter) Write(b []byte) (int, error) {
+	if _, err := p.w.Write(ansiEscape.ReplaceAll(b, nil)); err != nil {
+		return 0, err
+	}
+	return len(b), nil
+}
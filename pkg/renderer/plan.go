@@ -0,0 +1,102 @@
+package renderer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/nekoman-hq/neko-cli/pkg/log"
+	"github.com/nekoman-hq/neko-cli/pkg/plugin"
+)
+
+// RenderPlan renders the summary of a planned action to STDOUT, see RenderPlanTo
+func RenderPlan(plan plugin.Plan) error {
+	return RenderPlanTo(plan, os.Stdout)
+}
+
+// RenderPlanTo renders the summary of a planned action as key-value pairs followed by
+// the files and commands tables, in the same styling as command output
+func RenderPlanTo(plan plugin.Plan, w io.Writer) error {
+	if !log.ColorsEnabled() {
+		w = plainWriter{w}
+	}
+	return renderPlan(plan, w)
+}
+
+func renderPlan(plan plugin.Plan, w io.Writer) error {
+	_, _ = fmt.Fprintf(w, "%s%s%s%s\n", log.ColorBold, log.ColorPurple, plan.Summary, log.ColorReset)
+
+	// Only set fields are listed, key-value keys are sorted so the order is pinned
+	data := map[string]any{}
+	order := make([]string, 0, 2)
+	if plan.From != "" || plan.To != "" {
+		data["version"] = planVersion(plan.From, plan.To)
+		order = append(order, "version")
+	}
+	if plan.Remote != "" {
+		data["remote"] = plan.Remote
+		order = append(order, "remote")
+	}
+	if len(data) > 0 {
+		if err := renderKeyValue(data, order, w); err != nil {
+			return err
+		}
+	}
+
+	if len(plan.Files) > 0 {
+		_, _ = fmt.Fprintln(w)
+		if err := renderList(planRows("file", plan.Files), nil, w); err != nil {
+			return err
+		}
+	}
+	if len(plan.Commands) > 0 {
+		_, _ = fmt.Fprintln(w)
+		if err := renderList(planRows("command", plan.Commands), nil, w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// planVersion formats a version transition, a missing side is rendered as "-"
+func planVersion(from, to string) string {
+	if from == "" {
+		from = "-"
+	}
+	if to == "" {
+		to = "-"
+	}
+	return from + " " + log.Glyph("\u2192", "->") + " " + to
+}
+
+// planRows turns a list into single-column table rows
+func planRows(column string, values []string) []map[string]any {
+	rows := make([]map[string]any, 0, len(values))
+	for _, v := range values {
+		rows = append(rows, map[string]any{column: v})
+	}
+	return rows
+}
+
+// planFromData extracts the plan of a confirmation response.
+// Plugin responses are decoded from JSON, so the plan arrives as a generic map.
+func planFromData(data map[string]any) (plugin.Plan, bool) {
+	raw, ok := data[plugin.PlanKey]
+	if !ok {
+		return plugin.Plan{}, false
+	}
+	if plan, ok := raw.(plugin.Plan); ok {
+		return plan, true
+	}
+
+	encoded, err := json.Marshal(raw)
+	if err != nil {
+		return plugin.Plan{}, false
+	}
+	var plan plugin.Plan
+	if err := json.Unmarshal(encoded, &plan); err != nil || plan.Summary == "" {
+		return plugin.Plan{}, false
+	}
+	return plan, true
+}
@@ -0,0 +1,163 @@
+package renderer
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/nekoman-hq/neko-cli/pkg/log"
+	"github.com/nekoman-hq/neko-cli/pkg/plugin"
+)
+
+// plainASCII renders without colors and unicode glyphs for the test
+func plainASCII(t *testing.T) {
+	t.Helper()
+	previous := log.ActiveTheme()
+	log.SetTheme(log.NoTheme)
+	log.SetUnicode(false)
+	t.Cleanup(func() {
+		log.SetTheme(previous)
+		log.ResetUnicode()
+	})
+}
+
+// trimLines drops the trailing padding of table cells
+func trimLines(s string) string {
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " ")
+	}
+	return strings.Join(lines, "\n")
+}
+
+var undoPlan = plugin.Plan{
+	Summary:  "Delete tag v1.2.0",
+	From:     "1.2.0",
+	To:       "1.1.0",
+	Remote:   "origin",
+	Files:    []string{".release.neko.json"},
+	Commands: []string{"git tag -d v1.2.0", "git push origin --delete v1.2.0"},
+}
+
+func TestRenderPlanTo(t *testing.T) {
+	plainASCII(t)
+
+	tests := []struct {
+		name string
+		plan plugin.Plan
+		want string
+	}{
+		{
+			name: "full plan",
+			plan: undoPlan,
+			want: `Delete tag v1.2.0
+Version:  1.2.0 -> 1.1.0
+Remote :  origin
+
+FILE
+.release.neko.json
+
+COMMAND
+git tag -d v1.2.0
+git push origin --delete v1.2.0
+`,
+		},
+		{
+			name: "summary only",
+			plan: plugin.Plan{Summary: "Delete 2 orphan tags"},
+			want: "Delete 2 orphan tags\n",
+		},
+		{
+			name: "new version only",
+			plan: plugin.Plan{Summary: "Update release", To: "1.3.0", Commands: []string{"PATCH /releases/1"}},
+			want: `Update release
+Version:  - -> 1.3.0
+
+COMMAND
+PATCH /releases/1
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := RenderPlanTo(tt.plan, &buf); err != nil {
+				t.Fatal(err)
+			}
+			if got := trimLines(buf.String()); got != tt.want {
+				t.Errorf("RenderPlanTo =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPlanFromData(t *testing.T) {
+	// Plugin responses reach the renderer as JSON, the plan is a generic map then
+	var decoded map[string]any
+	encoded, _ := json.Marshal(undoPlan.ConfirmationData())
+	if err := json.Unmarshal(encoded, &decoded); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		data   map[string]any
+		wantOK bool
+	}{
+		{"typed plan", undoPlan.ConfirmationData(), true},
+		{"decoded plan", decoded, true},
+		{"no plan", map[string]any{"tags": []string{"v1.2.0"}}, false},
+		{"plan without summary", map[string]any{plugin.PlanKey: map[string]any{"files": []string{"a"}}}, false},
+		{"plan of another shape", map[string]any{plugin.PlanKey: "delete"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan, ok := planFromData(tt.data)
+			if ok != tt.wantOK {
+				t.Fatalf("planFromData ok = %t, want %t", ok, tt.wantOK)
+			}
+			if ok && (plan.Summary != undoPlan.Summary || len(plan.Commands) != 2 || plan.Remote != "origin") {
+				t.Errorf("planFromData = %+v, want %+v", plan, undoPlan)
+			}
+		})
+	}
+}
+
+func TestRenderConfirmation(t *testing.T) {
+	plainASCII(t)
+
+	resp := &plugin.Response{
+		Status: "error",
+		Data:   undoPlan.ConfirmationData(),
+		Error: &plugin.ResponseError{
+			Code:    "CONFIRMATION_REQUIRED",
+			Message: "Deleting v1.2.0 requires confirmation",
+			Details: map[string]any{"tag": "v1.2.0", "hint": "Re-run with --yes to delete the tag"},
+		},
+	}
+	encoded, err := json.Marshal(resp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded plugin.Response
+	if err := json.Unmarshal(encoded, &decoded); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := New(&buf, RenderOptions{Format: FormatTable}).Render(&decoded); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	for _, want := range []string{"CONFIRMATION_REQUIRED", "Delete tag v1.2.0", "1.2.0 -> 1.1.0", "git push origin --delete v1.2.0", "Re-run with --yes"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output does not contain %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "Details:") {
+		t.Errorf("the plan should replace the details:\n%s", out)
+	}
+}
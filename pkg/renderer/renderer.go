@@ -4,10 +4,12 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"maps"
 	"os"
 	"reflect"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/nekoman-hq/neko-cli/pkg/log"
 	"github.com/nekoman-hq/neko-cli/pkg/plugin"
@@ -20,19 +22,86 @@ const (
 	FormatTable OutputFormat = "table" // kubectl-style with Colors (default)
 	FormatJSON  OutputFormat = "json"  // Raw JSON output
 	FormatWide  OutputFormat = "wide"  // Extended table with more columns if available
+
+	FormatTemplate OutputFormat = "template" // Go text/template given via RenderOptions.Template
 )
 
 type RenderOptions struct {
-	Format   OutputFormat
-	Describe bool // when true, include logs and metadata
+	Format    OutputFormat
+	Describe  bool      // when true, include logs and metadata
+	LogFilter LogFilter // restricts the logs shown in describe mode
+	Template  string    // text/template executed against the response data with FormatTemplate
+	Compact   bool      // with FormatJSON, encode the response on a single line
+}
+
+// Renderer renders plugin responses to a writer, e.g. a buffer when embedding neko's output
+type Renderer struct {
+	w    io.Writer
+	opts RenderOptions
+}
+
+// New returns a Renderer writing to w, a nil writer renders to STDOUT.
+// Colors are stripped if the active theme disables them.
+func New(w io.Writer, opts RenderOptions) *Renderer {
+	if w == nil {
+		w = os.Stdout
+	}
+	if !log.ColorsEnabled() {
+		w = plainWriter{w}
+	}
+	return &Renderer{w: w, opts: opts}
+}
+
+// Render renders the command output in the configured format
+// Supported formats: table (default), json, wide, template
+func (r *Renderer) Render(resp *plugin.Response) error {
+	switch r.opts.Format {
+	case FormatTemplate:
+		return renderTemplate(resp, r.opts.Template, r.w)
+	case FormatJSON:
+		return renderJSON(resp, r.w, r.opts.Compact)
+	}
+
+	if err := renderTable(resp, r.w, r.opts.Format == FormatWide); err != nil {
+		return err
+	}
+	renderWarningsSection(resp.Warnings, r.w)
+	return nil
+}
+
+// RenderDescribe renders metadata, the execution logs matching the log filter and the command output
+func (r *Renderer) RenderDescribe(resp *plugin.Response) error {
+	resp.Logs = FilterLogs(resp.Logs, r.opts.LogFilter)
+
+	if r.opts.Format == FormatJSON {
+		// JSON format includes everything
+		return renderJSON(resp, r.w, r.opts.Compact)
+	}
+
+	// Render metadata section
+	renderMetadataSection(resp, r.w)
+
+	// Render execution logs
+	if len(resp.Logs) > 0 {
+		renderLogsSection(resp.Logs, r.w)
+	}
+
+	// Render progress events
+	if len(resp.Progress) > 0 {
+		renderProgressSection(resp.Progress, r.w)
+	}
+
+	// Render output data
+	return r.renderOutputSection(resp)
 }
 
 // RenderWithOptions is the new unified render function
 func RenderWithOptions(resp *plugin.Response, opts RenderOptions) error {
+	r := New(os.Stdout, opts)
 	if opts.Describe {
-		return RenderDescribe(resp, opts.Format)
+		return r.RenderDescribe(resp)
 	}
-	return Render(resp, opts.Format)
+	return r.Render(resp)
 }
 
 // Render is the main entry point to render a plugin response to STDOUT
@@ -44,16 +113,7 @@ func Render(resp *plugin.Response, format OutputFormat) error {
 
 // RenderTo renders the plugin response to the given writer
 func RenderTo(resp *plugin.Response, format OutputFormat, w io.Writer) error {
-	switch format {
-	case FormatJSON:
-		return renderJSON(resp, w)
-	case FormatWide:
-		return renderTable(resp, w, true)
-	case FormatTable:
-		return renderTable(resp, w, false)
-	default:
-		return renderTable(resp, w, false)
-	}
+	return New(w, RenderOptions{Format: format}).Render(resp)
 }
 
 // RenderDescribe renders both execution logs and command output
@@ -63,28 +123,12 @@ func RenderDescribe(resp *plugin.Response, format OutputFormat) error {
 
 // RenderDescribeTo renders describe output to the given writer
 func RenderDescribeTo(resp *plugin.Response, format OutputFormat, w io.Writer) error {
-	if format == FormatJSON {
-		// JSON format includes everything
-		return renderJSON(resp, w)
-	}
-
-	// Render metadata section
-	renderMetadataSection(resp, w)
-
-	// Render execution logs
-	if len(resp.Logs) > 0 {
-		renderLogsSection(resp.Logs, w)
-	}
-
-	// Render output data
-	renderOutputSection(resp, format, w)
-
-	return nil
+	return New(w, RenderOptions{Format: format, Describe: true}).RenderDescribe(resp)
 }
 
 func renderMetadataSection(resp *plugin.Response, w io.Writer) {
-	_, _ = fmt.Fprintf(w, "\n%s%s━━━ Command Metadata ━━━%s\n",
-		log.ColorBold, log.ColorCyan, log.ColorReset)
+	_, _ = fmt.Fprintf(w, "\n%s%s%s Command Metadata %s%s\n",
+		log.ColorBold, log.ColorCyan, sectionRule(), sectionRule(), log.ColorReset)
 
 	_, _ = fmt.Fprintf(w, "%sPlugin:%s     %s\n",
 		log.ColorBrightBlack, log.ColorReset, resp.Metadata.Plugin)
@@ -100,8 +144,8 @@ func renderMetadataSection(resp *plugin.Response, w io.Writer) {
 }
 
 func renderLogsSection(logs []plugin.LogEntry, w io.Writer) {
-	_, _ = fmt.Fprintf(w, "%s%s━━━ Execution Logs (%d entries) ━━━%s\n",
-		log.ColorYellow, log.ColorBold, len(logs), log.ColorReset)
+	_, _ = fmt.Fprintf(w, "%s%s%s Execution Logs (%d entries) %s%s\n",
+		log.ColorYellow, log.ColorBold, sectionRule(), len(logs), sectionRule(), log.ColorReset)
 
 	for _, entry := range logs {
 		levelColor := getLogLevelColor(entry.Level)
@@ -110,6 +154,9 @@ func renderLogsSection(logs []plugin.LogEntry, w io.Writer) {
 		categoryStr := ""
 		if entry.Category != "" && entry.Category != "plugin" {
 			categoryStr = fmt.Sprintf("[%s] ", entry.Category)
+			if icon := getCategoryIcon(entry.Category); icon != "" {
+				categoryStr = icon + " " + categoryStr
+			}
 		}
 
 		_, _ = fmt.Fprintf(w, "%s%s %s%s%s%s\n",
@@ -120,20 +167,71 @@ func renderLogsSection(logs []plugin.LogEntry, w io.Writer) {
 	_, _ = fmt.Fprintln(w)
 }
 
-func renderOutputSection(resp *plugin.Response, format OutputFormat, w io.Writer) {
-	_, _ = fmt.Fprintf(w, "%s%s━━━ Output ━━━%s\n",
-		log.ColorGreen, log.ColorBold, log.ColorReset)
+func renderProgressSection(events []plugin.ProgressEvent, w io.Writer) {
+	_, _ = fmt.Fprintf(w, "%s%s%s Progress %s%s\n",
+		log.ColorBlue, log.ColorBold, sectionRule(), sectionRule(), log.ColorReset)
+
+	for _, e := range events {
+		_, _ = fmt.Fprintf(w, "%s%s %s%s %d/%d%s %s\n",
+			log.ColorBrightBlack, e.Timestamp,
+			log.ColorCyan, progressBar(e.Current, e.Total, 20),
+			e.Current, e.Total, log.ColorReset, e.Step)
+	}
+	_, _ = fmt.Fprintln(w)
+}
+
+// progressBar renders a fixed width bar like [#####-----]
+func progressBar(current, total, width int) string {
+	filled := 0
+	if total > 0 {
+		filled = current * width / total
+	}
+	filled = max(0, min(filled, width))
+	return "[" + strings.Repeat("#", filled) + strings.Repeat("-", width-filled) + "]"
+}
+
+func (r *Renderer) renderOutputSection(resp *plugin.Response) error {
+	_, _ = fmt.Fprintf(r.w, "%s%s%s Output %s%s\n",
+		log.ColorGreen, log.ColorBold, sectionRule(), sectionRule(), log.ColorReset)
+
+	if r.opts.Format == FormatTemplate {
+		return renderTemplate(resp, r.opts.Template, r.w)
+	}
+
+	wide := r.opts.Format == FormatWide
+	_ = renderTable(resp, r.w, wide)
+	renderWarningsSection(resp.Warnings, r.w)
+	return nil
+}
+
+// renderWarningsSection lists the non-fatal issues of the command below its output
+func renderWarningsSection(warnings []plugin.ResponseError, w io.Writer) {
+	if len(warnings) == 0 {
+		return
+	}
+
+	color := log.ActiveTheme().Warn
+	_, _ = fmt.Fprintf(w, "\n%s%s%s Warnings (%d) %s%s\n",
+		color, log.ColorBold, sectionRule(), len(warnings), sectionRule(), log.ColorReset)
 
-	wide := format == FormatWide
-	_ = renderTable(resp, w, wide)
+	for _, warning := range warnings {
+		message := strings.ReplaceAll(strings.TrimSpace(warning.Message), "\n", "\n    ")
+		_, _ = fmt.Fprintf(w, "%s%s%s%s\n    %s\n",
+			color, log.Glyph("\u26A0 ", "[!] "), warning.Code, log.ColorReset, message)
+	}
+}
+
+// sectionRule is the line drawn around section titles
+func sectionRule() string {
+	return log.Glyph("━━━", "===")
 }
 
 func colorizeStatus(status string) string {
 	switch strings.ToLower(status) {
 	case "success":
-		return log.ColorText(log.ColorGreen, "✓ "+status)
+		return log.ColorText(log.ActiveTheme().Success, log.Glyph("✓ ", "[ok] ")+status)
 	case "error":
-		return log.ColorText(log.ColorRed, "✗ "+status)
+		return log.ColorText(log.ActiveTheme().Failure, log.Glyph("✗ ", "[x] ")+status)
 	default:
 		return status
 	}
@@ -142,9 +240,9 @@ func colorizeStatus(status string) string {
 func getLogLevelColor(level string) string {
 	switch level {
 	case "error":
-		return log.ColorRed
+		return log.ActiveTheme().Error
 	case "warn":
-		return log.ColorYellow
+		return log.ActiveTheme().Warn
 	case "verbose":
 		return log.ColorPurple
 	default:
@@ -152,24 +250,18 @@ func getLogLevelColor(level string) string {
 	}
 }
 
-func getLogLevelIcon(level string) string {
-	switch level {
-	case "error":
-		return "✗ "
-	case "warn":
-		return "⚠ "
-	case "verbose":
-		return "V$ "
-	default:
-		return "• "
-	}
-}
-
 // renderJSON - raw JSON output
-func renderJSON(resp *plugin.Response, w io.Writer) error {
+// Empty lists are always encoded as [] so scripts can tell "empty" apart from an error.
+// Compact output is a single line, both variants end with exactly one newline.
+func renderJSON(resp *plugin.Response, w io.Writer, compact bool) error {
+	normalized := *resp
+	normalized.Data = normalizeEmptyLists(resp.Data)
+
 	encoder := json.NewEncoder(w)
-	encoder.SetIndent("", "  ")
-	return encoder.Encode(resp)
+	if !compact {
+		encoder.SetIndent("", "  ")
+	}
+	return encoder.Encode(&normalized)
 }
 
 // renderTable - unified kubectl-style output
@@ -179,17 +271,61 @@ func renderTable(resp *plugin.Response, w io.Writer, wide bool) error {
 	_ = wide // TODO: implement wide output format with additional columns
 
 	if resp.Status == "error" {
-		return renderError(resp, w)
+		if plan, ok := planFromData(resp.Data); ok && resp.Error != nil {
+			return renderConfirmation(resp, plan, w)
+		}
+		if err := renderError(resp, w); err != nil {
+			return err
+		}
+		// Partial results, e.g. per-item failures, are rendered below the error
+		if listData := findListInData(resp.Data); listData != nil {
+			_, _ = fmt.Fprintln(w)
+			return renderList(listData, resp.ColumnOrder, w)
+		}
+		return nil
+	}
+
+	// Several lists, each rendered as its own captioned table
+	if sections, ok := resp.Data["sections"]; ok {
+		return renderSections(sections, resp.ColumnOrder, w)
 	}
 
 	// Find any list in the data (items, releases, pods, etc.)
-	listData := findListInData(resp.Data)
+	data := normalizeEmptyLists(resp.Data)
+	listData := findListInData(data)
 	if listData != nil {
-		return renderList(listData, w)
+		return renderList(listData, resp.ColumnOrder, w)
 	}
 
 	// Single object or key-value data
-	return renderKeyValue(resp.Data, w)
+	return renderKeyValue(data, resp.ColumnOrder, w)
+}
+
+// listKeys are the data keys that are expected to hold a list, in priority order
+var listKeys = []string{"items", "releases", "resources", "results", "data", "list"}
+
+// normalizeEmptyLists returns data with nil values of known list keys replaced by an empty list.
+// The response data is copied before it is changed, it may still be used by the caller.
+func normalizeEmptyLists(data map[string]any) map[string]any {
+	normalized, copied := data, false
+	for _, key := range listKeys {
+		if val, ok := data[key]; ok && isNilList(val) {
+			if !copied {
+				normalized, copied = maps.Clone(data), true
+			}
+			normalized[key] = []any{}
+		}
+	}
+	return normalized
+}
+
+// isNilList reports whether val is nil or a nil slice
+func isNilList(val any) bool {
+	if val == nil {
+		return true
+	}
+	v := reflect.ValueOf(val)
+	return v.Kind() == reflect.Slice && v.IsNil()
 }
 
 // findListInData searches for any slice/array in the data map
@@ -200,9 +336,7 @@ func findListInData(data map[string]any) any {
 	}
 
 	// Priority keys for lists
-	priorityKeys := []string{"items", "releases", "resources", "results", "data", "list"}
-
-	for _, key := range priorityKeys {
+	for _, key := range listKeys {
 		if val, ok := data[key]; ok {
 			if reflect.TypeOf(val) != nil && reflect.TypeOf(val).Kind() == reflect.Slice {
 				return val
@@ -221,9 +355,7 @@ func findListInData(data map[string]any) any {
 }
 
 func renderError(resp *plugin.Response, w io.Writer) error {
-	_, _ = fmt.Fprintf(w, "%s%s✗ ERROR%s\n", log.ColorRed, log.ColorBold, log.ColorReset)
-	_, _ = fmt.Fprintf(w, "%sCode:%s    %s\n", log.ColorBrightBlack, log.ColorReset, resp.Error.Code)
-	_, _ = fmt.Fprintf(w, "%sMessage:%s %s\n", log.ColorBrightBlack, log.ColorReset, resp.Error.Message)
+	renderErrorHeader(resp, w)
 
 	if len(resp.Error.Details) > 0 {
 		_, _ = fmt.Fprintf(w, "\n%sDetails:%s\n", log.ColorBrightBlack, log.ColorReset)
@@ -234,10 +366,84 @@ func renderError(resp *plugin.Response, w io.Writer) error {
 	return nil
 }
 
-func renderList(items any, w io.Writer) error {
+func renderErrorHeader(resp *plugin.Response, w io.Writer) {
+	_, _ = fmt.Fprintf(w, "%s%s%sERROR%s\n", log.ActiveTheme().Error, log.ColorBold, log.Glyph("✗ ", "[x] "), log.ColorReset)
+	_, _ = fmt.Fprintf(w, "%sCode:%s    %s\n", log.ColorBrightBlack, log.ColorReset, resp.Error.Code)
+	_, _ = fmt.Fprintf(w, "%sMessage:%s %s\n", log.ColorBrightBlack, log.ColorReset, resp.Error.Message)
+}
+
+// renderConfirmation renders an error that asks for confirmation with the plan of the action.
+// The plan replaces the details, only the hint how to confirm is kept.
+func renderConfirmation(resp *plugin.Response, plan plugin.Plan, w io.Writer) error {
+	renderErrorHeader(resp, w)
+	_, _ = fmt.Fprintln(w)
+	if err := renderPlan(plan, w); err != nil {
+		return err
+	}
+	if hint, ok := resp.Error.Details["hint"]; ok {
+		_, _ = fmt.Fprintf(w, "\n%s%v%s\n", log.ColorBrightBlack, hint, log.ColorReset)
+	}
+	return nil
+}
+
+// renderSections renders data["sections"], a list of {title, items, column_order} objects,
+// as one captioned table per section. column_order falls back to the response's column order.
+func renderSections(sections any, columnOrder []string, w io.Writer) error {
+	slice := reflect.ValueOf(sections)
+	if slice.Kind() != reflect.Slice {
+		return renderKeyValue(map[string]any{"sections": sections}, columnOrder, w)
+	}
+
+	for i := 0; i < slice.Len(); i++ {
+		section, ok := slice.Index(i).Interface().(map[string]any)
+		if !ok {
+			continue
+		}
+		if i > 0 {
+			_, _ = fmt.Fprintln(w)
+		}
+
+		if title, _ := section["title"].(string); title != "" {
+			_, _ = fmt.Fprintf(w, "%s%s%s%s\n", log.ColorBold, log.ColorPurple, title, log.ColorReset)
+		}
+
+		order := columnOrder
+		if sectionOrder := toStrings(section["column_order"]); len(sectionOrder) > 0 {
+			order = sectionOrder
+		}
+
+		items := section["items"]
+		if isNilList(items) {
+			items = []any{}
+		}
+		if err := renderList(items, order, w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// toStrings converts a []string or a decoded JSON []any of strings to []string
+func toStrings(v any) []string {
+	switch list := v.(type) {
+	case []string:
+		return list
+	case []any:
+		out := make([]string, 0, len(list))
+		for _, item := range list {
+			if str, ok := item.(string); ok {
+				out = append(out, str)
+			}
+		}
+		return out
+	}
+	return nil
+}
+
+func renderList(items any, columnOrder []string, w io.Writer) error {
 	slice := reflect.ValueOf(items)
 	if slice.Kind() != reflect.Slice {
-		return renderKeyValue(map[string]any{"items": items}, w)
+		return renderKeyValue(map[string]any{"items": items}, columnOrder, w)
 	}
 
 	if slice.Len() == 0 {
@@ -246,7 +452,7 @@ func renderList(items any, w io.Writer) error {
 	}
 
 	// Extract all keys from the first item to build headers
-	headers, rows := extractTableData(slice)
+	headers, rows := extractTableData(slice, columnOrder)
 
 	if len(headers) == 0 {
 		// Fallback for non-map items
@@ -270,7 +476,7 @@ func renderList(items any, w io.Writer) error {
 	return nil
 }
 
-func extractTableData(slice reflect.Value) ([]string, []map[string]string) {
+func extractTableData(slice reflect.Value, columnOrder []string) ([]string, []map[string]string) {
 	var headers []string
 	headerSet := make(map[string]bool)
 	var rows []map[string]string
@@ -294,13 +500,19 @@ func extractTableData(slice reflect.Value) ([]string, []map[string]string) {
 	// Prioritize common fields first
 	headers = prioritizeHeaders(headers)
 
+	// An explicit column order from the plugin always wins
+	headers = applyColumnOrder(headers, columnOrder)
+
 	// Second pass: extract row data
 	for i := 0; i < slice.Len(); i++ {
 		item := slice.Index(i).Interface()
 		row := make(map[string]string)
 		if m, ok := item.(map[string]any); ok {
 			for _, h := range headers {
-				row[h] = formatValue(m[h])
+				// Columns missing in this row stay blank
+				if v, ok := m[h]; ok {
+					row[h] = formatColumnValue(h, v)
+				}
 			}
 		}
 		rows = append(rows, row)
@@ -338,6 +550,34 @@ func prioritizeHeaders(headers []string) []string {
 	return headers
 }
 
+// applyColumnOrder moves the columns listed in order to the front, in that order.
+// Columns not present in headers are ignored, unlisted columns keep their relative order.
+func applyColumnOrder(headers, order []string) []string {
+	if len(order) == 0 {
+		return headers
+	}
+
+	present := make(map[string]bool, len(headers))
+	for _, h := range headers {
+		present[h] = true
+	}
+
+	ordered := make([]string, 0, len(headers))
+	placed := make(map[string]bool, len(order))
+	for _, h := range order {
+		if present[h] && !placed[h] {
+			ordered = append(ordered, h)
+			placed[h] = true
+		}
+	}
+	for _, h := range headers {
+		if !placed[h] {
+			ordered = append(ordered, h)
+		}
+	}
+	return ordered
+}
+
 func calculateColumnWidths(headers []string, rows []map[string]string) map[string]int {
 	widths := make(map[string]int)
 
@@ -386,42 +626,102 @@ func printRow(w io.Writer, headers []string, row map[string]string, widths map[s
 }
 
 func colorizeValue(key, value string) string {
+	theme := log.ActiveTheme()
 	keyLower := strings.ToLower(key)
 	valueLower := strings.ToLower(value)
 
 	// Status-based coloring
 	if keyLower == "status" || keyLower == "state" {
-		switch valueLower {
-		case "success", "running", "active", "ready", "healthy", "ok":
-			return log.ColorText(log.ColorGreen, value)
-		case "error", "failed", "terminated", "unhealthy":
-			return log.ColorText(log.ColorRed, value)
-		case "pending", "waiting", "unknown":
-			return log.ColorText(log.ColorYellow, value)
+		if color, ok := theme.StatusColor(value); ok {
+			return log.ColorText(color, value)
 		}
 	}
 
+	// Commit graph coloring (history --graph)
+	switch keyLower {
+	case "graph":
+		return colorizeGraph(value)
+	case "commit":
+		return log.ColorText(log.ColorYellow, value)
+	case "refs":
+		return log.ColorText(log.ColorPurple, value)
+	}
+
 	// Version coloring
 	if keyLower == "version" || strings.HasPrefix(value, "v") {
-		return log.ColorText(log.ColorPurple, value)
+		return log.ColorText(theme.Version, value)
 	}
 
 	// Boolean coloring
 	if valueLower == "true" || valueLower == "yes" {
-		return log.ColorText(log.ColorGreen, value)
+		return log.ColorText(theme.Success, value)
 	}
 	if valueLower == "false" || valueLower == "no" {
-		return log.ColorText(log.ColorRed, value)
+		return log.ColorText(theme.Failure, value)
 	}
 
 	// Name highlighting
 	if keyLower == "name" || keyLower == "id" {
-		return log.ColorText(log.ColorBrightWhite, value)
+		return log.ColorText(theme.Name, value)
 	}
 
 	return value
 }
 
+// colorizeGraph highlights the commit markers of a git log --graph line
+func colorizeGraph(graph string) string {
+	var b strings.Builder
+	for _, r := range graph {
+		switch r {
+		case '*':
+			b.WriteString(log.ColorText(log.ColorGreen, "*"))
+		case ' ':
+			b.WriteRune(r)
+		default:
+			b.WriteString(log.ColorText(log.ColorBrightBlack, string(r)))
+		}
+	}
+	return b.String()
+}
+
+// timeColumns are rendered as a relative age instead of a raw timestamp
+var timeColumns = map[string]bool{"age": true, "created": true, "published": true}
+
+// formatColumnValue formats a value, humanizing RFC3339 timestamps in time columns
+func formatColumnValue(key string, v any) string {
+	if s, ok := v.(string); ok && timeColumns[strings.ToLower(key)] {
+		return formatAge(s, time.Now())
+	}
+	return formatValue(v)
+}
+
+// formatAge renders an RFC3339 timestamp as a compact kubectl-style age (e.g. 45s, 3h, 5d, 2y).
+// Falls back to the raw value if it cannot be parsed.
+func formatAge(value string, now time.Time) string {
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return formatValue(value)
+	}
+
+	d := now.Sub(t)
+	if d < 0 {
+		d = 0
+	}
+
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	case d < 365*24*time.Hour:
+		return fmt.Sprintf("%dd", int(d.Hours()/24))
+	default:
+		return fmt.Sprintf("%dy", int(d.Hours()/(24*365)))
+	}
+}
+
 func formatValue(v any) string {
 	if v == nil {
 		return "<none>"
@@ -466,7 +766,7 @@ func formatValue(v any) string {
 	}
 }
 
-func renderKeyValue(data map[string]any, w io.Writer) error {
+func renderKeyValue(data map[string]any, keyOrder []string, w io.Writer) error {
 	if len(data) == 0 {
 		_, _ = fmt.Fprintf(w, "%sNo data.%s\n", log.ColorBrightBlack, log.ColorReset)
 		return nil
@@ -478,6 +778,7 @@ func renderKeyValue(data map[string]any, w io.Writer) error {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
+	keys = applyColumnOrder(keys, keyOrder)
 
 	// Find max key length for alignment
 	maxKeyLen := 0
@@ -491,7 +792,7 @@ func renderKeyValue(data map[string]any, w io.Writer) error {
 	for _, k := range keys {
 		v := data[k]
 		formattedKey := fmt.Sprintf("%-*s", maxKeyLen, capitalizeFirst(k))
-		formattedValue := formatValue(v)
+		formattedValue := formatColumnValue(k, v)
 		coloredValue := colorizeValue(k, formattedValue)
 
 		_, _ = fmt.Fprintf(w, "%s%s:%s  %s\n",
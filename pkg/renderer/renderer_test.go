@@ -0,0 +1,644 @@
+package renderer
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nekoman-hq/neko-cli/pkg/log"
+	"github.com/nekoman-hq/neko-cli/pkg/plugin"
+)
+
+func TestNormalizeEmptyLists(t *testing.T) {
+	var nilItems []map[string]any
+	tests := []struct {
+		name string
+		data map[string]any
+		want map[string]any
+	}{
+		{"nil data", nil, nil},
+		{"untyped nil", map[string]any{"items": nil}, map[string]any{"items": []any{}}},
+		{"nil slice", map[string]any{"releases": nilItems}, map[string]any{"releases": []any{}}},
+		{"empty slice", map[string]any{"items": []map[string]any{}}, map[string]any{"items": []map[string]any{}}},
+		{"filled slice", map[string]any{"items": []string{"a"}}, map[string]any{"items": []string{"a"}}},
+		{"unknown key", map[string]any{"tags": nil}, map[string]any{"tags": nil}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := normalizeEmptyLists(tt.data); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("normalizeEmptyLists = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeEmptyListsCopies(t *testing.T) {
+	data := map[string]any{"items": nil, "count": 0}
+
+	normalized := normalizeEmptyLists(data)
+	if data["items"] != nil {
+		t.Errorf("the response data was changed to %#v", data["items"])
+	}
+	if normalized["count"] != 0 {
+		t.Errorf("normalized data lost the other keys: %#v", normalized)
+	}
+}
+
+func TestRenderJSONEmptyLists(t *testing.T) {
+	tests := []struct {
+		name  string
+		items any
+		want  string
+	}{
+		{"nil", nil, "[]"},
+		{"nil slice", []map[string]any(nil), "[]"},
+		{"empty", []map[string]any{}, "[]"},
+		{"filled", []map[string]any{{"name": "v1.0.0"}}, `[{"name":"v1.0.0"}]`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &plugin.Response{Status: "success", Data: map[string]any{"items": tt.items}}
+
+			var out bytes.Buffer
+			if err := renderJSON(resp, &out, true); err != nil {
+				t.Fatal(err)
+			}
+			var decoded struct {
+				Data struct {
+					Items json.RawMessage `json:"items"`
+				} `json:"data"`
+			}
+			if err := json.Unmarshal(out.Bytes(), &decoded); err != nil {
+				t.Fatal(err)
+			}
+			if got := string(decoded.Data.Items); got != tt.want {
+				t.Errorf("items = %s, want %s", got, tt.want)
+			}
+			if !reflect.DeepEqual(resp.Data["items"], tt.items) {
+				t.Errorf("rendering changed the response items to %#v", resp.Data["items"])
+			}
+		})
+	}
+}
+
+func TestFormatAge(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{"2026-03-10T11:59:15Z", "45s"},
+		{"2026-03-10T11:57:00Z", "3m"},
+		{"2026-03-10T09:00:00Z", "3h"},
+		{"2026-03-05T12:00:00Z", "5d"},
+		{"2024-03-10T12:00:00Z", "2y"},
+		{"2026-03-10T13:00:00+01:00", "0s"},
+		{"2026-03-11T12:00:00Z", "0s"},
+		{"yesterday", "yesterday"},
+	}
+
+	for _, tt := range tests {
+		if got := formatAge(tt.value, now); got != tt.want {
+			t.Errorf("formatAge(%q) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestFormatColumnValue(t *testing.T) {
+	stamp := time.Now().Add(-3 * time.Hour).Format(time.RFC3339)
+	tests := []struct {
+		key  string
+		v    any
+		want string
+	}{
+		{"published", stamp, "3h"},
+		{"Created", stamp, "3h"},
+		{"age", stamp, "3h"},
+		{"timestamp", stamp, stamp},
+		{"created", nil, "<none>"},
+	}
+
+	for _, tt := range tests {
+		if got := formatColumnValue(tt.key, tt.v); got != tt.want {
+			t.Errorf("formatColumnValue(%q, %v) = %q, want %q", tt.key, tt.v, got, tt.want)
+		}
+	}
+}
+
+func TestProgressBar(t *testing.T) {
+	tests := []struct {
+		current, total int
+		want           string
+	}{
+		{0, 5, "[----------]"},
+		{2, 5, "[####------]"},
+		{5, 5, "[##########]"},
+		{7, 5, "[##########]"},
+		{-1, 5, "[----------]"},
+		{3, 0, "[----------]"},
+	}
+
+	for _, tt := range tests {
+		if got := progressBar(tt.current, tt.total, 10); got != tt.want {
+			t.Errorf("progressBar(%d, %d) = %s, want %s", tt.current, tt.total, got, tt.want)
+		}
+	}
+}
+
+func TestApplyColumnOrder(t *testing.T) {
+	tests := []struct {
+		name    string
+		headers []string
+		order   []string
+		want    []string
+	}{
+		{"no order", []string{"status", "property", "value"}, nil, []string{"status", "property", "value"}},
+		{"full order", []string{"status", "property", "value"}, []string{"value", "property", "status"}, []string{"value", "property", "status"}},
+		{"partial order", []string{"status", "property", "value"}, []string{"property", "value"}, []string{"property", "value", "status"}},
+		{"unknown columns", []string{"property", "value"}, []string{"missing", "value"}, []string{"value", "property"}},
+		{"duplicates", []string{"property", "value"}, []string{"value", "value"}, []string{"value", "property"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := applyColumnOrder(tt.headers, tt.order); !slices.Equal(got, tt.want) {
+				t.Errorf("applyColumnOrder(%v, %v) = %v, want %v", tt.headers, tt.order, got, tt.want)
+			}
+		})
+	}
+}
+
+// Without the hint the priority columns move before property and value
+func TestExtractTableDataColumnOrder(t *testing.T) {
+	items := []map[string]any{
+		{"property": "Version", "value": "1.2.0", "status": "ok"},
+		{"property": "Release System", "value": "goreleaser"},
+	}
+
+	tests := []struct {
+		order []string
+		want  []string
+	}{
+		{nil, []string{"status", "property", "value"}},
+		{[]string{"property", "value"}, []string{"property", "value", "status"}},
+	}
+
+	for _, tt := range tests {
+		headers, rows := extractTableData(reflect.ValueOf(items), tt.order)
+		if !slices.Equal(headers, tt.want) {
+			t.Errorf("headers with order %v = %v, want %v", tt.order, headers, tt.want)
+		}
+		if rows[0]["property"] != "Version" || rows[1]["property"] != "Release System" || rows[1]["status"] != "" {
+			t.Errorf("rows = %v, want the item order with blank missing columns", rows)
+		}
+	}
+}
+
+// ansi matches the color codes around rendered keys
+var ansi = regexp.MustCompile("\x1b\\[[0-9;]*m")
+
+func TestRenderKeyValueOrder(t *testing.T) {
+	var buf bytes.Buffer
+	data := map[string]any{"alpha": 1, "beta": 2, "gamma": 3}
+	if err := renderKeyValue(data, []string{"gamma", "alpha"}, &buf); err != nil {
+		t.Fatal(err)
+	}
+
+	var keys []string
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		key, _, _ := strings.Cut(line, ":")
+		keys = append(keys, strings.TrimSpace(ansi.ReplaceAllString(key, "")))
+	}
+	if want := []string{"Gamma", "Alpha", "Beta"}; !slices.Equal(keys, want) {
+		t.Errorf("keys = %v, want %v", keys, want)
+	}
+}
+
+func TestColorizeGraph(t *testing.T) {
+	tests := []struct {
+		graph string
+		want  string
+	}{
+		{"*", log.ColorText(log.ColorGreen, "*")},
+		{"| *", log.ColorText(log.ColorBrightBlack, "|") + " " + log.ColorText(log.ColorGreen, "*")},
+		{"|\\", log.ColorText(log.ColorBrightBlack, "|") + log.ColorText(log.ColorBrightBlack, "\\")},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		got := colorizeGraph(tt.graph)
+		if got != tt.want {
+			t.Errorf("colorizeGraph(%q) = %q, want %q", tt.graph, got, tt.want)
+		}
+		if plain := ansi.ReplaceAllString(got, ""); plain != tt.graph {
+			t.Errorf("colorizeGraph(%q) changed the graph to %q", tt.graph, plain)
+		}
+	}
+}
+
+func TestColorizeValueGraphColumns(t *testing.T) {
+	tests := []struct {
+		key, value string
+		want       string
+	}{
+		{"commit", "abc1234", log.ColorText(log.ColorYellow, "abc1234")},
+		{"refs", "tag: v1.0.0", log.ColorText(log.ColorPurple, "tag: v1.0.0")},
+		{"graph", "*", log.ColorText(log.ColorGreen, "*")},
+	}
+
+	for _, tt := range tests {
+		if got := colorizeValue(tt.key, tt.value); got != tt.want {
+			t.Errorf("colorizeValue(%q, %q) = %q, want %q", tt.key, tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestColorizeValueStatus(t *testing.T) {
+	theme := log.DefaultTheme
+	theme.Statuses = map[string]string{"deployed": log.ColorBlue}
+	previous := log.ActiveTheme()
+	log.SetTheme(theme)
+	t.Cleanup(func() { log.SetTheme(previous) })
+
+	tests := []struct {
+		key, value string
+		want       string
+	}{
+		{"status", "completed", log.ColorText(log.ColorGreen, "completed")},
+		{"Status", "in_progress", log.ColorText(log.ColorYellow, "in_progress")},
+		{"state", "cancelled", log.ColorText(log.ColorBrightBlack, "cancelled")},
+		{"status", "neutral", log.ColorText(log.ColorCyan, "neutral")},
+		{"status", "Deployed", log.ColorText(log.ColorBlue, "Deployed")},
+		{"conclusion", "skipped", "skipped"},
+	}
+
+	for _, tt := range tests {
+		if got := colorizeValue(tt.key, tt.value); got != tt.want {
+			t.Errorf("colorizeValue(%q, %q) = %q, want %q", tt.key, tt.value, got, tt.want)
+		}
+	}
+}
+
+// captureStdout returns what fn writes to STDOUT
+func captureStdout(t *testing.T, fn func()) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	fn()
+	os.Stdout = stdout
+	_ = w.Close()
+
+	out, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(out)
+}
+
+func testResponse() *plugin.Response {
+	return &plugin.Response{
+		Status:   "success",
+		Metadata: plugin.ResponseMetadata{Plugin: "release", Version: "1.0.0", Command: "history"},
+		Data: map[string]any{
+			"items": []map[string]any{{"version": "v1.2.0", "commits": 3}},
+		},
+		Logs: []plugin.LogEntry{
+			{Level: "verbose", Category: "Exec", Message: "running git log"},
+			{Level: "warn", Category: "Guard", Message: "tag diverged"},
+		},
+	}
+}
+
+func TestRendererWriter(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     RenderOptions
+		describe bool
+		want     []string
+		wantNot  []string
+	}{
+		{name: "table", opts: RenderOptions{Format: FormatTable}, want: []string{"VERSION", "v1.2.0"}},
+		{name: "wide", opts: RenderOptions{Format: FormatWide}, want: []string{"VERSION", "v1.2.0"}},
+		{name: "json", opts: RenderOptions{Format: FormatJSON}, want: []string{`"version": "v1.2.0"`}},
+		{
+			name:     "describe",
+			opts:     RenderOptions{Format: FormatTable, LogFilter: LogFilter{MinLevel: "warn"}},
+			describe: true,
+			want:     []string{"Command Metadata", "tag diverged", "v1.2.0"},
+			wantNot:  []string{"running git log"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			r := New(&buf, tt.opts)
+
+			stdout := captureStdout(t, func() {
+				var err error
+				if tt.describe {
+					err = r.RenderDescribe(testResponse())
+				} else {
+					err = r.Render(testResponse())
+				}
+				if err != nil {
+					t.Error(err)
+				}
+			})
+			if stdout != "" {
+				t.Errorf("rendered %q to STDOUT instead of the writer", stdout)
+			}
+
+			out := ansi.ReplaceAllString(buf.String(), "")
+			for _, want := range tt.want {
+				if !strings.Contains(out, want) {
+					t.Errorf("output does not contain %q:\n%s", want, out)
+				}
+			}
+			for _, unwanted := range tt.wantNot {
+				if strings.Contains(out, unwanted) {
+					t.Errorf("output contains %q:\n%s", unwanted, out)
+				}
+			}
+		})
+	}
+}
+
+func TestRenderCompactJSON(t *testing.T) {
+	tests := []struct {
+		name      string
+		compact   bool
+		describe  bool
+		wantLines int
+	}{
+		{"indented", false, false, 0},
+		{"compact", true, false, 1},
+		{"compact describe", true, true, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			r := New(&buf, RenderOptions{Format: FormatJSON, Compact: tt.compact})
+			var err error
+			if tt.describe {
+				err = r.RenderDescribe(testResponse())
+			} else {
+				err = r.Render(testResponse())
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			out := buf.String()
+			if !strings.HasSuffix(out, "}\n") || strings.HasSuffix(out, "\n\n") {
+				t.Errorf("output does not end with exactly one newline: %q", out)
+			}
+			lines := strings.Count(out, "\n")
+			if tt.wantLines > 0 && lines != tt.wantLines {
+				t.Errorf("output has %d lines, want %d:\n%s", lines, tt.wantLines, out)
+			}
+			if tt.wantLines == 0 && lines < 2 {
+				t.Errorf("output is not indented:\n%s", out)
+			}
+			var decoded plugin.Response
+			if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil || decoded.Status != "success" {
+				t.Errorf("output is not the response: %v\n%s", err, out)
+			}
+		})
+	}
+}
+
+func TestNewNilWriter(t *testing.T) {
+	out := captureStdout(t, func() {
+		if err := New(nil, RenderOptions{Format: FormatJSON}).Render(testResponse()); err != nil {
+			t.Error(err)
+		}
+	})
+	if !strings.Contains(out, `"status": "success"`) {
+		t.Errorf("a nil writer rendered %q, want the response on STDOUT", out)
+	}
+}
+
+func TestToStrings(t *testing.T) {
+	tests := []struct {
+		name string
+		v    any
+		want []string
+	}{
+		{"strings", []string{"a", "b"}, []string{"a", "b"}},
+		{"decoded JSON", []any{"a", 1, "b"}, []string{"a", "b"}},
+		{"nil", nil, nil},
+		{"string", "a", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := toStrings(tt.v); !slices.Equal(got, tt.want) {
+				t.Errorf("toStrings(%v) = %q, want %q", tt.v, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderSections(t *testing.T) {
+	tests := []struct {
+		name     string
+		sections any
+		want     []string
+	}{
+		{
+			name: "captioned tables",
+			sections: []map[string]any{
+				{"title": "Local", "items": []map[string]any{{"tag": "v1.0.0", "commit": "abc123"}}},
+				{"title": "Remote", "items": []map[string]any{{"tag": "v1.1.0", "commit": "def456"}}},
+			},
+			want: []string{"Local", "TAG", "v1.0.0", "Remote", "TAG", "v1.1.0"},
+		},
+		{
+			name: "section column order",
+			sections: []any{
+				map[string]any{
+					"title":        "Decoded",
+					"column_order": []any{"commit", "tag"},
+					"items":        []any{map[string]any{"tag": "v1.0.0", "commit": "abc123"}},
+				},
+			},
+			want: []string{"Decoded", "COMMIT", "abc123"},
+		},
+		{
+			name:     "empty section",
+			sections: []map[string]any{{"title": "Empty", "items": nil}},
+			want:     []string{"Empty"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := renderSections(tt.sections, []string{"tag", "commit"}, &buf); err != nil {
+				t.Fatal(err)
+			}
+			lines := strings.Split(ansi.ReplaceAllString(buf.String(), ""), "\n")
+
+			// Every wanted line prefix appears in order
+			i := 0
+			for _, line := range lines {
+				if i < len(tt.want) && strings.HasPrefix(strings.TrimSpace(line), tt.want[i]) {
+					i++
+				}
+			}
+			if i != len(tt.want) {
+				t.Errorf("output misses %q in order:\n%s", tt.want[i:], buf.String())
+			}
+		})
+	}
+}
+
+func TestRenderTableSections(t *testing.T) {
+	resp := &plugin.Response{
+		Status:      "success",
+		ColumnOrder: []string{"tag"},
+		Data: map[string]any{"sections": []map[string]any{
+			{"title": "Only local", "items": []map[string]any{{"tag": "v1.0.0"}}},
+		}},
+	}
+
+	var buf bytes.Buffer
+	if err := renderTable(resp, &buf, false); err != nil {
+		t.Fatal(err)
+	}
+	out := ansi.ReplaceAllString(buf.String(), "")
+	if !strings.HasPrefix(out, "Only local\n") || !strings.Contains(out, "v1.0.0") {
+		t.Errorf("sections not rendered as a captioned table:\n%s", out)
+	}
+}
+
+func TestPlainWriter(t *testing.T) {
+	var buf bytes.Buffer
+	input := []byte(log.ColorRed + "failed" + log.ColorReset + " \x1b[1;32mok\x1b[0m")
+
+	n, err := plainWriter{&buf}.Write(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != len(input) {
+		t.Errorf("Write = %d, want the input length %d", n, len(input))
+	}
+	if buf.String() != "failed ok" {
+		t.Errorf("plainWriter wrote %q", buf.String())
+	}
+}
+
+func TestRenderTheme(t *testing.T) {
+	tests := []struct {
+		name      string
+		theme     log.Theme
+		wantColor string
+	}{
+		{"default", log.DefaultTheme, log.ColorPurple},
+		{"custom version color", log.Theme{Version: log.ColorBlue}, log.ColorBlue},
+		{"none", log.NoTheme, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			previous := log.ActiveTheme()
+			log.SetTheme(tt.theme)
+			t.Cleanup(func() { log.SetTheme(previous) })
+
+			var buf bytes.Buffer
+			if err := New(&buf, RenderOptions{Format: FormatTable}).Render(testResponse()); err != nil {
+				t.Fatal(err)
+			}
+			out := buf.String()
+
+			if tt.wantColor == "" {
+				if ansi.MatchString(out) {
+					t.Errorf("the none theme rendered colors:\n%q", out)
+				}
+				return
+			}
+			if !strings.Contains(out, tt.wantColor+"v1.2.0") {
+				t.Errorf("version not colored with %q:\n%q", tt.wantColor, out)
+			}
+		})
+	}
+}
+
+func TestRenderWarningsSection(t *testing.T) {
+	log.SetUnicode(false)
+	t.Cleanup(log.ResetUnicode)
+
+	tests := []struct {
+		name     string
+		warnings []plugin.ResponseError
+		want     string
+	}{
+		{"none", nil, ""},
+		{
+			"indented messages",
+			[]plugin.ResponseError{
+				{Code: "CONFIG_UPDATE_FAILED", Message: "could not write\nthe version\n"},
+				{Code: "TAG_DIVERGED", Message: "v1.2.0 differs"},
+			},
+			"\n=== Warnings (2) ===\n" +
+				"[!] CONFIG_UPDATE_FAILED\n    could not write\n    the version\n" +
+				"[!] TAG_DIVERGED\n    v1.2.0 differs\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			renderWarningsSection(tt.warnings, &buf)
+			if got := ansi.ReplaceAllString(buf.String(), ""); got != tt.want {
+				t.Errorf("renderWarningsSection = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// Warnings are rendered below the table and survive the JSON output
+func TestRenderWarnings(t *testing.T) {
+	resp := testResponse()
+	resp.Warnings = []plugin.ResponseError{{Code: "TAG_DIVERGED", Message: "v1.2.0 differs"}}
+
+	tests := []struct {
+		format OutputFormat
+		want   string
+	}{
+		{FormatTable, "TAG_DIVERGED"},
+		{FormatWide, "TAG_DIVERGED"},
+		{FormatJSON, `"warnings": [`},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.format), func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := New(&buf, RenderOptions{Format: tt.format}).Render(resp); err != nil {
+				t.Fatal(err)
+			}
+			out := ansi.ReplaceAllString(buf.String(), "")
+			if !strings.Contains(out, tt.want) {
+				t.Errorf("output does not contain %q:\n%s", tt.want, out)
+			}
+			if tt.format != FormatJSON && strings.Index(out, "TAG_DIVERGED") < strings.Index(out, "v1.2.0") {
+				t.Errorf("warnings rendered above the output:\n%s", out)
+			}
+		})
+	}
+}
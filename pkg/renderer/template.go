@@ -0,0 +1,85 @@
+package renderer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"text/template"
+
+	"github.com/nekoman-hq/neko-cli/pkg/log"
+	"github.com/nekoman-hq/neko-cli/pkg/plugin"
+)
+
+// templateColors maps the color names usable with the template color function
+var templateColors = map[string]string{
+	"red":    log.ColorRed,
+	"green":  log.ColorGreen,
+	"yellow": log.ColorYellow,
+	"blue":   log.ColorBlue,
+	"purple": log.ColorPurple,
+	"cyan":   log.ColorCyan,
+	"gray":   log.ColorBrightBlack,
+	"bold":   log.ColorBold,
+}
+
+// templateFuncs are the helper functions available in --template
+var templateFuncs = template.FuncMap{
+	"color": func(name string, v any) string {
+		color, ok := templateColors[strings.ToLower(name)]
+		if !ok {
+			return fmt.Sprint(v)
+		}
+		return log.ColorText(color, fmt.Sprint(v))
+	},
+	"upper": func(v any) string { return strings.ToUpper(fmt.Sprint(v)) },
+	"lower": func(v any) string { return strings.ToLower(fmt.Sprint(v)) },
+	"join":  joinValues,
+	"json": func(v any) (string, error) {
+		b, err := json.Marshal(v)
+		return string(b), err
+	},
+}
+
+// joinValues joins the elements of any slice or array, e.g. []string from plugins
+// or []any after JSON decoding. Other values are printed as they are.
+func joinValues(sep string, v any) string {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		if v == nil {
+			return ""
+		}
+		return fmt.Sprint(v)
+	}
+
+	parts := make([]string, rv.Len())
+	for i := range parts {
+		parts[i] = fmt.Sprint(rv.Index(i).Interface())
+	}
+	return strings.Join(parts, sep)
+}
+
+// templateEscapes turns the escapes typed in a shell-quoted template into real characters
+var templateEscapes = strings.NewReplacer(`\n`, "\n", `\t`, "\t")
+
+// renderTemplate executes the user template against the response data.
+// Error responses are rendered as usual since they carry no data to format.
+func renderTemplate(resp *plugin.Response, text string, w io.Writer) error {
+	if resp.Status == "error" && resp.Error != nil {
+		return renderError(resp, w)
+	}
+	if text == "" {
+		return fmt.Errorf("--output template requires --template")
+	}
+
+	tmpl, err := template.New("output").Funcs(templateFuncs).Option("missingkey=zero").Parse(templateEscapes.Replace(text))
+	if err != nil {
+		return fmt.Errorf("invalid template: %w", err)
+	}
+
+	if err := tmpl.Execute(w, normalizeEmptyLists(resp.Data)); err != nil {
+		return fmt.Errorf("template execution failed: %w", err)
+	}
+	return nil
+}
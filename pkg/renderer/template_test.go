@@ -0,0 +1,96 @@
+package renderer
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/nekoman-hq/neko-cli/pkg/log"
+	"github.com/nekoman-hq/neko-cli/pkg/plugin"
+)
+
+func renderWithTemplate(t *testing.T, resp *plugin.Response, text string) (string, error) {
+	t.Helper()
+	var buf bytes.Buffer
+	err := New(&buf, RenderOptions{Format: FormatTemplate, Template: text}).Render(resp)
+	return buf.String(), err
+}
+
+func historyResponse() *plugin.Response {
+	return &plugin.Response{
+		Status: "success",
+		Data: map[string]any{
+			"items": []map[string]any{
+				{"version": "v1.0.0", "commits": 3, "changes": []string{"feat: a", "fix: b"}},
+				{"version": "v1.1.0", "commits": 5, "changes": []any{"feat: c"}},
+			},
+			"tags": []string{"v1.0.0", "v1.1.0"},
+		},
+	}
+}
+
+func TestRenderTemplate(t *testing.T) {
+	previous := log.ActiveTheme()
+	log.SetTheme(log.NoTheme)
+	t.Cleanup(func() { log.SetTheme(previous) })
+
+	tests := []struct {
+		name     string
+		template string
+		want     string
+	}{
+		{"sample", `{{range .items}}{{.version}} {{.commits}}\n{{end}}`, "v1.0.0 3\nv1.1.0 5\n"},
+		{"join strings", `{{join ", " .tags}}`, "v1.0.0, v1.1.0"},
+		{"join per item", `{{range .items}}{{join "; " .changes}}|{{end}}`, "feat: a; fix: b|feat: c|"},
+		{"join missing", `[{{join ", " .missing}}]`, "[]"},
+		{"upper and color", `{{range .items}}{{upper .version}} {{color "green" .commits}}\t{{end}}`, "V1.0.0 3\tV1.1.0 5\t"},
+		{"json", `{{json .tags}}`, `["v1.0.0","v1.1.0"]`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := renderWithTemplate(t, historyResponse(), tt.template)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("rendered %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderTemplateColor(t *testing.T) {
+	previous := log.ActiveTheme()
+	log.SetTheme(log.DefaultTheme)
+	t.Cleanup(func() { log.SetTheme(previous) })
+
+	got, err := renderWithTemplate(t, historyResponse(), `{{color "green" "ok"}}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != log.ColorGreen+"ok"+log.ColorReset {
+		t.Errorf("rendered %q, want green ok", got)
+	}
+}
+
+func TestRenderTemplateErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		want     string
+	}{
+		{"missing template", "", "requires --template"},
+		{"parse error", `{{range .items}}`, "invalid template"},
+		{"execution error", `{{index .tags 5}}`, "template execution failed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := renderWithTemplate(t, historyResponse(), tt.template)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
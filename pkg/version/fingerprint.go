@@ -0,0 +1,100 @@
+package version
+
+import (
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/nekoman-hq/neko-cli/pkg/config"
+)
+
+// fingerprintTools are the binaries neko or one of its release systems may call
+var fingerprintTools = []string{"git", "gh", "goreleaser", "jreleaser", "npm", "bun", "node"}
+
+// ToolInfo describes a resolved binary
+type ToolInfo struct {
+	Name    string `json:"name"`
+	Path    string `json:"path"`
+	Version string `json:"version"`
+	Found   bool   `json:"found"`
+}
+
+// Fingerprint describes the environment neko runs in, meant to be attached to bug reports
+type Fingerprint struct {
+	CLIVersion string     `json:"cli_version"`
+	Commit     string     `json:"commit"`
+	Executable string     `json:"executable"`
+	OS         string     `json:"os"`
+	Arch       string     `json:"arch"`
+	Token      string     `json:"github_token"`
+	Tools      []ToolInfo `json:"tools"`
+}
+
+// Probe resolves binaries and their versions, replaceable to gather a fingerprint without exec
+type Probe struct {
+	LookPath func(name string) (string, error)
+	Version  func(path string) string
+	Token    func() (string, error)
+}
+
+// DefaultProbe uses exec.LookPath, '<binary> --version' and config.GetPAT
+var DefaultProbe = Probe{
+	LookPath: exec.LookPath,
+	Version:  binaryVersion,
+	Token:    config.GetPAT,
+}
+
+// GatherFingerprint collects the environment fingerprint using the given probe
+func GatherFingerprint(p Probe) Fingerprint {
+	fp := Fingerprint{
+		CLIVersion: Version,
+		Commit:     Commit,
+		Executable: executablePath(),
+		OS:         runtime.GOOS,
+		Arch:       runtime.GOARCH,
+		Token:      "not configured",
+		Tools:      make([]ToolInfo, 0, len(fingerprintTools)),
+	}
+
+	if token, err := p.Token(); err == nil {
+		fp.Token = MaskToken(token)
+	}
+
+	for _, name := range fingerprintTools {
+		info := ToolInfo{Name: name}
+		if path, err := p.LookPath(name); err == nil {
+			info.Path = path
+			info.Version = p.Version(path)
+			info.Found = true
+		}
+		fp.Tools = append(fp.Tools, info)
+	}
+	return fp
+}
+
+// MaskToken keeps only the first four characters of a token
+func MaskToken(token string) string {
+	if len(token) <= 4 {
+		return strings.Repeat("*", len(token))
+	}
+	return token[:4] + strings.Repeat("*", 8)
+}
+
+func executablePath() string {
+	path, err := os.Executable()
+	if err != nil {
+		return ""
+	}
+	return path
+}
+
+// binaryVersion returns the first line of '<binary> --version'
+func binaryVersion(path string) string {
+	output, err := exec.Command(path, "--version").Output()
+	if err != nil {
+		return "unknown"
+	}
+	line, _, _ := strings.Cut(strings.TrimSpace(string(output)), "\n")
+	return strings.TrimSpace(line)
+}
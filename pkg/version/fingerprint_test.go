@@ -0,0 +1,78 @@
+package version
+
+import (
+	"errors"
+	"os/exec"
+	"runtime"
+	"testing"
+)
+
+func TestGatherFingerprint(t *testing.T) {
+	installed := map[string]string{
+		"git":        "/usr/bin/git",
+		"goreleaser": "/usr/local/bin/goreleaser",
+	}
+	probe := Probe{
+		LookPath: func(name string) (string, error) {
+			if path, ok := installed[name]; ok {
+				return path, nil
+			}
+			return "", exec.ErrNotFound
+		},
+		Version: func(path string) string { return "version of " + path },
+		Token:   func() (string, error) { return "ghp_secret1234", nil },
+	}
+
+	fp := GatherFingerprint(probe)
+
+	if fp.OS != runtime.GOOS || fp.Arch != runtime.GOARCH || fp.CLIVersion != Version {
+		t.Errorf("fingerprint = %+v, want the runtime platform and CLI version", fp)
+	}
+	if fp.Token != "ghp_********" {
+		t.Errorf("token = %q, want it masked", fp.Token)
+	}
+	if len(fp.Tools) != len(fingerprintTools) {
+		t.Fatalf("got %d tools, want %d", len(fp.Tools), len(fingerprintTools))
+	}
+	for i, tool := range fp.Tools {
+		if tool.Name != fingerprintTools[i] {
+			t.Errorf("tool %d = %s, want %s", i, tool.Name, fingerprintTools[i])
+		}
+		path, found := installed[tool.Name]
+		want := ToolInfo{Name: tool.Name}
+		if found {
+			want = ToolInfo{Name: tool.Name, Path: path, Version: "version of " + path, Found: true}
+		}
+		if tool != want {
+			t.Errorf("tool %s = %+v, want %+v", tool.Name, tool, want)
+		}
+	}
+}
+
+func TestGatherFingerprintWithoutToken(t *testing.T) {
+	fp := GatherFingerprint(Probe{
+		LookPath: func(string) (string, error) { return "", exec.ErrNotFound },
+		Version:  func(string) string { t.Error("version probed for a missing binary"); return "" },
+		Token:    func() (string, error) { return "", errors.New("no token") },
+	})
+	if fp.Token != "not configured" {
+		t.Errorf("token = %q, want not configured", fp.Token)
+	}
+}
+
+func TestMaskToken(t *testing.T) {
+	tests := []struct {
+		token, want string
+	}{
+		{"", ""},
+		{"abc", "***"},
+		{"abcd", "****"},
+		{"ghp_0123456789", "ghp_********"},
+	}
+
+	for _, tt := range tests {
+		if got := MaskToken(tt.token); got != tt.want {
+			t.Errorf("MaskToken(%q) = %q, want %q", tt.token, got, tt.want)
+		}
+	}
+}
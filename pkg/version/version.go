@@ -46,24 +46,24 @@ func Latest(repoInfo *git.RepoInfo) error {
 func displayCLIVersion() {
 	fmt.Println()
 	fmt.Printf("%s %s\n",
-		log.ColorText(log.ColorCyan, "┌─"),
+		log.ColorText(log.ColorCyan, log.Sanitize("┌─")),
 		log.ColorText(log.ColorBold, "neko-cli"))
-	fmt.Printf("%s\n", log.ColorText(log.ColorCyan, "│"))
+	fmt.Printf("%s\n", log.ColorText(log.ColorCyan, log.Sanitize("│")))
 	fmt.Printf("%s %s %s\n",
-		log.ColorText(log.ColorCyan, "├─"),
-		log.ColorText(log.ColorCyan, "\uF02B Version:  "),
+		log.ColorText(log.ColorCyan, log.Sanitize("├─")),
+		log.ColorText(log.ColorCyan, log.Sanitize("\uF02B Version:  ")),
 		log.ColorText(log.ColorGreen, Version))
 	fmt.Printf("%s %s %s\n",
-		log.ColorText(log.ColorCyan, "├─"),
-		log.ColorText(log.ColorCyan, "\uF1D3 Commit:   "),
+		log.ColorText(log.ColorCyan, log.Sanitize("├─")),
+		log.ColorText(log.ColorCyan, log.Sanitize("\uF1D3 Commit:   ")),
 		log.ColorText(log.ColorYellow, Commit))
 	fmt.Printf("%s %s %s\n",
-		log.ColorText(log.ColorCyan, "├─"),
-		log.ColorText(log.ColorCyan, "\uF133 Built:    "),
+		log.ColorText(log.ColorCyan, log.Sanitize("├─")),
+		log.ColorText(log.ColorCyan, log.Sanitize("\uF133 Built:    ")),
 		log.ColorText(log.ColorYellow, Date))
 	fmt.Printf("%s %s %s\n",
-		log.ColorText(log.ColorCyan, "└─"),
-		log.ColorText(log.ColorCyan, "\uF007 Built by: "),
+		log.ColorText(log.ColorCyan, log.Sanitize("└─")),
+		log.ColorText(log.ColorCyan, log.Sanitize("\uF007 Built by: ")),
 		log.ColorText(log.ColorYellow, BuiltBy))
 	fmt.Println()
 }
@@ -80,12 +80,12 @@ func displayRelease(repoInfo *git.RepoInfo, release *github.Release) {
 
 	fmt.Println()
 	fmt.Printf("%s %s\n",
-		log.ColorText(log.ColorPurple, "┌─"),
+		log.ColorText(log.ColorPurple, log.Sanitize("┌─")),
 		log.ColorText(log.ColorBold, "Latest Release"))
-	fmt.Printf("%s\n", log.ColorText(log.ColorPurple, "│"))
+	fmt.Printf("%s\n", log.ColorText(log.ColorPurple, log.Sanitize("│")))
 	fmt.Printf("%s %s %s\n",
-		log.ColorText(log.ColorPurple, "├─"),
-		log.ColorText(log.ColorPurple, "\uF09B Repository:"),
+		log.ColorText(log.ColorPurple, log.Sanitize("├─")),
+		log.ColorText(log.ColorPurple, log.Sanitize("\uF09B Repository:")),
 		log.ColorText(log.ColorYellow, fmt.Sprintf("%s/%s", repoInfo.Owner, repoInfo.Repo)))
 
 	versionStr := release.Name
@@ -93,14 +93,14 @@ func displayRelease(repoInfo *git.RepoInfo, release *github.Release) {
 		versionStr = fmt.Sprintf("%s (%s)", release.Name, release.TagName)
 	}
 	fmt.Printf("%s %s %s\n",
-		log.ColorText(log.ColorPurple, "├─"),
-		log.ColorText(log.ColorPurple, "\uF02B Version:   "),
+		log.ColorText(log.ColorPurple, log.Sanitize("├─")),
+		log.ColorText(log.ColorPurple, log.Sanitize("\uF02B Version:   ")),
 		log.ColorText(log.ColorGreen, versionStr))
 
 	if release.PreRelease {
 		fmt.Printf("%s %s %s\n",
-			log.ColorText(log.ColorPurple, "├─"),
-			log.ColorText(log.ColorPurple, "\uF12A Type:      "),
+			log.ColorText(log.ColorPurple, log.Sanitize("├─")),
+			log.ColorText(log.ColorPurple, log.Sanitize("\uF12A Type:      ")),
 			log.ColorText(log.ColorYellow, "Pre-release"))
 	}
 
@@ -110,13 +110,13 @@ func displayRelease(repoInfo *git.RepoInfo, release *github.Release) {
 			log.ColorText(log.ColorCyan, release.Author.Login))
 	}
 	fmt.Printf("%s %s %s\n",
-		log.ColorText(log.ColorPurple, "├─"),
-		log.ColorText(log.ColorPurple, "\uF133 Published: "),
+		log.ColorText(log.ColorPurple, log.Sanitize("├─")),
+		log.ColorText(log.ColorPurple, log.Sanitize("\uF133 Published: ")),
 		publishedStr)
 
 	fmt.Printf("%s %s %s\n",
-		log.ColorText(log.ColorPurple, "└─"),
-		log.ColorText(log.ColorPurple, "\uF0C1 URL:       "),
+		log.ColorText(log.ColorPurple, log.Sanitize("└─")),
+		log.ColorText(log.ColorPurple, log.Sanitize("\uF0C1 URL:       ")),
 		log.ColorText(log.ColorBlue, release.HTMLURL))
 	fmt.Println()
 }
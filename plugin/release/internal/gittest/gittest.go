@@ -0,0 +1,91 @@
+// Package gittest provides git repository fixtures for the release plugin tests.
+package gittest
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// Identity sets a fixed author and committer and hides the user's global git config
+func Identity(t testing.TB) {
+	t.Helper()
+	t.Setenv("GIT_AUTHOR_NAME", "neko")
+	t.Setenv("GIT_AUTHOR_EMAIL", "neko@example.com")
+	t.Setenv("GIT_COMMITTER_NAME", "neko")
+	t.Setenv("GIT_COMMITTER_EMAIL", "neko@example.com")
+	t.Setenv("GIT_CONFIG_GLOBAL", os.DevNull)
+}
+
+// NewRepo creates an empty repository on main in a temporary directory and changes into it
+func NewRepo(t testing.TB) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+
+	dir := t.TempDir()
+	t.Chdir(dir)
+	Identity(t)
+	Run(t, "init", "-q", "-b", "main")
+	return dir
+}
+
+// NewRemote creates a bare repository and adds it as origin of the current repository
+func NewRemote(t testing.TB) string {
+	t.Helper()
+	remote := filepath.Join(t.TempDir(), "origin.git")
+	Run(t, "init", "-q", "--bare", "-b", "main", remote)
+	Run(t, "remote", "add", "origin", remote)
+	return remote
+}
+
+// Run runs git in the current directory and returns its trimmed output
+func Run(t testing.TB, args ...string) string {
+	t.Helper()
+	return RunIn(t, "", args...)
+}
+
+// RunIn runs git in dir and returns its trimmed output
+func RunIn(t testing.TB, dir string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %s: %v: %s", strings.Join(args, " "), err, out)
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// Commit creates an empty commit with the given message and returns its hash
+func Commit(t testing.TB, message string) string {
+	t.Helper()
+	Run(t, "commit", "-q", "--allow-empty", "-m", message)
+	return Run(t, "rev-parse", "HEAD")
+}
+
+// WriteFile writes a file relative to the current directory, creating its parent directories
+func WriteFile(t testing.TB, name, content string) {
+	t.Helper()
+	if dir := filepath.Dir(name); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(name, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// RejectPushes installs a pre-receive hook in the bare remote that prints message and declines every push
+func RejectPushes(t testing.TB, remote, message string) {
+	t.Helper()
+	hook := filepath.Join(remote, "hooks", "pre-receive")
+	script := "#!/bin/sh\necho '" + message + "'\nexit 1\n"
+	if err := os.WriteFile(hook, []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+}
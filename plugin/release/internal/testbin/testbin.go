@@ -0,0 +1,44 @@
+// Package testbin puts fake executables on PATH for the release plugin tests.
+package testbin
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// Calls records the invocations of a fake executable, one line of arguments per call
+type Calls struct {
+	file string
+}
+
+// Fake installs an executable called name in a temporary directory in front of PATH.
+// It records its arguments and then runs script, a POSIX shell snippet.
+func Fake(t testing.TB, name, script string) *Calls {
+	t.Helper()
+	bin := t.TempDir()
+	calls := &Calls{file: filepath.Join(bin, name+".calls")}
+
+	content := fmt.Sprintf("#!/bin/sh\nprintf '%%s\\n' \"$*\" >> %q\n%s\n", calls.file, script)
+	if err := os.WriteFile(filepath.Join(bin, name), []byte(content), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+	return calls
+}
+
+// Args returns the arguments of every invocation so far
+func (c *Calls) Args() []string {
+	data, err := os.ReadFile(c.file)
+	if err != nil {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+}
+
+// Reset forgets the recorded invocations
+func (c *Calls) Reset() {
+	_ = os.Remove(c.file)
+}
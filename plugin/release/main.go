@@ -2,17 +2,33 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
+	"os/signal"
+	"sort"
+	"syscall"
 
+	"github.com/nekoman-hq/neko-cli/pkg/crash"
 	"github.com/nekoman-hq/neko-cli/pkg/errors"
 	"github.com/nekoman-hq/neko-cli/pkg/log"
 	"github.com/nekoman-hq/neko-cli/pkg/plugin"
+	"github.com/nekoman-hq/neko-cli/plugin/release/pkg/amend"
+	"github.com/nekoman-hq/neko-cli/plugin/release/pkg/attach"
+	"github.com/nekoman-hq/neko-cli/plugin/release/pkg/changes"
 	"github.com/nekoman-hq/neko-cli/plugin/release/pkg/contributors"
+	"github.com/nekoman-hq/neko-cli/plugin/release/pkg/gc"
+	"github.com/nekoman-hq/neko-cli/plugin/release/pkg/git"
 	"github.com/nekoman-hq/neko-cli/plugin/release/pkg/history"
 	initcmd "github.com/nekoman-hq/neko-cli/plugin/release/pkg/init"
+	"github.com/nekoman-hq/neko-cli/plugin/release/pkg/lock"
+	"github.com/nekoman-hq/neko-cli/plugin/release/pkg/migrate"
+	"github.com/nekoman-hq/neko-cli/plugin/release/pkg/notes"
 	"github.com/nekoman-hq/neko-cli/plugin/release/pkg/release"
+	"github.com/nekoman-hq/neko-cli/plugin/release/pkg/remotetags"
+	"github.com/nekoman-hq/neko-cli/plugin/release/pkg/signature"
+	"github.com/nekoman-hq/neko-cli/plugin/release/pkg/undo"
 	"github.com/nekoman-hq/neko-cli/plugin/release/pkg/validate"
 
 	// Register all release tools
@@ -24,14 +40,33 @@ func main() {
 	errors.PluginName = "release"
 	errors.PluginVersion = "1.0.0"
 
-	// Read request from stdin
 	var req plugin.Request
+	defer recoverCrash(&req)
+
+	// Read request from stdin
 	if err := json.NewDecoder(os.Stdin).Decode(&req); err != nil {
 		errors.WriteError("PARSE_ERROR", fmt.Sprintf("failed to parse request: %v", err))
 	}
 
 	// Set verbose mode from request context
 	log.Verbose = req.Context.Verbose
+	git.SetDryRun(req.Context.DryRun)
+
+	// Explicit flags win over the user's defaults file
+	if err := req.ApplyFlagDefaults(); err != nil {
+		log.PluginPrint(log.Config, "\u26A0 Ignoring flag defaults: %s", err.Error())
+	}
+
+	// Every command but init-options shells out to git
+	if req.Command != "init-options" {
+		if err := git.EnsureInstalled(); err != nil {
+			errors.WriteError("GIT_NOT_INSTALLED", err.Error())
+		}
+	}
+
+	// Ctrl+C or SIGTERM cancels ctx, which kills the release tools started by the release commands
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
 	var resp *plugin.Response
 	var err error
@@ -41,18 +76,42 @@ func main() {
 		resp, err = initcmd.HandleInit(req)
 	case "init-options":
 		resp, err = initcmd.GetAvailableOptions()
+	case "migrate-tool":
+		resp, err = migrate.HandleMigrateTool(req)
+	case "migrate-config":
+		resp, err = migrate.HandleMigrateConfig(req)
 	case "patch":
-		resp, err = release.HandleRelease(req, release.Patch)
+		resp, err = release.HandleRelease(ctx, req, release.Patch)
 	case "minor":
-		resp, err = release.HandleRelease(req, release.Minor)
+		resp, err = release.HandleRelease(ctx, req, release.Minor)
 	case "major":
-		resp, err = release.HandleRelease(req, release.Major)
+		resp, err = release.HandleRelease(ctx, req, release.Major)
+	case "retry":
+		resp, err = release.HandleRetry(ctx)
+	case "preview-notes":
+		resp, err = notes.HandlePreviewNotes(req)
 	case "history":
-		resp, err = history.HandleHistory()
+		resp, err = history.HandleHistory(req)
 	case "contributors":
-		resp, err = contributors.HandleContributors()
+		resp, err = contributors.HandleContributors(req)
 	case "validate":
 		resp, err = validate.HandleValidate(req)
+	case "config-lock":
+		resp, err = lock.HandleLock()
+	case "amend":
+		resp, err = amend.HandleAmend(req)
+	case "undo-last-tag":
+		resp, err = undo.HandleUndoLastTag(req)
+	case "gc":
+		resp, err = gc.HandleGC(req)
+	case "what-changed":
+		resp, err = changes.HandleWhatChanged(req)
+	case "ls-remote-tags":
+		resp, err = remotetags.HandleLsRemoteTags(req)
+	case "verify-tag-signature":
+		resp, err = signature.HandleVerifyTagSignature(req)
+	case "attach-provenance":
+		resp, err = attach.HandleAttachProvenance(req)
 	default:
 		resp, err = nil, fmt.Errorf("unknown command: %s", req.Command)
 	}
@@ -61,7 +120,41 @@ func main() {
 		errors.WriteError("EXECUTION_ERROR", err.Error())
 	}
 
+	// Non-fatal issues recorded along the way
+	resp.Warnings = append(resp.Warnings, errors.Warnings()...)
+	resp.Metadata.ProtocolVersion = plugin.ProtocolVersion
+
 	if err := json.NewEncoder(os.Stdout).Encode(resp); err != nil {
 		errors.WriteError("RESPONSE_ERROR", fmt.Sprintf("failed to encode response: %v", err))
 	}
 }
+
+// recoverCrash writes a crash report for a panic and answers with a PANIC error response, see crash.Capture
+func recoverCrash(req *plugin.Request) {
+	r := recover()
+	if r == nil {
+		return
+	}
+
+	program := fmt.Sprintf("%s plugin %s", errors.PluginName, errors.PluginVersion)
+	path, err := crash.Capture(r, program, errors.PluginName+" "+req.Command, requestArgs(req))
+	if err != nil {
+		errors.WriteError("PANIC", fmt.Sprintf("plugin crashed: %v (could not write crash report: %s)", r, err.Error()))
+	}
+	errors.WriteErrorWithDetails("PANIC", fmt.Sprintf("plugin crashed: %v", r), map[string]any{"crash_report": path})
+}
+
+// requestArgs returns the request's args followed by its flags as --name=value, sorted by name
+func requestArgs(req *plugin.Request) []string {
+	names := make([]string, 0, len(req.Flags))
+	for name := range req.Flags {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	args := append([]string{}, req.Args...)
+	for _, name := range names {
+		args = append(args, fmt.Sprintf("--%s=%v", name, req.Flags[name]))
+	}
+	return args
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/nekoman-hq/neko-cli/pkg/plugin"
+)
+
+func TestRequestArgs(t *testing.T) {
+	tests := []struct {
+		name string
+		req  plugin.Request
+		want []string
+	}{
+		{"empty", plugin.Request{}, []string{}},
+		{"args", plugin.Request{Args: []string{"v1.2.0"}}, []string{"v1.2.0"}},
+		{
+			"flags sorted by name",
+			plugin.Request{Args: []string{"v1.2.0"}, Flags: map[string]any{"yes": true, "output": "json"}},
+			[]string{"v1.2.0", "--output=json", "--yes=true"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := requestArgs(&tt.req)
+			if !slices.Equal(args, tt.want) {
+				t.Errorf("requestArgs = %q, want %q", args, tt.want)
+			}
+			if len(tt.req.Args) > 0 && &args[0] == &tt.req.Args[0] {
+				t.Error("requestArgs shares the backing array of the request args")
+			}
+		})
+	}
+}
@@ -0,0 +1,173 @@
+// Package amend includes the amend command handler
+package amend
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/nekoman-hq/neko-cli/pkg/config"
+	"github.com/nekoman-hq/neko-cli/pkg/log"
+	"github.com/nekoman-hq/neko-cli/pkg/plugin"
+	"github.com/nekoman-hq/neko-cli/plugin/release/pkg/git"
+	"github.com/nekoman-hq/neko-cli/plugin/release/pkg/git/github"
+	"github.com/nekoman-hq/neko-cli/plugin/release/pkg/release"
+)
+
+const (
+	PluginName    = "release"
+	PluginVersion = "1.0.0"
+)
+
+// HandleAmend updates title, notes or prerelease flag of an existing GitHub release.
+// No commit or tag is created. Defaults to the latest version tag if no tag is given.
+func HandleAmend(req plugin.Request) (*plugin.Response, error) {
+	update, err := BuildUpdate(req.Flags)
+	if err != nil {
+		return errorResponse("INVALID_FLAGS", err.Error(), nil), nil
+	}
+	if update.IsEmpty() {
+		return errorResponse("NOTHING_TO_AMEND", "No changes given", map[string]any{
+			"hint": "Use --title, --notes, --notes-file or --prerelease",
+		}), nil
+	}
+
+	tag := ""
+	if len(req.Args) > 0 {
+		tag = req.Args[0]
+	} else if tag, err = release.LatestReleaseTag(); err != nil || tag == "" {
+		return errorResponse("NO_TAGS", "No release tag given and no release tags found", nil), nil
+	}
+
+	token, err := config.GetPAT()
+	if err != nil {
+		return errorResponse("MISSING_GITHUB_TOKEN", err.Error(), nil), nil
+	}
+
+	repo, err := git.Current()
+	if err != nil {
+		return errorResponse("NOT_A_GIT_REPOSITORY", err.Error(), nil), nil
+	}
+
+	log.PluginPrint(log.Exec, "Amending release %s", log.ColorText(log.ColorCyan, tag))
+
+	existing, err := git.ReleaseByTag(repo, tag, token)
+	if err != nil {
+		return errorResponse("RELEASE_NOT_FOUND", err.Error(), map[string]any{"tag": tag}), nil
+	}
+
+	if update.Body != nil && existing.Body != "" && !req.Confirmed() {
+		resp := errorResponse("CONFIRMATION_REQUIRED",
+			fmt.Sprintf("Overwriting the notes of release %s requires confirmation", tag),
+			map[string]any{
+				"tag":   tag,
+				"notes": existing.Body,
+				"hint":  "Re-run with --yes to overwrite the release notes",
+			})
+		resp.Data = plugin.Plan{
+			Summary: fmt.Sprintf("Overwrite the notes of release %s (%d lines)", tag, strings.Count(existing.Body, "\n")+1),
+			Remote:  repo.Owner + "/" + repo.Repo,
+		}.ConfirmationData()
+		return resp, nil
+	}
+
+	release, err := updateRelease(repo, existing, update, token)
+	if err != nil {
+		return errorResponse("AMEND_FAILED", err.Error(), map[string]any{"tag": tag}), nil
+	}
+	log.PluginPrint(log.Exec, "\uF00C Amended release %s", log.ColorText(log.ColorGreen, tag))
+
+	return &plugin.Response{
+		Status: "success",
+		Metadata: plugin.ResponseMetadata{
+			Plugin:    PluginName,
+			Version:   PluginVersion,
+			Command:   "amend",
+			Timestamp: time.Now(),
+		},
+		Data: map[string]any{
+			"items": []map[string]any{
+				{
+					"property": "Tag",
+					"value":    release.TagName,
+				},
+				{
+					"property": "Title",
+					"value":    release.Name,
+				},
+				{
+					"property": "Prerelease",
+					"value":    release.PreRelease,
+				},
+				{
+					"property": "URL",
+					"value":    release.HTMLURL,
+				},
+				{
+					"property": "Status",
+					"value":    "Release amended",
+				},
+			},
+		},
+		RendererHint: "table",
+		ColumnOrder:  []string{"property", "value"},
+	}, nil
+}
+
+// BuildUpdate converts the amend flags into a release update.
+// Only flags that were passed are set, so unspecified fields stay untouched.
+func BuildUpdate(flags map[string]any) (github.ReleaseUpdate, error) {
+	var update github.ReleaseUpdate
+
+	if title, ok := flags["title"].(string); ok {
+		update.Name = &title
+	}
+
+	notes, hasNotes := flags["notes"].(string)
+	notesFile, hasNotesFile := flags["notes-file"].(string)
+	if hasNotes && hasNotesFile {
+		return update, fmt.Errorf("--notes and --notes-file cannot be used together")
+	}
+	if hasNotesFile {
+		data, err := os.ReadFile(notesFile)
+		if err != nil {
+			return update, fmt.Errorf("failed to read notes file: %w", err)
+		}
+		notes, hasNotes = string(data), true
+	}
+	if hasNotes {
+		update.Body = &notes
+	}
+
+	if prerelease, ok := flags["prerelease"].(bool); ok {
+		update.PreRelease = &prerelease
+	}
+	return update, nil
+}
+
+func errorResponse(code, message string, details map[string]any) *plugin.Response {
+	return &plugin.Response{
+		Status: "error",
+		Metadata: plugin.ResponseMetadata{
+			Plugin:    PluginName,
+			Version:   PluginVersion,
+			Command:   "amend",
+			Timestamp: time.Now(),
+		},
+		Error: &plugin.ResponseError{
+			Code:    code,
+			Message: message,
+			Details: details,
+		},
+	}
+}
+
+// updateRelease updates the release on GitHub, in dry-run mode the update is only previewed
+func updateRelease(repo *git.RepoInfo, existing *github.Release, update github.ReleaseUpdate, token string) (*github.Release, error) {
+	if git.SkipInDryRun("updating the GitHub release of", existing.TagName) {
+		preview := update.Apply(*existing)
+		return &preview, nil
+	}
+	return git.UpdateRelease(repo, existing.ID, update, token)
+}
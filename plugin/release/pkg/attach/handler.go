@@ -0,0 +1,100 @@
+// Package attach includes the attach-provenance command handler
+package attach
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/nekoman-hq/neko-cli/pkg/plugin"
+	config2 "github.com/nekoman-hq/neko-cli/plugin/release/pkg/config"
+	"github.com/nekoman-hq/neko-cli/plugin/release/pkg/git"
+	"github.com/nekoman-hq/neko-cli/plugin/release/pkg/provenance"
+	"github.com/nekoman-hq/neko-cli/plugin/release/pkg/release"
+)
+
+const (
+	PluginName    = "release"
+	PluginVersion = "1.0.0"
+)
+
+// HandleAttachProvenance attaches the build provenance of a release tag to its GitHub release.
+// Defaults to the latest version tag and to the provenance target of the config, then to an asset.
+func HandleAttachProvenance(req plugin.Request) (*plugin.Response, error) {
+	target, err := resolveTarget(req.Flags)
+	if err != nil {
+		return errorResponse("INVALID_FLAGS", err.Error(), nil), nil
+	}
+
+	tag := ""
+	if len(req.Args) > 0 {
+		tag = req.Args[0]
+	} else if tag, err = release.LatestReleaseTag(); err != nil || tag == "" {
+		return errorResponse("NO_TAGS", "No release tag given and no release tags found", nil), nil
+	}
+
+	if !git.RefExists("refs/tags/" + tag) {
+		return errorResponse("TAG_NOT_FOUND", fmt.Sprintf("Tag %s does not exist", tag), map[string]any{"tag": tag}), nil
+	}
+
+	doc, err := provenance.Attach(target, tag, PluginVersion)
+	if err != nil {
+		return errorResponse("ATTACH_FAILED", err.Error(), map[string]any{"tag": tag, "target": string(target)}), nil
+	}
+
+	return &plugin.Response{
+		Status: "success",
+		Metadata: plugin.ResponseMetadata{
+			Plugin:    PluginName,
+			Version:   PluginVersion,
+			Command:   "attach-provenance",
+			Timestamp: time.Now(),
+		},
+		Data: map[string]any{
+			"items": []map[string]any{
+				{"property": "Tag", "value": doc.Source.Tag},
+				{"property": "Commit", "value": doc.Source.Commit},
+				{"property": "Repository", "value": doc.Source.Repository},
+				{"property": "Builder", "value": doc.Builder.ID},
+				{"property": "Attached As", "value": string(target)},
+			},
+			"provenance": doc,
+		},
+		RendererHint: "table",
+		ColumnOrder:  []string{"property", "value"},
+	}, nil
+}
+
+// resolveTarget returns the --to flag, the provenance target of the config or ProvenanceAsset
+func resolveTarget(flags map[string]any) (config2.ProvenanceTarget, error) {
+	if to, _ := flags["to"].(string); to != "" {
+		target := config2.ProvenanceTarget(to)
+		if !target.IsValid() {
+			return "", fmt.Errorf("--to must be %q or %q", config2.ProvenanceAsset, config2.ProvenanceBody)
+		}
+		return target, nil
+	}
+
+	if config2.Exists() {
+		if cfg, err := config2.LoadConfig(); err == nil && cfg.Provenance != "" {
+			return cfg.Provenance, nil
+		}
+	}
+	return config2.ProvenanceAsset, nil
+}
+
+func errorResponse(code, message string, details map[string]any) *plugin.Response {
+	return &plugin.Response{
+		Status: "error",
+		Metadata: plugin.ResponseMetadata{
+			Plugin:    PluginName,
+			Version:   PluginVersion,
+			Command:   "attach-provenance",
+			Timestamp: time.Now(),
+		},
+		Error: &plugin.ResponseError{
+			Code:    code,
+			Message: message,
+			Details: details,
+		},
+	}
+}
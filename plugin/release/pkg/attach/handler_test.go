@@ -0,0 +1,48 @@
+package attach
+
+import (
+	"testing"
+
+	"github.com/nekoman-hq/neko-cli/plugin/release/internal/gittest"
+	config2 "github.com/nekoman-hq/neko-cli/plugin/release/pkg/config"
+)
+
+func TestResolveTarget(t *testing.T) {
+	tests := []struct {
+		name    string
+		to      string
+		config  config2.ProvenanceTarget
+		want    config2.ProvenanceTarget
+		wantErr bool
+	}{
+		{name: "default", want: config2.ProvenanceAsset},
+		{name: "config", config: config2.ProvenanceBody, want: config2.ProvenanceBody},
+		{name: "flag wins over config", to: "asset", config: config2.ProvenanceBody, want: config2.ProvenanceAsset},
+		{name: "flag", to: "body", want: config2.ProvenanceBody},
+		{name: "invalid flag", to: "release", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gittest.NewRepo(t)
+			if tt.config != "" {
+				if err := config2.SaveConfig(config2.NekoConfig{
+					ProjectType:   config2.ProjectTypeBackend,
+					ReleaseSystem: config2.ReleaseTypeGoReleaser,
+					Version:       "1.0.0",
+					Provenance:    tt.config,
+				}); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			got, err := resolveTarget(map[string]any{"to": tt.to})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("resolveTarget error = %v, want error %t", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("resolveTarget = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,118 @@
+// Package changes includes the what-changed command handler
+package changes
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/nekoman-hq/neko-cli/pkg/log"
+	"github.com/nekoman-hq/neko-cli/pkg/plugin"
+	"github.com/nekoman-hq/neko-cli/plugin/release/pkg/git"
+	"github.com/nekoman-hq/neko-cli/plugin/release/pkg/release"
+)
+
+const (
+	PluginName    = "release"
+	PluginVersion = "1.0.0"
+)
+
+// HandleWhatChanged lists the files changed between a release tag and the previous release tag.
+// The first release is diffed against the empty tree.
+func HandleWhatChanged(req plugin.Request) (*plugin.Response, error) {
+	if len(req.Args) == 0 {
+		return errorResponse("MISSING_TAG", "No release tag given", map[string]any{
+			"hint": "Usage: neko release what-changed <tag>",
+		}), nil
+	}
+	tag := req.Args[0]
+
+	if !git.RefExists(tag) {
+		return errorResponse("TAG_NOT_FOUND", fmt.Sprintf("Tag %s does not exist", tag), map[string]any{"tag": tag}), nil
+	}
+
+	tags := git.GetTags()
+	prefix, err := release.RepositoryTagPrefix(tags)
+	if err != nil {
+		return errorResponse("CONFIG_ERROR", err.Error(), nil), nil
+	}
+
+	previous, err := release.PreviousReleaseTag(tags, prefix, tag)
+	if err != nil {
+		return errorResponse("INVALID_TAG", err.Error(), map[string]any{"tag": tag}), nil
+	}
+
+	from := previous
+	if from == "" {
+		from = "root"
+	}
+	log.PluginPrint(log.Exec, "Listing changes from %s to %s",
+		log.ColorText(log.ColorCyan, from), log.ColorText(log.ColorCyan, tag))
+
+	changes, err := git.DiffStat(previous, tag)
+	if err != nil {
+		return errorResponse("DIFF_FAILED", err.Error(), map[string]any{"tag": tag, "from": from}), nil
+	}
+
+	insertions, deletions := Totals(changes)
+	log.PluginPrint(log.Exec, "%d files changed, %s, %s", len(changes),
+		log.ColorText(log.ColorGreen, fmt.Sprintf("%d insertions(+)", insertions)),
+		log.ColorText(log.ColorRed, fmt.Sprintf("%d deletions(-)", deletions)))
+
+	items := make([]map[string]any, 0, len(changes))
+	for _, c := range changes {
+		item := map[string]any{
+			"file":       c.Path,
+			"insertions": c.Insertions,
+			"deletions":  c.Deletions,
+		}
+		if c.Binary {
+			item["insertions"], item["deletions"] = "-", "-"
+		}
+		items = append(items, item)
+	}
+
+	return &plugin.Response{
+		Status: "success",
+		Metadata: plugin.ResponseMetadata{
+			Plugin:    PluginName,
+			Version:   PluginVersion,
+			Command:   "what-changed",
+			Timestamp: time.Now(),
+		},
+		Data: map[string]any{
+			"items":      items,
+			"tag":        tag,
+			"from":       from,
+			"insertions": insertions,
+			"deletions":  deletions,
+		},
+		RendererHint: "table",
+		ColumnOrder:  []string{"file", "insertions", "deletions"},
+	}, nil
+}
+
+// Totals sums the inserted and deleted lines, binary files are not counted
+func Totals(changes []git.FileChange) (insertions, deletions int) {
+	for _, c := range changes {
+		insertions += c.Insertions
+		deletions += c.Deletions
+	}
+	return insertions, deletions
+}
+
+func errorResponse(code, message string, details map[string]any) *plugin.Response {
+	return &plugin.Response{
+		Status: "error",
+		Metadata: plugin.ResponseMetadata{
+			Plugin:    PluginName,
+			Version:   PluginVersion,
+			Command:   "what-changed",
+			Timestamp: time.Now(),
+		},
+		Error: &plugin.ResponseError{
+			Code:    code,
+			Message: message,
+			Details: details,
+		},
+	}
+}
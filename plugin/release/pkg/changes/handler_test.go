@@ -0,0 +1,81 @@
+package changes
+
+import (
+	"testing"
+
+	"github.com/nekoman-hq/neko-cli/pkg/plugin"
+	"github.com/nekoman-hq/neko-cli/plugin/release/internal/gittest"
+	"github.com/nekoman-hq/neko-cli/plugin/release/pkg/git"
+)
+
+func TestTotals(t *testing.T) {
+	changes := []git.FileChange{
+		{Path: "a.go", Insertions: 3, Deletions: 1},
+		{Path: "logo.png", Binary: true},
+		{Path: "b.go", Insertions: 2, Deletions: 5},
+	}
+	if insertions, deletions := Totals(changes); insertions != 5 || deletions != 6 {
+		t.Errorf("Totals = %d, %d, want 5, 6", insertions, deletions)
+	}
+}
+
+// changesRepo tags v1.0.0 and v1.1.0, the latter adds a line to a.txt and a binary file
+func changesRepo(t *testing.T) {
+	t.Helper()
+	gittest.NewRepo(t)
+	gittest.WriteFile(t, "a.txt", "one\n")
+	gittest.Run(t, "add", "a.txt")
+	gittest.Run(t, "commit", "-q", "-m", "feat: a")
+	gittest.Run(t, "tag", "v1.0.0")
+	gittest.WriteFile(t, "a.txt", "one\ntwo\n")
+	gittest.WriteFile(t, "logo.png", "\x89PNG\x00\x01")
+	gittest.Run(t, "add", "a.txt", "logo.png")
+	gittest.Run(t, "commit", "-q", "-m", "feat: b")
+	gittest.Run(t, "tag", "v1.1.0")
+}
+
+func TestHandleWhatChanged(t *testing.T) {
+	tests := []struct {
+		name       string
+		args       []string
+		wantCode   string
+		wantFrom   string
+		wantFiles  int
+		insertions int
+	}{
+		{"release", []string{"v1.1.0"}, "", "v1.0.0", 2, 1},
+		{"first release", []string{"v1.0.0"}, "", "root", 1, 1},
+		{"missing tag argument", nil, "MISSING_TAG", "", 0, 0},
+		{"unknown tag", []string{"v9.9.9"}, "TAG_NOT_FOUND", "", 0, 0},
+		{"not a release tag", []string{"main"}, "INVALID_TAG", "", 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			changesRepo(t)
+
+			resp, err := HandleWhatChanged(plugin.Request{Command: "what-changed", Args: tt.args})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.wantCode != "" {
+				if resp.Error == nil || resp.Error.Code != tt.wantCode {
+					t.Errorf("error = %+v, want %s", resp.Error, tt.wantCode)
+				}
+				return
+			}
+			if resp.Status != "success" {
+				t.Fatalf("status = %s, error = %+v", resp.Status, resp.Error)
+			}
+			if resp.Data["from"] != tt.wantFrom {
+				t.Errorf("from = %v, want %s", resp.Data["from"], tt.wantFrom)
+			}
+			if items := resp.Data["items"].([]map[string]any); len(items) != tt.wantFiles {
+				t.Errorf("items = %v, want %d files", items, tt.wantFiles)
+			}
+			if resp.Data["insertions"] != tt.insertions {
+				t.Errorf("insertions = %v, want %d", resp.Data["insertions"], tt.insertions)
+			}
+		})
+	}
+}
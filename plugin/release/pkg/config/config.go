@@ -13,10 +13,12 @@ import (
 	"os"
 	"regexp"
 
+	config2 "github.com/nekoman-hq/neko-cli/pkg/config"
 	"github.com/nekoman-hq/neko-cli/pkg/log"
 )
 
-const FileName = ".release.neko.json"
+// FileName is the canonical neko config shared with the CLI
+const FileName = config2.FileName
 
 // Exists checks if the configuration file already exists
 func Exists() bool {
@@ -25,13 +27,23 @@ func Exists() bool {
 }
 
 func LoadConfig() (*NekoConfig, error) {
+	return LoadConfigFrom(FileName)
+}
+
+// LoadConfigFrom loads and validates the configuration at the given path
+func LoadConfigFrom(path string) (*NekoConfig, error) {
 	log.PluginV(log.Config, "Loading config from file...")
 
-	data, err := os.ReadFile(FileName)
+	data, err := os.ReadFile(path)
 	if err != nil {
-		if os.IsNotExist(err) {
-			return nil, errors.New(
-				"configuration not found: No .release.neko.json configuration found. Run 'neko release init' first",
+		if os.IsNotExist(err) && path == FileName && config2.LegacyExists() {
+			return nil, fmt.Errorf(
+				"configuration not found: No %s configuration found, but an old %s. Run 'neko release migrate-config' to move it",
+				path, config2.LegacyFileName,
+			)
+		} else if os.IsNotExist(err) {
+			return nil, fmt.Errorf(
+				"configuration not found: No %s configuration found. Run 'neko release init' first", path,
 			)
 		} else {
 			return nil, fmt.Errorf(
@@ -58,6 +70,15 @@ var semverRegex = regexp.MustCompile(
 	`^(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)(?:-[\da-zA-Z-]+(?:\.[\da-zA-Z-]+)*)?(?:\+[\da-zA-Z-]+(?:\.[\da-zA-Z-]+)*)?$`,
 )
 
+// emailRegex loosely matches an email address usable as git identity
+var emailRegex = regexp.MustCompile(`^[^\s@<>]+@[^\s@<>]+\.[^\s@<>]+$`)
+
+// scopeRegex matches a conventional commit scope, empty for no scope
+var scopeRegex = regexp.MustCompile(`^[A-Za-z0-9_./-]*$`)
+
+// trailerRegex matches a single-line git trailer like "Co-authored-by: Name <mail>"
+var trailerRegex = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9-]*: [^\r\n]*\S$`)
+
 func Validate(cfg *NekoConfig) error {
 	log.PluginV(log.Config, "Validating serialised config...")
 
@@ -73,6 +94,14 @@ func Validate(cfg *NekoConfig) error {
 		)
 	}
 
+	if err := validateReleaseSystems(cfg); err != nil {
+		return err
+	}
+
+	if err := validateGoReleaserSkip(cfg); err != nil {
+		return err
+	}
+
 	if cfg.Version == "" {
 		return errors.New(
 			"invalid configuration: Version is missing in ..release.neko.json",
@@ -85,6 +114,45 @@ func Validate(cfg *NekoConfig) error {
 		)
 	}
 
+	if _, err := cfg.ReleaseInterval(); err != nil {
+		return fmt.Errorf(
+			"invalid configuration: min-release-interval %q is not a valid duration like \"30m\" or \"24h\": %w",
+			cfg.MinReleaseInterval, err,
+		)
+	}
+
+	if !cfg.DirtyTree.IsValid() {
+		return fmt.Errorf(
+			"invalid configuration: dirty-tree must be %q or %q", DirtyTreeWarn, DirtyTreeStage,
+		)
+	}
+
+	if !cfg.Provenance.IsValid() {
+		return fmt.Errorf(
+			"invalid configuration: provenance must be %q or %q", ProvenanceAsset, ProvenanceBody,
+		)
+	}
+
+	if cfg.ReleaseCommitEmail != "" && !emailRegex.MatchString(cfg.ReleaseCommitEmail) {
+		return fmt.Errorf(
+			"invalid configuration: release-commit-email %q is not a valid email address", cfg.ReleaseCommitEmail,
+		)
+	}
+
+	if cfg.ReleaseCommitScope != nil && !scopeRegex.MatchString(*cfg.ReleaseCommitScope) {
+		return fmt.Errorf(
+			"invalid configuration: release-commit-scope %q may only contain letters, digits, '-', '_', '.' and '/'", *cfg.ReleaseCommitScope,
+		)
+	}
+
+	for _, trailer := range cfg.CommitTrailers {
+		if !trailerRegex.MatchString(trailer) {
+			return fmt.Errorf(
+				"invalid configuration: commit trailer %q must have the format 'Key: Value'", trailer,
+			)
+		}
+	}
+
 	log.PluginPrint(log.Config, "\uF00C Config appears valid")
 
 	return nil
@@ -98,5 +166,45 @@ func SaveConfig(config NekoConfig) error {
 	if err = os.WriteFile(FileName, data, 0644); err != nil {
 		return fmt.Errorf("configuration write failed: %w", err)
 	}
+
+	// Changes made by neko itself are trusted, keep an existing lock in sync
+	if LockExists() {
+		if _, err = WriteLock(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateReleaseSystems checks that the fan-out systems can share one release commit and tag
+func validateReleaseSystems(cfg *NekoConfig) error {
+	if len(cfg.ReleaseSystems) == 0 {
+		return nil
+	}
+
+	if cfg.ReleaseSystems[0] != cfg.ReleaseSystem {
+		return fmt.Errorf(
+			"invalid configuration: release-systems must start with the release-system %q", cfg.ReleaseSystem,
+		)
+	}
+
+	seen := make(map[ReleaseSystem]bool, len(cfg.ReleaseSystems))
+	for i, system := range cfg.ReleaseSystems {
+		if !system.IsValid() {
+			return fmt.Errorf("invalid configuration: unknown release system %q in release-systems", system)
+		}
+		if seen[system] {
+			return fmt.Errorf("invalid configuration: release system %q is listed twice in release-systems", system)
+		}
+		seen[system] = true
+
+		// Only the first system creates the commit and tag, the others have to publish onto them
+		if i > 0 && !system.PublishesExistingTag() {
+			return fmt.Errorf(
+				"invalid configuration: %s cannot follow %s in release-systems since it creates its own tag and release",
+				system, cfg.ReleaseSystems[0],
+			)
+		}
+	}
 	return nil
 }
@@ -0,0 +1,192 @@
+package config
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestValidateCommitTrailers(t *testing.T) {
+	tests := []struct {
+		trailer string
+		wantErr bool
+	}{
+		{"Co-authored-by: Jane Doe <jane@example.com>", false},
+		{"Reviewed-by: John", false},
+		{"Ticket: ABC-123", false},
+		{"Co-authored-by Jane", true},
+		{"Co-authored-by:Jane", true},
+		{"Co authored by: Jane", true},
+		{"Co-authored-by: ", true},
+		{"Co-authored-by: Jane\nSigned-off-by: John", true},
+		{"-by: Jane", true},
+	}
+
+	for _, tt := range tests {
+		cfg := &NekoConfig{
+			ProjectType:    ProjectTypeBackend,
+			ReleaseSystem:  ReleaseTypeGoReleaser,
+			Version:        "1.0.0",
+			CommitTrailers: []string{tt.trailer},
+		}
+		if err := Validate(cfg); (err != nil) != tt.wantErr {
+			t.Errorf("Validate with trailer %q = %v, want error %t", tt.trailer, err, tt.wantErr)
+		}
+	}
+}
+
+func TestValidateReleaseSystems(t *testing.T) {
+	tests := []struct {
+		name    string
+		primary ReleaseSystem
+		systems []ReleaseSystem
+		wantErr string
+	}{
+		{"no fan-out", ReleaseTypeReleaseIt, nil, ""},
+		{"release-it then goreleaser", ReleaseTypeReleaseIt, []ReleaseSystem{ReleaseTypeReleaseIt, ReleaseTypeGoReleaser}, ""},
+		{"jreleaser then goreleaser", ReleaseTypeJReleaser, []ReleaseSystem{ReleaseTypeJReleaser, ReleaseTypeGoReleaser}, ""},
+		{"primary only", ReleaseTypeGoReleaser, []ReleaseSystem{ReleaseTypeGoReleaser}, ""},
+		{"wrong primary", ReleaseTypeReleaseIt, []ReleaseSystem{ReleaseTypeGoReleaser, ReleaseTypeReleaseIt}, "must start with the release-system"},
+		{"unknown system", ReleaseTypeReleaseIt, []ReleaseSystem{ReleaseTypeReleaseIt, "semantic-release"}, "unknown release system"},
+		{"listed twice", ReleaseTypeReleaseIt, []ReleaseSystem{ReleaseTypeReleaseIt, ReleaseTypeGoReleaser, ReleaseTypeGoReleaser}, "listed twice"},
+		{"follower creates its own tag", ReleaseTypeGoReleaser, []ReleaseSystem{ReleaseTypeGoReleaser, ReleaseTypeJReleaser}, "jreleaser cannot follow goreleaser"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &NekoConfig{
+				ProjectType:    ProjectTypeBackend,
+				ReleaseSystem:  tt.primary,
+				ReleaseSystems: tt.systems,
+				Version:        "1.0.0",
+			}
+			err := Validate(cfg)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate = %v, want an error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSystems(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  NekoConfig
+		want []ReleaseSystem
+	}{
+		{"release-system", NekoConfig{ReleaseSystem: ReleaseTypeGoReleaser}, []ReleaseSystem{ReleaseTypeGoReleaser}},
+		{"release-systems", NekoConfig{
+			ReleaseSystem:  ReleaseTypeReleaseIt,
+			ReleaseSystems: []ReleaseSystem{ReleaseTypeReleaseIt, ReleaseTypeGoReleaser},
+		}, []ReleaseSystem{ReleaseTypeReleaseIt, ReleaseTypeGoReleaser}},
+	}
+
+	for _, tt := range tests {
+		if got := tt.cfg.Systems(); !slices.Equal(got, tt.want) {
+			t.Errorf("%s: Systems() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestValidateReleaseCommitEmail(t *testing.T) {
+	tests := []struct {
+		email   string
+		wantErr bool
+	}{
+		{"", false},
+		{"bot@example.com", false},
+		{"neko-bot+ci@users.noreply.github.com", false},
+		{"bot", true},
+		{"bot@localhost", true},
+		{"neko bot@example.com", true},
+		{"<bot@example.com>", true},
+	}
+
+	for _, tt := range tests {
+		cfg := &NekoConfig{
+			ProjectType:        ProjectTypeBackend,
+			ReleaseSystem:      ReleaseTypeGoReleaser,
+			Version:            "1.0.0",
+			ReleaseCommitEmail: tt.email,
+		}
+		if err := Validate(cfg); (err != nil) != tt.wantErr {
+			t.Errorf("Validate with release-commit-email %q = %v, want error %t", tt.email, err, tt.wantErr)
+		}
+	}
+}
+
+func TestReleaseInterval(t *testing.T) {
+	tests := []struct {
+		interval string
+		want     time.Duration
+		wantErr  bool
+	}{
+		{"", 0, false},
+		{"30m", 30 * time.Minute, false},
+		{"24h", 24 * time.Hour, false},
+		{"1h30m", 90 * time.Minute, false},
+		{"0s", 0, false},
+		{"-1h", 0, true},
+		{"1d", 0, true},
+		{"soon", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.interval, func(t *testing.T) {
+			cfg := &NekoConfig{
+				ProjectType:        ProjectTypeBackend,
+				ReleaseSystem:      ReleaseTypeGoReleaser,
+				Version:            "1.0.0",
+				MinReleaseInterval: tt.interval,
+			}
+			got, err := cfg.ReleaseInterval()
+			if (err != nil) != tt.wantErr || got != tt.want {
+				t.Errorf("ReleaseInterval() = %s, %v, want %s, error %t", got, err, tt.want, tt.wantErr)
+			}
+			if err := Validate(cfg); (err != nil) != tt.wantErr {
+				t.Errorf("Validate = %v, want error %t", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateReleaseCommitScope(t *testing.T) {
+	tests := []struct {
+		name    string
+		scope   *string
+		wantErr bool
+	}{
+		{"unset", nil, false},
+		{"empty", ptr(""), false},
+		{"word", ptr("release"), false},
+		{"path", ptr("deps/ci_v1.2-x"), false},
+		{"space", ptr("neko release"), true},
+		{"parenthesis", ptr("release)"), true},
+		{"colon", ptr("release:"), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &NekoConfig{
+				ProjectType:        ProjectTypeBackend,
+				ReleaseSystem:      ReleaseTypeGoReleaser,
+				Version:            "1.0.0",
+				ReleaseCommitScope: tt.scope,
+			}
+			if err := Validate(cfg); (err != nil) != tt.wantErr {
+				t.Errorf("Validate = %v, want error %t", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func ptr(s string) *string {
+	return &s
+}
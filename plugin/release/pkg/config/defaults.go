@@ -0,0 +1,33 @@
+package config
+
+// recommendedSystems maps each project type to the release system init recommends
+var recommendedSystems = map[ProjectType]ReleaseSystem{
+	ProjectTypeFrontend: ReleaseTypeReleaseIt,
+	ProjectTypeBackend:  ReleaseTypeJReleaser,
+	ProjectTypeOther:    ReleaseTypeGoReleaser,
+}
+
+// defaultReleaseArgs are seeded into release-args by init, depending on project type and release system.
+// Frontend apps are rarely published to npm and backend services have no use for goreleaser announcements.
+var defaultReleaseArgs = map[ProjectType]map[ReleaseSystem][]string{
+	ProjectTypeFrontend: {
+		ReleaseTypeReleaseIt: {"--no-npm.publish"},
+	},
+	ProjectTypeBackend: {
+		ReleaseTypeGoReleaser: {"--skip=announce"},
+	},
+}
+
+// RecommendedReleaseSystem returns the release system recommended for the project type
+func RecommendedReleaseSystem(p ProjectType) ReleaseSystem {
+	return recommendedSystems[p]
+}
+
+// DefaultReleaseArgs returns the release args init seeds for the project type and release system
+func DefaultReleaseArgs(p ProjectType, r ReleaseSystem) []string {
+	args := defaultReleaseArgs[p][r]
+	if len(args) == 0 {
+		return nil
+	}
+	return append([]string(nil), args...)
+}
@@ -0,0 +1,55 @@
+package config
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestRecommendedReleaseSystem(t *testing.T) {
+	tests := []struct {
+		projectType ProjectType
+		want        ReleaseSystem
+	}{
+		{ProjectTypeFrontend, ReleaseTypeReleaseIt},
+		{ProjectTypeBackend, ReleaseTypeJReleaser},
+		{ProjectTypeOther, ReleaseTypeGoReleaser},
+		{ProjectType("mobile"), ""},
+	}
+
+	for _, tt := range tests {
+		if got := RecommendedReleaseSystem(tt.projectType); got != tt.want {
+			t.Errorf("RecommendedReleaseSystem(%s) = %q, want %q", tt.projectType, got, tt.want)
+		}
+	}
+}
+
+func TestDefaultReleaseArgs(t *testing.T) {
+	tests := []struct {
+		projectType ProjectType
+		system      ReleaseSystem
+		want        []string
+	}{
+		{ProjectTypeFrontend, ReleaseTypeReleaseIt, []string{"--no-npm.publish"}},
+		{ProjectTypeFrontend, ReleaseTypeGoReleaser, nil},
+		{ProjectTypeBackend, ReleaseTypeGoReleaser, []string{"--skip=announce"}},
+		{ProjectTypeBackend, ReleaseTypeJReleaser, nil},
+		{ProjectTypeOther, ReleaseTypeGoReleaser, nil},
+		{ProjectType("mobile"), ReleaseTypeReleaseIt, nil},
+	}
+
+	for _, tt := range tests {
+		if got := DefaultReleaseArgs(tt.projectType, tt.system); !slices.Equal(got, tt.want) {
+			t.Errorf("DefaultReleaseArgs(%s, %s) = %q, want %q", tt.projectType, tt.system, got, tt.want)
+		}
+	}
+}
+
+// The defaults are seeded into configs, changing the returned slice must not leak into the next init
+func TestDefaultReleaseArgsCopy(t *testing.T) {
+	args := DefaultReleaseArgs(ProjectTypeBackend, ReleaseTypeGoReleaser)
+	args[0] = "--changed"
+
+	if got := DefaultReleaseArgs(ProjectTypeBackend, ReleaseTypeGoReleaser); got[0] != "--skip=announce" {
+		t.Errorf("DefaultReleaseArgs = %q after modifying a previous result", got)
+	}
+}
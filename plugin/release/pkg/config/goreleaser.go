@@ -0,0 +1,40 @@
+package config
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// goReleaserSkipValues are the pipes goreleaser release accepts for --skip
+var goReleaserSkipValues = []string{
+	"after", "announce", "archive", "aur", "aur-source", "before", "chocolatey", "docker", "homebrew",
+	"ko", "nfpm", "nix", "notarize", "publish", "sbom", "scoop", "sign", "snapcraft", "validate", "winget",
+}
+
+// GoReleaserSkipArgs returns the --skip argument for goreleaser-skip, or nil if nothing is skipped
+func (c *NekoConfig) GoReleaserSkipArgs() []string {
+	if len(c.GoReleaserSkip) == 0 {
+		return nil
+	}
+	return []string{"--skip=" + strings.Join(c.GoReleaserSkip, ",")}
+}
+
+// validateGoReleaserSkip checks goreleaser-skip against the values goreleaser knows,
+// so a typo fails before the release commit instead of in the middle of the release
+func validateGoReleaserSkip(cfg *NekoConfig) error {
+	seen := make(map[string]bool, len(cfg.GoReleaserSkip))
+	for _, item := range cfg.GoReleaserSkip {
+		if !slices.Contains(goReleaserSkipValues, item) {
+			return fmt.Errorf(
+				"invalid configuration: unknown goreleaser-skip value %q, must be one of %s",
+				item, strings.Join(goReleaserSkipValues, ", "),
+			)
+		}
+		if seen[item] {
+			return fmt.Errorf("invalid configuration: %q is listed twice in goreleaser-skip", item)
+		}
+		seen[item] = true
+	}
+	return nil
+}
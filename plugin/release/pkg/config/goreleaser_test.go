@@ -0,0 +1,62 @@
+package config
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestGoReleaserSkipArgs(t *testing.T) {
+	tests := []struct {
+		name string
+		skip []string
+		want []string
+	}{
+		{"nothing skipped", nil, nil},
+		{"one pipe", []string{"announce"}, []string{"--skip=announce"}},
+		{"several pipes", []string{"announce", "docker", "sign"}, []string{"--skip=announce,docker,sign"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &NekoConfig{GoReleaserSkip: tt.skip}
+			if got := cfg.GoReleaserSkipArgs(); !slices.Equal(got, tt.want) {
+				t.Errorf("GoReleaserSkipArgs = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateGoReleaserSkip(t *testing.T) {
+	tests := []struct {
+		name    string
+		skip    []string
+		wantErr string
+	}{
+		{"empty", nil, ""},
+		{"known pipes", []string{"announce", "docker", "aur-source"}, ""},
+		{"unknown pipe", []string{"dokcer"}, `unknown goreleaser-skip value "dokcer"`},
+		{"duplicate", []string{"docker", "docker"}, `"docker" is listed twice`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &NekoConfig{
+				ProjectType:    ProjectTypeOther,
+				ReleaseSystem:  ReleaseTypeGoReleaser,
+				Version:        "1.0.0",
+				GoReleaserSkip: tt.skip,
+			}
+			err := Validate(cfg)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate = %v, want valid", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	config2 "github.com/nekoman-hq/neko-cli/pkg/config"
+)
+
+// MergeLegacy consolidates an old .neko.json and the canonical config into one config.
+// Fields set in the canonical config win, the legacy file only fills in the missing or empty ones.
+func MergeLegacy() (*NekoConfig, error) {
+	legacy, err := os.ReadFile(config2.LegacyFileName)
+	if err != nil {
+		return nil, fmt.Errorf("configuration read error: %w", err)
+	}
+
+	var cfg NekoConfig
+	if err := json.Unmarshal(legacy, &cfg); err != nil {
+		return nil, fmt.Errorf("configuration parse error in %s: %w", config2.LegacyFileName, err)
+	}
+
+	if Exists() {
+		canonical, err := os.ReadFile(FileName)
+		if err != nil {
+			return nil, fmt.Errorf("configuration read error: %w", err)
+		}
+		var fields map[string]any
+		if err := json.Unmarshal(canonical, &fields); err != nil {
+			return nil, fmt.Errorf("configuration parse error in %s: %w", FileName, err)
+		}
+		// SaveConfig writes empty strings for unset fields, those must not clear the legacy values
+		for key, value := range fields {
+			if value == nil || value == "" {
+				delete(fields, key)
+			}
+		}
+		set, err := json.Marshal(fields)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(set, &cfg); err != nil {
+			return nil, fmt.Errorf("configuration parse error in %s: %w", FileName, err)
+		}
+	}
+
+	if err := Validate(&cfg); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
@@ -0,0 +1,128 @@
+package config
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	config2 "github.com/nekoman-hq/neko-cli/pkg/config"
+)
+
+// unifiedConfig is a canonical config carrying the CLI and the plugin fields
+const unifiedConfig = `{
+  "project-name": "neko-cli",
+  "project-owner": "nekoman-hq",
+  "project-type": "backend",
+  "release-system": "goreleaser",
+  "version": "1.2.0",
+  "release-args": ["--skip=announce"]
+}`
+
+func writeLegacyFile(t *testing.T, content string) {
+	t.Helper()
+	if err := os.WriteFile(config2.LegacyFileName, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestUnifiedConfigReaders(t *testing.T) {
+	t.Chdir(t.TempDir())
+	writeConfigFile(t, unifiedConfig)
+
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	project, err := config2.LoadProject()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if project.Path != FileName {
+		t.Errorf("CLI read %s, want %s", project.Path, FileName)
+	}
+	got := config2.Project{
+		ProjectName:   cfg.ProjectName,
+		ProjectOwner:  cfg.ProjectOwner,
+		ProjectType:   string(cfg.ProjectType),
+		ReleaseSystem: string(cfg.ReleaseSystem),
+		Version:       cfg.Version,
+		Path:          project.Path,
+	}
+	if got != *project {
+		t.Errorf("plugin read %+v, CLI read %+v", got, *project)
+	}
+	if len(cfg.ReleaseArgs) != 1 || cfg.ReleaseArgs[0] != "--skip=announce" {
+		t.Errorf("ReleaseArgs = %q, want the plugin fields kept", cfg.ReleaseArgs)
+	}
+}
+
+func TestMergeLegacy(t *testing.T) {
+	tests := []struct {
+		name      string
+		legacy    string
+		canonical string
+		want      NekoConfig
+		wantErr   string
+	}{
+		{
+			name:   "legacy only",
+			legacy: `{"project-name": "neko-cli", "project-owner": "nekoman-hq", "project-type": "backend", "release-system": "goreleaser", "version": "0.9.0"}`,
+			want: NekoConfig{ProjectName: "neko-cli", ProjectOwner: "nekoman-hq",
+				ProjectType: ProjectTypeBackend, ReleaseSystem: ReleaseTypeGoReleaser, Version: "0.9.0"},
+		},
+		{
+			name:      "canonical wins",
+			legacy:    `{"project-name": "neko-cli", "project-owner": "nekoman-hq", "project-type": "frontend", "release-system": "release-it", "version": "0.9.0"}`,
+			canonical: lockedConfig,
+			want: NekoConfig{ProjectName: "neko-cli", ProjectOwner: "nekoman-hq",
+				ProjectType: ProjectTypeBackend, ReleaseSystem: ReleaseTypeGoReleaser, Version: "1.0.0"},
+		},
+		{
+			name:      "empty canonical fields",
+			legacy:    `{"project-name": "neko-cli", "project-owner": "nekoman-hq", "project-type": "frontend", "release-system": "release-it", "version": "0.9.0"}`,
+			canonical: `{"project-name": "", "project-owner": "", "project-type": "backend", "release-system": "goreleaser", "version": "1.0.0"}`,
+			want: NekoConfig{ProjectName: "neko-cli", ProjectOwner: "nekoman-hq",
+				ProjectType: ProjectTypeBackend, ReleaseSystem: ReleaseTypeGoReleaser, Version: "1.0.0"},
+		},
+		{name: "invalid legacy", legacy: "{", wantErr: config2.LegacyFileName},
+		{name: "invalid canonical", legacy: lockedConfig, canonical: "{", wantErr: FileName},
+		{name: "invalid merged", legacy: `{"project-type": "backend", "release-system": "goreleaser", "version": "one"}`, wantErr: "version"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Chdir(t.TempDir())
+			writeLegacyFile(t, tt.legacy)
+			if tt.canonical != "" {
+				writeConfigFile(t, tt.canonical)
+			}
+
+			cfg, err := MergeLegacy()
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("MergeLegacy error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if cfg.ProjectName != tt.want.ProjectName || cfg.ProjectOwner != tt.want.ProjectOwner ||
+				cfg.ProjectType != tt.want.ProjectType || cfg.ReleaseSystem != tt.want.ReleaseSystem ||
+				cfg.Version != tt.want.Version {
+				t.Errorf("MergeLegacy = %+v, want %+v", *cfg, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadConfigLegacyHint(t *testing.T) {
+	t.Chdir(t.TempDir())
+	writeLegacyFile(t, lockedConfig)
+
+	_, err := LoadConfig()
+	if err == nil || !strings.Contains(err.Error(), "neko release migrate-config") {
+		t.Errorf("LoadConfig = %v, want the migrate-config hint", err)
+	}
+}
@@ -0,0 +1,88 @@
+package config
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/nekoman-hq/neko-cli/pkg/log"
+)
+
+const LockFileName = ".neko/config.lock"
+
+// ErrLockMismatch is returned when the config no longer matches the recorded lock
+var ErrLockMismatch = errors.New("configuration lock mismatch")
+
+// LockExists checks if a config lock has been recorded
+func LockExists() bool {
+	_, err := os.Stat(LockFileName)
+	return err == nil
+}
+
+// Hash returns the sha256 checksum of the current configuration file
+func Hash() (string, error) {
+	data, err := os.ReadFile(FileName)
+	if err != nil {
+		return "", fmt.Errorf("configuration read error: %w", err)
+	}
+
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// WriteLock records the checksum of the current configuration in the lock file
+func WriteLock() (string, error) {
+	hash, err := Hash()
+	if err != nil {
+		return "", err
+	}
+
+	if err = os.MkdirAll(filepath.Dir(LockFileName), 0755); err != nil {
+		return "", fmt.Errorf("lock directory creation failed: %w", err)
+	}
+
+	content := fmt.Sprintf("%s  %s\n", hash, FileName)
+	if err = os.WriteFile(LockFileName, []byte(content), 0644); err != nil {
+		return "", fmt.Errorf("lock write failed: %w", err)
+	}
+
+	log.PluginV(log.Config, fmt.Sprintf("Recorded config checksum in %s",
+		log.ColorText(log.ColorGreen, LockFileName)))
+	return hash, nil
+}
+
+// VerifyLock compares the current configuration against the recorded lock.
+// Returns nil if no lock exists.
+func VerifyLock() error {
+	data, err := os.ReadFile(LockFileName)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("lock read error: %w", err)
+	}
+
+	fields := strings.Fields(string(data))
+	if len(fields) == 0 {
+		return fmt.Errorf("%w: %s is empty", ErrLockMismatch, LockFileName)
+	}
+
+	hash, err := Hash()
+	if err != nil {
+		return err
+	}
+
+	if fields[0] != hash {
+		return fmt.Errorf(
+			"%w: %s was modified since it was locked.\nReview the changes and run 'neko release config-lock' to accept them",
+			ErrLockMismatch, FileName,
+		)
+	}
+
+	log.PluginV(log.Config, "Config matches recorded lock")
+	return nil
+}
@@ -0,0 +1,80 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"testing"
+)
+
+const lockedConfig = `{"project-type": "backend", "release-system": "goreleaser", "version": "1.0.0"}`
+
+func writeConfigFile(t *testing.T, content string) {
+	t.Helper()
+	if err := os.WriteFile(FileName, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestVerifyLock(t *testing.T) {
+	tests := []struct {
+		name    string
+		lock    bool
+		change  string
+		empty   bool
+		wantErr error
+	}{
+		{name: "no lock"},
+		{name: "match", lock: true},
+		{name: "modified", lock: true, change: `{"project-type": "backend", "release-system": "jreleaser", "version": "1.0.0"}`, wantErr: ErrLockMismatch},
+		{name: "whitespace change", lock: true, change: lockedConfig + "\n", wantErr: ErrLockMismatch},
+		{name: "empty lock", lock: true, empty: true, wantErr: ErrLockMismatch},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Chdir(t.TempDir())
+			writeConfigFile(t, lockedConfig)
+
+			if tt.lock {
+				hash, err := WriteLock()
+				if err != nil {
+					t.Fatal(err)
+				}
+				if want, _ := Hash(); hash != want {
+					t.Errorf("WriteLock = %s, want the config hash %s", hash, want)
+				}
+			}
+			if tt.empty {
+				if err := os.WriteFile(LockFileName, nil, 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if tt.change != "" {
+				writeConfigFile(t, tt.change)
+			}
+
+			if got := LockExists(); got != tt.lock {
+				t.Errorf("LockExists = %t, want %t", got, tt.lock)
+			}
+			if err := VerifyLock(); !errors.Is(err, tt.wantErr) {
+				t.Errorf("VerifyLock = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestVerifyLockWithoutConfig(t *testing.T) {
+	t.Chdir(t.TempDir())
+	writeConfigFile(t, lockedConfig)
+	if _, err := WriteLock(); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Remove(FileName); err != nil {
+		t.Fatal(err)
+	}
+
+	err := VerifyLock()
+	if err == nil || errors.Is(err, ErrLockMismatch) {
+		t.Errorf("VerifyLock = %v, want a read error", err)
+	}
+}
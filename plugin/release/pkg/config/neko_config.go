@@ -7,6 +7,11 @@ package config
 @Since      17.12.2025
 */
 
+import (
+	"fmt"
+	"time"
+)
+
 type (
 	ProjectType   string
 	ReleaseSystem string
@@ -24,14 +29,143 @@ const (
 	ReleaseTypeGoReleaser ReleaseSystem = "goreleaser"
 )
 
+// DirtyTreePolicy decides what happens to unexpected working tree changes during a release
+type DirtyTreePolicy string
+
+const (
+	// DirtyTreeWarn lists unexpected changes as a warning and leaves them untouched
+	DirtyTreeWarn DirtyTreePolicy = "warn"
+	// DirtyTreeStage stages unexpected changes into the release commit
+	DirtyTreeStage DirtyTreePolicy = "stage"
+)
+
+// ProvenanceTarget decides where the build provenance of a release is attached
+type ProvenanceTarget string
+
+const (
+	// ProvenanceAsset uploads the provenance as provenance.json asset of the GitHub release
+	ProvenanceAsset ProvenanceTarget = "asset"
+	// ProvenanceBody appends the provenance to the notes of the GitHub release
+	ProvenanceBody ProvenanceTarget = "body"
+)
+
 type NekoConfig struct {
 	ProjectName   string        `json:"project-name"`
 	ProjectOwner  string        `json:"project-owner"`
 	ProjectType   ProjectType   `json:"project-type"`
 	ReleaseSystem ReleaseSystem `json:"release-system"`
 	Version       string        `json:"version"`
-	// TagName 	  string 		`json:"tag-name"`   (No implementation yet)
+	VersionScheme string        `json:"version-scheme,omitempty"`
+	ChangelogFile string        `json:"changelog-file,omitempty"`
+	// RequireChangelog fails the release if the changelog has no entry for the new version
+	RequireChangelog bool `json:"require-changelog,omitempty"`
+	// TagPrefix overrides the detected tag prefix, e.g. "v" for v1.2.3, "" for bare 1.2.3 or "release-" for release-1.2.3 tags
+	TagPrefix *string `json:"tag-prefix,omitempty"`
 	// TokenName	  string		`json:"token-name"`	(No implementation yet)
+
+	// CommitTrailers are appended to the release commit, e.g. "Co-authored-by: Name <mail>"
+	CommitTrailers []string `json:"commit-trailers,omitempty"`
+	// SignOff adds a Signed-off-by trailer to the release commit
+	SignOff bool `json:"sign-off,omitempty"`
+	// ReleaseCommitAuthor and ReleaseCommitEmail set the identity of the release commit, e.g. "neko-bot" <bot@org>.
+	// Empty values use the ambient git config.
+	ReleaseCommitAuthor string `json:"release-commit-author,omitempty"`
+	ReleaseCommitEmail  string `json:"release-commit-email,omitempty"`
+	// ReleaseCommitScope replaces the "neko-release" scope of "chore(neko-release): x.y.z".
+	// An empty scope creates "chore: release x.y.z" instead.
+	ReleaseCommitScope *string `json:"release-commit-scope,omitempty"`
+	// CreateReleaseCommit creates the release commit before tagging (default: true).
+	// If false, the current HEAD is tagged directly.
+	CreateReleaseCommit *bool `json:"create-release-commit,omitempty"`
+
+	// FetchTags force-fetches tags before comparing versions (default: true)
+	FetchTags *bool `json:"fetch-tags,omitempty"`
+	// IgnorePrereleaseTags compares against the latest stable tag, so e.g. v1.3.0-rc.1 does not block a stable release from 1.2.0
+	IgnorePrereleaseTags bool `json:"ignore-prerelease-tags,omitempty"`
+	// MinReleaseInterval refuses a release within this duration after the last release tag's commit, e.g. "1h".
+	// Guards against repeated runs in CI loops, --force bypasses it.
+	MinReleaseInterval string `json:"min-release-interval,omitempty"`
+
+	// ReleaseArgs are appended to the release command of the release system
+	ReleaseArgs []string `json:"release-args,omitempty"`
+
+	// GoReleaserSkip lists goreleaser pipes to skip on release, e.g. ["announce", "docker"].
+	// Unlike release-args it applies whenever goreleaser runs, also as a follow-up release system.
+	GoReleaserSkip []string `json:"goreleaser-skip,omitempty"`
+
+	// Gitmoji adds or overrides gitmoji to commit type mappings used for release notes, e.g. {":rocket:": "feat"}
+	Gitmoji map[string]string `json:"gitmoji,omitempty"`
+
+	// DirtyTree decides what happens to files the release tool modified unexpectedly (default: warn)
+	DirtyTree DirtyTreePolicy `json:"dirty-tree,omitempty"`
+
+	// Provenance attaches a build provenance document to the GitHub release after a release (default: off)
+	Provenance ProvenanceTarget `json:"provenance,omitempty"`
+
+	// ReleaseSystems fans a release out to several systems, e.g. ["release-it", "goreleaser"].
+	// The first one must be the release-system, it creates the release commit and tag which the others publish.
+	ReleaseSystems []ReleaseSystem `json:"release-systems,omitempty"`
+
+	// PrereleaseBranches maps branch patterns (e.g. release/*) to prerelease identifiers (e.g. rc)
+	PrereleaseBranches []PrereleaseBranch `json:"prerelease-branches,omitempty"`
+}
+
+// PrereleaseBranch maps a branch glob pattern to a prerelease identifier
+type PrereleaseBranch struct {
+	Pattern    string `json:"pattern"`
+	Identifier string `json:"identifier"`
+}
+
+// DefaultChangelogFile is used when no changelog file is configured
+const DefaultChangelogFile = "CHANGELOG.md"
+
+// Changelog returns the configured changelog file or DefaultChangelogFile
+func (c *NekoConfig) Changelog() string {
+	if c.ChangelogFile == "" {
+		return DefaultChangelogFile
+	}
+	return c.ChangelogFile
+}
+
+// ShouldCreateReleaseCommit reports whether a release commit is created before tagging
+func (c *NekoConfig) ShouldCreateReleaseCommit() bool {
+	return c.CreateReleaseCommit == nil || *c.CreateReleaseCommit
+}
+
+// ShouldFetchTags reports whether tags are force-fetched before the version guard runs
+func (c *NekoConfig) ShouldFetchTags() bool {
+	return c.FetchTags == nil || *c.FetchTags
+}
+
+// Systems returns the release systems in the order they run, the primary release system first
+func (c *NekoConfig) Systems() []ReleaseSystem {
+	if len(c.ReleaseSystems) == 0 {
+		return []ReleaseSystem{c.ReleaseSystem}
+	}
+	return c.ReleaseSystems
+}
+
+// ReleaseInterval returns the parsed min-release-interval, zero if none is configured
+func (c *NekoConfig) ReleaseInterval() (time.Duration, error) {
+	if c.MinReleaseInterval == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(c.MinReleaseInterval)
+	if err != nil {
+		return 0, err
+	}
+	if d < 0 {
+		return 0, fmt.Errorf("duration must not be negative")
+	}
+	return d, nil
+}
+
+// DirtyTreePolicy returns the configured dirty tree policy or DirtyTreeWarn
+func (c *NekoConfig) DirtyTreePolicy() DirtyTreePolicy {
+	if c.DirtyTree == "" {
+		return DirtyTreeWarn
+	}
+	return c.DirtyTree
 }
 
 func (p ProjectType) IsValid() bool {
@@ -51,3 +185,26 @@ func (r ReleaseSystem) IsValid() bool {
 		return false
 	}
 }
+
+// PublishesExistingTag reports whether the system can publish a tag and GitHub release created by another system
+func (r ReleaseSystem) PublishesExistingTag() bool {
+	return r == ReleaseTypeGoReleaser
+}
+
+func (t ProvenanceTarget) IsValid() bool {
+	switch t {
+	case "", ProvenanceAsset, ProvenanceBody:
+		return true
+	default:
+		return false
+	}
+}
+
+func (d DirtyTreePolicy) IsValid() bool {
+	switch d {
+	case "", DirtyTreeWarn, DirtyTreeStage:
+		return true
+	default:
+		return false
+	}
+}
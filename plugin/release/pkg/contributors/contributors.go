@@ -6,12 +6,26 @@ import (
 	"github.com/nekoman-hq/neko-cli/pkg/log"
 	"github.com/nekoman-hq/neko-cli/pkg/plugin"
 	"github.com/nekoman-hq/neko-cli/plugin/release/pkg/git"
+	"github.com/nekoman-hq/neko-cli/plugin/release/pkg/release"
 )
 
-func HandleContributors() (*plugin.Response, error) {
+func HandleContributors(req plugin.Request) (*plugin.Response, error) {
 	log.PluginPrint(log.Exec, "Collecting contributors")
 
-	contributors, _ := git.Contributors()
+	rev := "HEAD"
+	if since, _ := req.Flags["since-last-release"].(bool); since {
+		baseline, err := release.LastReleaseBaseline()
+		if err != nil {
+			return nil, err
+		}
+		rev = baseline.Range("HEAD")
+		log.PluginV(log.Exec, "Counting contributions since %s", baseline.Ref())
+	}
+
+	contributors, err := git.Contributors(rev)
+	if err != nil {
+		return errorResponse("CONTRIBUTORS_FAILED", err.Error(), map[string]any{"range": rev}), nil
+	}
 
 	items := make([]map[string]any, 0, len(contributors))
 	for _, c := range contributors {
@@ -33,6 +47,24 @@ func HandleContributors() (*plugin.Response, error) {
 		},
 		Data: map[string]any{
 			"items": items,
+			"range": rev,
 		},
 	}, nil
 }
+
+func errorResponse(code, message string, details map[string]any) *plugin.Response {
+	return &plugin.Response{
+		Status: "error",
+		Metadata: plugin.ResponseMetadata{
+			Plugin:    "release",
+			Version:   "1.0.0",
+			Command:   "contributors",
+			Timestamp: time.Now(),
+		},
+		Error: &plugin.ResponseError{
+			Code:    code,
+			Message: message,
+			Details: details,
+		},
+	}
+}
@@ -0,0 +1,65 @@
+package contributors
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nekoman-hq/neko-cli/pkg/plugin"
+	"github.com/nekoman-hq/neko-cli/plugin/release/internal/gittest"
+)
+
+// commitAs creates an empty commit by the given author
+func commitAs(t *testing.T, name, message string) {
+	t.Helper()
+	t.Setenv("GIT_AUTHOR_NAME", name)
+	t.Setenv("GIT_AUTHOR_EMAIL", strings.ToLower(name)+"@example.com")
+	t.Setenv("GIT_COMMITTER_NAME", name)
+	t.Setenv("GIT_COMMITTER_EMAIL", strings.ToLower(name)+"@example.com")
+	gittest.Run(t, "commit", "-q", "--allow-empty", "-m", message)
+}
+
+func contributorsRequest(sinceLastRelease bool) plugin.Request {
+	return plugin.Request{
+		Command: "contributors",
+		Flags:   map[string]any{"since-last-release": sinceLastRelease},
+	}
+}
+
+func TestHandleContributorsSinceLastRelease(t *testing.T) {
+	gittest.NewRepo(t)
+	commitAs(t, "Alice", "feat: first")
+	gittest.Run(t, "tag", "v1.0.0")
+	commitAs(t, "Bob", "fix: after the release")
+
+	resp, err := HandleContributors(contributorsRequest(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Status != "success" {
+		t.Fatalf("status = %s, error = %+v", resp.Status, resp.Error)
+	}
+
+	items := resp.Data["items"].([]map[string]any)
+	if len(items) != 1 || !strings.HasPrefix(items[0]["author"].(string), "Bob") {
+		t.Errorf("items = %v, want only Bob", items)
+	}
+	if resp.Data["range"] != "v1.0.0..HEAD" {
+		t.Errorf("range = %v, want v1.0.0..HEAD", resp.Data["range"])
+	}
+}
+
+// A failing git shortlog must not look like a range without contributors
+func TestHandleContributorsGitFailure(t *testing.T) {
+	gittest.NewRepo(t)
+
+	resp, err := HandleContributors(contributorsRequest(false))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Status != "error" || resp.Error == nil || resp.Error.Code != "CONTRIBUTORS_FAILED" {
+		t.Fatalf("response = %s %+v, want CONTRIBUTORS_FAILED", resp.Status, resp.Error)
+	}
+	if resp.Error.Details["range"] != "HEAD" {
+		t.Errorf("details = %v, want the failing range", resp.Error.Details)
+	}
+}
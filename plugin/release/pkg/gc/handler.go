@@ -0,0 +1,194 @@
+// Package gc includes the gc command handler that prunes orphan release tags
+package gc
+
+import (
+	stderrors "errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/nekoman-hq/neko-cli/pkg/config"
+	"github.com/nekoman-hq/neko-cli/pkg/log"
+	"github.com/nekoman-hq/neko-cli/pkg/plugin"
+	"github.com/nekoman-hq/neko-cli/plugin/release/pkg/git"
+	"github.com/nekoman-hq/neko-cli/plugin/release/pkg/release"
+)
+
+const (
+	PluginName    = "release"
+	PluginVersion = "1.0.0"
+)
+
+// Tag statuses reported by gc
+const (
+	StatusOrphan     = "orphan"
+	StatusReleased   = "released"
+	StatusUnverified = "unverified"
+)
+
+// TagStatus is a local version tag missing on origin
+type TagStatus struct {
+	Tag    string
+	Status string
+	Reason string
+}
+
+// HandleGC lists local version tags that are neither on origin nor backing a GitHub release
+// and deletes them locally once confirmed
+func HandleGC(req plugin.Request) (*plugin.Response, error) {
+	log.PluginPrint(log.Exec, "Looking for orphan release tags")
+
+	localTags := git.GetTags()
+	prefix, err := release.RepositoryTagPrefix(localTags)
+	if err != nil {
+		return errorResponse("CONFIG_ERROR", err.Error(), nil), nil
+	}
+
+	remoteTags, err := git.RemoteTags()
+	if err != nil {
+		return errorResponse("REMOTE_LOOKUP_FAILED", err.Error(), nil), nil
+	}
+
+	candidates := LocalOnlyTags(localTags, remoteTags, prefix)
+	statuses := make([]TagStatus, 0, len(candidates))
+	released := newReleaseChecker()
+	for _, tag := range candidates {
+		statuses = append(statuses, Classify(tag, released))
+	}
+
+	orphans := make([]string, 0)
+	for _, st := range statuses {
+		if st.Status == StatusOrphan {
+			orphans = append(orphans, st.Tag)
+		}
+	}
+
+	if len(orphans) > 0 && !req.Confirmed() {
+		resp := errorResponse("CONFIRMATION_REQUIRED",
+			fmt.Sprintf("Deleting %d orphan tag(s) requires confirmation", len(orphans)),
+			map[string]any{
+				"tags": orphans,
+				"hint": "Re-run with --yes to delete the tags",
+			})
+		resp.Data = deletePlan(orphans).ConfirmationData()
+		return resp, nil
+	}
+
+	for _, tag := range orphans {
+		if err = git.DeleteLocalTag(tag); err != nil {
+			return errorResponse("TAG_DELETE_FAILED", err.Error(), nil), nil
+		}
+		log.PluginPrint(log.Exec, "\uF00C Deleted local tag %s", log.ColorText(log.ColorGreen, tag))
+	}
+
+	items := make([]map[string]any, 0, len(statuses))
+	for _, st := range statuses {
+		action := "kept"
+		if st.Status == StatusOrphan {
+			action = "deleted"
+		}
+		items = append(items, map[string]any{
+			"tag":    st.Tag,
+			"status": st.Status,
+			"action": action,
+			"reason": st.Reason,
+		})
+	}
+
+	return &plugin.Response{
+		Status: "success",
+		Metadata: plugin.ResponseMetadata{
+			Plugin:    PluginName,
+			Version:   PluginVersion,
+			Command:   "gc",
+			Timestamp: time.Now(),
+		},
+		Data: map[string]any{
+			"items":   items,
+			"deleted": orphans,
+		},
+		RendererHint: "table",
+		ColumnOrder:  []string{"tag", "status", "action", "reason"},
+	}, nil
+}
+
+// LocalOnlyTags returns the local version tags that do not exist on origin
+func LocalOnlyTags(local, remote []string, prefix string) []string {
+	tags := make([]string, 0)
+	for _, tag := range local {
+		if release.IsVersionTag(tag, prefix) && !slices.Contains(remote, tag) {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// Classify decides whether a local-only tag is an orphan.
+// Tags backing a GitHub release, or whose release could not be looked up, are never orphans.
+func Classify(tag string, released func(tag string) (bool, error)) TagStatus {
+	ok, err := released(tag)
+	switch {
+	case err != nil:
+		return TagStatus{Tag: tag, Status: StatusUnverified, Reason: err.Error()}
+	case ok:
+		return TagStatus{Tag: tag, Status: StatusReleased, Reason: "GitHub release exists"}
+	default:
+		return TagStatus{Tag: tag, Status: StatusOrphan, Reason: "not on origin, no GitHub release"}
+	}
+}
+
+// newReleaseChecker creates the GitHub release lookup, replaceable to avoid the network call
+var newReleaseChecker = releaseChecker
+
+// releaseChecker looks up GitHub releases by tag. Without a repository or token every tag stays unverified.
+func releaseChecker() func(tag string) (bool, error) {
+	repo, repoErr := git.Current()
+	token, tokenErr := config.GetPAT()
+
+	return func(tag string) (bool, error) {
+		if repoErr != nil {
+			return false, fmt.Errorf("cannot check GitHub releases: %w", repoErr)
+		}
+		if tokenErr != nil {
+			return false, fmt.Errorf("cannot check GitHub releases: %w", config.ErrMissingPAT)
+		}
+
+		_, err := git.ReleaseByTag(repo, tag, token)
+		if stderrors.Is(err, git.ErrReleaseNotFound) {
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		return true, nil
+	}
+}
+
+// deletePlan describes the deletion of the orphan tags for the confirmation summary
+func deletePlan(orphans []string) plugin.Plan {
+	commands := make([]string, 0, len(orphans))
+	for _, tag := range orphans {
+		commands = append(commands, "git tag -d "+tag)
+	}
+	return plugin.Plan{
+		Summary:  fmt.Sprintf("Delete %d orphan tag(s)", len(orphans)),
+		Commands: commands,
+	}
+}
+
+func errorResponse(code, message string, details map[string]any) *plugin.Response {
+	return &plugin.Response{
+		Status: "error",
+		Metadata: plugin.ResponseMetadata{
+			Plugin:    PluginName,
+			Version:   PluginVersion,
+			Command:   "gc",
+			Timestamp: time.Now(),
+		},
+		Error: &plugin.ResponseError{
+			Code:    code,
+			Message: message,
+			Details: details,
+		},
+	}
+}
@@ -0,0 +1,164 @@
+package gc
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/nekoman-hq/neko-cli/pkg/plugin"
+	"github.com/nekoman-hq/neko-cli/plugin/release/internal/gittest"
+)
+
+// withReleases replaces the GitHub lookup with the released tags, errs maps tags to lookup errors
+func withReleases(t *testing.T, released []string, errs map[string]error) {
+	t.Helper()
+	original := newReleaseChecker
+	newReleaseChecker = func() func(string) (bool, error) {
+		return func(tag string) (bool, error) {
+			if err := errs[tag]; err != nil {
+				return false, err
+			}
+			return slices.Contains(released, tag), nil
+		}
+	}
+	t.Cleanup(func() { newReleaseChecker = original })
+}
+
+func TestLocalOnlyTags(t *testing.T) {
+	tests := []struct {
+		name   string
+		local  []string
+		remote []string
+		prefix string
+		want   []string
+	}{
+		{"all pushed", []string{"v1.0.0", "v1.1.0"}, []string{"v1.0.0", "v1.1.0"}, "v", []string{}},
+		{"local only", []string{"v1.0.0", "v1.1.0"}, []string{"v1.0.0"}, "v", []string{"v1.1.0"}},
+		{"non-version tags are kept", []string{"nightly", "v1.1.0"}, nil, "v", []string{"v1.1.0"}},
+		{"other prefix", []string{"release-1.0.0", "v1.1.0"}, nil, "release-", []string{"release-1.0.0"}},
+		{"no tags", nil, nil, "v", []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := LocalOnlyTags(tt.local, tt.remote, tt.prefix); !slices.Equal(got, tt.want) {
+				t.Errorf("LocalOnlyTags = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		name     string
+		released bool
+		err      error
+		want     string
+	}{
+		{"orphan", false, nil, StatusOrphan},
+		{"released", true, nil, StatusReleased},
+		{"lookup failed", false, errors.New("rate limited"), StatusUnverified},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := Classify("v1.0.0", func(string) (bool, error) { return tt.released, tt.err })
+			if st.Tag != "v1.0.0" || st.Status != tt.want {
+				t.Errorf("Classify = %+v, want status %s", st, tt.want)
+			}
+		})
+	}
+}
+
+// gcRepo pushes v1.0.0 to origin and keeps v1.1.0, v1.2.0 and v1.3.0 local
+func gcRepo(t *testing.T) {
+	t.Helper()
+	gittest.NewRepo(t)
+	gittest.NewRemote(t)
+	gittest.Commit(t, "feat: initial")
+	gittest.Run(t, "tag", "v1.0.0")
+	gittest.Run(t, "push", "-q", "origin", "main", "v1.0.0")
+	for _, tag := range []string{"v1.1.0", "v1.2.0", "v1.3.0"} {
+		gittest.Commit(t, "feat: "+tag)
+		gittest.Run(t, "tag", tag)
+	}
+}
+
+func TestHandleGC(t *testing.T) {
+	tests := []struct {
+		name     string
+		req      plugin.Request
+		wantCode string
+		wantTags []string
+	}{
+		{"unconfirmed", plugin.Request{Command: "gc"}, "CONFIRMATION_REQUIRED", []string{"v1.0.0", "v1.1.0", "v1.2.0", "v1.3.0"}},
+		{"confirmed", plugin.Request{Command: "gc", Flags: map[string]any{"yes": true}}, "", []string{"v1.0.0", "v1.2.0", "v1.3.0"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gcRepo(t)
+			withReleases(t, []string{"v1.2.0"}, map[string]error{"v1.3.0": errors.New("rate limited")})
+
+			resp, err := HandleGC(tt.req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.wantCode != "" {
+				if resp.Error == nil || resp.Error.Code != tt.wantCode {
+					t.Fatalf("error = %+v, want %s", resp.Error, tt.wantCode)
+				}
+				if tags := resp.Error.Details["tags"].([]string); !slices.Equal(tags, []string{"v1.1.0"}) {
+					t.Errorf("confirmation lists %q, want only the orphan", tags)
+				}
+				if plan, ok := resp.Data[plugin.PlanKey].(plugin.Plan); !ok || !slices.Contains(plan.Commands, "git tag -d v1.1.0") {
+					t.Errorf("confirmation plan = %+v, want the deletion of v1.1.0", resp.Data[plugin.PlanKey])
+				}
+			} else {
+				if resp.Status != "success" {
+					t.Fatalf("status = %s, error = %+v", resp.Status, resp.Error)
+				}
+				if deleted := resp.Data["deleted"].([]string); !slices.Equal(deleted, []string{"v1.1.0"}) {
+					t.Errorf("deleted = %q, want v1.1.0", deleted)
+				}
+				actions := map[string]string{}
+				for _, item := range resp.Data["items"].([]map[string]any) {
+					actions[item["tag"].(string)] = item["status"].(string) + "/" + item["action"].(string)
+				}
+				want := map[string]string{
+					"v1.1.0": "orphan/deleted",
+					"v1.2.0": "released/kept",
+					"v1.3.0": "unverified/kept",
+				}
+				for tag, action := range want {
+					if actions[tag] != action {
+						t.Errorf("%s = %q, want %q", tag, actions[tag], action)
+					}
+				}
+			}
+
+			if tags := strings.Fields(gittest.Run(t, "tag")); !slices.Equal(tags, tt.wantTags) {
+				t.Errorf("local tags = %q, want %q", tags, tt.wantTags)
+			}
+		})
+	}
+}
+
+func TestHandleGCWithoutOrigin(t *testing.T) {
+	gittest.NewRepo(t)
+	gittest.Commit(t, "feat: initial")
+	gittest.Run(t, "tag", "v1.0.0")
+	withReleases(t, nil, nil)
+
+	resp, err := HandleGC(plugin.Request{Command: "gc", Flags: map[string]any{"yes": true}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Error == nil || resp.Error.Code != "REMOTE_LOOKUP_FAILED" {
+		t.Errorf("error = %+v, want REMOTE_LOOKUP_FAILED", resp.Error)
+	}
+	if tags := gittest.Run(t, "tag"); tags != "v1.0.0" {
+		t.Errorf("local tags = %q, gc deleted without knowing origin", tags)
+	}
+}
@@ -0,0 +1,68 @@
+package git
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/nekoman-hq/neko-cli/pkg/log"
+)
+
+// GitHubUploads is the base URL release assets are uploaded to
+const GitHubUploads = "https://uploads.github.com"
+
+// NewUploadAssetRequest builds the POST request that uploads data as asset of a release
+func NewUploadAssetRequest(repoInfo *RepoInfo, id int64, name, contentType string, data []byte, token string) (*http.Request, error) {
+	uploadURL := fmt.Sprintf("%s/repos/%s/%s/releases/%d/assets?name=%s",
+		GitHubUploads, repoInfo.Owner, repoInfo.Repo, id, url.QueryEscape(name))
+	req, err := http.NewRequest("POST", uploadURL, bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf(
+			"request Creation Failed: %w", err,
+		)
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("User-Agent", "neko-cli")
+	return req, nil
+}
+
+// UploadReleaseAsset uploads data as asset of an existing release.
+// GitHub refuses to overwrite an existing asset with the same name.
+func UploadReleaseAsset(repoInfo *RepoInfo, id int64, name, contentType string, data []byte, token string) error {
+	if SkipInDryRun("uploading release asset", name) {
+		return nil
+	}
+
+	req, err := NewUploadAssetRequest(repoInfo, id, name, contentType, data, token)
+	if err != nil {
+		return err
+	}
+
+	log.PluginV(log.Exec, fmt.Sprintf("Uploading release asset: %s",
+		log.ColorText(log.ColorGreen, "POST "+req.URL.String()),
+	))
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf(
+			"API Request Failed: %w", err,
+		)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	logRateLimit(resp)
+
+	if resp.StatusCode == http.StatusUnprocessableEntity {
+		return fmt.Errorf("release already has an asset named %s", name)
+	}
+	if resp.StatusCode != http.StatusCreated {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf(
+			"GitHub API returned status %d: %s", resp.StatusCode, string(body),
+		)
+	}
+	return nil
+}
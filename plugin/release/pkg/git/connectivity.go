@@ -0,0 +1,46 @@
+package git
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/nekoman-hq/neko-cli/pkg/log"
+)
+
+// GitHubAPI is the root of the GitHub REST API
+const GitHubAPI = "https://api.github.com"
+
+// connectivityTimeout bounds the connectivity check so an offline machine fails fast
+const connectivityTimeout = 5 * time.Second
+
+// CheckGitHub checks that the GitHub API at baseURL is reachable and not failing.
+// Any answer below 500 counts as reachable, e.g. a rate limited 403.
+func CheckGitHub(baseURL string) error {
+	url := baseURL + "/meta"
+	log.PluginV(log.Preflight, fmt.Sprintf("%s (Check GitHub connectivity)",
+		log.ColorText(log.ColorGreen, "GET "+url),
+	))
+
+	client := &http.Client{Timeout: connectivityTimeout}
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return fmt.Errorf("request Creation Failed: %w", err)
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("User-Agent", "neko-cli")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("cannot reach GitHub at %s: %w", baseURL, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	logRateLimit(resp)
+
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return fmt.Errorf("cannot reach GitHub: %s answered with status %d, check https://www.githubstatus.com", baseURL, resp.StatusCode)
+	}
+
+	log.PluginV(log.Preflight, "GitHub is reachable")
+	return nil
+}
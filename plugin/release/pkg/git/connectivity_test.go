@@ -0,0 +1,57 @@
+package git
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestCheckGitHub(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		wantErr string
+	}{
+		{"ok", http.StatusOK, ""},
+		{"rate limited", http.StatusForbidden, ""},
+		{"not found", http.StatusNotFound, ""},
+		{"server error", http.StatusInternalServerError, "status 500"},
+		{"unavailable", http.StatusServiceUnavailable, "status 503"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var path string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				path = r.URL.Path
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			err := CheckGitHub(server.URL)
+			if path != "/meta" {
+				t.Errorf("requested %q, want /meta", path)
+			}
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("CheckGitHub = %v, want reachable", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("CheckGitHub = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCheckGitHubUnreachable(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+
+	err := CheckGitHub(server.URL)
+	if err == nil || !strings.Contains(err.Error(), "cannot reach GitHub") {
+		t.Errorf("CheckGitHub = %v, want a connection error", err)
+	}
+}
@@ -0,0 +1,74 @@
+package git
+
+import (
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"github.com/nekoman-hq/neko-cli/pkg/log"
+)
+
+// FileChange is a file changed between two references as listed by DiffStat.
+// Binary files have no line counts.
+type FileChange struct {
+	Path       string
+	Insertions int
+	Deletions  int
+	Binary     bool
+}
+
+// DiffStat returns the files changed between two references.
+// An empty from diffs against the empty tree, i.e. lists everything up to to.
+func DiffStat(from, to string) ([]FileChange, error) {
+	if from == "" {
+		tree, err := emptyTree()
+		if err != nil {
+			return nil, err
+		}
+		from = tree
+	}
+	args := []string{"diff", "--numstat", from, to}
+
+	log.PluginV(log.Exec, fmt.Sprintf("Listing changed files between %s and %s: %s",
+		from, to, log.ColorText(log.ColorGreen, "git "+strings.Join(args, " "))))
+
+	out, err := exec.Command("git", args...).CombinedOutput()
+	if err != nil {
+		return nil, fmt.Errorf("git diff %s %s failed: %s", from, to, strings.TrimSpace(text(out)))
+	}
+	return parseNumstat(text(out)), nil
+}
+
+// emptyTree returns the id of the empty tree in the repository's hash format
+func emptyTree() (string, error) {
+	cmd := exec.Command("git", "hash-object", "-t", "tree", "--stdin")
+	cmd.Stdin = strings.NewReader("")
+
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("git hash-object -t tree failed: %s", strings.TrimSpace(text(out)))
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// parseNumstat parses "<insertions>\t<deletions>\t<path>" lines, binary files show "-" as counts
+func parseNumstat(output string) []FileChange {
+	changes := make([]FileChange, 0)
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.SplitN(line, "\t", 3)
+		if len(fields) != 3 {
+			continue
+		}
+
+		change := FileChange{Path: fields[2]}
+		if fields[0] == "-" && fields[1] == "-" {
+			change.Binary = true
+		} else {
+			change.Insertions, _ = strconv.Atoi(fields[0])
+			change.Deletions, _ = strconv.Atoi(fields[1])
+		}
+		changes = append(changes, change)
+	}
+	return changes
+}
@@ -0,0 +1,74 @@
+package git
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/nekoman-hq/neko-cli/plugin/release/internal/gittest"
+)
+
+func TestParseNumstat(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		want   []FileChange
+	}{
+		{"empty", "", []FileChange{}},
+		{"text files", "3\t1\tmain.go\n0\t7\tREADME.md\n", []FileChange{
+			{Path: "main.go", Insertions: 3, Deletions: 1},
+			{Path: "README.md", Deletions: 7},
+		}},
+		{"binary file", "-\t-\tlogo.png\n", []FileChange{{Path: "logo.png", Binary: true}}},
+		{"tab in path", "1\t0\tdocs/a\tb.md\n", []FileChange{{Path: "docs/a\tb.md", Insertions: 1}}},
+		{"malformed line", "garbage\n2\t2\tgo.mod\n", []FileChange{{Path: "go.mod", Insertions: 2, Deletions: 2}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseNumstat(tt.output); !slices.Equal(got, tt.want) {
+				t.Errorf("parseNumstat(%q) = %+v, want %+v", tt.output, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiffStat(t *testing.T) {
+	gittest.NewRepo(t)
+	gittest.WriteFile(t, "a.txt", "one\ntwo\n")
+	gittest.Run(t, "add", "a.txt")
+	gittest.Run(t, "commit", "-q", "-m", "feat: a")
+	gittest.Run(t, "tag", "v1.0.0")
+	gittest.WriteFile(t, "a.txt", "one\nthree\nfour\n")
+	gittest.WriteFile(t, "b.txt", "new\n")
+	gittest.Run(t, "add", "a.txt", "b.txt")
+	gittest.Run(t, "commit", "-q", "-m", "feat: b")
+	gittest.Run(t, "tag", "v1.1.0")
+
+	tests := []struct {
+		name     string
+		from, to string
+		want     []FileChange
+	}{
+		{"between tags", "v1.0.0", "v1.1.0", []FileChange{
+			{Path: "a.txt", Insertions: 2, Deletions: 1},
+			{Path: "b.txt", Insertions: 1},
+		}},
+		{"from the empty tree", "", "v1.0.0", []FileChange{{Path: "a.txt", Insertions: 2}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DiffStat(tt.from, tt.to)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("DiffStat(%q, %q) = %+v, want %+v", tt.from, tt.to, got, tt.want)
+			}
+		})
+	}
+
+	if _, err := DiffStat("v1.0.0", "v9.9.9"); err == nil {
+		t.Error("DiffStat succeeded for a missing reference")
+	}
+}
@@ -0,0 +1,31 @@
+package git
+
+import (
+	"strings"
+
+	"github.com/nekoman-hq/neko-cli/pkg/log"
+)
+
+// dryRun makes the mutating functions log their command instead of running it
+var dryRun bool
+
+// SetDryRun enables or disables the dry-run mode of the mutating git and GitHub functions
+func SetDryRun(enabled bool) {
+	dryRun = enabled
+}
+
+// DryRun reports whether mutating git and GitHub operations are skipped
+func DryRun() bool {
+	return dryRun
+}
+
+// SkipInDryRun logs the command that would run and reports whether it has to be skipped.
+// Every function changing the repository, origin or GitHub calls it before doing so.
+func SkipInDryRun(command ...string) bool {
+	if !dryRun {
+		return false
+	}
+	log.PluginPrint(log.Exec, "[dry-run] Skipping %s",
+		log.ColorText(log.ColorYellow, strings.Join(command, " ")))
+	return true
+}
@@ -0,0 +1,92 @@
+package git
+
+import (
+	"testing"
+
+	"github.com/nekoman-hq/neko-cli/plugin/release/internal/gittest"
+)
+
+// withDryRun enables the dry-run mode for the test
+func withDryRun(t *testing.T, enabled bool) {
+	t.Helper()
+	previous := dryRun
+	SetDryRun(enabled)
+	t.Cleanup(func() { SetDryRun(previous) })
+}
+
+// dryRunRepo pushes v1.0.0 to origin and keeps a second commit tagged v1.1.0 and an untracked file local.
+// Returns the hashes of both commits.
+func dryRunRepo(t *testing.T) (first, second string) {
+	t.Helper()
+	gittest.NewRepo(t)
+	gittest.NewRemote(t)
+	gittest.WriteFile(t, "version.txt", "1.0.0\n")
+	gittest.Run(t, "add", "version.txt")
+	gittest.Run(t, "commit", "-q", "-m", "feat: initial")
+	gittest.Run(t, "tag", "v1.0.0")
+	gittest.Run(t, "push", "-q", "origin", "main", "v1.0.0")
+	first = gittest.Run(t, "rev-parse", "HEAD")
+
+	gittest.WriteFile(t, "version.txt", "1.1.0\n")
+	gittest.Run(t, "commit", "-q", "-am", "feat: next")
+	gittest.Run(t, "tag", "v1.1.0")
+	second = gittest.Run(t, "rev-parse", "HEAD")
+
+	gittest.WriteFile(t, "scratch.txt", "notes\n")
+	return first, second
+}
+
+// repoState summarizes everything a mutating git function could change
+func repoState(t *testing.T) string {
+	t.Helper()
+	return gittest.Run(t, "rev-parse", "HEAD") + "\n" +
+		gittest.Run(t, "status", "--porcelain") + "\n" +
+		gittest.Run(t, "tag") + "\n" +
+		gittest.Run(t, "ls-remote", "origin")
+}
+
+func TestDryRunSkipsMutations(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(first, second string) error
+	}{
+		{"Push", func(_, _ string) error { return Push("HEAD") }},
+		{"Stage", func(_, _ string) error { return Stage([]string{"scratch.txt"}) }},
+		{"CommitFiles", func(_, _ string) error { return CommitFiles("chore: notes", []string{"scratch.txt"}) }},
+		{"CleanUntracked", func(_, _ string) error { return CleanUntracked() }},
+		{"DeleteLocalTag", func(_, _ string) error { return DeleteLocalTag("v1.1.0") }},
+		{"DeleteRemoteTag", func(_, _ string) error { return DeleteRemoteTag("v1.0.0") }},
+		{"RevertCommit", func(_, second string) error { return RevertCommit(second) }},
+		{"CreateCommit", func(_, _ string) error { return CreateCommit("chore: empty") }},
+		{"HardResetTo", func(first, _ string) error { return HardResetTo(first) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, enabled := range []bool{true, false} {
+				first, second := dryRunRepo(t)
+				withDryRun(t, enabled)
+				before := repoState(t)
+
+				if err := tt.mutate(first, second); err != nil {
+					t.Fatalf("dry-run %t: %v", enabled, err)
+				}
+				// Without dry-run the same call must change the repository, otherwise the check proves nothing
+				if changed := repoState(t) != before; changed == enabled {
+					t.Errorf("dry-run %t: repository changed = %t", enabled, changed)
+				}
+			}
+		})
+	}
+}
+
+func TestSkipInDryRun(t *testing.T) {
+	withDryRun(t, false)
+	if SkipInDryRun("git", "tag", "v1.0.0") || DryRun() {
+		t.Error("SkipInDryRun skipped without dry-run")
+	}
+	withDryRun(t, true)
+	if !SkipInDryRun("git", "tag", "v1.0.0") || !DryRun() {
+		t.Error("SkipInDryRun ran the command in dry-run")
+	}
+}
@@ -8,6 +8,7 @@ package github
 */
 
 type Release struct {
+	ID          int64  `json:"id"`
 	Name        string `json:"name"`
 	TagName     string `json:"tag_name"`
 	PublishedAt string `json:"published_at"`
@@ -16,6 +17,33 @@ type Release struct {
 	Author      Author `json:"author"`
 	PreRelease  bool   `json:"prerelease"`
 }
+
+// ReleaseUpdate holds the release fields to change, nil fields are left untouched
+type ReleaseUpdate struct {
+	Name       *string `json:"name,omitempty"`
+	Body       *string `json:"body,omitempty"`
+	PreRelease *bool   `json:"prerelease,omitempty"`
+}
+
+// Apply returns the release with the fields of the update applied, e.g. to preview it
+func (u ReleaseUpdate) Apply(r Release) Release {
+	if u.Name != nil {
+		r.Name = *u.Name
+	}
+	if u.Body != nil {
+		r.Body = *u.Body
+	}
+	if u.PreRelease != nil {
+		r.PreRelease = *u.PreRelease
+	}
+	return r
+}
+
+// IsEmpty reports whether the update would not change anything
+func (u ReleaseUpdate) IsEmpty() bool {
+	return u.Name == nil && u.Body == nil && u.PreRelease == nil
+}
+
 type Author struct {
 	Login string `json:"login"`
 }
@@ -0,0 +1,29 @@
+package github
+
+import "testing"
+
+func TestReleaseUpdateApply(t *testing.T) {
+	release := Release{ID: 42, Name: "v1.2.0", TagName: "v1.2.0", Body: "generated notes", PreRelease: true}
+	name, body, prerelease := "v1.2.0 - Faster history", "", false
+
+	tests := []struct {
+		name   string
+		update ReleaseUpdate
+		want   Release
+	}{
+		{"nothing", ReleaseUpdate{}, release},
+		{"title", ReleaseUpdate{Name: &name}, Release{ID: 42, Name: name, TagName: "v1.2.0", Body: "generated notes", PreRelease: true}},
+		{"clear notes and prerelease", ReleaseUpdate{Body: &body, PreRelease: &prerelease}, Release{ID: 42, Name: "v1.2.0", TagName: "v1.2.0"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.update.Apply(release); got != tt.want {
+				t.Errorf("Apply = %+v, want %+v", got, tt.want)
+			}
+			if tt.update.IsEmpty() != (tt.name == "nothing") {
+				t.Errorf("IsEmpty = %t", tt.update.IsEmpty())
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package github
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// LowRateLimit is the number of remaining requests below which callers should warn
+const LowRateLimit = 10
+
+// RateLimit is the GitHub API rate limit status reported with a response
+type RateLimit struct {
+	Limit     int
+	Remaining int
+	Reset     time.Time
+}
+
+// ParseRateLimit reads the X-RateLimit-* headers of a response.
+// Returns false if the response carries no rate limit, e.g. asset downloads.
+func ParseRateLimit(h http.Header) (RateLimit, bool) {
+	remaining, err := strconv.Atoi(h.Get("X-RateLimit-Remaining"))
+	if err != nil {
+		return RateLimit{}, false
+	}
+
+	rl := RateLimit{Remaining: remaining}
+	if limit, err := strconv.Atoi(h.Get("X-RateLimit-Limit")); err == nil {
+		rl.Limit = limit
+	}
+	if reset, err := strconv.ParseInt(h.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+		rl.Reset = time.Unix(reset, 0)
+	}
+	return rl, true
+}
+
+// Low reports whether the remaining requests dropped below LowRateLimit
+func (r RateLimit) Low() bool {
+	return r.Remaining < LowRateLimit
+}
+
+// String returns a one-line summary like "4990/5000 requests left, resets at 15:04:05"
+func (r RateLimit) String() string {
+	s := fmt.Sprintf("%d requests left", r.Remaining)
+	if r.Limit > 0 {
+		s = fmt.Sprintf("%d/%d requests left", r.Remaining, r.Limit)
+	}
+	if !r.Reset.IsZero() {
+		s += ", resets at " + r.Reset.Format("15:04:05")
+	}
+	return s
+}
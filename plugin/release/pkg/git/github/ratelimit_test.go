@@ -0,0 +1,91 @@
+package github
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestParseRateLimit(t *testing.T) {
+	tests := []struct {
+		name    string
+		headers map[string]string
+		want    RateLimit
+		wantOK  bool
+	}{
+		{
+			name:    "full",
+			headers: map[string]string{"X-RateLimit-Limit": "5000", "X-RateLimit-Remaining": "4990", "X-RateLimit-Reset": "1700000000"},
+			want:    RateLimit{Limit: 5000, Remaining: 4990, Reset: time.Unix(1700000000, 0)},
+			wantOK:  true,
+		},
+		{
+			name:    "remaining only",
+			headers: map[string]string{"X-RateLimit-Remaining": "0"},
+			want:    RateLimit{Remaining: 0},
+			wantOK:  true,
+		},
+		{
+			name:    "invalid limit and reset",
+			headers: map[string]string{"X-RateLimit-Limit": "many", "X-RateLimit-Remaining": "7", "X-RateLimit-Reset": "soon"},
+			want:    RateLimit{Remaining: 7},
+			wantOK:  true,
+		},
+		{name: "no headers", headers: nil},
+		{name: "invalid remaining", headers: map[string]string{"X-RateLimit-Limit": "5000", "X-RateLimit-Remaining": "n/a"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := http.Header{}
+			for k, v := range tt.headers {
+				h.Set(k, v)
+			}
+
+			got, ok := ParseRateLimit(h)
+			if ok != tt.wantOK {
+				t.Fatalf("ParseRateLimit ok = %t, want %t", ok, tt.wantOK)
+			}
+			if got.Limit != tt.want.Limit || got.Remaining != tt.want.Remaining || !got.Reset.Equal(tt.want.Reset) {
+				t.Errorf("ParseRateLimit = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRateLimitLow(t *testing.T) {
+	tests := []struct {
+		remaining int
+		want      bool
+	}{
+		{0, true},
+		{LowRateLimit - 1, true},
+		{LowRateLimit, false},
+		{4990, false},
+	}
+
+	for _, tt := range tests {
+		if got := (RateLimit{Remaining: tt.remaining}).Low(); got != tt.want {
+			t.Errorf("Low() with %d remaining = %t, want %t", tt.remaining, got, tt.want)
+		}
+	}
+}
+
+func TestRateLimitString(t *testing.T) {
+	reset := time.Date(2026, 10, 15, 14, 30, 5, 0, time.Local)
+
+	tests := []struct {
+		rl   RateLimit
+		want string
+	}{
+		{RateLimit{Limit: 5000, Remaining: 4990, Reset: reset}, "4990/5000 requests left, resets at 14:30:05"},
+		{RateLimit{Limit: 60, Remaining: 3}, "3/60 requests left"},
+		{RateLimit{Remaining: 3, Reset: reset}, "3 requests left, resets at 14:30:05"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.rl.String(); got != tt.want {
+			t.Errorf("String() = %q, want %q", got, tt.want)
+		}
+	}
+}
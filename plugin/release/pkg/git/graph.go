@@ -0,0 +1,159 @@
+package git
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/nekoman-hq/neko-cli/pkg/log"
+)
+
+// CommitGraph is the commit history of all tags, loaded with a single git log.
+// Commit counts between tags are computed in memory instead of spawning
+// git per tag pair, results are cached for the lifetime of the graph.
+type CommitGraph struct {
+	index   map[string]int
+	parents [][]int
+	tags    map[string]string
+
+	ranges map[string][]int
+	reach  map[int][]bool
+}
+
+// LoadCommitGraph reads the history of all tags with one git log and resolves the tag commits
+func LoadCommitGraph() (*CommitGraph, error) {
+	tags, err := LocalTagCommits()
+	if err != nil {
+		return nil, err
+	}
+
+	args := []string{"log", "--tags", "--format=%H %P"}
+	log.PluginV(log.Exec, "Loading commit graph of all tags: %s",
+		log.ColorText(log.ColorGreen, "git "+strings.Join(args, " ")))
+
+	out, err := exec.Command("git", args...).CombinedOutput()
+	if err != nil {
+		return nil, fmt.Errorf("git log --tags failed: %s", strings.TrimSpace(text(out)))
+	}
+
+	graph := parseCommitGraph(text(out))
+	graph.tags = tags
+	return graph, nil
+}
+
+// parseCommitGraph parses "<hash> <parents...>" lines
+func parseCommitGraph(output string) *CommitGraph {
+	graph := &CommitGraph{
+		index:  make(map[string]int),
+		ranges: make(map[string][]int),
+		reach:  make(map[int][]bool),
+	}
+
+	hashes := make([][]string, 0)
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		graph.index[fields[0]] = len(hashes)
+		hashes = append(hashes, fields[1:])
+	}
+
+	// Parents missing from the output, e.g. beyond a shallow clone boundary, are dropped like git does
+	graph.parents = make([][]int, len(hashes))
+	for i, parents := range hashes {
+		for _, parent := range parents {
+			if p, ok := graph.index[parent]; ok {
+				graph.parents[i] = append(graph.parents[i], p)
+			}
+		}
+	}
+	return graph
+}
+
+// CountBetween returns the number of commits between two tags, like git rev-list --count from..to.
+// An empty from counts the whole history up to to.
+func (g *CommitGraph) CountBetween(from, to string) (int, error) {
+	commits, err := g.between(from, to)
+	if err != nil {
+		return 0, err
+	}
+	return len(commits), nil
+}
+
+// between returns the commits reachable from to but not from from
+func (g *CommitGraph) between(from, to string) ([]int, error) {
+	key := from + ".." + to
+	if commits, ok := g.ranges[key]; ok {
+		return commits, nil
+	}
+
+	tip, err := g.resolve(to)
+	if err != nil {
+		return nil, err
+	}
+	included := g.reachable(tip)
+
+	base := -1
+	var excluded []bool
+	if from != "" {
+		if base, err = g.resolve(from); err != nil {
+			return nil, err
+		}
+		excluded = g.reachable(base)
+	}
+
+	commits := make([]int, 0)
+	for c, ok := range included {
+		if ok && (excluded == nil || !excluded[c]) {
+			commits = append(commits, c)
+		}
+	}
+
+	// History walks from one tag to the next, only the sets of the current range are kept
+	for c := range g.reach {
+		if c != tip && c != base {
+			delete(g.reach, c)
+		}
+	}
+
+	g.ranges[key] = commits
+	return commits, nil
+}
+
+// resolve returns the graph index of the commit the tag points at
+func (g *CommitGraph) resolve(tag string) (int, error) {
+	hash, ok := g.tags[tag]
+	if !ok {
+		return 0, fmt.Errorf("tag %s not found", tag)
+	}
+	c, ok := g.index[hash]
+	if !ok {
+		return 0, fmt.Errorf("tag %s does not point at a commit in the history", tag)
+	}
+	return c, nil
+}
+
+// reachable marks all commits reachable from the tip, including the tip itself
+func (g *CommitGraph) reachable(tip int) []bool {
+	if seen, ok := g.reach[tip]; ok {
+		return seen
+	}
+
+	seen := make([]bool, len(g.parents))
+	stack := []int{tip}
+	seen[tip] = true
+	for len(stack) > 0 {
+		c := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for _, p := range g.parents[c] {
+			if !seen[p] {
+				seen[p] = true
+				stack = append(stack, p)
+			}
+		}
+	}
+
+	g.reach[tip] = seen
+	return seen
+}
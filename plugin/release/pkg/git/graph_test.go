@@ -0,0 +1,168 @@
+package git
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/nekoman-hq/neko-cli/plugin/release/internal/gittest"
+)
+
+// mergeHistory is git log --format="%H %P" output of
+//
+//	a - b ------ d - e
+//	     \      /
+//	      - c -
+//
+// with c branching off a
+const mergeHistory = `e d
+d b c
+c a
+b a
+a
+`
+
+func mergeGraph() *CommitGraph {
+	graph := parseCommitGraph(mergeHistory)
+	graph.tags = map[string]string{"v1": "b", "side": "c", "v2": "e"}
+	return graph
+}
+
+func TestCommitGraphCountBetween(t *testing.T) {
+	tests := []struct {
+		from, to string
+		want     int
+	}{
+		{"", "v1", 2},
+		{"", "v2", 5},
+		{"v1", "v2", 3}, // e, d and the merged c
+		{"side", "v2", 3},
+		{"v2", "v1", 0},
+		{"v2", "v2", 0},
+	}
+
+	graph := mergeGraph()
+	for _, tt := range tests {
+		got, err := graph.CountBetween(tt.from, tt.to)
+		if err != nil {
+			t.Fatalf("CountBetween(%q, %q): %v", tt.from, tt.to, err)
+		}
+		if got != tt.want {
+			t.Errorf("CountBetween(%q, %q) = %d, want %d", tt.from, tt.to, got, tt.want)
+		}
+	}
+}
+
+func TestCommitGraphCachesRanges(t *testing.T) {
+	graph := mergeGraph()
+	if _, err := graph.CountBetween("v1", "v2"); err != nil {
+		t.Fatal(err)
+	}
+
+	// A cached range is answered without walking the graph again
+	graph.parents = nil
+	got, err := graph.CountBetween("v1", "v2")
+	if err != nil || got != 3 {
+		t.Errorf("cached CountBetween = %d, %v, want 3", got, err)
+	}
+}
+
+func TestCommitGraphShallowBoundary(t *testing.T) {
+	// b's parent was cut off by a shallow clone and is not in the output
+	graph := parseCommitGraph("c b\nb a\n")
+	graph.tags = map[string]string{"v1": "c"}
+
+	got, err := graph.CountBetween("", "v1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != 2 {
+		t.Errorf("CountBetween = %d, want 2", got)
+	}
+}
+
+func TestCommitGraphUnknownTag(t *testing.T) {
+	graph := mergeGraph()
+	graph.tags["outside"] = "f"
+
+	for _, tag := range []string{"missing", "outside"} {
+		if _, err := graph.CountBetween("", tag); err == nil {
+			t.Errorf("CountBetween(%q) succeeded, want an error so the caller falls back to git", tag)
+		}
+	}
+}
+
+// The counts of a shallow clone must match git rev-list --count, which also stops at the boundary
+func TestLoadCommitGraphMatchesRevList(t *testing.T) {
+	gittest.Identity(t)
+	origin := t.TempDir()
+	gittest.RunIn(t, origin, "init", "-q")
+	for i := 1; i <= 10; i++ {
+		gittest.RunIn(t, origin, "commit", "-q", "--allow-empty", "-m", fmt.Sprintf("commit %d", i))
+		if i%3 == 0 || i == 10 {
+			gittest.RunIn(t, origin, "tag", fmt.Sprintf("v0.%d.0", i))
+		}
+	}
+
+	clone := t.TempDir()
+	gittest.RunIn(t, clone, "clone", "-q", "--depth", "5", "file://"+origin, ".")
+	t.Chdir(clone)
+
+	graph, err := LoadCommitGraph()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The clone only follows the tags inside its 5 commits, v0.3.0 is beyond the boundary
+	tags := GetTags()
+	if len(tags) != 3 {
+		t.Fatalf("shallow clone has tags %v, want v0.6.0, v0.9.0 and v0.10.0", tags)
+	}
+	for i, tag := range tags {
+		from := ""
+		if i > 0 {
+			from = tags[i-1]
+		}
+		got, err := graph.CountBetween(from, tag)
+		if err != nil {
+			t.Fatalf("CountBetween(%q, %q): %v", from, tag, err)
+		}
+		if want := CountCommitsBetween(from, tag); got != want {
+			t.Errorf("CountBetween(%q, %q) = %d, git rev-list --count = %d", from, tag, got, want)
+		}
+	}
+}
+
+// BenchmarkCommitGraphHistory counts the ranges between 200 tags of a linear history with 10000 commits
+func BenchmarkCommitGraphHistory(b *testing.B) {
+	const commits, every = 10000, 50
+
+	var out strings.Builder
+	tags := make(map[string]string)
+	names := make([]string, 0, commits/every)
+	for i := commits; i > 0; i-- {
+		if i > 1 {
+			fmt.Fprintf(&out, "c%d c%d\n", i, i-1)
+		} else {
+			fmt.Fprintf(&out, "c%d\n", i)
+		}
+	}
+	for i := every; i <= commits; i += every {
+		name := fmt.Sprintf("v%d", i)
+		tags[name] = fmt.Sprintf("c%d", i)
+		names = append(names, name)
+	}
+	output := out.String()
+
+	for b.Loop() {
+		graph := parseCommitGraph(output)
+		graph.tags = tags
+		from := ""
+		for _, tag := range names {
+			if _, err := graph.CountBetween(from, tag); err != nil {
+				b.Fatal(err)
+			}
+			from = tag
+		}
+	}
+}
@@ -0,0 +1,30 @@
+package git
+
+import (
+	stderrors "errors"
+	"fmt"
+	"os/exec"
+	"sync"
+)
+
+// ErrNotInstalled is returned by EnsureInstalled if git cannot be found on PATH
+var ErrNotInstalled = stderrors.New("git is not installed or not on PATH")
+
+// lookPath resolves the git binary, replaceable to simulate a missing git
+var lookPath = exec.LookPath
+
+var (
+	installedOnce sync.Once
+	installedErr  error
+)
+
+// EnsureInstalled checks that git is on PATH before any git helper runs.
+// The lookup happens once, later calls return the cached result.
+func EnsureInstalled() error {
+	installedOnce.Do(func() {
+		if _, err := lookPath("git"); err != nil {
+			installedErr = fmt.Errorf("%w, install it from https://git-scm.com/downloads", ErrNotInstalled)
+		}
+	})
+	return installedErr
+}
@@ -0,0 +1,65 @@
+package git
+
+import (
+	"errors"
+	"os/exec"
+	"sync"
+	"testing"
+)
+
+// resetInstalled drops the cached lookup, so the next EnsureInstalled checks again
+func resetInstalled(t *testing.T) {
+	t.Helper()
+	installedOnce, installedErr = sync.Once{}, nil
+	t.Cleanup(func() {
+		installedOnce, installedErr = sync.Once{}, nil
+		lookPath = exec.LookPath
+	})
+}
+
+func TestEnsureInstalled(t *testing.T) {
+	tests := []struct {
+		name    string
+		lookErr error
+		wantErr bool
+	}{
+		{"installed", nil, false},
+		{"missing", exec.ErrNotFound, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetInstalled(t)
+			lookups := 0
+			lookPath = func(file string) (string, error) {
+				lookups++
+				if file != "git" {
+					t.Errorf("looked up %q, want git", file)
+				}
+				return "/usr/bin/git", tt.lookErr
+			}
+
+			for range 3 {
+				err := EnsureInstalled()
+				if (err != nil) != tt.wantErr {
+					t.Fatalf("EnsureInstalled = %v, want error %t", err, tt.wantErr)
+				}
+				if tt.wantErr && !errors.Is(err, ErrNotInstalled) {
+					t.Errorf("EnsureInstalled = %v, want ErrNotInstalled", err)
+				}
+			}
+			if lookups != 1 {
+				t.Errorf("git was looked up %d times, want the result cached", lookups)
+			}
+		})
+	}
+}
+
+func TestEnsureInstalledEmptyPath(t *testing.T) {
+	resetInstalled(t)
+	t.Setenv("PATH", t.TempDir())
+
+	if err := EnsureInstalled(); !errors.Is(err, ErrNotInstalled) {
+		t.Errorf("EnsureInstalled without git on PATH = %v, want ErrNotInstalled", err)
+	}
+}
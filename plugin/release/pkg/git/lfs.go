@@ -0,0 +1,65 @@
+package git
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/nekoman-hq/neko-cli/pkg/log"
+)
+
+// LFSPatterns returns the path patterns tracked by git-lfs in the .gitattributes files of the repository
+func LFSPatterns() ([]string, error) {
+	log.PluginV(log.Preflight, fmt.Sprintf("Looking for LFS filters: %s",
+		log.ColorText(log.ColorGreen, "git ls-files -- ':(glob)**/.gitattributes'")))
+
+	out, err := exec.Command("git", "ls-files", "--", ":(glob)**/.gitattributes").CombinedOutput()
+	if err != nil {
+		return nil, fmt.Errorf("git ls-files failed: %s", strings.TrimSpace(text(out)))
+	}
+
+	patterns := make([]string, 0)
+	for _, file := range strings.Fields(string(out)) {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", file, err)
+		}
+		patterns = append(patterns, ParseLFSPatterns(string(data))...)
+	}
+	return patterns, nil
+}
+
+// ParseLFSPatterns returns the patterns of .gitattributes lines using the lfs filter, e.g. "*.bin filter=lfs diff=lfs"
+func ParseLFSPatterns(gitattributes string) []string {
+	patterns := make([]string, 0)
+	for _, line := range strings.Split(gitattributes, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		for _, attr := range fields[1:] {
+			if attr == "filter=lfs" {
+				patterns = append(patterns, fields[0])
+				break
+			}
+		}
+	}
+	return patterns
+}
+
+// CheckLFS verifies that git-lfs is installed and its filters are configured ('git lfs install')
+func CheckLFS() error {
+	if _, err := lookPath("git-lfs"); err != nil {
+		return fmt.Errorf("git-lfs is not installed, LFS files would be released as pointer files; install it from https://git-lfs.com")
+	}
+
+	log.PluginV(log.Preflight, fmt.Sprintf("Checking LFS filters: %s",
+		log.ColorText(log.ColorGreen, "git config --get filter.lfs.process")))
+
+	out, err := exec.Command("git", "config", "--get", "filter.lfs.process").Output()
+	if err != nil || strings.TrimSpace(string(out)) == "" {
+		return fmt.Errorf("git-lfs is not initialized, run 'git lfs install' and 'git lfs pull'")
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package git
+
+import (
+	"os/exec"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/nekoman-hq/neko-cli/plugin/release/internal/gittest"
+)
+
+func TestParseLFSPatterns(t *testing.T) {
+	tests := []struct {
+		name          string
+		gitattributes string
+		want          []string
+	}{
+		{"empty", "", []string{}},
+		{"lfs patterns", "*.bin filter=lfs diff=lfs merge=lfs -text\n*.go text\nassets/** filter=lfs\n", []string{"*.bin", "assets/**"}},
+		{"comment", "# *.bin filter=lfs\n", []string{}},
+		{"other filter", "*.secret filter=git-crypt\n", []string{}},
+		{"pattern only", "*.bin\n", []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ParseLFSPatterns(tt.gitattributes); !slices.Equal(got, tt.want) {
+				t.Errorf("ParseLFSPatterns(%q) = %q, want %q", tt.gitattributes, got, tt.want)
+			}
+		})
+	}
+}
+
+// Only tracked .gitattributes files count, in any directory
+func TestLFSPatterns(t *testing.T) {
+	gittest.NewRepo(t)
+	gittest.WriteFile(t, ".gitattributes", "*.bin filter=lfs diff=lfs merge=lfs -text\n")
+	gittest.WriteFile(t, "assets/.gitattributes", "*.png filter=lfs\n")
+	gittest.Run(t, "add", ".gitattributes", "assets/.gitattributes")
+	gittest.Run(t, "commit", "-q", "-m", "chore: lfs")
+	gittest.WriteFile(t, "tmp/.gitattributes", "*.tmp filter=lfs\n")
+
+	patterns, err := LFSPatterns()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"*.bin", "*.png"}; !slices.Equal(patterns, want) {
+		t.Errorf("LFSPatterns() = %q, want %q", patterns, want)
+	}
+}
+
+func TestCheckLFS(t *testing.T) {
+	tests := []struct {
+		name      string
+		installed bool
+		process   string
+		wantErr   string
+	}{
+		{"ready", true, "git-lfs filter-process", ""},
+		{"not installed", false, "git-lfs filter-process", "not installed"},
+		{"not initialized", true, "", "git lfs install"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetInstalled(t)
+			gittest.NewRepo(t)
+			t.Setenv("GIT_CONFIG_NOSYSTEM", "1")
+			if tt.process != "" {
+				gittest.Run(t, "config", "filter.lfs.process", tt.process)
+			}
+			lookPath = func(file string) (string, error) {
+				if file == "git-lfs" && !tt.installed {
+					return "", exec.ErrNotFound
+				}
+				return "/usr/bin/" + file, nil
+			}
+
+			err := CheckLFS()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("CheckLFS = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("CheckLFS = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,11 @@
+package git
+
+import "strings"
+
+// text converts git output to a string, replacing invalid UTF-8 sequences.
+// Commit messages and author names may contain arbitrary bytes, which would
+// otherwise break JSON encoding and the renderer's width calculation.
+// Use only for displayed output, never for refs or paths passed back to git.
+func text(output []byte) string {
+	return strings.ToValidUTF8(string(output), "\uFFFD")
+}
@@ -0,0 +1,32 @@
+package git
+
+import (
+	"testing"
+	"unicode/utf8"
+)
+
+func TestText(t *testing.T) {
+	tests := []struct {
+		name   string
+		output []byte
+		want   string
+	}{
+		{"ascii", []byte("feat: add login\n"), "feat: add login\n"},
+		{"utf-8", []byte("fix: M\xc3\xbcller\n"), "fix: M\u00fcller\n"},
+		{"latin-1", []byte("fix: M\xfcller\n"), "fix: M\uFFFDller\n"},
+		{"truncated sequence", []byte("feat: \xe2\x9c"), "feat: \uFFFD"},
+		{"empty", nil, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := text(tt.output)
+			if got != tt.want {
+				t.Errorf("text(%q) = %q, want %q", tt.output, got, tt.want)
+			}
+			if !utf8.ValidString(got) {
+				t.Errorf("text(%q) = %q is not valid UTF-8", tt.output, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strings"
+)
+
+// PushRejectedError is returned if the remote declined a push, e.g. by a pre-receive hook
+type PushRejectedError struct {
+	Ref    string
+	Reason string
+}
+
+func (e *PushRejectedError) Error() string {
+	return fmt.Sprintf("push of %s rejected by remote: %s", e.Ref, e.Reason)
+}
+
+// rejectedLine matches "! [remote rejected] HEAD -> main (pre-receive hook declined)"
+var rejectedLine = regexp.MustCompile(`!\s+\[remote rejected]\s+\S+\s+->\s+\S+\s+\((.+)\)`)
+
+// ParsePushRejection extracts the rejection reason from the output of git push.
+// The messages printed by the hook ("remote: ...") are included since they usually explain the rejection.
+// Returns false if the push did not fail because the remote rejected it.
+func ParsePushRejection(output string) (string, bool) {
+	var hookMessages []string
+	status := ""
+
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		if msg, found := strings.CutPrefix(line, "remote:"); found {
+			if msg = strings.TrimSpace(msg); msg != "" {
+				hookMessages = append(hookMessages, msg)
+			}
+			continue
+		}
+		if m := rejectedLine.FindStringSubmatch(line); m != nil {
+			status = m[1]
+		}
+	}
+
+	if status == "" {
+		return "", false
+	}
+	if len(hookMessages) == 0 {
+		return status, true
+	}
+	return fmt.Sprintf("%s: %s", status, strings.Join(hookMessages, "\n")), true
+}
+
+// pushError turns a failed git push into a PushRejectedError if the remote declined it
+func pushError(ref, output string, err error) error {
+	if reason, ok := ParsePushRejection(output); ok {
+		return &PushRejectedError{Ref: ref, Reason: reason}
+	}
+	return fmt.Errorf("git push origin %s failed: %s: %w", ref, strings.TrimSpace(output), err)
+}
+
+// Push pushes the given ref to origin.
+// Returns a PushRejectedError if a hook on the remote declined the push.
+func Push(ref string) error {
+	return PushContext(context.Background(), ref)
+}
+
+// PushContext is Push, killing git if the context is cancelled
+func PushContext(ctx context.Context, ref string) error {
+	if SkipInDryRun("git", "push", "origin", ref) {
+		return nil
+	}
+	out, err := exec.CommandContext(ctx, "git", "push", "origin", ref).CombinedOutput()
+	if err != nil {
+		return pushError(ref, text(out), err)
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package git
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/nekoman-hq/neko-cli/plugin/release/internal/gittest"
+)
+
+func TestParsePushRejection(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		want   string
+		ok     bool
+	}{
+		{
+			name: "pre-receive hook",
+			output: "remote: commit-lint: subject must not end with a period\n" +
+				"remote: \n" +
+				"To github.com:nekoman-hq/app.git\n" +
+				" ! [remote rejected] HEAD -> main (pre-receive hook declined)\n" +
+				"error: failed to push some refs to 'github.com:nekoman-hq/app.git'\n",
+			want: "pre-receive hook declined: commit-lint: subject must not end with a period",
+			ok:   true,
+		},
+		{
+			name:   "protected branch without hook output",
+			output: " ! [remote rejected] v1.2.0 -> v1.2.0 (protected tag)\n",
+			want:   "protected tag",
+			ok:     true,
+		},
+		{
+			name:   "non fast-forward",
+			output: " ! [rejected]        HEAD -> main (fetch first)\nerror: failed to push some refs\n",
+		},
+		{
+			name:   "network error",
+			output: "fatal: unable to access 'https://github.com/nekoman-hq/app.git/': Could not resolve host\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := ParsePushRejection(tt.output)
+			if got != tt.want || ok != tt.ok {
+				t.Errorf("ParsePushRejection = %q, %t, want %q, %t", got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
+
+func TestPushRejectedByHook(t *testing.T) {
+	gittest.NewRepo(t)
+	remote := gittest.NewRemote(t)
+	gittest.Commit(t, "feat: initial")
+	gittest.RejectPushes(t, remote, "signed commits required")
+
+	err := Push("HEAD")
+	var rejected *PushRejectedError
+	if !errors.As(err, &rejected) {
+		t.Fatalf("Push = %v, want a PushRejectedError", err)
+	}
+	if rejected.Ref != "HEAD" || rejected.Reason != "pre-receive hook declined: signed commits required" {
+		t.Errorf("rejection = %+v, want the hook's message", rejected)
+	}
+}
+
+// A push failing for another reason keeps the generic error
+func TestPushFailureNotRejected(t *testing.T) {
+	gittest.NewRepo(t)
+	gittest.Commit(t, "feat: initial")
+	gittest.Run(t, "remote", "add", "origin", t.TempDir()+"/missing.git")
+
+	err := Push("HEAD")
+	var rejected *PushRejectedError
+	if err == nil || errors.As(err, &rejected) {
+		t.Errorf("Push to a missing remote = %v, want a generic error", err)
+	}
+}
@@ -0,0 +1,22 @@
+package git
+
+import (
+	"net/http"
+
+	"github.com/nekoman-hq/neko-cli/pkg/log"
+	"github.com/nekoman-hq/neko-cli/plugin/release/pkg/git/github"
+)
+
+// logRateLimit reports the GitHub API rate limit of the response and warns when it runs low
+func logRateLimit(resp *http.Response) {
+	rl, ok := github.ParseRateLimit(resp.Header)
+	if !ok {
+		return
+	}
+
+	if rl.Low() {
+		log.PluginPrint(log.Exec, "\u26A0 GitHub API rate limit is running low: %s", rl)
+		return
+	}
+	log.PluginV(log.Exec, "GitHub API rate limit: %s", rl)
+}
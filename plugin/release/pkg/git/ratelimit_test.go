@@ -0,0 +1,70 @@
+package git
+
+import (
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/nekoman-hq/neko-cli/pkg/log"
+)
+
+// captureStderr returns what fn logs to stderr
+func captureStderr(t *testing.T, fn func()) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stderr := os.Stderr
+	os.Stderr = w
+	fn()
+	os.Stderr = stderr
+	_ = w.Close()
+
+	out, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(out)
+}
+
+func TestLogRateLimit(t *testing.T) {
+	tests := []struct {
+		name      string
+		remaining string
+		verbose   bool
+		want      string
+	}{
+		{"low", "3", false, "rate limit is running low: 3/5000 requests left"},
+		{"plenty quiet", "4990", false, ""},
+		{"plenty verbose", "4990", true, "GitHub API rate limit: 4990/5000 requests left"},
+		{"no rate limit", "", true, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			verbose := log.Verbose
+			log.Verbose = tt.verbose
+			t.Cleanup(func() { log.Verbose = verbose })
+
+			resp := &http.Response{Header: http.Header{}}
+			if tt.remaining != "" {
+				resp.Header.Set("X-RateLimit-Limit", "5000")
+				resp.Header.Set("X-RateLimit-Remaining", tt.remaining)
+			}
+
+			out := captureStderr(t, func() { logRateLimit(resp) })
+			if tt.want == "" {
+				if out != "" {
+					t.Errorf("logged %q, want nothing", out)
+				}
+				return
+			}
+			if !strings.Contains(out, tt.want) {
+				t.Errorf("logged %q, want %q", out, tt.want)
+			}
+		})
+	}
+}
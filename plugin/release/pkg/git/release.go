@@ -8,7 +8,9 @@ package git
 */
 
 import (
+	"bytes"
 	"encoding/json"
+	stderrors "errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -18,6 +20,9 @@ import (
 	"github.com/nekoman-hq/neko-cli/plugin/release/pkg/git/github"
 )
 
+// ErrReleaseNotFound is returned if GitHub has no release for the requested tag or id
+var ErrReleaseNotFound = stderrors.New("release not found")
+
 func LatestRelease(repoInfo *RepoInfo) (*github.Release, error) {
 	token, err := config.GetPAT()
 	if err != nil {
@@ -54,6 +59,7 @@ func LatestRelease(repoInfo *RepoInfo) (*github.Release, error) {
 			return
 		}
 	}(resp.Body)
+	logRateLimit(resp)
 
 	if resp.StatusCode == 404 {
 		return nil, fmt.Errorf("repository %s/%s has no releases yet", repoInfo.Owner, repoInfo.Repo)
@@ -83,3 +89,96 @@ func LatestRelease(repoInfo *RepoInfo) (*github.Release, error) {
 	log.PluginV(log.Exec, "\uF00C Successfully received release information from remote!")
 	return &release, nil
 }
+
+// ReleaseByTag fetches the GitHub release of the given tag
+func ReleaseByTag(repoInfo *RepoInfo, tag, token string) (*github.Release, error) {
+	url := fmt.Sprintf("https://api.github.com/repos/%s/%s/releases/tags/%s", repoInfo.Owner, repoInfo.Repo, tag)
+
+	log.PluginV(log.Exec, fmt.Sprintf("Fetching release by tag: %s",
+		log.ColorText(log.ColorGreen, url),
+	))
+
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"request Creation Failed: %w", err,
+		)
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("User-Agent", "neko-cli")
+
+	return doReleaseRequest(req, http.StatusOK)
+}
+
+// NewUpdateReleaseRequest builds the PATCH request that updates a release
+func NewUpdateReleaseRequest(repoInfo *RepoInfo, id int64, update github.ReleaseUpdate, token string) (*http.Request, error) {
+	payload, err := json.Marshal(update)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode release update: %w", err)
+	}
+
+	url := fmt.Sprintf("https://api.github.com/repos/%s/%s/releases/%d", repoInfo.Owner, repoInfo.Repo, id)
+	req, err := http.NewRequest("PATCH", url, bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf(
+			"request Creation Failed: %w", err,
+		)
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "neko-cli")
+	return req, nil
+}
+
+// UpdateRelease changes the title, notes or prerelease flag of an existing release.
+// The tag and its artifacts are left untouched.
+func UpdateRelease(repoInfo *RepoInfo, id int64, update github.ReleaseUpdate, token string) (*github.Release, error) {
+	req, err := NewUpdateReleaseRequest(repoInfo, id, update, token)
+	if err != nil {
+		return nil, err
+	}
+
+	log.PluginV(log.Exec, fmt.Sprintf("Updating release: %s",
+		log.ColorText(log.ColorGreen, "PATCH "+req.URL.String()),
+	))
+
+	return doReleaseRequest(req, http.StatusOK)
+}
+
+// doReleaseRequest sends a release API request and decodes the returned release
+func doReleaseRequest(req *http.Request, expected int) (*github.Release, error) {
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"API Request Failed: %w", err,
+		)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	logRateLimit(resp)
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"response Read Failed: %w", err,
+		)
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%w: %s", ErrReleaseNotFound, req.URL.String())
+	}
+	if resp.StatusCode != expected {
+		return nil, fmt.Errorf(
+			"GitHub API returned status %d: %s", resp.StatusCode, string(body),
+		)
+	}
+
+	var release github.Release
+	if err := json.Unmarshal(body, &release); err != nil {
+		return nil, fmt.Errorf(
+			"JSON Parse Failed: %w", err,
+		)
+	}
+	return &release, nil
+}
@@ -0,0 +1,112 @@
+package git
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/nekoman-hq/neko-cli/plugin/release/pkg/git/github"
+)
+
+func TestNewUpdateReleaseRequest(t *testing.T) {
+	title := "v1.2.0 - Faster history"
+	notes := ""
+	prerelease := false
+
+	tests := []struct {
+		name   string
+		update github.ReleaseUpdate
+		want   map[string]any
+	}{
+		{"title", github.ReleaseUpdate{Name: &title}, map[string]any{"name": title}},
+		// Clearing the notes or the prerelease flag must still be sent
+		{"empty notes", github.ReleaseUpdate{Body: &notes}, map[string]any{"body": ""}},
+		{"stable", github.ReleaseUpdate{PreRelease: &prerelease}, map[string]any{"prerelease": false}},
+		{"all", github.ReleaseUpdate{Name: &title, Body: &notes, PreRelease: &prerelease},
+			map[string]any{"name": title, "body": "", "prerelease": false}},
+	}
+
+	repo := &RepoInfo{Owner: "nekoman-hq", Repo: "neko-cli"}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := NewUpdateReleaseRequest(repo, 42, tt.update, "test-token")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if req.Method != http.MethodPatch || req.URL.String() != "https://api.github.com/repos/nekoman-hq/neko-cli/releases/42" {
+				t.Errorf("request = %s %s", req.Method, req.URL)
+			}
+			if got := req.Header.Get("Authorization"); got != "Bearer test-token" {
+				t.Errorf("Authorization = %q", got)
+			}
+			if got := req.Header.Get("Content-Type"); got != "application/json" {
+				t.Errorf("Content-Type = %q", got)
+			}
+
+			var body map[string]any
+			if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+				t.Fatal(err)
+			}
+			gotJSON, _ := json.Marshal(body)
+			wantJSON, _ := json.Marshal(tt.want)
+			if !bytes.Equal(gotJSON, wantJSON) {
+				t.Errorf("body = %s, want %s", gotJSON, wantJSON)
+			}
+		})
+	}
+}
+
+// statusTransport answers every request with the status and body
+type statusTransport struct {
+	status int
+	body   string
+}
+
+func (st statusTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return &http.Response{
+		StatusCode: st.status,
+		Body:       io.NopCloser(bytes.NewReader([]byte(st.body))),
+		Request:    req,
+	}, nil
+}
+
+func TestUpdateRelease(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		body     string
+		wantName string
+		wantErr  error
+	}{
+		{"updated", http.StatusOK, `{"id": 42, "name": "v1.2.0 - Faster history", "tag_name": "v1.2.0"}`, "v1.2.0 - Faster history", nil},
+		{"not found", http.StatusNotFound, `{"message": "Not Found"}`, "", ErrReleaseNotFound},
+		{"forbidden", http.StatusForbidden, `{"message": "Resource not accessible"}`, "", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			previous := http.DefaultClient.Transport
+			http.DefaultClient.Transport = statusTransport{tt.status, tt.body}
+			t.Cleanup(func() { http.DefaultClient.Transport = previous })
+
+			title := "v1.2.0 - Faster history"
+			release, err := UpdateRelease(&RepoInfo{Owner: "nekoman-hq", Repo: "neko-cli"}, 42, github.ReleaseUpdate{Name: &title}, "test-token")
+			if tt.status == http.StatusOK {
+				if err != nil || release.Name != tt.wantName {
+					t.Errorf("UpdateRelease = %+v, %v, want the updated release", release, err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("UpdateRelease with status %d succeeded", tt.status)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -38,30 +38,65 @@ func Fetch() {
 	_ = exec.Command("git", "fetch").Run()
 }
 
+// FetchTags fetches all tags from origin, overwriting local tags that were force-moved on the remote
+func FetchTags() error {
+	log.PluginV(log.Guard, fmt.Sprintf("%s (Updating tags)",
+		log.ColorText(log.ColorGreen, "git fetch --tags --force origin"),
+	))
+
+	cmd := exec.Command("git", "fetch", "--tags", "--force", "origin")
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("git fetch --tags failed: %s", strings.TrimSpace(text(output)))
+	}
+	return nil
+}
+
 // Current checks if a git repository exists and returns owner and repo name
 func Current() (*RepoInfo, error) {
+	url, err := remoteURL()
+	if err != nil {
+		return nil, err
+	}
+	return parseRemote(url)
+}
+
+// remoteURL returns the push URL of origin. Without origin the first configured remote is used.
+func remoteURL() (string, error) {
 	log.PluginV(log.Config, fmt.Sprintf("%s (Checking Repository Origin)",
-		log.ColorText(log.ColorGreen, "git remote -v"),
+		log.ColorText(log.ColorGreen, "git remote get-url --push origin"),
 	))
 
-	cmd := exec.Command("git", "remote", "-v")
-	output, err := cmd.CombinedOutput()
+	if out, err := exec.Command("git", "remote", "get-url", "--push", "origin").Output(); err == nil {
+		return strings.TrimSpace(string(out)), nil
+	}
+
+	output, err := exec.Command("git", "remote").CombinedOutput()
 	if err != nil {
-		return nil, fmt.Errorf(
+		return "", fmt.Errorf(
 			"not a Git Repository: %w", err,
 		)
 	}
 
-	outputStr := string(output)
-	if strings.TrimSpace(outputStr) == "" {
-		return nil, errors.New(
+	remotes := strings.Fields(string(output))
+	if len(remotes) == 0 {
+		return "", errors.New(
 			"no Remote Found: This git repository has no remote configured.\nAdd a remote with: git remote add origin <url>",
 		)
 	}
-	return parseRemote(outputStr)
+
+	log.PluginV(log.Config, fmt.Sprintf("No origin remote, using %s: %s",
+		log.ColorText(log.ColorCyan, remotes[0]),
+		log.ColorText(log.ColorGreen, "git remote get-url --push "+remotes[0]),
+	))
+	out, err := exec.Command("git", "remote", "get-url", "--push", remotes[0]).CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("git remote get-url %s failed: %s", remotes[0], strings.TrimSpace(text(out)))
+	}
+	return strings.TrimSpace(string(out)), nil
 }
 
-// parseRemote extracts owner and repo from git remote output
+// parseRemote extracts owner and repo from a remote URL, e.g. the output of git remote get-url
 func parseRemote(remoteOutput string) (*RepoInfo, error) {
 	// Regex patterns for both SSH and HTTPS URLs
 	// SSH: git@git.com:owner/repo.git
@@ -114,6 +149,73 @@ func IsClean() error {
 	return nil
 }
 
+// ChangedFiles returns all modified and untracked files of the working tree
+func ChangedFiles() ([]string, error) {
+	log.PluginV(log.Exec, fmt.Sprintf("%s (List changed files)",
+		log.ColorText(log.ColorGreen, "git status --porcelain --untracked-files=all"),
+	))
+	cmd := exec.Command("git", "status", "--porcelain", "--untracked-files=all")
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return nil, fmt.Errorf("unable to check git status: %w", err)
+	}
+
+	return parsePorcelain(string(output)), nil
+}
+
+// parsePorcelain extracts file paths from git status --porcelain output
+func parsePorcelain(output string) []string {
+	var files []string
+	for _, line := range strings.Split(output, "\n") {
+		if len(line) < 4 {
+			continue
+		}
+		path := line[3:]
+		// Renames are reported as "orig -> path"
+		if idx := strings.Index(path, " -> "); idx >= 0 {
+			path = path[idx+4:]
+		}
+		files = append(files, strings.Trim(path, "\""))
+	}
+	return files
+}
+
+// Stage adds the given files to the index
+func Stage(files []string) error {
+	log.PluginV(log.Exec, fmt.Sprintf("Staging files: %s",
+		log.ColorText(log.ColorGreen, fmt.Sprintf("git add -- %s", strings.Join(files, " ")))))
+
+	args := append([]string{"add", "--"}, files...)
+	if SkipInDryRun(append([]string{"git"}, args...)...) {
+		return nil
+	}
+	if out, err := exec.Command("git", args...).CombinedOutput(); err != nil {
+		return fmt.Errorf("git add failed: %s", strings.TrimSpace(text(out)))
+	}
+	return nil
+}
+
+// CommitFiles stages the given files and commits them with the given message
+func CommitFiles(message string, files []string) error {
+	log.PluginV(log.Exec, fmt.Sprintf("Committing files: %s",
+		log.ColorText(log.ColorGreen, fmt.Sprintf("git add %s && git commit -m \"%s\"", strings.Join(files, " "), message))))
+
+	if SkipInDryRun("git", "commit", "-m", message, "--", strings.Join(files, " ")) {
+		return nil
+	}
+
+	args := append([]string{"add", "--"}, files...)
+	if out, err := exec.Command("git", args...).CombinedOutput(); err != nil {
+		return fmt.Errorf("git add failed: %s", strings.TrimSpace(text(out)))
+	}
+
+	args = append([]string{"commit", "-m", message, "--"}, files...)
+	if out, err := exec.Command("git", args...).CombinedOutput(); err != nil {
+		return fmt.Errorf("git commit -m '%s' failed: %s", message, strings.TrimSpace(text(out)))
+	}
+	return nil
+}
+
 func EnsureNotDetached() error {
 	log.PluginV(log.Preflight, fmt.Sprintf("%s (Ensure branch is not detached)",
 		log.ColorText(log.ColorGreen, "git rev-parse --abbrev-ref HEAD"),
@@ -133,6 +235,8 @@ func EnsureNotDetached() error {
 	return nil
 }
 
+// OnMainBranch checks that HEAD is on the default branch of origin.
+// Falls back to main/master if the default branch cannot be determined.
 func OnMainBranch() error {
 	log.PluginV(log.Preflight, fmt.Sprintf("%s (Check on main branch)",
 		log.ColorText(log.ColorGreen, "git rev-parse --abbrev-ref HEAD"),
@@ -145,14 +249,66 @@ func OnMainBranch() error {
 	}
 
 	branch := strings.TrimSpace(string(output))
-	if branch != "main" && branch != "master" {
-		return fmt.Errorf("you are on branch '%s'. Releases are only allowed from 'main' or 'master'", branch)
+
+	if defaultBranch, err := DefaultBranch(); err == nil {
+		if branch != defaultBranch {
+			return fmt.Errorf("you are on branch '%s'. Releases are only allowed from the default branch '%s'", branch, defaultBranch)
+		}
+	} else {
+		log.PluginV(log.Preflight, fmt.Sprintf("Falling back to main/master: %v", err))
+		if branch != "main" && branch != "master" {
+			return fmt.Errorf("you are on branch '%s'. Releases are only allowed from 'main' or 'master'", branch)
+		}
 	}
 
 	log.PluginV(log.Preflight, fmt.Sprintf("On %s branch", log.ColorText(log.ColorGreen, branch)))
 	return nil
 }
 
+// DefaultBranch returns the default branch of origin.
+// The local origin/HEAD is preferred, the remote is only asked if it is not set.
+func DefaultBranch() (string, error) {
+	log.PluginV(log.Preflight, fmt.Sprintf("%s (Resolve default branch)",
+		log.ColorText(log.ColorGreen, "git symbolic-ref refs/remotes/origin/HEAD"),
+	))
+
+	out, err := exec.Command("git", "symbolic-ref", "refs/remotes/origin/HEAD").Output()
+	if err == nil {
+		if branch := parseSymbolicRef(string(out)); branch != "" {
+			return branch, nil
+		}
+	}
+
+	log.PluginV(log.Preflight, fmt.Sprintf("%s (Ask remote for default branch)",
+		log.ColorText(log.ColorGreen, "git ls-remote --symref origin HEAD"),
+	))
+
+	out, err = exec.Command("git", "ls-remote", "--symref", "origin", "HEAD").CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("git ls-remote --symref origin HEAD failed: %s", strings.TrimSpace(text(out)))
+	}
+	if branch := parseRemoteSymref(string(out)); branch != "" {
+		return branch, nil
+	}
+	return "", fmt.Errorf("origin does not report a default branch")
+}
+
+// parseSymbolicRef extracts the branch from "refs/remotes/origin/<branch>"
+func parseSymbolicRef(output string) string {
+	return strings.TrimPrefix(strings.TrimSpace(output), "refs/remotes/origin/")
+}
+
+// parseRemoteSymref extracts the branch from the "ref: refs/heads/<branch>\tHEAD" line of ls-remote --symref
+func parseRemoteSymref(output string) string {
+	for _, line := range strings.Split(output, "\n") {
+		ref, name, ok := strings.Cut(strings.TrimPrefix(line, "ref: "), "\t")
+		if ok && strings.HasPrefix(line, "ref: ") && strings.TrimSpace(name) == "HEAD" {
+			return strings.TrimPrefix(ref, "refs/heads/")
+		}
+	}
+	return ""
+}
+
 func HasUpstream() error {
 	log.PluginV(log.Preflight, fmt.Sprintf("%s (Check upstream configuration)",
 		log.ColorText(log.ColorGreen, "git for-each-ref"),
@@ -238,7 +394,7 @@ func LastCommit() (string, error) {
 		)
 	}
 
-	lastCommit := strings.TrimSpace(string(lastCommitOut))
+	lastCommit := strings.TrimSpace(text(lastCommitOut))
 	return lastCommit, nil
 }
 
@@ -275,18 +431,26 @@ func FilesCount() (int, error) {
 	return len(files), nil
 }
 
-// RepoSize returns the repository size using du command
+// RepoSize returns the repository size using du command.
+// In a linked worktree the shared git directory is included in the total.
 func RepoSize() (string, error) {
+	paths := []string{"."}
+	if wt, err := Worktree(); err == nil && wt.Linked {
+		paths = append(paths, wt.CommonDir)
+	}
+
 	log.PluginV(log.Exec, "Calculating repository size: "+
-		log.ColorText(log.ColorGreen, "du -sh ."))
+		log.ColorText(log.ColorGreen, "du -shc "+strings.Join(paths, " ")))
 
-	cmd := exec.Command("du", "-sh", ".")
+	cmd := exec.Command("du", append([]string{"-shc"}, paths...)...)
 	sizeOut, err := cmd.Output()
 	if err != nil {
 		return "", errors.New("could not determine repository size (du command not available")
 	}
 
-	fields := strings.Fields(string(sizeOut))
+	// The last line holds the grand total
+	lines := strings.Split(strings.TrimSpace(string(sizeOut)), "\n")
+	fields := strings.Fields(lines[len(lines)-1])
 	if len(fields) == 0 {
 		return "", errors.New("failed determing repository size")
 	}
@@ -294,43 +458,62 @@ func RepoSize() (string, error) {
 	return fields[0], nil
 }
 
-// Contributors returns a list of contributors with their commit counts
-func Contributors() ([]Contributor, error) {
+// Contributors returns a list of contributors with their commit counts in rev, e.g. HEAD or v1.0.0..HEAD
+func Contributors(rev string) ([]Contributor, error) {
 	log.PluginV(log.Exec, "Fetching contributors: "+
-		log.ColorText(log.ColorGreen, "git shortlog -sne HEAD"))
+		log.ColorText(log.ColorGreen, "git shortlog -sne "+rev))
 
-	cmd := exec.Command("git", "shortlog", "-sne", "HEAD")
+	cmd := exec.Command("git", "shortlog", "-sne", rev)
 	contrib, err := cmd.Output()
 	if err != nil {
+		// stderr is kept apart so warnings never end up in the parsed shortlog
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && len(exitErr.Stderr) > 0 {
+			return nil, fmt.Errorf("failed to fetch contributors of %s: %s", rev, strings.TrimSpace(text(exitErr.Stderr)))
+		}
 		return nil, fmt.Errorf(
 			"failed to fetch contributors: %w", err,
 		)
 	}
 
-	contribLines := strings.Split(strings.TrimSpace(string(contrib)), "\n")
-	log.PluginV(log.Exec, fmt.Sprintf("Found %d contributors", len(contribLines)))
+	contributors := parseShortlog(text(contrib))
+	log.PluginV(log.Exec, fmt.Sprintf("Found %d contributors", len(contributors)))
 
-	contributors := make([]Contributor, 0, len(contribLines))
-	for _, line := range contribLines {
-		parts := strings.Fields(line)
-		if len(parts) < 2 {
-			log.PluginV(log.Exec, fmt.Sprintf("Skipping invalid contributor line: %s", line))
-			continue
+	return contributors, nil
+}
+
+// parseShortlog parses "<commits>\t<author>" lines of git shortlog -sne
+func parseShortlog(output string) []Contributor {
+	contributors := make([]Contributor, 0)
+	for _, line := range strings.Split(output, "\n") {
+		commits, author, ok := strings.Cut(strings.TrimSpace(line), "\t")
+		if !ok {
+			// Fall back to whitespace if the tab got lost
+			parts := strings.Fields(line)
+			if len(parts) < 2 {
+				if strings.TrimSpace(line) != "" {
+					log.PluginV(log.Exec, fmt.Sprintf("Skipping invalid contributor line: %s", line))
+				}
+				continue
+			}
+			commits, author = parts[0], strings.Join(parts[1:], " ")
 		}
 
 		contributors = append(contributors, Contributor{
-			Commits: parts[0],
-			Author:  strings.Join(parts[1:], " "),
+			Commits: strings.TrimSpace(commits),
+			Author:  strings.TrimSpace(author),
 		})
 	}
-
-	return contributors, nil
+	return contributors
 }
 
 func DeleteGithubRelease(tag string, token string) error {
 	if tag == "" {
 		return nil
 	}
+	if SkipInDryRun("deleting the GitHub release of", tag) {
+		return nil
+	}
 	if token == "" {
 		return fmt.Errorf("github token is empty")
 	}
@@ -359,6 +542,7 @@ func DeleteGithubRelease(tag string, token string) error {
 		return err
 	}
 	defer func() { _ = resp.Body.Close() }()
+	logRateLimit(resp)
 
 	// If release does not exist, rollback should be idempotent -> success.
 	if resp.StatusCode == http.StatusNotFound {
@@ -397,6 +581,7 @@ func DeleteGithubRelease(tag string, token string) error {
 		return err
 	}
 	defer func() { _ = delResp.Body.Close() }()
+	logRateLimit(delResp)
 
 	if delResp.StatusCode != http.StatusNoContent {
 		body, _ := io.ReadAll(delResp.Body)
@@ -412,58 +597,96 @@ func Head() (string, error) {
 	cmd := exec.Command("git", "rev-parse", "--short", "HEAD")
 	out, err := cmd.CombinedOutput()
 	if err != nil {
-		return "", fmt.Errorf("git rev-parse HEAD failed: %s", strings.TrimSpace(string(out)))
+		return "", fmt.Errorf("git rev-parse HEAD failed: %s", strings.TrimSpace(text(out)))
 	}
 	return strings.TrimSpace(string(out)), nil
 }
 
 // CleanUntracked removes untracked files and directories.
 func CleanUntracked() error {
+	if SkipInDryRun("git", "clean", "-fd") {
+		return nil
+	}
 	cmd := exec.Command("git", "clean", "-fd")
 	out, err := cmd.CombinedOutput()
 	if err != nil {
-		return fmt.Errorf("git clean -fd failed: %s", strings.TrimSpace(string(out)))
+		return fmt.Errorf("git clean -fd failed: %s", strings.TrimSpace(text(out)))
 	}
 	return nil
 }
 
 // DeleteLocalTag deletes a local git tag.
 func DeleteLocalTag(tag string) error {
+	if SkipInDryRun("git", "tag", "-d", tag) {
+		return nil
+	}
 	cmd := exec.Command("git", "tag", "-d", tag)
 	out, err := cmd.CombinedOutput()
 	if err != nil {
-		return fmt.Errorf("git tag -d %s failed: %s", tag, strings.TrimSpace(string(out)))
+		return fmt.Errorf("git tag -d %s failed: %s", tag, strings.TrimSpace(text(out)))
 	}
 	return nil
 }
 
 // DeleteRemoteTag deletes a tag from origin.
 func DeleteRemoteTag(tag string) error {
+	if SkipInDryRun("git", "push", "origin", "--delete", tag) {
+		return nil
+	}
 	cmd := exec.Command("git", "push", "origin", "--delete", tag)
 	out, err := cmd.CombinedOutput()
 	if err != nil {
-		return fmt.Errorf("git push origin --delete %s failed: %s", tag, strings.TrimSpace(string(out)))
+		return fmt.Errorf("git push origin --delete %s failed: %s", tag, strings.TrimSpace(text(out)))
 	}
 	return nil
 }
 
 // RevertCommit creates a new commit that reverts the given commit hash.
 func RevertCommit(hash string) error {
+	if SkipInDryRun("git", "revert", "--no-edit", hash) {
+		return nil
+	}
 	cmd := exec.Command("git", "revert", "--no-edit", hash)
 	out, err := cmd.CombinedOutput()
 	if err != nil {
-		return fmt.Errorf("git revert %s failed: %s", hash, strings.TrimSpace(string(out)))
+		return fmt.Errorf("git revert %s failed: %s", hash, strings.TrimSpace(text(out)))
 	}
 	return nil
 }
 
+// IsEmptyCommit reports whether the commit has the same tree as its first parent.
+// A root commit is never empty.
+func IsEmptyCommit(hash string) (bool, error) {
+	log.PluginV(log.Exec, fmt.Sprintf("Comparing trees of %s and its parent: %s",
+		hash, log.ColorText(log.ColorGreen, fmt.Sprintf("git rev-parse %s^{tree} %s^^{tree}", hash, hash))))
+
+	out, err := exec.Command("git", "rev-parse", hash+"^{tree}", hash+"^^{tree}").CombinedOutput()
+	if err != nil {
+		// Without a parent the second tree does not resolve, check that the commit itself exists
+		if _, terr := exec.Command("git", "rev-parse", "--verify", "--quiet", hash+"^{tree}").Output(); terr == nil {
+			return false, nil
+		}
+		return false, fmt.Errorf("git rev-parse %s^{tree} failed: %s", hash, strings.TrimSpace(text(out)))
+	}
+	return treesEqual(string(out)), nil
+}
+
+// treesEqual reports whether rev-parse printed the same tree twice
+func treesEqual(output string) bool {
+	trees := strings.Fields(output)
+	return len(trees) == 2 && trees[0] == trees[1]
+}
+
 // CreateCommit creates a new commit with a given message
 func CreateCommit(message string) error {
+	if SkipInDryRun("git", "commit", "--allow-empty", "-m", message) {
+		return nil
+	}
 	cmd := exec.Command("git", "commit", "--allow-empty", "-m", message)
 	out, err := cmd.CombinedOutput()
 
 	if err != nil {
-		return fmt.Errorf("git commit -m '%s' failed: %s", message, strings.TrimSpace(string(out)))
+		return fmt.Errorf("git commit -m '%s' failed: %s", message, strings.TrimSpace(text(out)))
 	}
 
 	return nil
@@ -471,10 +694,13 @@ func CreateCommit(message string) error {
 
 // HardResetTo resets HEAD, index, and working tree to the given commit hash.
 func HardResetTo(hash string) error {
+	if SkipInDryRun("git", "reset", "--hard", hash) {
+		return nil
+	}
 	cmd := exec.Command("git", "reset", "--hard", hash)
 	out, err := cmd.CombinedOutput()
 	if err != nil {
-		return fmt.Errorf("git reset --hard %s failed: %s", hash, strings.TrimSpace(string(out)))
+		return fmt.Errorf("git reset --hard %s failed: %s", hash, strings.TrimSpace(text(out)))
 	}
 	return nil
 }
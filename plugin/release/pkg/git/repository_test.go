@@ -0,0 +1,223 @@
+package git
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/nekoman-hq/neko-cli/plugin/release/internal/gittest"
+)
+
+func TestParseSymbolicRef(t *testing.T) {
+	tests := []struct {
+		output string
+		want   string
+	}{
+		{"refs/remotes/origin/main\n", "main"},
+		{"refs/remotes/origin/release/v2\n", "release/v2"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		if got := parseSymbolicRef(tt.output); got != tt.want {
+			t.Errorf("parseSymbolicRef(%q) = %q, want %q", tt.output, got, tt.want)
+		}
+	}
+}
+
+func TestParseRemoteSymref(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		want   string
+	}{
+		{"main", "ref: refs/heads/main\tHEAD\n1111111111111111111111111111111111111111\tHEAD\n", "main"},
+		{"trunk", "ref: refs/heads/trunk\tHEAD\n", "trunk"},
+		{"other symref", "ref: refs/heads/main\trefs/remotes/x\n", ""},
+		{"no symref", "1111111111111111111111111111111111111111\tHEAD\n", ""},
+		{"empty", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseRemoteSymref(tt.output); got != tt.want {
+				t.Errorf("parseRemoteSymref(%q) = %q, want %q", tt.output, got, tt.want)
+			}
+		})
+	}
+}
+
+// defaultBranchRepo creates a repository whose origin's default branch is trunk
+func defaultBranchRepo(t *testing.T, originHead bool) {
+	t.Helper()
+	gittest.NewRepo(t)
+	remote := gittest.NewRemote(t)
+	gittest.Run(t, "checkout", "-q", "-b", "trunk")
+	gittest.Commit(t, "feat: initial")
+	gittest.Run(t, "push", "-q", "origin", "trunk")
+	gittest.RunIn(t, remote, "symbolic-ref", "HEAD", "refs/heads/trunk")
+	if originHead {
+		gittest.Run(t, "remote", "set-head", "origin", "--auto")
+	}
+}
+
+func TestDefaultBranch(t *testing.T) {
+	tests := []struct {
+		name       string
+		originHead bool
+	}{
+		{"origin/HEAD", true},
+		{"ls-remote", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defaultBranchRepo(t, tt.originHead)
+			if branch, err := DefaultBranch(); err != nil || branch != "trunk" {
+				t.Errorf("DefaultBranch = %q, %v, want trunk", branch, err)
+			}
+		})
+	}
+}
+
+func TestOnMainBranch(t *testing.T) {
+	tests := []struct {
+		name      string
+		remote    bool
+		branch    string
+		wantError string
+	}{
+		{"default branch", true, "trunk", ""},
+		{"feature branch", true, "feature", "default branch 'trunk'"},
+		{"main is not the default branch", true, "main", "default branch 'trunk'"},
+		{"no remote on main", false, "main", ""},
+		{"no remote on master", false, "master", ""},
+		{"no remote on feature", false, "feature", "'main' or 'master'"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.remote {
+				defaultBranchRepo(t, true)
+			} else {
+				gittest.NewRepo(t)
+				gittest.Commit(t, "feat: initial")
+			}
+			gittest.Run(t, "checkout", "-q", "-B", tt.branch)
+
+			err := OnMainBranch()
+			if tt.wantError == "" {
+				if err != nil {
+					t.Errorf("OnMainBranch = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantError) {
+				t.Errorf("OnMainBranch = %v, want an error mentioning %s", err, tt.wantError)
+			}
+		})
+	}
+}
+
+func TestParseRemote(t *testing.T) {
+	tests := []struct {
+		url     string
+		want    RepoInfo
+		wantErr bool
+	}{
+		{url: "git@github.com:nekoman-hq/neko-cli.git", want: RepoInfo{"nekoman-hq", "neko-cli"}},
+		{url: "git@github.com:nekoman-hq/neko-cli", want: RepoInfo{"nekoman-hq", "neko-cli"}},
+		{url: "https://github.com/nekoman-hq/neko-cli.git", want: RepoInfo{"nekoman-hq", "neko-cli"}},
+		{url: "https://github.com/nekoman-hq/neko.cli", want: RepoInfo{"nekoman-hq", "neko.cli"}},
+		{url: "https://gitlab.com/nekoman-hq/neko-cli.git", wantErr: true},
+		{url: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			got, err := parseRemote(tt.url)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("parseRemote(%q) = %+v, want an error", tt.url, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if *got != tt.want {
+				t.Errorf("parseRemote(%q) = %+v, want %+v", tt.url, *got, tt.want)
+			}
+		})
+	}
+}
+
+// Current reads the single URL of git remote get-url instead of the mixed git remote -v listing
+func TestCurrent(t *testing.T) {
+	tests := []struct {
+		name    string
+		remotes [][]string
+		want    RepoInfo
+		wantErr string
+	}{
+		{
+			name:    "origin",
+			remotes: [][]string{{"remote", "add", "origin", "git@github.com:nekoman-hq/app.git"}},
+			want:    RepoInfo{"nekoman-hq", "app"},
+		},
+		{
+			name: "origin wins over other remotes",
+			remotes: [][]string{
+				{"remote", "add", "fork", "https://github.com/someone/app.git"},
+				{"remote", "add", "origin", "https://github.com/nekoman-hq/app.git"},
+			},
+			want: RepoInfo{"nekoman-hq", "app"},
+		},
+		{
+			name: "push url of origin",
+			remotes: [][]string{
+				{"remote", "add", "origin", "https://github.com/upstream/app.git"},
+				{"remote", "set-url", "--push", "origin", "git@github.com:nekoman-hq/app.git"},
+			},
+			want: RepoInfo{"nekoman-hq", "app"},
+		},
+		{
+			name:    "first remote without origin",
+			remotes: [][]string{{"remote", "add", "upstream", "https://github.com/nekoman-hq/app.git"}},
+			want:    RepoInfo{"nekoman-hq", "app"},
+		},
+		{name: "no remote", wantErr: "no Remote Found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gittest.NewRepo(t)
+			for _, args := range tt.remotes {
+				gittest.Run(t, args...)
+			}
+
+			got, err := Current()
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("Current() = %+v, %v, want %q", got, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if *got != tt.want {
+				t.Errorf("Current() = %+v, want %+v", *got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCurrentOutsideRepository(t *testing.T) {
+	t.Chdir(t.TempDir())
+	t.Setenv("GIT_CEILING_DIRECTORIES", filepath.Dir(t.TempDir()))
+
+	if _, err := Current(); err == nil || !strings.Contains(err.Error(), "not a Git Repository") {
+		t.Errorf("Current() outside a repository = %v, want not a Git Repository", err)
+	}
+}
@@ -0,0 +1,98 @@
+package git
+
+import (
+	"bytes"
+	stderrors "errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"strings"
+
+	"github.com/nekoman-hq/neko-cli/pkg/log"
+)
+
+// SignatureStatus is the result of verifying a tag signature
+type SignatureStatus string
+
+const (
+	SignatureValid      SignatureStatus = "valid"
+	SignatureUnsigned   SignatureStatus = "unsigned"
+	SignatureBad        SignatureStatus = "bad"
+	SignatureUnverified SignatureStatus = "unverified" // signed, but the key is unknown or not trusted
+)
+
+// TagSignature describes the signature of a tag as reported by git tag -v
+type TagSignature struct {
+	Status SignatureStatus
+	Signer string
+	Key    string
+	Output string
+}
+
+var (
+	// gpg: Good signature from "Name <mail>" [ultimate] / gpg: BAD signature from "Name <mail>"
+	gpgSignerPattern = regexp.MustCompile(`(?:Good|BAD) signature from "([^"]+)"`)
+	// gpg: using EDDSA key FF6D0F87...
+	gpgKeyPattern = regexp.MustCompile(`using (\S+ key \S+)`)
+	// Good "git" signature for mail with ED25519 key SHA256:...
+	sshSignerPattern = regexp.MustCompile(`Good "git" signature for (\S+) with`)
+	// Good "git" signature [for mail] with ED25519 key SHA256:...
+	sshKeyPattern = regexp.MustCompile(`Good "git" signature (?:for \S+ )?with (\S+ key \S+)`)
+)
+
+// VerifyTag verifies the GPG or SSH signature of a tag via git tag -v.
+// An unsigned tag or a bad signature is reported in the status, the error is only set if git could not run.
+func VerifyTag(tag string) (*TagSignature, error) {
+	log.PluginV(log.Exec, fmt.Sprintf("Verifying signature of %s: %s",
+		tag, log.ColorText(log.ColorGreen, "git tag -v "+tag)))
+
+	// Only stderr carries the verification result, the tag message on stdout could imitate it.
+	// gpg is forced to English output, its messages are parsed below.
+	var stderr bytes.Buffer
+	cmd := exec.Command("git", "tag", "-v", tag)
+	cmd.Env = append(os.Environ(), "LC_ALL=C")
+	cmd.Stderr = &stderr
+	err := cmd.Run()
+
+	var exitErr *exec.ExitError
+	if err != nil && !stderrors.As(err, &exitErr) {
+		return nil, fmt.Errorf("git tag -v failed: %w", err)
+	}
+
+	sig := ParseTagVerification(text(stderr.Bytes()), err == nil)
+	return &sig, nil
+}
+
+// ParseTagVerification interprets the stderr output of git tag -v.
+// verified is true if git exited successfully, which it only does for a good signature of a trusted key.
+func ParseTagVerification(output string, verified bool) TagSignature {
+	sig := TagSignature{Output: strings.TrimSpace(output)}
+
+	if m := gpgSignerPattern.FindStringSubmatch(output); m != nil {
+		sig.Signer = m[1]
+	} else if m := sshSignerPattern.FindStringSubmatch(output); m != nil {
+		sig.Signer = m[1]
+	}
+	if m := gpgKeyPattern.FindStringSubmatch(output); m != nil {
+		sig.Key = m[1]
+	} else if m := sshKeyPattern.FindStringSubmatch(output); m != nil {
+		sig.Key = m[1]
+	}
+
+	switch {
+	case strings.Contains(output, "no signature found"),
+		strings.Contains(output, "cannot verify a non-tag object"):
+		sig.Status = SignatureUnsigned
+	case strings.Contains(output, "BAD signature"),
+		strings.Contains(output, "incorrect signature"),
+		strings.Contains(output, "Could not verify signature"):
+		sig.Status = SignatureBad
+	case verified:
+		sig.Status = SignatureValid
+	default:
+		// e.g. gpg "No public key", ssh "No principal matched" or no allowed signers file
+		sig.Status = SignatureUnverified
+	}
+	return sig
+}
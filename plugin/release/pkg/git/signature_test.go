@@ -0,0 +1,80 @@
+package git
+
+import "testing"
+
+func TestParseTagVerification(t *testing.T) {
+	tests := []struct {
+		name       string
+		output     string
+		verified   bool
+		wantStatus SignatureStatus
+		wantSigner string
+		wantKey    string
+	}{
+		{
+			name: "good gpg signature",
+			output: `gpg: Signature made Thu Oct 15 10:00:00 2026 UTC
+gpg:                using EDDSA key FF6D0F87A1B2C3D4
+gpg: Good signature from "Neko <neko@example.com>" [ultimate]`,
+			verified:   true,
+			wantStatus: SignatureValid,
+			wantSigner: "Neko <neko@example.com>",
+			wantKey:    "EDDSA key FF6D0F87A1B2C3D4",
+		},
+		{
+			name: "bad gpg signature",
+			output: `gpg:                using RSA key 0123456789ABCDEF
+gpg: BAD signature from "Neko <neko@example.com>" [ultimate]`,
+			wantStatus: SignatureBad,
+			wantSigner: "Neko <neko@example.com>",
+			wantKey:    "RSA key 0123456789ABCDEF",
+		},
+		{
+			name: "unknown gpg key",
+			output: `gpg:                using RSA key 0123456789ABCDEF
+gpg: Can't check signature: No public key`,
+			wantStatus: SignatureUnverified,
+			wantKey:    "RSA key 0123456789ABCDEF",
+		},
+		{
+			name:       "good ssh signature",
+			output:     `Good "git" signature for neko@example.com with ED25519 key SHA256:kfzRZO55DHCUqmQXnyfBdSxCEys7XC51tpWuGLeQn+Y`,
+			verified:   true,
+			wantStatus: SignatureValid,
+			wantSigner: "neko@example.com",
+			wantKey:    "ED25519 key SHA256:kfzRZO55DHCUqmQXnyfBdSxCEys7XC51tpWuGLeQn+Y",
+		},
+		{
+			name:       "ssh signature without principal",
+			output:     `Good "git" signature with ED25519 key SHA256:kfzRZO55DHCUqmQXnyfBdSxCEys7XC51tpWuGLeQn+Y` + "\nNo principal matched.",
+			wantStatus: SignatureUnverified,
+			wantKey:    "ED25519 key SHA256:kfzRZO55DHCUqmQXnyfBdSxCEys7XC51tpWuGLeQn+Y",
+		},
+		{
+			name:       "bad ssh signature",
+			output:     "Could not verify signature.\nSignature verification failed: incorrect signature",
+			wantStatus: SignatureBad,
+		},
+		{
+			name:       "no allowed signers",
+			output:     "error: gpg.ssh.allowedSignersFile needs to be configured and exist for ssh signature verification",
+			wantStatus: SignatureUnverified,
+		},
+		{name: "unsigned annotated tag", output: "error: no signature found", wantStatus: SignatureUnsigned},
+		{
+			name:       "lightweight tag",
+			output:     "error: v1.2.0: cannot verify a non-tag object of type commit.",
+			wantStatus: SignatureUnsigned,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sig := ParseTagVerification(tt.output, tt.verified)
+			if sig.Status != tt.wantStatus || sig.Signer != tt.wantSigner || sig.Key != tt.wantKey {
+				t.Errorf("ParseTagVerification = %s %q %q, want %s %q %q",
+					sig.Status, sig.Signer, sig.Key, tt.wantStatus, tt.wantSigner, tt.wantKey)
+			}
+		})
+	}
+}
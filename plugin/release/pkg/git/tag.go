@@ -2,10 +2,13 @@
 package git
 
 import (
+	stderrors "errors"
 	"fmt"
 	"os/exec"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/nekoman-hq/neko-cli/pkg/errors"
 	"github.com/nekoman-hq/neko-cli/pkg/log"
@@ -17,52 +20,152 @@ import (
 @Since      20.12.2025
 */
 
-func LatestTag() string {
-	log.PluginV(log.Exec, fmt.Sprintf("%s (Extract last tag)", log.ColorText(log.ColorGreen, "git describe --tags --abbrev=0")))
-	cmd := exec.Command("git", "describe", "--tags", "--abbrev=0")
-	output, err := cmd.CombinedOutput()
-	if err != nil {
-		errors.WriteWarning(
-			"Failed to get latest tag",
-			"No tags found or could not execute git describe.\nUsing default version 0.1.0.",
-		)
-		return "0.1.0"
-	}
+// ErrNoTags is returned if no release tag exists yet, e.g. in a fresh repository
+var ErrNoTags = stderrors.New("no tags found")
 
-	outputStr := strings.TrimSpace(string(output))
-	if outputStr == "" {
+// GetTags returns a list of all git tags
+func GetTags() []string {
+	tags, err := Tags()
+	if err != nil {
 		errors.WriteWarning(
-			"No tags found",
-			"No tags exist in this repository.\nUsing default version 0.1.0.",
+			"Failed to fetch tags",
+			fmt.Sprintf("Command failed: %s", err.Error()),
 		)
-		return "0.1.0"
+		return []string{}
 	}
-
-	log.PluginV(log.Guard, fmt.Sprintf("Latest tag: %s", outputStr))
-	return outputStr
+	return tags
 }
 
-// GetTags returns a list of all git tags
-func GetTags() []string {
+// Tags returns a list of all git tags, unlike GetTags a failing git is reported as an error
+func Tags() ([]string, error) {
 	log.PluginV(log.Exec, "Fetching git tags: "+
 		log.ColorText(log.ColorGreen, "git tag"))
 
 	cmd := exec.Command("git", "tag")
-	tagsOut, err := cmd.Output()
+	tagsOut, err := cmd.CombinedOutput()
 	if err != nil {
-		errors.WriteWarning(
-			"Failed to fetch tags",
-			fmt.Sprintf("Command failed: %s", err.Error()),
-		)
-		return []string{}
+		return nil, fmt.Errorf("git tag failed: %s: %w", strings.TrimSpace(text(tagsOut)), err)
 	}
 
-	tagList := strings.Split(strings.TrimSpace(string(tagsOut)), "\n")
+	tagList := strings.Split(strings.TrimSpace(text(tagsOut)), "\n")
 	if len(tagList) == 1 && tagList[0] == "" {
-		return []string{}
+		return []string{}, nil
+	}
+
+	return tagList, nil
+}
+
+// MergedTags returns the tags reachable from HEAD
+func MergedTags() ([]string, error) {
+	log.PluginV(log.Exec, "Fetching tags reachable from HEAD: "+
+		log.ColorText(log.ColorGreen, "git tag --merged HEAD"))
+
+	out, err := exec.Command("git", "tag", "--merged", "HEAD").CombinedOutput()
+	if err != nil {
+		return nil, fmt.Errorf("git tag --merged HEAD failed: %s", strings.TrimSpace(text(out)))
+	}
+	return strings.Fields(string(out)), nil
+}
+
+// CommitSubjectsBetween returns the subjects of at most limit commits between two references, newest first
+func CommitSubjectsBetween(from, to string, limit int) ([]string, error) {
+	entries, err := logEntries(from, to, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	subjects := make([]string, 0, len(entries))
+	for _, e := range entries {
+		subjects = append(subjects, e.Subject)
 	}
+	return subjects, nil
+}
+
+// LogEntry is a commit as listed by CommitsBetween
+type LogEntry struct {
+	Hash    string
+	Subject string
+}
+
+// CommitsBetween returns all commits between two references, newest first.
+// An empty from lists the whole history up to to.
+func CommitsBetween(from, to string) ([]LogEntry, error) {
+	return logEntries(from, to, 0)
+}
+
+// logEntries lists the commits between two references, newest first. A limit of 0 lists all commits.
+func logEntries(from, to string, limit int) ([]LogEntry, error) {
+	rng := to
+	if from != "" {
+		rng = fmt.Sprintf("%s..%s", from, to)
+	}
+	args := []string{"log", "--format=%h%x09%s"}
+	if limit > 0 {
+		args = append(args, fmt.Sprintf("--max-count=%d", limit))
+	}
+	args = append(args, rng)
+
+	log.PluginV(log.Exec, fmt.Sprintf("Listing commits of %s: %s",
+		rng, log.ColorText(log.ColorGreen, "git "+strings.Join(args, " "))))
+
+	out, err := exec.Command("git", args...).CombinedOutput()
+	if err != nil {
+		return nil, fmt.Errorf("git log %s failed: %s", rng, strings.TrimSpace(text(out)))
+	}
+	return parseLogEntries(text(out)), nil
+}
+
+// parseLogEntries parses "<hash>\t<subject>" lines
+func parseLogEntries(output string) []LogEntry {
+	entries := make([]LogEntry, 0)
+	for _, line := range strings.Split(output, "\n") {
+		hash, subject, ok := strings.Cut(line, "\t")
+		if !ok {
+			continue
+		}
+		entries = append(entries, LogEntry{Hash: hash, Subject: strings.TrimSpace(subject)})
+	}
+	return entries
+}
+
+// LogGraph returns the commit graph of the range as "<graph><hash>\t<refs>\t<subject>" lines
+func LogGraph(rng string) (string, error) {
+	args := []string{"log", "--graph", "--format=%h%x09%D%x09%s", rng}
+
+	log.PluginV(log.Exec, fmt.Sprintf("Drawing commit graph of %s: %s",
+		rng, log.ColorText(log.ColorGreen, "git "+strings.Join(args, " "))))
+
+	out, err := exec.Command("git", args...).CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("git log --graph %s failed: %s", rng, strings.TrimSpace(text(out)))
+	}
+	return text(out), nil
+}
+
+// RootCommit returns the first commit reachable from HEAD
+func RootCommit() (string, error) {
+	log.PluginV(log.Exec, "Resolving root commit: "+
+		log.ColorText(log.ColorGreen, "git rev-list --max-parents=0 HEAD"))
+
+	out, err := exec.Command("git", "rev-list", "--max-parents=0", "HEAD").CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("git rev-list --max-parents=0 HEAD failed: %s", strings.TrimSpace(text(out)))
+	}
+
+	// Merged unrelated histories have several roots, the oldest is listed last
+	roots := strings.Fields(string(out))
+	if len(roots) == 0 {
+		return "", fmt.Errorf("repository has no commits")
+	}
+	return roots[len(roots)-1], nil
+}
+
+// RefExists reports whether the ref resolves to a commit
+func RefExists(ref string) bool {
+	log.PluginV(log.Exec, fmt.Sprintf("Resolving %s: %s",
+		ref, log.ColorText(log.ColorGreen, fmt.Sprintf("git rev-parse --verify --quiet %s^{commit}", ref))))
 
-	return tagList
+	return exec.Command("git", "rev-parse", "--verify", "--quiet", ref+"^{commit}").Run() == nil
 }
 
 // CountCommitsBetween counts commits between two references
@@ -100,3 +203,182 @@ func CountCommitsBetween(from, to string) int {
 
 	return count
 }
+
+// DefaultReleaseCommitScope is the scope of release commits unless release-commit-scope is configured
+const DefaultReleaseCommitScope = "neko-release"
+
+// releaseCommitScope is the scope of the release commits neko creates, see SetReleaseCommitScope
+var releaseCommitScope = DefaultReleaseCommitScope
+
+// SetReleaseCommitScope sets the configured release commit scope, nil keeps the default.
+// An empty scope creates "chore: release x.y.z" commits.
+func SetReleaseCommitScope(scope *string) {
+	if scope != nil {
+		releaseCommitScope = *scope
+	}
+}
+
+// ReleaseCommitScope returns the scope of the release commits neko creates
+func ReleaseCommitScope() string {
+	return releaseCommitScope
+}
+
+// ReleaseCommitSubject returns the subject of the release commit for the version
+func ReleaseCommitSubject(version string) string {
+	return releaseCommitSubjectPrefix(releaseCommitScope) + version
+}
+
+// releaseCommitSubjectPrefix returns "chore(<scope>): ", or "chore: release " without scope
+func releaseCommitSubjectPrefix(scope string) string {
+	if scope == "" {
+		return "chore: release "
+	}
+	return "chore(" + scope + "): "
+}
+
+// IsReleaseCommit reports whether a commit subject belongs to a neko release commit.
+// Commits with the default scope are recognized too, e.g. releases from before the scope was changed.
+func IsReleaseCommit(subject string) bool {
+	return strings.HasPrefix(subject, releaseCommitSubjectPrefix(releaseCommitScope)) ||
+		strings.HasPrefix(subject, releaseCommitSubjectPrefix(DefaultReleaseCommitScope))
+}
+
+// CommitSubject returns the subject line of the commit the given ref points at
+func CommitSubject(ref string) (string, error) {
+	log.PluginV(log.Exec, "Fetching commit subject: "+
+		log.ColorText(log.ColorGreen, fmt.Sprintf("git log -1 --format=%%s %s", ref)))
+
+	cmd := exec.Command("git", "log", "-1", "--format=%s", ref)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("git log -1 %s failed: %s", ref, strings.TrimSpace(text(out)))
+	}
+	return strings.TrimSpace(text(out)), nil
+}
+
+// CommitTime returns the committer date of the commit the given ref points at
+func CommitTime(ref string) (time.Time, error) {
+	log.PluginV(log.Exec, "Fetching commit date: "+
+		log.ColorText(log.ColorGreen, fmt.Sprintf("git log -1 --format=%%ct %s", ref)))
+
+	cmd := exec.Command("git", "log", "-1", "--format=%ct", ref)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return time.Time{}, fmt.Errorf("git log -1 %s failed: %s", ref, strings.TrimSpace(text(out)))
+	}
+	seconds, err := strconv.ParseInt(strings.TrimSpace(text(out)), 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid commit date of %s: %w", ref, err)
+	}
+	return time.Unix(seconds, 0), nil
+}
+
+// RemoteTagExists checks whether the given tag exists on origin
+func RemoteTagExists(tag string) (bool, error) {
+	log.PluginV(log.Exec, "Checking remote tag: "+
+		log.ColorText(log.ColorGreen, fmt.Sprintf("git ls-remote --tags origin refs/tags/%s", tag)))
+
+	cmd := exec.Command("git", "ls-remote", "--tags", "origin", "refs/tags/"+tag)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return false, fmt.Errorf("git ls-remote failed: %s", strings.TrimSpace(text(out)))
+	}
+	return strings.TrimSpace(text(out)) != "", nil
+}
+
+// RemoteTags returns the names of all tags on origin, sorted by name like ls-remote lists them
+func RemoteTags() ([]string, error) {
+	commits, err := RemoteTagCommits()
+	if err != nil {
+		return nil, err
+	}
+	return tagNames(commits), nil
+}
+
+// TagCommit returns the commit hash the local tag points at
+func TagCommit(tag string) (string, error) {
+	log.PluginV(log.Exec, "Resolving tag commit: "+
+		log.ColorText(log.ColorGreen, fmt.Sprintf("git rev-parse %s^{commit}", tag)))
+
+	cmd := exec.Command("git", "rev-parse", tag+"^{commit}")
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("git rev-parse %s failed: %s", tag, strings.TrimSpace(text(out)))
+	}
+	return strings.TrimSpace(text(out)), nil
+}
+
+// RemoteTagCommit returns the commit hash the tag points at on origin, or an empty string if it does not exist there
+func RemoteTagCommit(tag string) (string, error) {
+	log.PluginV(log.Exec, "Resolving remote tag commit: "+
+		log.ColorText(log.ColorGreen, fmt.Sprintf("git ls-remote --tags origin refs/tags/%s", tag)))
+
+	cmd := exec.Command("git", "ls-remote", "--tags", "origin", "refs/tags/"+tag, "refs/tags/"+tag+"^{}")
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("git ls-remote failed: %s", strings.TrimSpace(text(out)))
+	}
+	return parseTagCommits(string(out))[tag], nil
+}
+
+// LocalTagCommits returns the commit hash of every local tag, keyed by tag name
+func LocalTagCommits() (map[string]string, error) {
+	log.PluginV(log.Exec, "Listing local tags: "+
+		log.ColorText(log.ColorGreen, "git show-ref --tags --dereference"))
+
+	out, err := exec.Command("git", "show-ref", "--tags", "--dereference").CombinedOutput()
+	if err != nil {
+		// show-ref exits with 1 and prints nothing if there are no tags
+		var exitErr *exec.ExitError
+		if stderrors.As(err, &exitErr) && exitErr.ExitCode() == 1 && len(out) == 0 {
+			return map[string]string{}, nil
+		}
+		return nil, fmt.Errorf("git show-ref failed: %s", strings.TrimSpace(text(out)))
+	}
+	return parseTagCommits(string(out)), nil
+}
+
+// RemoteTagCommits returns the commit hash of every tag on origin, keyed by tag name
+func RemoteTagCommits() (map[string]string, error) {
+	log.PluginV(log.Exec, "Listing remote tags: "+
+		log.ColorText(log.ColorGreen, "git ls-remote --tags origin"))
+
+	out, err := exec.Command("git", "ls-remote", "--tags", "origin").CombinedOutput()
+	if err != nil {
+		return nil, fmt.Errorf("git ls-remote failed: %s", strings.TrimSpace(text(out)))
+	}
+	return parseTagCommits(string(out)), nil
+}
+
+// parseTagCommits parses "<hash> refs/tags/<tag>" lines as printed by ls-remote and show-ref.
+// Annotated tags list the tag object and the peeled commit (^{}), the latter wins.
+func parseTagCommits(output string) map[string]string {
+	commits := make(map[string]string)
+	peeled := make(map[string]bool)
+	for _, line := range strings.Split(strings.TrimSpace(output), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+		ref, ok := strings.CutPrefix(fields[1], "refs/tags/")
+		if !ok {
+			continue
+		}
+		if tag, isPeeled := strings.CutSuffix(ref, "^{}"); isPeeled {
+			commits[tag], peeled[tag] = fields[0], true
+		} else if !peeled[ref] {
+			commits[ref] = fields[0]
+		}
+	}
+	return commits
+}
+
+// tagNames returns the tags of a parseTagCommits result sorted by name
+func tagNames(commits map[string]string) []string {
+	tags := make([]string, 0, len(commits))
+	for tag := range commits {
+		tags = append(tags, tag)
+	}
+	slices.Sort(tags)
+	return tags
+}
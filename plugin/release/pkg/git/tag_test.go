@@ -0,0 +1,304 @@
+package git
+
+import (
+	"maps"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/nekoman-hq/neko-cli/plugin/release/internal/gittest"
+)
+
+func TestTags(t *testing.T) {
+	gittest.NewRepo(t)
+	gittest.Commit(t, "feat: initial")
+
+	tags, err := Tags()
+	if err != nil || tags == nil || len(tags) != 0 {
+		t.Fatalf("Tags() without tags = %q, %v, want an empty list", tags, err)
+	}
+
+	gittest.Run(t, "tag", "v1.0.0")
+	gittest.Run(t, "tag", "v1.1.0")
+	if tags, err := Tags(); err != nil || !slices.Equal(tags, []string{"v1.0.0", "v1.1.0"}) {
+		t.Errorf("Tags() = %q, %v, want both tags", tags, err)
+	}
+}
+
+// Outside a repository git fails, which must not look like a repository without tags
+func TestTagsGitFailure(t *testing.T) {
+	t.Chdir(t.TempDir())
+	t.Setenv("GIT_CEILING_DIRECTORIES", filepath.Dir(t.TempDir()))
+
+	if tags, err := Tags(); err == nil {
+		t.Errorf("Tags() outside a repository = %q, want an error", tags)
+	}
+	if tags := GetTags(); len(tags) != 0 {
+		t.Errorf("GetTags() outside a repository = %q, want none", tags)
+	}
+}
+
+// Tag messages and names may contain arbitrary bytes, the parsers must keep the valid lines
+func TestMergedTags(t *testing.T) {
+	gittest.NewRepo(t)
+	gittest.Commit(t, "feat: initial")
+	gittest.Run(t, "tag", "v1.0.0")
+	gittest.Run(t, "checkout", "-q", "-b", "side")
+	gittest.Commit(t, "feat: side")
+	gittest.Run(t, "tag", "v2.0.0")
+	gittest.Run(t, "checkout", "-q", "main")
+	gittest.Commit(t, "feat: next")
+	gittest.Run(t, "tag", "v1.1.0-rc.1")
+
+	tags, err := MergedTags()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"v1.0.0", "v1.1.0-rc.1"}; !slices.Equal(tags, want) {
+		t.Errorf("MergedTags() = %q, want %q", tags, want)
+	}
+}
+
+func TestRemoteTags(t *testing.T) {
+	gittest.NewRepo(t)
+	gittest.NewRemote(t)
+	gittest.Commit(t, "feat: initial")
+	gittest.Run(t, "tag", "v1.0.0")
+	gittest.Run(t, "tag", "-a", "-m", "annotated", "v1.1.0")
+	gittest.Run(t, "tag", "v1.2.0")
+	gittest.Run(t, "push", "-q", "origin", "main", "v1.0.0", "v1.1.0")
+
+	tags, err := RemoteTags()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The annotated tag is listed once, without its peeled ^{} entry
+	if want := []string{"v1.0.0", "v1.1.0"}; !slices.Equal(tags, want) {
+		t.Errorf("RemoteTags() = %q, want %q", tags, want)
+	}
+}
+
+func TestParseTagCommits(t *testing.T) {
+	const (
+		commitA = "1111111111111111111111111111111111111111"
+		commitB = "2222222222222222222222222222222222222222"
+		object  = "3333333333333333333333333333333333333333"
+	)
+
+	tests := []struct {
+		name   string
+		output string
+		want   map[string]string
+	}{
+		{"empty", "", map[string]string{}},
+		{"lightweight", commitA + "\trefs/tags/v1.0.0\n", map[string]string{"v1.0.0": commitA}},
+		{"annotated", object + "\trefs/tags/v1.1.0\n" + commitB + "\trefs/tags/v1.1.0^{}\n", map[string]string{"v1.1.0": commitB}},
+		{"peeled listed first", commitB + " refs/tags/v1.1.0^{}\n" + object + " refs/tags/v1.1.0\n", map[string]string{"v1.1.0": commitB}},
+		{"show-ref format", commitA + " refs/tags/v1.0.0\n", map[string]string{"v1.0.0": commitA}},
+		{"other refs", commitA + "\tHEAD\n" + commitA + "\trefs/heads/main\n", map[string]string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseTagCommits(tt.output); !maps.Equal(got, tt.want) {
+				t.Errorf("parseTagCommits = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// The remote lookups derive from the same parser: names, single commits and the full map agree
+func TestRemoteTagLookups(t *testing.T) {
+	gittest.NewRepo(t)
+	gittest.NewRemote(t)
+	first := gittest.Commit(t, "feat: initial")
+	gittest.Run(t, "tag", "v1.0.0")
+	second := gittest.Commit(t, "feat: next")
+	gittest.Run(t, "tag", "-a", "-m", "annotated", "v1.1.0")
+	gittest.Run(t, "push", "-q", "origin", "main", "--tags")
+
+	commits, err := RemoteTagCommits()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := map[string]string{"v1.0.0": first, "v1.1.0": second}; !maps.Equal(commits, want) {
+		t.Errorf("RemoteTagCommits() = %q, want %q", commits, want)
+	}
+
+	tests := []struct {
+		tag  string
+		want string
+	}{
+		{"v1.0.0", first},
+		{"v1.1.0", second},
+		{"v1.1", ""},
+		{"v9.9.9", ""},
+	}
+	for _, tt := range tests {
+		commit, err := RemoteTagCommit(tt.tag)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if commit != tt.want {
+			t.Errorf("RemoteTagCommit(%q) = %q, want %q", tt.tag, commit, tt.want)
+		}
+	}
+}
+
+func TestParseInvalidUTF8(t *testing.T) {
+	output := text([]byte(
+		"1111111111111111111111111111111111111111\trefs/tags/v1.0.0\n" +
+			"2222222222222222222222222222222222222222\trefs/tags/caf\xe9\n" +
+			"\xff\xfe garbage line\n" +
+			"3333333333333333333333333333333333333333\trefs/tags/v1.1.0\n" +
+			"4444444444444444444444444444444444444444\trefs/tags/v1.1.0^{}\n",
+	))
+
+	commits := parseTagCommits(output)
+	want := map[string]string{
+		"v1.0.0":    "1111111111111111111111111111111111111111",
+		"caf\uFFFD": "2222222222222222222222222222222222222222",
+		"v1.1.0":    "4444444444444444444444444444444444444444",
+	}
+	if !maps.Equal(commits, want) {
+		t.Errorf("parseTagCommits = %q, want %q", commits, want)
+	}
+
+	if tags := tagNames(commits); !slices.Equal(tags, []string{"caf\uFFFD", "v1.0.0", "v1.1.0"}) {
+		t.Errorf("tagNames = %q", tags)
+	}
+}
+
+func TestCommitsBetween(t *testing.T) {
+	gittest.NewRepo(t)
+	gittest.Commit(t, "feat: one")
+	gittest.Run(t, "tag", "v1.0.0")
+	gittest.Commit(t, "fix: two")
+	gittest.Commit(t, "feat: three")
+	gittest.Run(t, "tag", "v1.1.0")
+
+	tests := []struct {
+		name     string
+		from, to string
+		want     []string
+	}{
+		{"between tags", "v1.0.0", "v1.1.0", []string{"feat: three", "fix: two"}},
+		{"full history", "", "v1.1.0", []string{"feat: three", "fix: two", "feat: one"}},
+		{"first tag", "", "v1.0.0", []string{"feat: one"}},
+		{"empty range", "v1.1.0", "HEAD", []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entries, err := CommitsBetween(tt.from, tt.to)
+			if err != nil {
+				t.Fatal(err)
+			}
+			subjects := make([]string, 0, len(entries))
+			for _, e := range entries {
+				subjects = append(subjects, e.Subject)
+				if e.Hash != gittest.Run(t, "rev-parse", "--short", e.Hash) {
+					t.Errorf("hash %q of %q is not a short hash", e.Hash, e.Subject)
+				}
+			}
+			if !slices.Equal(subjects, tt.want) {
+				t.Errorf("CommitsBetween(%q, %q) = %q, want %q", tt.from, tt.to, subjects, tt.want)
+			}
+
+			// The subjects are the same commits, capped at the limit
+			capped, err := CommitSubjectsBetween(tt.from, tt.to, 2)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want := tt.want[:min(2, len(tt.want))]; !slices.Equal(capped, want) {
+				t.Errorf("CommitSubjectsBetween(%q, %q, 2) = %q, want %q", tt.from, tt.to, capped, want)
+			}
+		})
+	}
+
+	if _, err := CommitsBetween("v9.9.9", "HEAD"); err == nil {
+		t.Error("CommitsBetween accepted an unknown ref")
+	}
+}
+
+func TestParseLogEntries(t *testing.T) {
+	output := "abc1234\tfeat: login\n" +
+		"def5678\t  fix: tabs\tin subject  \n" +
+		"no tab here\n" +
+		"\n"
+
+	want := []LogEntry{
+		{Hash: "abc1234", Subject: "feat: login"},
+		{Hash: "def5678", Subject: "fix: tabs\tin subject"},
+	}
+	if got := parseLogEntries(output); !slices.Equal(got, want) {
+		t.Errorf("parseLogEntries = %+v, want %+v", got, want)
+	}
+	if got := parseLogEntries(""); got == nil || len(got) != 0 {
+		t.Errorf("parseLogEntries(\"\") = %#v, want an empty list", got)
+	}
+}
+
+// withReleaseCommitScope configures the release commit scope for the test
+func withReleaseCommitScope(t *testing.T, scope *string) {
+	t.Helper()
+	previous := releaseCommitScope
+	SetReleaseCommitScope(scope)
+	t.Cleanup(func() { releaseCommitScope = previous })
+}
+
+func TestReleaseCommitScope(t *testing.T) {
+	custom, empty := "release", ""
+
+	tests := []struct {
+		name        string
+		scope       *string
+		wantSubject string
+		release     []string
+		other       []string
+	}{
+		{
+			name:        "default",
+			wantSubject: "chore(neko-release): 1.2.0",
+			release:     []string{"chore(neko-release): 1.1.0"},
+			other:       []string{"chore(release): 1.1.0", "chore: release 1.1.0", "feat(neko-release): x"},
+		},
+		{
+			name:        "custom scope",
+			scope:       &custom,
+			wantSubject: "chore(release): 1.2.0",
+			release:     []string{"chore(release): 1.1.0", "chore(neko-release): 1.0.0"},
+			other:       []string{"chore: release 1.1.0", "chore(release-notes): x"},
+		},
+		{
+			name:        "no scope",
+			scope:       &empty,
+			wantSubject: "chore: release 1.2.0",
+			release:     []string{"chore: release 1.1.0", "chore(neko-release): 1.0.0"},
+			other:       []string{"chore: bump deps", "chore(release): 1.1.0"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			withReleaseCommitScope(t, tt.scope)
+
+			if got := ReleaseCommitSubject("1.2.0"); got != tt.wantSubject {
+				t.Errorf("ReleaseCommitSubject = %q, want %q", got, tt.wantSubject)
+			}
+			if !IsReleaseCommit(tt.wantSubject) {
+				t.Errorf("IsReleaseCommit(%q) = false for its own subject", tt.wantSubject)
+			}
+			for _, subject := range tt.release {
+				if !IsReleaseCommit(subject) {
+					t.Errorf("IsReleaseCommit(%q) = false, want true", subject)
+				}
+			}
+			for _, subject := range tt.other {
+				if IsReleaseCommit(subject) {
+					t.Errorf("IsReleaseCommit(%q) = true, want false", subject)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+// Package git includes operations using git or git-cli
+package git
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/nekoman-hq/neko-cli/pkg/log"
+)
+
+// WorktreeInfo describes the git directory layout of the current checkout.
+// In a linked worktree .git is a file and objects/refs live in the common dir.
+type WorktreeInfo struct {
+	GitDir    string
+	CommonDir string
+	Linked    bool
+}
+
+// Worktree detects whether the current directory is inside a (linked) work tree
+func Worktree() (*WorktreeInfo, error) {
+	log.PluginV(log.Preflight, fmt.Sprintf("%s (Detect work tree)",
+		log.ColorText(log.ColorGreen, "git rev-parse --is-inside-work-tree --git-dir --git-common-dir"),
+	))
+
+	cmd := exec.Command("git", "rev-parse", "--is-inside-work-tree", "--git-dir", "--git-common-dir")
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return nil, fmt.Errorf("not a Git Repository: %s", strings.TrimSpace(text(output)))
+	}
+
+	return parseWorktree(string(output))
+}
+
+// parseWorktree parses the output of git rev-parse --is-inside-work-tree --git-dir --git-common-dir
+func parseWorktree(output string) (*WorktreeInfo, error) {
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	if len(lines) < 3 {
+		return nil, fmt.Errorf("unexpected git rev-parse output: %s", output)
+	}
+	if strings.TrimSpace(lines[0]) != "true" {
+		return nil, errors.New("not inside a git work tree")
+	}
+
+	gitDir, _ := filepath.Abs(strings.TrimSpace(lines[1]))
+	commonDir, _ := filepath.Abs(strings.TrimSpace(lines[2]))
+
+	info := &WorktreeInfo{
+		GitDir:    gitDir,
+		CommonDir: commonDir,
+		Linked:    gitDir != commonDir,
+	}
+	if info.Linked {
+		log.PluginV(log.Preflight, fmt.Sprintf("Running inside linked worktree (common dir: %s)",
+			log.ColorText(log.ColorGreen, commonDir)))
+	}
+	return info, nil
+}
@@ -0,0 +1,70 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nekoman-hq/neko-cli/plugin/release/internal/gittest"
+)
+
+func TestParseWorktree(t *testing.T) {
+	tests := []struct {
+		name       string
+		output     string
+		wantLinked bool
+		wantErr    bool
+	}{
+		{"main work tree", "true\n/repo/.git\n/repo/.git\n", false, false},
+		{"linked work tree", "true\n/repo/.git/worktrees/hotfix\n/repo/.git\n", true, false},
+		{"bare repository", "false\n/repo.git\n/repo.git\n", false, true},
+		{"truncated output", "true\n", false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info, err := parseWorktree(tt.output)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseWorktree error = %v, want error %t", err, tt.wantErr)
+			}
+			if err == nil && info.Linked != tt.wantLinked {
+				t.Errorf("Linked = %t, want %t", info.Linked, tt.wantLinked)
+			}
+		})
+	}
+}
+
+func TestWorktreeLinked(t *testing.T) {
+	repo := gittest.NewRepo(t)
+	gittest.Commit(t, "feat: initial")
+
+	info, err := Worktree()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Linked {
+		t.Errorf("main work tree reported as linked: %+v", info)
+	}
+	commonDir := info.CommonDir
+
+	linked := filepath.Join(t.TempDir(), "hotfix")
+	gittest.Run(t, "worktree", "add", "-q", "-b", "hotfix", linked)
+	t.Chdir(linked)
+
+	if fi, err := os.Stat(".git"); err != nil || fi.IsDir() {
+		t.Fatalf(".git of the linked worktree is not a file: %v", err)
+	}
+	info, err = Worktree()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !info.Linked {
+		t.Errorf("linked worktree not detected: %+v", info)
+	}
+	if info.CommonDir != commonDir {
+		t.Errorf("CommonDir = %s, want the git dir of %s (%s)", info.CommonDir, repo, commonDir)
+	}
+	if info.GitDir == info.CommonDir {
+		t.Errorf("GitDir = CommonDir = %s, want the per-worktree git dir", info.GitDir)
+	}
+}
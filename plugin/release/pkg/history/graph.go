@@ -0,0 +1,69 @@
+package history
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/nekoman-hq/neko-cli/plugin/release/pkg/release"
+)
+
+// GraphLine is a parsed line of git log --graph. Lines only continuing
+// the graph (e.g. merges) have an empty commit.
+type GraphLine struct {
+	Graph   string
+	Commit  string
+	Refs    string
+	Subject string
+}
+
+// GraphRange returns the range graphed by --graph: from the previous release tag to HEAD,
+// so the latest release and unreleased commits are shown. Without two version tags the whole history is used.
+func GraphRange(tags []string) string {
+	type versionTag struct {
+		tag string
+		v   *semver.Version
+	}
+
+	versions := make([]versionTag, 0, len(tags))
+	for _, tag := range tags {
+		if v, err := release.ParseVersion(tag); err == nil {
+			versions = append(versions, versionTag{tag, v})
+		}
+	}
+	if len(versions) < 2 {
+		return "HEAD"
+	}
+
+	sort.Slice(versions, func(i, j int) bool {
+		return versions[i].v.LessThan(versions[j].v)
+	})
+	return versions[len(versions)-2].tag + "..HEAD"
+}
+
+// ParseGraph parses "<graph><hash>\t<refs>\t<subject>" lines as written by git.LogGraph
+func ParseGraph(output string) []GraphLine {
+	lines := make([]GraphLine, 0)
+	for _, raw := range strings.Split(strings.TrimRight(output, "\n"), "\n") {
+		if raw == "" {
+			continue
+		}
+
+		head, rest, ok := strings.Cut(raw, "\t")
+		if !ok {
+			lines = append(lines, GraphLine{Graph: strings.TrimRight(raw, " ")})
+			continue
+		}
+
+		// The hash is the last word before the first tab, everything in front of it is graph
+		split := strings.LastIndex(head, " ") + 1
+		refs, subject, _ := strings.Cut(rest, "\t")
+		lines = append(lines, GraphLine{
+			Graph:   strings.TrimRight(head[:split], " "),
+			Commit:  head[split:],
+			Refs:    refs,
+			Subject: subject,
+		})
+	}
+	return lines
+}
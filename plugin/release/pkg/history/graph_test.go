@@ -0,0 +1,96 @@
+package history
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/nekoman-hq/neko-cli/pkg/plugin"
+	"github.com/nekoman-hq/neko-cli/plugin/release/internal/gittest"
+)
+
+func TestGraphRange(t *testing.T) {
+	tests := []struct {
+		name string
+		tags []string
+		want string
+	}{
+		{"no tags", nil, "HEAD"},
+		{"one tag", []string{"v1.0.0"}, "HEAD"},
+		{"two tags", []string{"v1.0.0", "v1.1.0"}, "v1.0.0..HEAD"},
+		{"semver order", []string{"v1.10.0", "v1.9.0", "v1.2.0"}, "v1.9.0..HEAD"},
+		{"non-version tags are ignored", []string{"v1.0.0", "latest", "v2.0.0", "nightly"}, "v1.0.0..HEAD"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GraphRange(tt.tags); got != tt.want {
+				t.Errorf("GraphRange(%q) = %q, want %q", tt.tags, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseGraph(t *testing.T) {
+	output := "*   abc1234\tHEAD -> main\tMerge branch 'side'\n" +
+		"|\\  \n" +
+		"| * def5678\t\tfix: side\n" +
+		"|/  \n" +
+		"* 0123abc\ttag: v1.0.0\tfeat: tabs\tin subject\n"
+
+	want := []GraphLine{
+		{Graph: "*", Commit: "abc1234", Refs: "HEAD -> main", Subject: "Merge branch 'side'"},
+		{Graph: "|\\"},
+		{Graph: "| *", Commit: "def5678", Subject: "fix: side"},
+		{Graph: "|/"},
+		{Graph: "*", Commit: "0123abc", Refs: "tag: v1.0.0", Subject: "feat: tabs\tin subject"},
+	}
+	if got := ParseGraph(output); !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseGraph =\n%+v\nwant\n%+v", got, want)
+	}
+	if got := ParseGraph(""); got == nil || len(got) != 0 {
+		t.Errorf("ParseGraph(\"\") = %#v, want an empty list", got)
+	}
+}
+
+func TestHandleHistoryGraph(t *testing.T) {
+	gittest.NewRepo(t)
+	gittest.Commit(t, "feat: one")
+	gittest.Run(t, "tag", "v1.0.0")
+	gittest.Commit(t, "feat: two")
+	gittest.Run(t, "tag", "v1.1.0")
+	gittest.Run(t, "checkout", "-q", "-b", "side")
+	gittest.Commit(t, "fix: side")
+	gittest.Run(t, "checkout", "-q", "main")
+	gittest.Run(t, "merge", "-q", "--no-ff", "--no-edit", "side")
+
+	resp, err := HandleHistory(plugin.Request{Command: "history", Flags: map[string]any{"graph": true}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Data["range"] != "v1.0.0..HEAD" {
+		t.Errorf("range = %v, want v1.0.0..HEAD", resp.Data["range"])
+	}
+
+	var subjects []string
+	continuations := 0
+	for _, item := range resp.Data["items"].([]map[string]any) {
+		subject, ok := item["subject"].(string)
+		if !ok {
+			// Graph-only lines leave the other columns out so they render blank
+			if _, hasCommit := item["commit"]; hasCommit {
+				t.Errorf("graph-only line %v has a commit", item)
+			}
+			continuations++
+			continue
+		}
+		subjects = append(subjects, subject)
+	}
+
+	want := []string{"Merge branch 'side'", "fix: side", "feat: two"}
+	if !reflect.DeepEqual(subjects, want) {
+		t.Errorf("subjects = %q, want %q", subjects, want)
+	}
+	if continuations == 0 {
+		t.Error("the merge drew no graph-only lines")
+	}
+}
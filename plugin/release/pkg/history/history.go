@@ -7,6 +7,7 @@ package history
 */
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/nekoman-hq/neko-cli/pkg/log"
@@ -14,31 +15,44 @@ import (
 	"github.com/nekoman-hq/neko-cli/plugin/release/pkg/git"
 )
 
-func HandleHistory() (*plugin.Response, error) {
+func HandleHistory(req plugin.Request) (*plugin.Response, error) {
 	log.PluginPrint(log.Exec, "Starting release history")
 
+	if graph, _ := req.Flags["graph"].(bool); graph {
+		return handleGraph()
+	}
+
+	// Only the listed subjects are capped, the commit counts stay accurate
+	maxCommits := getFlagInt(req.Flags, "max-commits")
+
 	tagList := git.GetTags()
 	log.PluginV(log.Exec, "Found %d tags", len(tagList))
 
+	counter := &rangeCounter{}
+
 	// Build tag history with commit counts between tags
 	items := make([]map[string]any, 0, len(tagList))
 	for i := range tagList {
-		var commitCount int
 		var from string
-
-		if i == 0 {
-			commitCount = git.CountCommitsBetween("", tagList[i])
-			from = ""
-		} else {
-			commitCount = git.CountCommitsBetween(tagList[i-1], tagList[i])
+		if i > 0 {
 			from = tagList[i-1]
 		}
+		commitCount := counter.count(from, tagList[i])
 
-		items = append(items, map[string]any{
+		item := map[string]any{
 			"version": tagList[i],
 			"from":    from,
 			"commits": commitCount,
-		})
+		}
+		if maxCommits > 0 {
+			subjects, err := git.CommitSubjectsBetween(from, tagList[i], maxCommits)
+			if err != nil {
+				log.PluginV(log.Exec, "Skipping commit preview: %s", err.Error())
+			}
+			item["changes"] = PreviewCommits(subjects, commitCount, maxCommits)
+		}
+
+		items = append(items, item)
 	}
 
 	log.PluginPrint(log.Exec, "Release history completed")
@@ -54,5 +68,94 @@ func HandleHistory() (*plugin.Response, error) {
 		Data: map[string]any{
 			"items": items,
 		},
+		ColumnOrder: []string{"version", "from", "commits", "changes"},
+	}, nil
+}
+
+// handleGraph renders the commit graph between the previous release tag and HEAD
+func handleGraph() (*plugin.Response, error) {
+	rng := GraphRange(git.GetTags())
+	output, err := git.LogGraph(rng)
+	if err != nil {
+		return nil, err
+	}
+
+	lines := ParseGraph(output)
+	items := make([]map[string]any, 0, len(lines))
+	for _, l := range lines {
+		// Empty fields are left out so they render blank instead of <none>
+		item := map[string]any{"graph": l.Graph}
+		for key, value := range map[string]string{"commit": l.Commit, "refs": l.Refs, "subject": l.Subject} {
+			if value != "" {
+				item[key] = value
+			}
+		}
+		items = append(items, item)
+	}
+
+	log.PluginPrint(log.Exec, "Commit graph of %s completed", log.ColorText(log.ColorCyan, rng))
+
+	return &plugin.Response{
+		Status: "success",
+		Metadata: plugin.ResponseMetadata{
+			Plugin:    "release",
+			Version:   "1.0.0",
+			Command:   "history",
+			Timestamp: time.Now(),
+		},
+		Data: map[string]any{
+			"range": rng,
+			"items": items,
+		},
+		ColumnOrder: []string{"graph", "commit", "refs", "subject"},
 	}, nil
 }
+
+// rangeCounter counts the commits between tags from a commit graph loaded once per invocation.
+// If the graph cannot be loaded or does not contain a tag, git is asked per range instead.
+type rangeCounter struct {
+	graph  *git.CommitGraph
+	loaded bool
+}
+
+func (rc *rangeCounter) count(from, to string) int {
+	if !rc.loaded {
+		rc.loaded = true
+		graph, err := git.LoadCommitGraph()
+		if err != nil {
+			log.PluginV(log.Exec, "Counting commits per tag instead: %s", err.Error())
+		}
+		rc.graph = graph
+	}
+
+	if rc.graph != nil {
+		if count, err := rc.graph.CountBetween(from, to); err == nil {
+			return count
+		}
+	}
+	return git.CountCommitsBetween(from, to)
+}
+
+// PreviewCommits caps the commit subjects at limit and appends a line with the number of omitted commits
+func PreviewCommits(subjects []string, total, limit int) []string {
+	if len(subjects) > limit {
+		subjects = subjects[:limit]
+	}
+	preview := append([]string{}, subjects...)
+	if hidden := total - len(preview); hidden > 0 {
+		preview = append(preview, fmt.Sprintf("%sand %d more", log.Glyph("\u2026", "..."), hidden))
+	}
+	return preview
+}
+
+// getFlagInt reads an int flag, which arrives as float64 after JSON decoding
+func getFlagInt(flags map[string]any, name string) int {
+	switch v := flags[name].(type) {
+	case float64:
+		return int(v)
+	case int:
+		return v
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,244 @@
+package history
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/nekoman-hq/neko-cli/pkg/log"
+	"github.com/nekoman-hq/neko-cli/pkg/plugin"
+	"github.com/nekoman-hq/neko-cli/plugin/release/internal/gittest"
+	"github.com/nekoman-hq/neko-cli/plugin/release/pkg/git"
+)
+
+// countGitCalls puts a git wrapper on PATH that counts its invocations in the returned file
+func countGitCalls(t *testing.T) string {
+	t.Helper()
+	realGit, err := exec.LookPath("git")
+	if err != nil {
+		t.Skip("git not installed")
+	}
+
+	bin := t.TempDir()
+	calls := filepath.Join(bin, "calls")
+	script := fmt.Sprintf("#!/bin/sh\necho >> %q\nexec %q \"$@\"\n", calls, realGit)
+	if err := os.WriteFile(filepath.Join(bin, "git"), []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+	return calls
+}
+
+// gitCalls returns the invocations counted since the last call and resets the counter
+func gitCalls(t *testing.T, calls string) int {
+	t.Helper()
+	data, err := os.ReadFile(calls)
+	if os.IsNotExist(err) {
+		return 0
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = os.Remove(calls)
+	return strings.Count(string(data), "\n")
+}
+
+// taggedRepo creates a repository with a merged side branch and a tag every other commit
+func taggedRepo(t *testing.T, commits int) {
+	t.Helper()
+	gittest.NewRepo(t)
+	for i := 1; i <= commits; i++ {
+		gittest.Run(t, "commit", "-q", "--allow-empty", "-m", fmt.Sprintf("feat: commit %d", i))
+		if i == commits/2 {
+			gittest.Run(t, "checkout", "-q", "-b", "side")
+			gittest.Run(t, "commit", "-q", "--allow-empty", "-m", "fix: side")
+			gittest.Run(t, "checkout", "-q", "main")
+			gittest.Run(t, "merge", "-q", "--no-ff", "--no-edit", "side")
+		}
+		if i%2 == 0 {
+			gittest.Run(t, "tag", fmt.Sprintf("v0.%d.0", i))
+		}
+	}
+}
+
+// The history counts come from one git log pass instead of one git rev-list per tag
+func TestRangeCounterGitInvocations(t *testing.T) {
+	taggedRepo(t, 20)
+	calls := countGitCalls(t)
+
+	tags := git.GetTags()
+	gitCalls(t, calls)
+
+	perRange := make([]int, len(tags))
+	for i, tag := range tags {
+		perRange[i] = git.CountCommitsBetween(previous(tags, i), tag)
+	}
+	before := gitCalls(t, calls)
+
+	counter := &rangeCounter{}
+	for i, tag := range tags {
+		if got := counter.count(previous(tags, i), tag); got != perRange[i] {
+			t.Errorf("count(%q, %q) = %d, git rev-list --count = %d", previous(tags, i), tag, got, perRange[i])
+		}
+	}
+	after := gitCalls(t, calls)
+
+	t.Logf("git invocations for %d tags: %d per range, %d with the commit graph", len(tags), before, after)
+	if before != len(tags) {
+		t.Errorf("per-range counting ran git %d times, want %d", before, len(tags))
+	}
+	if after != 2 {
+		t.Errorf("commit graph counting ran git %d times, want 2 (show-ref and log)", after)
+	}
+}
+
+// Tags the graph does not know, e.g. created after it was loaded, are counted with git rev-list
+func TestRangeCounterFallsBackToRevList(t *testing.T) {
+	taggedRepo(t, 4)
+	calls := countGitCalls(t)
+
+	counter := &rangeCounter{}
+	// Two commits, the side commit and the merge
+	if got := counter.count("", "v0.2.0"); got != 4 {
+		t.Fatalf("count(v0.2.0) = %d, want 4", got)
+	}
+	gitCalls(t, calls)
+
+	gittest.Run(t, "tag", "late", "HEAD")
+	gitCalls(t, calls)
+
+	if got := counter.count("v0.2.0", "late"); got != 2 {
+		t.Errorf("count(v0.2.0, late) = %d, want 2", got)
+	}
+	if n := gitCalls(t, calls); n != 1 {
+		t.Errorf("fallback ran git %d times, want a single rev-list", n)
+	}
+}
+
+func previous(tags []string, i int) string {
+	if i == 0 {
+		return ""
+	}
+	return tags[i-1]
+}
+
+func TestPreviewCommits(t *testing.T) {
+	log.SetUnicode(true)
+	t.Cleanup(log.ResetUnicode)
+
+	subjects := []string{"feat: a", "fix: b", "chore: c"}
+	tests := []struct {
+		name     string
+		subjects []string
+		total    int
+		limit    int
+		want     []string
+	}{
+		{"below the limit", subjects, 3, 5, []string{"feat: a", "fix: b", "chore: c"}},
+		{"at the limit", subjects, 3, 3, []string{"feat: a", "fix: b", "chore: c"}},
+		{"capped", subjects, 3, 2, []string{"feat: a", "fix: b", "\u2026and 1 more"}},
+		{"more commits than listed", subjects[:2], 12, 2, []string{"feat: a", "fix: b", "\u2026and 10 more"}},
+		{"no subjects", nil, 4, 2, []string{"\u2026and 4 more"}},
+		{"empty range", nil, 0, 2, []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := PreviewCommits(tt.subjects, tt.total, tt.limit); !slices.Equal(got, tt.want) {
+				t.Errorf("PreviewCommits = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPreviewCommitsASCII(t *testing.T) {
+	log.SetUnicode(false)
+	t.Cleanup(log.ResetUnicode)
+
+	got := PreviewCommits([]string{"feat: a", "fix: b"}, 2, 1)
+	if want := []string{"feat: a", "...and 1 more"}; !slices.Equal(got, want) {
+		t.Errorf("PreviewCommits = %q, want %q", got, want)
+	}
+}
+
+func TestHandleHistoryMaxCommits(t *testing.T) {
+	log.SetUnicode(true)
+	t.Cleanup(log.ResetUnicode)
+
+	gittest.NewRepo(t)
+	for i := 1; i <= 4; i++ {
+		gittest.Commit(t, fmt.Sprintf("feat: commit %d", i))
+	}
+	gittest.Run(t, "tag", "v0.1.0")
+	gittest.Commit(t, "fix: commit 5")
+	gittest.Run(t, "tag", "v0.1.1")
+
+	tests := []struct {
+		name       string
+		maxCommits any
+		want       map[string][]string
+	}{
+		{"disabled", nil, nil},
+		{"capped", float64(2), map[string][]string{
+			"v0.1.0": {"feat: commit 4", "feat: commit 3", "\u2026and 2 more"},
+			"v0.1.1": {"fix: commit 5"},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flags := map[string]any{}
+			if tt.maxCommits != nil {
+				flags["max-commits"] = tt.maxCommits
+			}
+			resp, err := HandleHistory(plugin.Request{Command: "history", Flags: flags})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			items := resp.Data["items"].([]map[string]any)
+			if len(items) != 2 {
+				t.Fatalf("got %d items, want 2", len(items))
+			}
+			for _, item := range items {
+				version := item["version"].(string)
+				changes, ok := item["changes"].([]string)
+				if tt.want == nil {
+					if ok {
+						t.Errorf("%s has changes %q without --max-commits", version, changes)
+					}
+					continue
+				}
+				if !slices.Equal(changes, tt.want[version]) {
+					t.Errorf("%s changes = %q, want %q", version, changes, tt.want[version])
+				}
+			}
+			// The count is not capped with the preview
+			if items[0]["commits"] != 4 {
+				t.Errorf("v0.1.0 commits = %v, want 4", items[0]["commits"])
+			}
+		})
+	}
+}
+
+func TestGetFlagInt(t *testing.T) {
+	tests := []struct {
+		value any
+		want  int
+	}{
+		{float64(5), 5},
+		{7, 7},
+		{"5", 0},
+		{nil, 0},
+	}
+
+	for _, tt := range tests {
+		if got := getFlagInt(map[string]any{"max-commits": tt.value}, "max-commits"); got != tt.want {
+			t.Errorf("getFlagInt(%#v) = %d, want %d", tt.value, got, tt.want)
+		}
+	}
+}
@@ -9,6 +9,8 @@ package init
 
 import (
 	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/nekoman-hq/neko-cli/pkg/log"
@@ -66,7 +68,7 @@ func HandleInit(req plugin.Request) (*plugin.Response, error) {
 				Message: err.Error(),
 				Details: map[string]any{
 					"required_flags": []string{"project-type", "release-system"},
-					"optional_flags": []string{"version", "force"},
+					"optional_flags": []string{"version", "release-args", "force", "commit"},
 				},
 			},
 		}, nil
@@ -141,10 +143,46 @@ func HandleInit(req plugin.Request) (*plugin.Response, error) {
 		log.PluginPrint(log.Init, "Release system %s initialized", cfg.ReleaseSystem)
 	}
 
+	// Report the files init wrote, also those that already had uncommitted changes
+	createdFiles := []string{config.FileName}
+	if f, ok := releaser.(release.InitFiler); ok {
+		createdFiles = append(createdFiles, f.InitFiles()...)
+	}
+
+	committed := false
+	if toCommit := changedFiles(createdFiles); getFlagBool(req.Flags, "commit") && len(toCommit) > 0 {
+		if err := git.CommitFiles("chore: neko init", toCommit); err != nil {
+			return &plugin.Response{
+				Status: "error",
+				Metadata: plugin.ResponseMetadata{
+					Plugin:    PluginName,
+					Version:   PluginVersion,
+					Command:   "init",
+					Timestamp: time.Now(),
+				},
+				Error: &plugin.ResponseError{
+					Code:    "COMMIT_FAILED",
+					Message: err.Error(),
+					Details: map[string]any{
+						"created_files": createdFiles,
+					},
+				},
+			}, nil
+		}
+		committed = true
+		log.PluginPrint(log.Init, "Committed generated files")
+	}
+
 	log.PluginPrint(log.Init, "Initialization completed successfully")
 
 	// Build next steps based on release system
 	nextSteps := buildNextSteps(cfg)
+	if !committed && len(createdFiles) > 0 {
+		nextSteps = append([]string{
+			fmt.Sprintf("Commit the generated files before releasing: git add %s && git commit -m \"chore: neko init\" (or re-run with --commit)",
+				strings.Join(createdFiles, " ")),
+		}, nextSteps...)
+	}
 
 	return &plugin.Response{
 		Status: "success",
@@ -161,7 +199,10 @@ func HandleInit(req plugin.Request) (*plugin.Response, error) {
 			"project_type":   string(cfg.ProjectType),
 			"release_system": string(cfg.ReleaseSystem),
 			"version":        cfg.Version,
+			"release_args":   cfg.ReleaseArgs,
 			"next_steps":     nextSteps,
+			"created_files":  createdFiles,
+			"committed":      committed,
 		},
 		RendererHint: "text",
 	}, nil
@@ -190,12 +231,45 @@ func GetAvailableOptions() (*plugin.Response, error) {
 			"required":    false,
 			"description": "Initial version (default: 0.1.0)",
 		},
+		{
+			"option":      "release-args",
+			"values":      "arguments (e.g. \"--skip=announce\")",
+			"required":    false,
+			"description": "Extra arguments for the release command (default: per project type)",
+		},
 		{
 			"option":      "force",
 			"values":      "true, false",
 			"required":    false,
 			"description": "Overwrite existing config",
 		},
+		{
+			"option":      "commit",
+			"values":      "true, false",
+			"required":    false,
+			"description": "Commit the generated files",
+		},
+	}
+
+	recommendations := make(map[string]string)
+	recommendationItems := make([]map[string]any, 0)
+	defaultArgs := make(map[string]map[string][]string)
+	for _, projectType := range []config.ProjectType{config.ProjectTypeFrontend, config.ProjectTypeBackend, config.ProjectTypeOther} {
+		recommended := config.RecommendedReleaseSystem(projectType)
+		recommendations[string(projectType)] = string(recommended)
+		recommendationItems = append(recommendationItems, map[string]any{
+			"project-type":   string(projectType),
+			"release-system": string(recommended),
+			"release-args":   strings.Join(config.DefaultReleaseArgs(projectType, recommended), " "),
+		})
+
+		args := make(map[string][]string)
+		for _, system := range []config.ReleaseSystem{config.ReleaseTypeReleaseIt, config.ReleaseTypeJReleaser, config.ReleaseTypeGoReleaser} {
+			if a := config.DefaultReleaseArgs(projectType, system); len(a) > 0 {
+				args[string(system)] = a
+			}
+		}
+		defaultArgs[string(projectType)] = args
 	}
 
 	return &plugin.Response{
@@ -207,12 +281,14 @@ func GetAvailableOptions() (*plugin.Response, error) {
 			Timestamp: time.Now(),
 		},
 		Data: map[string]any{
-			"items": items,
-			"recommendations": map[string]string{
-				"frontend": string(config.ReleaseTypeReleaseIt),
-				"backend":  string(config.ReleaseTypeJReleaser),
-				"other":    string(config.ReleaseTypeGoReleaser),
+			// The table shows both lists, the flat keys stay for JSON consumers
+			"sections": []map[string]any{
+				{"title": "Options", "items": items, "column_order": []string{"option", "values", "required", "description"}},
+				{"title": "Recommendations", "items": recommendationItems, "column_order": []string{"project-type", "release-system", "release-args"}},
 			},
+			"items":                items,
+			"recommendations":      recommendations,
+			"default_release_args": defaultArgs,
 		},
 		RendererHint: "table",
 	}, nil
@@ -248,9 +324,30 @@ func buildConfigFromFlags(flags map[string]any) (config.NekoConfig, error) {
 	}
 	cfg.Version = version
 
+	// Get release args (optional, defaults depend on project type and release system)
+	if _, ok := flags["release-args"]; ok {
+		cfg.ReleaseArgs = strings.Fields(getFlagString(flags, "release-args"))
+	} else {
+		cfg.ReleaseArgs = config.DefaultReleaseArgs(cfg.ProjectType, cfg.ReleaseSystem)
+		if len(cfg.ReleaseArgs) > 0 {
+			log.PluginV(log.Init, "Using default release args for %s: %s", cfg.ProjectType, strings.Join(cfg.ReleaseArgs, " "))
+		}
+	}
+
 	return cfg, nil
 }
 
+// changedFiles returns the files git reports as changed, e.g. a rewritten config may be unchanged
+func changedFiles(files []string) []string {
+	changed, err := git.ChangedFiles()
+	if err != nil {
+		return nil
+	}
+	return slices.DeleteFunc(slices.Clone(files), func(f string) bool {
+		return !slices.Contains(changed, f)
+	})
+}
+
 func getFlagString(flags map[string]any, key string) string {
 	if val, ok := flags[key]; ok {
 		if str, ok := val.(string); ok {
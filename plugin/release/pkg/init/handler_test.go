@@ -0,0 +1,235 @@
+package init
+
+import (
+	"context"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/nekoman-hq/neko-cli/pkg/plugin"
+	"github.com/nekoman-hq/neko-cli/plugin/release/internal/gittest"
+	"github.com/nekoman-hq/neko-cli/plugin/release/pkg/config"
+	"github.com/nekoman-hq/neko-cli/plugin/release/pkg/release"
+)
+
+// fakeGoReleaser writes a config and extends .gitignore like goreleaser init
+type fakeGoReleaser struct {
+	release.ToolBase
+}
+
+func (f *fakeGoReleaser) Name() string { return string(config.ReleaseTypeGoReleaser) }
+func (f *fakeGoReleaser) Init(*config.NekoConfig) error {
+	if err := os.WriteFile(".goreleaser.yaml", []byte("version: 2\n"), 0644); err != nil {
+		return err
+	}
+	gitignore, _ := os.ReadFile(".gitignore")
+	if !strings.Contains(string(gitignore), "dist/") {
+		if err := os.WriteFile(".gitignore", append(gitignore, "dist/\n"...), 0644); err != nil {
+			return err
+		}
+	}
+	f.RecordInitFiles(".goreleaser.yaml", ".gitignore")
+	return nil
+}
+func (f *fakeGoReleaser) Release(context.Context, *semver.Version) error { return nil }
+func (f *fakeGoReleaser) RevertRelease() error                           { return nil }
+
+var goreleaser = &fakeGoReleaser{}
+
+func init() {
+	release.Register(goreleaser)
+}
+
+func initRequest(commit bool) plugin.Request {
+	return plugin.Request{
+		Command: "init",
+		Flags: map[string]any{
+			"project-type":   "backend",
+			"release-system": "goreleaser",
+			"commit":         commit,
+		},
+	}
+}
+
+// initRepo creates a repository whose .gitignore already has uncommitted changes
+func initRepo(t *testing.T) {
+	t.Helper()
+	gittest.NewRepo(t)
+	goreleaser.ToolBase = release.ToolBase{}
+	gittest.WriteFile(t, ".gitignore", "*.log\n")
+	gittest.Run(t, "add", ".gitignore")
+	gittest.Run(t, "commit", "-q", "-m", "chore: initial")
+	gittest.WriteFile(t, ".gitignore", "*.log\n*.tmp\n")
+	gittest.WriteFile(t, "notes.txt", "not written by init\n")
+}
+
+func TestHandleInitCreatedFiles(t *testing.T) {
+	tests := []struct {
+		name   string
+		commit bool
+	}{
+		{"report", false},
+		{"commit", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			initRepo(t)
+
+			resp, err := HandleInit(initRequest(tt.commit))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if resp.Status != "success" {
+				t.Fatalf("status = %s, error = %+v", resp.Status, resp.Error)
+			}
+
+			// .gitignore had changes before init and is still reported, notes.txt is not
+			want := []string{config.FileName, ".goreleaser.yaml", ".gitignore"}
+			if got := resp.Data["created_files"].([]string); !slices.Equal(got, want) {
+				t.Errorf("created_files = %v, want %v", got, want)
+			}
+			if resp.Data["committed"] != tt.commit {
+				t.Errorf("committed = %v, want %t", resp.Data["committed"], tt.commit)
+			}
+
+			committed := strings.Fields(gittest.Run(t, "show", "--name-only", "--format=", "HEAD"))
+			if tt.commit {
+				slices.Sort(want)
+				if !slices.Equal(committed, want) {
+					t.Errorf("init commit contains %v, want %v", committed, want)
+				}
+			} else if !slices.Equal(committed, []string{".gitignore"}) {
+				t.Errorf("init committed %v without --commit", committed)
+			}
+		})
+	}
+}
+
+// A forced re-init rewrites the config without changes, --commit must not fail on it
+func TestHandleInitCommitUnchanged(t *testing.T) {
+	initRepo(t)
+	if resp, _ := HandleInit(initRequest(true)); resp.Status != "success" {
+		t.Fatalf("first init: %+v", resp.Error)
+	}
+	head := gittest.Run(t, "rev-parse", "HEAD")
+
+	req := initRequest(true)
+	req.Flags["force"] = true
+	resp, err := HandleInit(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Status != "success" {
+		t.Fatalf("status = %s, error = %+v", resp.Status, resp.Error)
+	}
+	if resp.Data["committed"] != false {
+		t.Error("committed = true although init changed nothing")
+	}
+	if got := gittest.Run(t, "rev-parse", "HEAD"); got != head {
+		t.Error("the re-init created a commit")
+	}
+}
+
+func TestBuildConfigReleaseArgs(t *testing.T) {
+	tests := []struct {
+		name          string
+		projectType   string
+		releaseSystem string
+		releaseArgs   any
+		want          []string
+	}{
+		{"backend goreleaser default", "backend", "goreleaser", nil, []string{"--skip=announce"}},
+		{"frontend release-it default", "frontend", "release-it", nil, []string{"--no-npm.publish"}},
+		{"other goreleaser has no default", "other", "goreleaser", nil, nil},
+		{"explicit args replace the default", "backend", "goreleaser", "--skip=publish --timeout 1h", []string{"--skip=publish", "--timeout", "1h"}},
+		{"empty args drop the default", "frontend", "release-it", "", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flags := map[string]any{"project-type": tt.projectType, "release-system": tt.releaseSystem}
+			if tt.releaseArgs != nil {
+				flags["release-args"] = tt.releaseArgs
+			}
+
+			cfg, err := buildConfigFromFlags(flags)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !slices.Equal(cfg.ReleaseArgs, tt.want) {
+				t.Errorf("ReleaseArgs = %q, want %q", cfg.ReleaseArgs, tt.want)
+			}
+		})
+	}
+}
+
+func TestHandleInitSavesDefaultReleaseArgs(t *testing.T) {
+	initRepo(t)
+
+	resp, err := HandleInit(initRequest(false))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Status != "success" {
+		t.Fatalf("status = %s, error = %+v", resp.Status, resp.Error)
+	}
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"--skip=announce"}; !slices.Equal(cfg.ReleaseArgs, want) {
+		t.Errorf("saved release-args = %q, want %q", cfg.ReleaseArgs, want)
+	}
+}
+
+func TestGetAvailableOptionsRecommendations(t *testing.T) {
+	resp, err := GetAvailableOptions()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sections := resp.Data["sections"].([]map[string]any)
+	if len(sections) != 2 || sections[0]["title"] != "Options" || sections[1]["title"] != "Recommendations" {
+		t.Fatalf("sections = %v, want Options and Recommendations", sections)
+	}
+	if columns := sections[1]["column_order"].([]string); !slices.Equal(columns, []string{"project-type", "release-system", "release-args"}) {
+		t.Errorf("recommendation columns = %q", columns)
+	}
+	// The flat keys stay for JSON consumers
+	if len(sections[0]["items"].([]map[string]any)) != len(resp.Data["items"].([]map[string]any)) {
+		t.Error("the Options section differs from items")
+	}
+
+	tests := []struct {
+		projectType   string
+		releaseSystem string
+		releaseArgs   string
+	}{
+		{"frontend", "release-it", "--no-npm.publish"},
+		{"backend", "jreleaser", ""},
+		{"other", "goreleaser", ""},
+	}
+
+	items := sections[1]["items"].([]map[string]any)
+	if len(items) != len(tests) {
+		t.Fatalf("recommendations = %v, want one per project type", items)
+	}
+	recommendations := resp.Data["recommendations"].(map[string]string)
+	for i, tt := range tests {
+		t.Run(tt.projectType, func(t *testing.T) {
+			want := map[string]any{"project-type": tt.projectType, "release-system": tt.releaseSystem, "release-args": tt.releaseArgs}
+			for key, value := range want {
+				if items[i][key] != value {
+					t.Errorf("%s = %v, want %q", key, items[i][key], value)
+				}
+			}
+			if recommendations[tt.projectType] != tt.releaseSystem {
+				t.Errorf("recommendations[%s] = %q, want %q", tt.projectType, recommendations[tt.projectType], tt.releaseSystem)
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+// Package lock includes the config-lock command handler
+package lock
+
+import (
+	"time"
+
+	"github.com/nekoman-hq/neko-cli/pkg/log"
+	"github.com/nekoman-hq/neko-cli/pkg/plugin"
+	"github.com/nekoman-hq/neko-cli/plugin/release/pkg/config"
+)
+
+const (
+	PluginName    = "release"
+	PluginVersion = "1.0.0"
+)
+
+// HandleLock records a checksum of .release.neko.json so later releases can detect tampering
+func HandleLock() (*plugin.Response, error) {
+	log.PluginPrint(log.Config, "Locking release configuration")
+
+	if _, err := config.LoadConfig(); err != nil {
+		return &plugin.Response{
+			Status: "error",
+			Metadata: plugin.ResponseMetadata{
+				Plugin:    PluginName,
+				Version:   PluginVersion,
+				Command:   "config-lock",
+				Timestamp: time.Now(),
+			},
+			Error: &plugin.ResponseError{
+				Code:    "CONFIG_INVALID",
+				Message: err.Error(),
+			},
+		}, nil
+	}
+
+	hash, err := config.WriteLock()
+	if err != nil {
+		return &plugin.Response{
+			Status: "error",
+			Metadata: plugin.ResponseMetadata{
+				Plugin:    PluginName,
+				Version:   PluginVersion,
+				Command:   "config-lock",
+				Timestamp: time.Now(),
+			},
+			Error: &plugin.ResponseError{
+				Code:    "LOCK_FAILED",
+				Message: err.Error(),
+			},
+		}, nil
+	}
+
+	log.PluginPrint(log.Config, "\uF00C Configuration locked")
+
+	return &plugin.Response{
+		Status: "success",
+		Metadata: plugin.ResponseMetadata{
+			Plugin:    PluginName,
+			Version:   PluginVersion,
+			Command:   "config-lock",
+			Timestamp: time.Now(),
+		},
+		Data: map[string]any{
+			"items": []map[string]any{
+				{
+					"property": "Configuration",
+					"value":    config.FileName,
+				},
+				{
+					"property": "Lock File",
+					"value":    config.LockFileName,
+				},
+				{
+					"property": "SHA256",
+					"value":    hash,
+				},
+			},
+		},
+		RendererHint: "table",
+		ColumnOrder:  []string{"property", "value"},
+	}, nil
+}
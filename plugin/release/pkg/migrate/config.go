@@ -0,0 +1,100 @@
+package migrate
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	config2 "github.com/nekoman-hq/neko-cli/pkg/config"
+	"github.com/nekoman-hq/neko-cli/pkg/log"
+	"github.com/nekoman-hq/neko-cli/pkg/plugin"
+	"github.com/nekoman-hq/neko-cli/plugin/release/pkg/config"
+)
+
+// HandleMigrateConfig consolidates an old .neko.json into the canonical .release.neko.json.
+// Fields of the canonical config win, the old file is deleted once confirmed.
+func HandleMigrateConfig(req plugin.Request) (*plugin.Response, error) {
+	if !config2.LegacyExists() {
+		return migrateConfigResponse(false, nil), nil
+	}
+
+	cfg, err := config.MergeLegacy()
+	if err != nil {
+		return migrateConfigError("MIGRATION_FAILED", err.Error(), map[string]any{
+			"hint": fmt.Sprintf("Fix %s or %s and re-run the migration", config2.LegacyFileName, config.FileName),
+		}), nil
+	}
+
+	if !req.Confirmed() {
+		resp := migrateConfigError("CONFIRMATION_REQUIRED",
+			fmt.Sprintf("Moving %s into %s deletes %s and requires confirmation",
+				config2.LegacyFileName, config.FileName, config2.LegacyFileName),
+			map[string]any{
+				"files": []string{config2.LegacyFileName},
+				"hint":  "Re-run with --yes to migrate and delete the file",
+			})
+		resp.Data = plugin.Plan{
+			Summary:  fmt.Sprintf("Merge %s into %s", config2.LegacyFileName, config.FileName),
+			Files:    []string{config.FileName, config2.LegacyFileName},
+			Commands: []string{"rm " + config2.LegacyFileName},
+		}.ConfirmationData()
+		return resp, nil
+	}
+
+	if err = config.SaveConfig(*cfg); err != nil {
+		return migrateConfigError("SAVE_ERROR", fmt.Sprintf("Failed to save configuration: %v", err), nil), nil
+	}
+	if err = os.Remove(config2.LegacyFileName); err != nil {
+		return migrateConfigError("REMOVE_FAILED",
+			fmt.Sprintf("%s was written, but %s could not be deleted: %v", config.FileName, config2.LegacyFileName, err), nil), nil
+	}
+	log.PluginPrint(log.Init, "\uF00C Moved %s into %s",
+		log.ColorText(log.ColorGreen, config2.LegacyFileName), log.ColorText(log.ColorGreen, config.FileName))
+
+	return migrateConfigResponse(true, cfg), nil
+}
+
+func migrateConfigResponse(migrated bool, cfg *config.NekoConfig) *plugin.Response {
+	items := []map[string]any{
+		{"property": "Config", "value": config.FileName},
+		{"property": "Migrated", "value": fmt.Sprintf("%t", migrated)},
+	}
+	if cfg != nil {
+		items = append(items,
+			map[string]any{"property": "Project Owner", "value": displayValue(cfg.ProjectOwner)},
+			map[string]any{"property": "Project Name", "value": displayValue(cfg.ProjectName)},
+			map[string]any{"property": "Release System", "value": string(cfg.ReleaseSystem)},
+			map[string]any{"property": "Version", "value": cfg.Version},
+		)
+	}
+
+	return &plugin.Response{
+		Status: "success",
+		Metadata: plugin.ResponseMetadata{
+			Plugin:    PluginName,
+			Version:   PluginVersion,
+			Command:   "migrate-config",
+			Timestamp: time.Now(),
+		},
+		Data: map[string]any{
+			"items":    items,
+			"migrated": migrated,
+		},
+		RendererHint: "table",
+		ColumnOrder:  []string{"property", "value"},
+	}
+}
+
+// displayValue returns the value for the table, "-" if it is empty
+func displayValue(v string) string {
+	if v == "" {
+		return "-"
+	}
+	return v
+}
+
+func migrateConfigError(code, message string, details map[string]any) *plugin.Response {
+	resp := errorResponse(code, message, details)
+	resp.Metadata.Command = "migrate-config"
+	return resp
+}
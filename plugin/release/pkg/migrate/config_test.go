@@ -0,0 +1,80 @@
+package migrate
+
+import (
+	"os"
+	"testing"
+
+	config2 "github.com/nekoman-hq/neko-cli/pkg/config"
+	"github.com/nekoman-hq/neko-cli/pkg/plugin"
+	"github.com/nekoman-hq/neko-cli/plugin/release/pkg/config"
+)
+
+const legacyConfig = `{"project-name": "neko-cli", "project-owner": "nekoman-hq", "project-type": "backend", "release-system": "jreleaser", "version": "0.9.0"}`
+
+func TestHandleMigrateConfig(t *testing.T) {
+	tests := []struct {
+		name         string
+		legacy       string
+		yes          bool
+		wantCode     string
+		wantMigrated bool
+		wantLegacy   bool
+	}{
+		{name: "no legacy config"},
+		{name: "unconfirmed", legacy: legacyConfig, wantCode: "CONFIRMATION_REQUIRED", wantLegacy: true},
+		{name: "confirmed", legacy: legacyConfig, yes: true, wantMigrated: true},
+		{name: "invalid legacy config", legacy: "{", yes: true, wantCode: "MIGRATION_FAILED", wantLegacy: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// The canonical config only has the version, the legacy file brings owner and name
+			setupProject(t, nil)
+			if tt.legacy != "" {
+				if err := os.WriteFile(config2.LegacyFileName, []byte(tt.legacy), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			resp, err := HandleMigrateConfig(plugin.Request{
+				Command: "migrate-config",
+				Context: plugin.Context{AssumeYes: tt.yes},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if tt.wantCode != "" {
+				if resp.Error == nil || resp.Error.Code != tt.wantCode {
+					t.Fatalf("error = %+v, want %s", resp.Error, tt.wantCode)
+				}
+			} else {
+				if resp.Status != "success" {
+					t.Fatalf("status = %s, error = %+v", resp.Status, resp.Error)
+				}
+				if resp.Data["migrated"] != tt.wantMigrated {
+					t.Errorf("migrated = %v, want %t", resp.Data["migrated"], tt.wantMigrated)
+				}
+			}
+
+			if config2.LegacyExists() != tt.wantLegacy {
+				t.Errorf("%s exists = %t, want %t", config2.LegacyFileName, config2.LegacyExists(), tt.wantLegacy)
+			}
+
+			cfg, err := config.LoadConfig()
+			if err != nil {
+				t.Fatal(err)
+			}
+			wantName, wantOwner := "", ""
+			if tt.wantMigrated {
+				wantName, wantOwner = "neko-cli", "nekoman-hq"
+			}
+			if cfg.ProjectName != wantName || cfg.ProjectOwner != wantOwner {
+				t.Errorf("project = %q/%q, want %q/%q", cfg.ProjectOwner, cfg.ProjectName, wantOwner, wantName)
+			}
+			if cfg.Version != "1.0.0" || cfg.ReleaseSystem != config.ReleaseTypeJReleaser {
+				t.Errorf("config = %s %s, want the canonical fields to win", cfg.ReleaseSystem, cfg.Version)
+			}
+		})
+	}
+}
@@ -0,0 +1,234 @@
+// Package migrate includes the migrate-tool command handler that switches the release system
+package migrate
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/nekoman-hq/neko-cli/pkg/errors"
+	"github.com/nekoman-hq/neko-cli/pkg/log"
+	"github.com/nekoman-hq/neko-cli/pkg/plugin"
+	"github.com/nekoman-hq/neko-cli/plugin/release/pkg/config"
+	"github.com/nekoman-hq/neko-cli/plugin/release/pkg/release"
+)
+
+const (
+	PluginName    = "release"
+	PluginVersion = "1.0.0"
+)
+
+// HandleMigrateTool switches the release system in the config and initializes the new tool.
+// With --remove-old-config the config files of the previous tool are deleted once confirmed.
+func HandleMigrateTool(req plugin.Request) (*plugin.Response, error) {
+	if len(req.Args) != 1 {
+		return errorResponse("INVALID_ARGS", "Expected the release system to migrate to", map[string]any{
+			"hint": "Usage: neko release migrate-tool <release-it|jreleaser|goreleaser>",
+		}), nil
+	}
+
+	target := config.ReleaseSystem(req.Args[0])
+	if !target.IsValid() {
+		return errorResponse("INVALID_RELEASE_SYSTEM",
+			fmt.Sprintf("Unknown release system %q (must be: release-it, jreleaser, or goreleaser)", target), nil), nil
+	}
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return errorResponse("CONFIG_NOT_FOUND", err.Error(), map[string]any{
+			"hint": "Run 'neko release init' first to initialize the release configuration",
+		}), nil
+	}
+
+	previous := cfg.ReleaseSystem
+	if previous == target {
+		return errorResponse("ALREADY_MIGRATED",
+			fmt.Sprintf("%s is already the release system", target), nil), nil
+	}
+
+	releaser, err := release.Get(string(target))
+	if err != nil {
+		return errorResponse("RELEASE_SYSTEM_ERROR", err.Error(), nil), nil
+	}
+
+	keptArgs := Migrate(cfg, target)
+	if err = config.Validate(cfg); err != nil {
+		return errorResponse("VALIDATION_ERROR", err.Error(), map[string]any{
+			"hint": "Adjust release-systems in " + config.FileName + " so it starts with the new release system",
+		}), nil
+	}
+
+	removeOld := getFlagBool(req.Flags, "remove-old-config")
+	oldFiles := make([]string, 0)
+	if removeOld {
+		oldFiles = existingConfigFiles(previous)
+	}
+
+	if len(oldFiles) > 0 && !req.Confirmed() {
+		resp := errorResponse("CONFIRMATION_REQUIRED",
+			fmt.Sprintf("Removing the %s config requires confirmation", previous),
+			map[string]any{
+				"files": oldFiles,
+				"hint":  "Re-run with --yes to migrate and delete the files",
+			})
+		resp.Data = migratePlan(previous, target, oldFiles).ConfirmationData()
+		return resp, nil
+	}
+
+	log.PluginPrint(log.Init, "Migrating from %s to %s",
+		log.ColorText(log.ColorCyan, string(previous)), log.ColorText(log.ColorCyan, string(target)))
+
+	// The config is only saved once the new tool is set up, a failed init leaves the project untouched
+	if err = releaser.Init(cfg); err != nil {
+		return errorResponse("INIT_FAILED",
+			fmt.Sprintf("Initializing %s failed, %s was not changed: %s", target, config.FileName, err.Error()), nil), nil
+	}
+
+	if err = config.SaveConfig(*cfg); err != nil {
+		return errorResponse("SAVE_ERROR", fmt.Sprintf("Failed to save configuration: %v", err), nil), nil
+	}
+	log.PluginPrint(log.Init, "\uF00C Release system set to %s in %s",
+		log.ColorText(log.ColorGreen, string(target)), config.FileName)
+	if keptArgs {
+		errors.WriteWarning("Check release-args",
+			fmt.Sprintf("release-args %v were kept, they are passed to %s now", cfg.ReleaseArgs, target))
+	}
+
+	removed := make([]string, 0, len(oldFiles))
+	for _, file := range oldFiles {
+		if err := os.Remove(file); err != nil {
+			errors.WriteWarning("Failed to remove old config", err.Error())
+			continue
+		}
+		removed = append(removed, file)
+		log.PluginPrint(log.Init, "\uF00C Removed %s", log.ColorText(log.ColorGreen, file))
+	}
+
+	items := []map[string]any{
+		{"property": "From", "value": string(previous)},
+		{"property": "To", "value": string(target)},
+		{"property": "Release Args", "value": displayList(cfg.ReleaseArgs)},
+		{"property": "Removed Files", "value": displayList(removed)},
+	}
+	if !removeOld {
+		if leftover := existingConfigFiles(previous); len(leftover) > 0 {
+			items = append(items, map[string]any{
+				"property": "Next Step",
+				"value": fmt.Sprintf("Delete %s or re-run with --remove-old-config",
+					strings.Join(leftover, ", ")),
+			})
+		}
+	}
+
+	return &plugin.Response{
+		Status: "success",
+		Metadata: plugin.ResponseMetadata{
+			Plugin:    PluginName,
+			Version:   PluginVersion,
+			Command:   "migrate-tool",
+			Timestamp: time.Now(),
+		},
+		Data: map[string]any{
+			"items":         items,
+			"from":          string(previous),
+			"to":            string(target),
+			"release_args":  cfg.ReleaseArgs,
+			"removed_files": removed,
+		},
+		RendererHint: "table",
+		ColumnOrder:  []string{"property", "value"},
+	}, nil
+}
+
+// Migrate switches the release system of the config to target.
+// A fan-out in release-systems starts with the new system, and release args that were
+// the init defaults of the previous system are replaced by the defaults of the new one.
+// Returns true if custom release args were kept and should be checked for the new system.
+func Migrate(cfg *config.NekoConfig, target config.ReleaseSystem) bool {
+	previous := cfg.ReleaseSystem
+	cfg.ReleaseSystem = target
+
+	if len(cfg.ReleaseSystems) > 0 {
+		systems := slices.DeleteFunc(slices.Clone(cfg.ReleaseSystems), func(s config.ReleaseSystem) bool {
+			return s == previous || s == target
+		})
+		cfg.ReleaseSystems = append([]config.ReleaseSystem{target}, systems...)
+		if len(cfg.ReleaseSystems) == 1 {
+			cfg.ReleaseSystems = nil
+		}
+	}
+
+	if !slices.Equal(cfg.ReleaseArgs, config.DefaultReleaseArgs(cfg.ProjectType, previous)) {
+		return true
+	}
+	cfg.ReleaseArgs = config.DefaultReleaseArgs(cfg.ProjectType, target)
+	return false
+}
+
+// existingConfigFiles returns the config files of the release system that exist in the project
+func existingConfigFiles(system config.ReleaseSystem) []string {
+	files := make([]string, 0)
+	releaser, err := release.Get(string(system))
+	if err != nil {
+		return files
+	}
+	filer, ok := releaser.(release.ConfigFiler)
+	if !ok {
+		return files
+	}
+	for _, file := range filer.ConfigFiles() {
+		if _, err := os.Stat(file); err == nil {
+			files = append(files, file)
+		}
+	}
+	return files
+}
+
+// migratePlan describes the migration for the confirmation summary
+func migratePlan(from, to config.ReleaseSystem, files []string) plugin.Plan {
+	commands := make([]string, 0, len(files))
+	for _, file := range files {
+		commands = append(commands, "rm "+file)
+	}
+	return plugin.Plan{
+		Summary:  fmt.Sprintf("Migrate from %s to %s and delete the %s config", from, to, from),
+		Files:    append([]string{config.FileName}, files...),
+		Commands: commands,
+	}
+}
+
+// displayList joins the values for the table, "-" if there are none
+func displayList(values []string) string {
+	if len(values) == 0 {
+		return "-"
+	}
+	return strings.Join(values, ", ")
+}
+
+func getFlagBool(flags map[string]any, key string) bool {
+	if val, ok := flags[key]; ok {
+		if b, ok := val.(bool); ok {
+			return b
+		}
+	}
+	return false
+}
+
+func errorResponse(code, message string, details map[string]any) *plugin.Response {
+	return &plugin.Response{
+		Status: "error",
+		Metadata: plugin.ResponseMetadata{
+			Plugin:    PluginName,
+			Version:   PluginVersion,
+			Command:   "migrate-tool",
+			Timestamp: time.Now(),
+		},
+		Error: &plugin.ResponseError{
+			Code:    code,
+			Message: message,
+			Details: details,
+		},
+	}
+}
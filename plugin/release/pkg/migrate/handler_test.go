@@ -0,0 +1,217 @@
+package migrate
+
+import (
+	"context"
+	stderrors "errors"
+	"os"
+	"slices"
+	"testing"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/nekoman-hq/neko-cli/pkg/errors"
+	"github.com/nekoman-hq/neko-cli/pkg/plugin"
+	"github.com/nekoman-hq/neko-cli/plugin/release/pkg/config"
+	"github.com/nekoman-hq/neko-cli/plugin/release/pkg/release"
+)
+
+// fakeTool records Init calls instead of setting up a release tool
+type fakeTool struct {
+	release.ToolBase
+	name    string
+	files   []string
+	initErr error
+	inits   []config.NekoConfig
+}
+
+func (f *fakeTool) Name() string { return f.name }
+func (f *fakeTool) Init(cfg *config.NekoConfig) error {
+	f.inits = append(f.inits, *cfg)
+	return f.initErr
+}
+func (f *fakeTool) Release(context.Context, *semver.Version) error { return nil }
+func (f *fakeTool) RevertRelease() error                           { return nil }
+func (f *fakeTool) ConfigFiles() []string                          { return f.files }
+
+var fakeTools = map[config.ReleaseSystem]*fakeTool{
+	config.ReleaseTypeReleaseIt:  {name: string(config.ReleaseTypeReleaseIt), files: []string{".release-it.json"}},
+	config.ReleaseTypeJReleaser:  {name: string(config.ReleaseTypeJReleaser), files: []string{"jreleaser.yml"}},
+	config.ReleaseTypeGoReleaser: {name: string(config.ReleaseTypeGoReleaser), files: []string{".goreleaser.yaml"}},
+}
+
+func init() {
+	for _, tool := range fakeTools {
+		release.Register(tool)
+	}
+}
+
+// setupProject writes a backend jreleaser config with its jreleaser.yml into a temporary directory
+func setupProject(t *testing.T, releaseArgs []string) {
+	t.Helper()
+	t.Chdir(t.TempDir())
+	for _, tool := range fakeTools {
+		tool.inits, tool.initErr = nil, nil
+	}
+
+	cfg := config.NekoConfig{
+		ProjectType:   config.ProjectTypeBackend,
+		ReleaseSystem: config.ReleaseTypeJReleaser,
+		Version:       "1.0.0",
+		ReleaseArgs:   releaseArgs,
+	}
+	if err := config.SaveConfig(cfg); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile("jreleaser.yml", []byte("project: {}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func migrateRequest(target string, flags map[string]any, yes bool) plugin.Request {
+	if flags == nil {
+		flags = map[string]any{}
+	}
+	return plugin.Request{
+		Command: "migrate-tool",
+		Args:    []string{target},
+		Flags:   flags,
+		Context: plugin.Context{AssumeYes: yes},
+	}
+}
+
+func TestMigrateReplacesDefaultArgs(t *testing.T) {
+	cfg := &config.NekoConfig{
+		ProjectType:   config.ProjectTypeBackend,
+		ReleaseSystem: config.ReleaseTypeJReleaser,
+	}
+
+	if kept := Migrate(cfg, config.ReleaseTypeGoReleaser); kept {
+		t.Error("Migrate reported kept release args for the init defaults")
+	}
+	if cfg.ReleaseSystem != config.ReleaseTypeGoReleaser {
+		t.Errorf("ReleaseSystem = %s, want goreleaser", cfg.ReleaseSystem)
+	}
+	if want := []string{"--skip=announce"}; !slices.Equal(cfg.ReleaseArgs, want) {
+		t.Errorf("ReleaseArgs = %v, want %v", cfg.ReleaseArgs, want)
+	}
+}
+
+func TestMigrateKeepsCustomArgs(t *testing.T) {
+	cfg := &config.NekoConfig{
+		ProjectType:   config.ProjectTypeBackend,
+		ReleaseSystem: config.ReleaseTypeJReleaser,
+		ReleaseArgs:   []string{"--custom"},
+	}
+
+	if kept := Migrate(cfg, config.ReleaseTypeGoReleaser); !kept {
+		t.Error("Migrate did not report the kept release args")
+	}
+	if want := []string{"--custom"}; !slices.Equal(cfg.ReleaseArgs, want) {
+		t.Errorf("ReleaseArgs = %v, want %v", cfg.ReleaseArgs, want)
+	}
+}
+
+func TestMigrateReordersReleaseSystems(t *testing.T) {
+	cfg := &config.NekoConfig{
+		ProjectType:    config.ProjectTypeOther,
+		ReleaseSystem:  config.ReleaseTypeJReleaser,
+		ReleaseSystems: []config.ReleaseSystem{config.ReleaseTypeJReleaser, config.ReleaseTypeGoReleaser},
+	}
+
+	Migrate(cfg, config.ReleaseTypeGoReleaser)
+	if cfg.ReleaseSystems != nil {
+		t.Errorf("ReleaseSystems = %v, want none once only the new system is left", cfg.ReleaseSystems)
+	}
+}
+
+func TestHandleMigrateToolInitsAndSaves(t *testing.T) {
+	setupProject(t, nil)
+
+	resp, err := HandleMigrateTool(migrateRequest("goreleaser", nil, false))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Status != "success" {
+		t.Fatalf("status = %s, error = %+v", resp.Status, resp.Error)
+	}
+
+	inits := fakeTools[config.ReleaseTypeGoReleaser].inits
+	if len(inits) != 1 {
+		t.Fatalf("goreleaser Init called %d times, want once", len(inits))
+	}
+	if inits[0].ReleaseSystem != config.ReleaseTypeGoReleaser {
+		t.Errorf("Init got release system %s, want goreleaser", inits[0].ReleaseSystem)
+	}
+
+	saved, err := config.LoadConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if saved.ReleaseSystem != config.ReleaseTypeGoReleaser {
+		t.Errorf("saved release system = %s, want goreleaser", saved.ReleaseSystem)
+	}
+	if want := []string{"--skip=announce"}; !slices.Equal(saved.ReleaseArgs, want) {
+		t.Errorf("saved release args = %v, want %v", saved.ReleaseArgs, want)
+	}
+	if _, err := os.Stat("jreleaser.yml"); err != nil {
+		t.Error("jreleaser.yml was removed without --remove-old-config")
+	}
+}
+
+func TestHandleMigrateToolInitFailureKeepsConfig(t *testing.T) {
+	setupProject(t, []string{"--custom"})
+	fakeTools[config.ReleaseTypeGoReleaser].initErr = stderrors.New("goreleaser missing")
+	warnings := len(errors.Warnings())
+
+	resp, _ := HandleMigrateTool(migrateRequest("goreleaser", nil, false))
+	if resp.Error == nil || resp.Error.Code != "INIT_FAILED" {
+		t.Fatalf("error = %+v, want INIT_FAILED", resp.Error)
+	}
+
+	saved, err := config.LoadConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if saved.ReleaseSystem != config.ReleaseTypeJReleaser {
+		t.Errorf("saved release system = %s, want jreleaser to be kept", saved.ReleaseSystem)
+	}
+	if n := len(errors.Warnings()) - warnings; n != 0 {
+		t.Errorf("%d warnings about release args although nothing was migrated", n)
+	}
+}
+
+func TestHandleMigrateToolRemoveOldConfig(t *testing.T) {
+	setupProject(t, []string{"--custom"})
+	flags := map[string]any{"remove-old-config": true}
+	warnings := len(errors.Warnings())
+
+	resp, _ := HandleMigrateTool(migrateRequest("goreleaser", flags, false))
+	if resp.Error == nil || resp.Error.Code != "CONFIRMATION_REQUIRED" {
+		t.Fatalf("error = %+v, want CONFIRMATION_REQUIRED", resp.Error)
+	}
+	if len(fakeTools[config.ReleaseTypeGoReleaser].inits) != 0 {
+		t.Error("Init ran before the confirmation")
+	}
+	if n := len(errors.Warnings()) - warnings; n != 0 {
+		t.Errorf("%d warnings about release args before the confirmation", n)
+	}
+
+	resp, _ = HandleMigrateTool(migrateRequest("goreleaser", flags, true))
+	if resp.Status != "success" {
+		t.Fatalf("status = %s, error = %+v", resp.Status, resp.Error)
+	}
+	if _, err := os.Stat("jreleaser.yml"); !os.IsNotExist(err) {
+		t.Error("jreleaser.yml was not removed")
+	}
+	if n := len(errors.Warnings()) - warnings; n != 1 {
+		t.Errorf("%d warnings after migrating with custom release args, want 1", n)
+	}
+}
+
+func TestHandleMigrateToolAlreadyMigrated(t *testing.T) {
+	setupProject(t, nil)
+
+	resp, _ := HandleMigrateTool(migrateRequest("jreleaser", nil, false))
+	if resp.Error == nil || resp.Error.Code != "ALREADY_MIGRATED" {
+		t.Errorf("error = %+v, want ALREADY_MIGRATED", resp.Error)
+	}
+}
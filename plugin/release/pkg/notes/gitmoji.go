@@ -0,0 +1,76 @@
+package notes
+
+import "strings"
+
+// breakingType marks a gitmoji that flags a breaking change instead of a commit type
+const breakingType = "!"
+
+// DefaultGitmoji maps gitmojis (as emoji and shortcode) to conventional commit types
+var DefaultGitmoji = map[string]string{
+	"\u2728":     "feat",       // sparkles
+	"\U0001F41B": "fix",        // bug
+	"\U0001F691": "fix",        // ambulance (hotfix)
+	"\u26A1":     "perf",       // zap
+	"\u267B":     "refactor",   // recycle
+	"\U0001F4DD": "docs",       // memo
+	"\U0001F3A8": "style",      // art
+	"\u2705":     "test",       // white check mark
+	"\U0001F527": "chore",      // wrench
+	"\U0001F4A5": breakingType, // boom
+
+	":sparkles:":         "feat",
+	":bug:":              "fix",
+	":ambulance:":        "fix",
+	":zap:":              "perf",
+	":recycle:":          "refactor",
+	":memo:":             "docs",
+	":art:":              "style",
+	":white_check_mark:": "test",
+	":wrench:":           "chore",
+	":boom:":             breakingType,
+}
+
+// gitmoji is the mapping used by ParseCommit, DefaultGitmoji plus configured overrides
+var gitmoji = DefaultGitmoji
+
+// SetGitmoji adds or overrides gitmoji mappings, e.g. {"\U0001F680": "feat"} or {":rocket:": "feat"}.
+// Map to "!" to mark breaking changes.
+func SetGitmoji(overrides map[string]string) {
+	merged := make(map[string]string, len(DefaultGitmoji)+len(overrides))
+	for k, v := range DefaultGitmoji {
+		merged[k] = v
+	}
+	for k, v := range overrides {
+		merged[k] = v
+	}
+	gitmoji = merged
+}
+
+// stripGitmoji removes a leading gitmoji from the subject and returns its commit type.
+// Returns an empty type if the subject does not start with a known gitmoji.
+func stripGitmoji(subject string) (string, string) {
+	if strings.HasPrefix(subject, ":") {
+		if end := strings.Index(subject[1:], ":"); end >= 0 {
+			code := subject[:end+2]
+			if t, ok := gitmoji[code]; ok {
+				return t, strings.TrimSpace(subject[len(code):])
+			}
+		}
+		return "", subject
+	}
+
+	// Prefer the longest match, so multi-rune emojis win over their first rune
+	match := ""
+	for emoji := range gitmoji {
+		if !strings.HasPrefix(emoji, ":") && strings.HasPrefix(subject, emoji) && len(emoji) > len(match) {
+			match = emoji
+		}
+	}
+	if match == "" {
+		return "", subject
+	}
+
+	// Skip the variation selector some emojis are written with (e.g. U+26A1 U+FE0F)
+	rest := strings.TrimPrefix(subject[len(match):], "\uFE0F")
+	return gitmoji[match], strings.TrimSpace(rest)
+}
@@ -0,0 +1,104 @@
+package notes
+
+import "testing"
+
+// withGitmoji applies the overrides and restores the defaults after the test
+func withGitmoji(t *testing.T, overrides map[string]string) {
+	t.Helper()
+	SetGitmoji(overrides)
+	t.Cleanup(func() { gitmoji = DefaultGitmoji })
+}
+
+func TestStripGitmoji(t *testing.T) {
+	tests := []struct {
+		subject  string
+		wantType string
+		wantRest string
+	}{
+		{"\u2728 add login", "feat", "add login"},
+		{"\U0001F41B fix crash", "fix", "fix crash"},
+		{"\u26A1\uFE0F faster startup", "perf", "faster startup"},
+		{"\u26A1 faster startup", "perf", "faster startup"},
+		{"\U0001F4A5 drop v1 api", breakingType, "drop v1 api"},
+		{":sparkles: add login", "feat", "add login"},
+		{":boom: drop v1 api", breakingType, "drop v1 api"},
+		{":rocket: deploy", "", ":rocket: deploy"},
+		{":not closed", "", ":not closed"},
+		{"\U0001F680 deploy", "", "\U0001F680 deploy"},
+		{"feat: plain", "", "feat: plain"},
+		{"", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.subject, func(t *testing.T) {
+			gotType, gotRest := stripGitmoji(tt.subject)
+			if gotType != tt.wantType || gotRest != tt.wantRest {
+				t.Errorf("stripGitmoji(%q) = %q, %q, want %q, %q", tt.subject, gotType, gotRest, tt.wantType, tt.wantRest)
+			}
+		})
+	}
+}
+
+func TestParseCommitGitmoji(t *testing.T) {
+	tests := []struct {
+		subject string
+		want    Commit
+	}{
+		{"\u2728 add login", Commit{Type: "feat", Description: "add login"}},
+		{":bug: fix crash", Commit{Type: "fix", Description: "fix crash"}},
+		{"\U0001F4A5 drop v1 api", Commit{Breaking: true, Description: "drop v1 api"}},
+		{"\U0001F4A5 feat(api): drop v1", Commit{Type: "feat", Scope: "api", Breaking: true, Description: "drop v1"}},
+		// The conventional prefix wins over the gitmoji
+		{"\u2728 fix(ui): button color", Commit{Type: "fix", Scope: "ui", Description: "button color"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.subject, func(t *testing.T) {
+			tt.want.Hash = "abc1234"
+			if got := ParseCommit("abc1234", tt.subject); got != tt.want {
+				t.Errorf("ParseCommit(%q) = %+v, want %+v", tt.subject, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetGitmoji(t *testing.T) {
+	withGitmoji(t, map[string]string{
+		"\U0001F680": "feat",
+		":bug:":      "chore",
+		":fire:":     breakingType,
+	})
+
+	tests := []struct {
+		subject  string
+		wantType string
+	}{
+		{"\U0001F680 deploy", "feat"},
+		{":bug: overridden", "chore"},
+		{"\U0001F41B emoji keeps the default", "fix"},
+		{":fire: remove api", breakingType},
+		{":sparkles: default", "feat"},
+	}
+
+	for _, tt := range tests {
+		if gotType, _ := stripGitmoji(tt.subject); gotType != tt.wantType {
+			t.Errorf("stripGitmoji(%q) type = %q, want %q", tt.subject, gotType, tt.wantType)
+		}
+	}
+	if DefaultGitmoji[":bug:"] != "fix" {
+		t.Error("SetGitmoji changed DefaultGitmoji")
+	}
+}
+
+// A longer emoji must win over an emoji that is a prefix of it
+func TestStripGitmojiLongestMatch(t *testing.T) {
+	withGitmoji(t, map[string]string{
+		"\U0001F468":                 "chore",
+		"\U0001F468\u200D\U0001F4BB": "feat",
+	})
+
+	gotType, gotRest := stripGitmoji("\U0001F468\u200D\U0001F4BB developer tools")
+	if gotType != "feat" || gotRest != "developer tools" {
+		t.Errorf("stripGitmoji = %q, %q, want the longer emoji", gotType, gotRest)
+	}
+}
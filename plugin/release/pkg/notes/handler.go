@@ -0,0 +1,144 @@
+package notes
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/nekoman-hq/neko-cli/pkg/log"
+	"github.com/nekoman-hq/neko-cli/pkg/plugin"
+	"github.com/nekoman-hq/neko-cli/plugin/release/pkg/config"
+	"github.com/nekoman-hq/neko-cli/plugin/release/pkg/git"
+	"github.com/nekoman-hq/neko-cli/plugin/release/pkg/release"
+)
+
+const (
+	PluginName    = "release"
+	PluginVersion = "1.0.0"
+)
+
+// HandlePreviewNotes generates categorized release notes. Without --compare the notes
+// cover the commits since the last release, i.e. the next release.
+func HandlePreviewNotes(req plugin.Request) (*plugin.Response, error) {
+	from, to, err := resolveRange(req.Flags)
+	if err != nil {
+		return errorResponse("INVALID_RANGE", err.Error(), map[string]any{
+			"hint": "Use --compare <tag1>..<tag2>, e.g. --compare v1.0.0..v1.1.0",
+		}), nil
+	}
+
+	if cfg, err := config.LoadConfig(); err == nil {
+		SetGitmoji(cfg.Gitmoji)
+		git.SetReleaseCommitScope(cfg.ReleaseCommitScope)
+	}
+
+	log.PluginPrint(log.Exec, "Generating release notes for %s",
+		log.ColorText(log.ColorCyan, displayRange(from, to)))
+
+	entries, err := git.CommitsBetween(from, to)
+	if err != nil {
+		return errorResponse("GIT_LOG_FAILED", err.Error(), nil), nil
+	}
+
+	sections := Categorize(entries, DefaultCategories)
+
+	items := make([]map[string]any, 0, len(entries))
+	for _, s := range sections {
+		for _, c := range s.Commits {
+			items = append(items, map[string]any{
+				"category":    s.Title,
+				"scope":       c.Scope,
+				"description": c.Description,
+				"commit":      c.Hash,
+			})
+		}
+	}
+
+	return &plugin.Response{
+		Status: "success",
+		Metadata: plugin.ResponseMetadata{
+			Plugin:    PluginName,
+			Version:   PluginVersion,
+			Command:   "preview-notes",
+			Timestamp: time.Now(),
+		},
+		Data: map[string]any{
+			"from":          from,
+			"to":            to,
+			"first_release": from == "",
+			"items":         items,
+			"markdown":      Markdown(sections),
+		},
+		RendererHint: "table",
+		ColumnOrder:  []string{"category", "scope", "description", "commit"},
+	}, nil
+}
+
+// resolveRange returns the explicit --compare range or the range since the last release
+func resolveRange(flags map[string]any) (string, string, error) {
+	spec, _ := flags["compare"].(string)
+	since, _ := flags["since-last-release"].(bool)
+	if spec != "" && since {
+		return "", "", fmt.Errorf("--compare and --since-last-release cannot be combined")
+	}
+
+	if spec == "" {
+		baseline, err := release.LastReleaseBaseline()
+		if err != nil {
+			return "", "", err
+		}
+		if baseline.FirstRelease() {
+			log.PluginPrint(log.Exec, "No release yet, the notes of the first release cover the full history")
+		}
+		return baseline.Tag, "HEAD", nil
+	}
+
+	from, to, err := ParseRange(spec)
+	if err != nil {
+		return "", "", err
+	}
+	for _, ref := range []string{from, to} {
+		if !git.RefExists(ref) {
+			return "", "", fmt.Errorf("unknown ref %q in range %s", ref, spec)
+		}
+	}
+	return from, to, nil
+}
+
+// ParseRange splits a "<from>..<to>" range. Both refs are required.
+func ParseRange(spec string) (string, string, error) {
+	if strings.Contains(spec, "...") {
+		return "", "", fmt.Errorf("invalid range %q: symmetric ranges (...) are not supported, use <from>..<to>", spec)
+	}
+
+	from, to, ok := strings.Cut(spec, "..")
+	from, to = strings.TrimSpace(from), strings.TrimSpace(to)
+	if !ok || from == "" || to == "" {
+		return "", "", fmt.Errorf("invalid range %q: expected <from>..<to>", spec)
+	}
+	return from, to, nil
+}
+
+func displayRange(from, to string) string {
+	if from == "" {
+		return to
+	}
+	return from + ".." + to
+}
+
+func errorResponse(code, message string, details map[string]any) *plugin.Response {
+	return &plugin.Response{
+		Status: "error",
+		Metadata: plugin.ResponseMetadata{
+			Plugin:    PluginName,
+			Version:   PluginVersion,
+			Command:   "preview-notes",
+			Timestamp: time.Now(),
+		},
+		Error: &plugin.ResponseError{
+			Code:    code,
+			Message: message,
+			Details: details,
+		},
+	}
+}
@@ -0,0 +1,132 @@
+package notes
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nekoman-hq/neko-cli/pkg/plugin"
+	"github.com/nekoman-hq/neko-cli/plugin/release/internal/gittest"
+)
+
+func TestParseRange(t *testing.T) {
+	tests := []struct {
+		spec     string
+		from, to string
+		wantErr  bool
+	}{
+		{"v1.0.0..v1.1.0", "v1.0.0", "v1.1.0", false},
+		{" v1.0.0 .. HEAD ", "v1.0.0", "HEAD", false},
+		{"v1.0.0...v1.1.0", "", "", true},
+		{"v1.0.0..", "", "", true},
+		{"..v1.1.0", "", "", true},
+		{"v1.0.0", "", "", true},
+		{"", "", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			from, to, err := ParseRange(tt.spec)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseRange(%q) error = %v, want error %t", tt.spec, err, tt.wantErr)
+			}
+			if from != tt.from || to != tt.to {
+				t.Errorf("ParseRange(%q) = %q, %q, want %q, %q", tt.spec, from, to, tt.from, tt.to)
+			}
+		})
+	}
+}
+
+// notesRepo creates a repository with two releases and unreleased commits
+func notesRepo(t *testing.T) {
+	t.Helper()
+	gittest.NewRepo(t)
+	gittest.Commit(t, "feat: initial")
+	gittest.Run(t, "tag", "v1.0.0")
+	gittest.Commit(t, "fix(api): timeouts")
+	gittest.Commit(t, "feat: login")
+	gittest.Run(t, "tag", "v1.1.0")
+	gittest.Commit(t, "docs: readme")
+}
+
+func TestHandlePreviewNotes(t *testing.T) {
+	notesRepo(t)
+
+	tests := []struct {
+		name         string
+		flags        map[string]any
+		wantFrom     string
+		wantTo       string
+		wantMarkdown string
+		wantCode     string
+	}{
+		{
+			name:         "since the last release",
+			flags:        map[string]any{},
+			wantFrom:     "v1.1.0",
+			wantTo:       "HEAD",
+			wantMarkdown: "### Documentation\n\n- readme",
+		},
+		{
+			name:         "compare",
+			flags:        map[string]any{"compare": "v1.0.0..v1.1.0"},
+			wantFrom:     "v1.0.0",
+			wantTo:       "v1.1.0",
+			wantMarkdown: "### Features\n\n- login",
+		},
+		{
+			name:     "unknown ref",
+			flags:    map[string]any{"compare": "v1.0.0..v9.0.0"},
+			wantCode: "INVALID_RANGE",
+		},
+		{
+			name:     "compare and since last release",
+			flags:    map[string]any{"compare": "v1.0.0..v1.1.0", "since-last-release": true},
+			wantCode: "INVALID_RANGE",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := HandlePreviewNotes(plugin.Request{Command: "preview-notes", Flags: tt.flags})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.wantCode != "" {
+				if resp.Error == nil || resp.Error.Code != tt.wantCode {
+					t.Errorf("error = %+v, want %s", resp.Error, tt.wantCode)
+				}
+				return
+			}
+			if resp.Status != "success" {
+				t.Fatalf("status = %s, error = %+v", resp.Status, resp.Error)
+			}
+			if resp.Data["from"] != tt.wantFrom || resp.Data["to"] != tt.wantTo {
+				t.Errorf("range = %v..%v, want %s..%s", resp.Data["from"], resp.Data["to"], tt.wantFrom, tt.wantTo)
+			}
+			if markdown := resp.Data["markdown"].(string); !strings.HasPrefix(markdown, tt.wantMarkdown) {
+				t.Errorf("markdown =\n%s\nwant it to start with\n%s", markdown, tt.wantMarkdown)
+			}
+		})
+	}
+}
+
+// Without a release the notes cover the full history
+func TestHandlePreviewNotesFirstRelease(t *testing.T) {
+	gittest.NewRepo(t)
+	gittest.Commit(t, "feat: initial")
+	gittest.Commit(t, "fix: typo")
+
+	resp, err := HandlePreviewNotes(plugin.Request{Command: "preview-notes", Flags: map[string]any{}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Status != "success" {
+		t.Fatalf("status = %s, error = %+v", resp.Status, resp.Error)
+	}
+	if resp.Data["first_release"] != true {
+		t.Errorf("first_release = %v, want true", resp.Data["first_release"])
+	}
+	if items := resp.Data["items"].([]map[string]any); len(items) != 2 {
+		t.Errorf("got %d items, want both commits", len(items))
+	}
+}
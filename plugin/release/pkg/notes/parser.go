@@ -0,0 +1,136 @@
+// Package notes generates categorized release notes from conventional commits
+package notes
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/nekoman-hq/neko-cli/plugin/release/pkg/git"
+)
+
+// Commit is a commit subject parsed as conventional commit
+type Commit struct {
+	Hash        string
+	Type        string
+	Scope       string
+	Breaking    bool
+	Description string
+}
+
+// Category groups commit types under a release notes heading
+type Category struct {
+	Title string
+	Types []string
+}
+
+// BreakingTitle is the heading of breaking changes, which are listed regardless of their type
+const BreakingTitle = "Breaking Changes"
+
+// OtherTitle is the heading of commits that match no category
+const OtherTitle = "Other Changes"
+
+// DefaultCategories are the release notes sections in display order
+var DefaultCategories = []Category{
+	{Title: "Features", Types: []string{"feat"}},
+	{Title: "Bug Fixes", Types: []string{"fix"}},
+	{Title: "Performance", Types: []string{"perf"}},
+	{Title: "Refactoring", Types: []string{"refactor"}},
+	{Title: "Documentation", Types: []string{"docs"}},
+}
+
+// conventionalRegex matches "type(scope)!: description"
+var conventionalRegex = regexp.MustCompile(`^(\w+)(?:\(([^)]*)\))?(!)?:\s*(.+)$`)
+
+// ParseCommit parses a commit subject. A leading gitmoji is mapped to its commit type, an
+// additional conventional commit prefix takes precedence. Other subjects keep an empty type.
+func ParseCommit(hash, subject string) Commit {
+	c := Commit{Hash: hash, Description: strings.TrimSpace(subject)}
+
+	emojiType, rest := stripGitmoji(c.Description)
+	if emojiType == breakingType {
+		c.Breaking = true
+	} else {
+		c.Type = emojiType
+	}
+	c.Description = rest
+
+	m := conventionalRegex.FindStringSubmatch(c.Description)
+	if m == nil {
+		return c
+	}
+
+	c.Type = strings.ToLower(m[1])
+	c.Scope = m[2]
+	c.Breaking = c.Breaking || m[3] == "!"
+	c.Description = m[4]
+	return c
+}
+
+// Section is a release notes heading with its commits
+type Section struct {
+	Title   string
+	Commits []Commit
+}
+
+// Categorize groups the commits into sections in the order of the categories.
+// Neko release commits are skipped and empty sections are omitted.
+func Categorize(entries []git.LogEntry, categories []Category) []Section {
+	byType := make(map[string]int)
+	for i, cat := range categories {
+		for _, t := range cat.Types {
+			byType[t] = i
+		}
+	}
+
+	breaking := Section{Title: BreakingTitle}
+	sections := make([]Section, len(categories))
+	for i, cat := range categories {
+		sections[i].Title = cat.Title
+	}
+	other := Section{Title: OtherTitle}
+
+	for _, entry := range entries {
+		if git.IsReleaseCommit(entry.Subject) {
+			continue
+		}
+
+		c := ParseCommit(entry.Hash, entry.Subject)
+		switch i, ok := byType[c.Type]; {
+		case c.Breaking:
+			breaking.Commits = append(breaking.Commits, c)
+		case ok:
+			sections[i].Commits = append(sections[i].Commits, c)
+		default:
+			// Without a heading telling the type, keep the full subject
+			other.Commits = append(other.Commits, Commit{Hash: c.Hash, Description: strings.TrimSpace(entry.Subject)})
+		}
+	}
+
+	result := make([]Section, 0, len(sections)+2)
+	for _, s := range append(append([]Section{breaking}, sections...), other) {
+		if len(s.Commits) > 0 {
+			result = append(result, s)
+		}
+	}
+	return result
+}
+
+// Markdown renders the sections as markdown release notes
+func Markdown(sections []Section) string {
+	var b strings.Builder
+	for i, s := range sections {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(&b, "### %s\n\n", s.Title)
+		for _, c := range s.Commits {
+			if c.Scope != "" {
+				fmt.Fprintf(&b, "- **%s:** %s (%s)\n", c.Scope, c.Description, c.Hash)
+			} else {
+				fmt.Fprintf(&b, "- %s (%s)\n", c.Description, c.Hash)
+			}
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,98 @@
+package notes
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/nekoman-hq/neko-cli/plugin/release/pkg/git"
+)
+
+func TestParseCommit(t *testing.T) {
+	tests := []struct {
+		subject string
+		want    Commit
+	}{
+		{"feat: add login", Commit{Type: "feat", Description: "add login"}},
+		{"fix(api): handle timeouts", Commit{Type: "fix", Scope: "api", Description: "handle timeouts"}},
+		{"feat(auth)!: drop sessions", Commit{Type: "feat", Scope: "auth", Breaking: true, Description: "drop sessions"}},
+		{"Feat:  upper case type", Commit{Type: "feat", Description: "upper case type"}},
+		{"  refactor: padded  ", Commit{Type: "refactor", Description: "padded"}},
+		{"Merge branch 'main'", Commit{Description: "Merge branch 'main'"}},
+		{"feat:", Commit{Description: "feat:"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.subject, func(t *testing.T) {
+			tt.want.Hash = "abc1234"
+			if got := ParseCommit("abc1234", tt.subject); got != tt.want {
+				t.Errorf("ParseCommit(%q) = %+v, want %+v", tt.subject, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCategorize(t *testing.T) {
+	entries := []git.LogEntry{
+		{Hash: "a1", Subject: "docs: explain tags"},
+		{Hash: "a2", Subject: "fix(cli): exit code"},
+		{Hash: "a3", Subject: git.ReleaseCommitSubject("1.2.0")},
+		{Hash: "a4", Subject: "feat!: new config format"},
+		{Hash: "a5", Subject: "update readme"},
+		{Hash: "a6", Subject: "feat: add login"},
+		{Hash: "a7", Subject: "chore: bump deps"},
+	}
+
+	want := []Section{
+		{Title: BreakingTitle, Commits: []Commit{{Hash: "a4", Type: "feat", Breaking: true, Description: "new config format"}}},
+		{Title: "Features", Commits: []Commit{{Hash: "a6", Type: "feat", Description: "add login"}}},
+		{Title: "Bug Fixes", Commits: []Commit{{Hash: "a2", Type: "fix", Scope: "cli", Description: "exit code"}}},
+		{Title: "Documentation", Commits: []Commit{{Hash: "a1", Type: "docs", Description: "explain tags"}}},
+		{Title: OtherTitle, Commits: []Commit{
+			{Hash: "a5", Description: "update readme"},
+			{Hash: "a7", Description: "chore: bump deps"},
+		}},
+	}
+	if got := Categorize(entries, DefaultCategories); !reflect.DeepEqual(got, want) {
+		t.Errorf("Categorize =\n%+v\nwant\n%+v", got, want)
+	}
+}
+
+func TestCategorizeEmpty(t *testing.T) {
+	entries := []git.LogEntry{{Hash: "a1", Subject: git.ReleaseCommitSubject("1.0.0")}}
+	if got := Categorize(entries, DefaultCategories); len(got) != 0 {
+		t.Errorf("Categorize = %+v, want no sections for release commits only", got)
+	}
+}
+
+func TestMarkdown(t *testing.T) {
+	tests := []struct {
+		name     string
+		sections []Section
+		want     string
+	}{
+		{"empty", nil, ""},
+		{
+			name: "sections",
+			sections: []Section{
+				{Title: "Features", Commits: []Commit{
+					{Hash: "a1", Type: "feat", Scope: "auth", Description: "add login"},
+					{Hash: "a2", Type: "feat", Description: "add logout"},
+				}},
+				{Title: "Bug Fixes", Commits: []Commit{{Hash: "a3", Type: "fix", Description: "exit code"}}},
+			},
+			want: "### Features\n\n" +
+				"- **auth:** add login (a1)\n" +
+				"- add logout (a2)\n" +
+				"\n### Bug Fixes\n\n" +
+				"- exit code (a3)\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Markdown(tt.sections); got != tt.want {
+				t.Errorf("Markdown =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
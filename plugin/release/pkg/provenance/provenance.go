@@ -0,0 +1,182 @@
+// Package provenance generates build provenance documents and attaches them to GitHub releases
+package provenance
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/nekoman-hq/neko-cli/pkg/config"
+	"github.com/nekoman-hq/neko-cli/pkg/log"
+	config2 "github.com/nekoman-hq/neko-cli/plugin/release/pkg/config"
+	"github.com/nekoman-hq/neko-cli/plugin/release/pkg/git"
+	"github.com/nekoman-hq/neko-cli/plugin/release/pkg/git/github"
+)
+
+// AssetName is the name of the release asset holding the provenance
+const AssetName = "provenance.json"
+
+// SchemaVersion is bumped on incompatible changes to Document
+const SchemaVersion = 1
+
+// Markers enclose the provenance block in the release notes, so attaching again replaces it
+const (
+	bodyStart = "<!-- neko-provenance -->"
+	bodyEnd   = "<!-- /neko-provenance -->"
+)
+
+// Document describes who built a release from which source, loosely following SLSA provenance
+type Document struct {
+	SchemaVersion int        `json:"schema_version"`
+	Builder       Builder    `json:"builder"`
+	Source        Source     `json:"source"`
+	Invocation    Invocation `json:"invocation"`
+	Timestamp     string     `json:"timestamp"`
+}
+
+// Builder identifies the environment that ran the release
+type Builder struct {
+	// ID is github-actions, gitlab-ci, ci or local
+	ID string `json:"id"`
+	// Version is the version of the release plugin
+	Version string `json:"version"`
+}
+
+// Source is the git state the release was built from
+type Source struct {
+	Repository string `json:"repository"`
+	Tag        string `json:"tag"`
+	Commit     string `json:"commit"`
+}
+
+// Invocation holds the CI run that triggered the release, empty for local releases
+type Invocation struct {
+	RunID    string `json:"run_id,omitempty"`
+	RunURL   string `json:"run_url,omitempty"`
+	Workflow string `json:"workflow,omitempty"`
+	Actor    string `json:"actor,omitempty"`
+}
+
+// New builds the provenance of source from the environment looked up by env
+func New(source Source, builderVersion string, env func(string) string, now time.Time) Document {
+	doc := Document{
+		SchemaVersion: SchemaVersion,
+		Builder:       Builder{ID: "local", Version: builderVersion},
+		Source:        source,
+		Timestamp:     now.UTC().Format(time.RFC3339),
+	}
+
+	switch {
+	case env("GITHUB_ACTIONS") == "true":
+		doc.Builder.ID = "github-actions"
+		doc.Invocation = Invocation{
+			RunID:    env("GITHUB_RUN_ID"),
+			Workflow: env("GITHUB_WORKFLOW"),
+			Actor:    env("GITHUB_ACTOR"),
+		}
+		if server, repo := env("GITHUB_SERVER_URL"), env("GITHUB_REPOSITORY"); server != "" && repo != "" && doc.Invocation.RunID != "" {
+			doc.Invocation.RunURL = fmt.Sprintf("%s/%s/actions/runs/%s", server, repo, doc.Invocation.RunID)
+		}
+	case env("GITLAB_CI") == "true":
+		doc.Builder.ID = "gitlab-ci"
+		doc.Invocation = Invocation{
+			RunID:    env("CI_PIPELINE_ID"),
+			RunURL:   env("CI_PIPELINE_URL"),
+			Workflow: env("CI_JOB_NAME"),
+			Actor:    env("GITLAB_USER_LOGIN"),
+		}
+	case env("CI") != "":
+		doc.Builder.ID = "ci"
+	}
+	return doc
+}
+
+// Generate builds the provenance of tag from the git state and the process environment
+func Generate(repo *git.RepoInfo, tag, builderVersion string) (Document, error) {
+	commit, err := git.TagCommit(tag)
+	if err != nil {
+		return Document{}, err
+	}
+
+	source := Source{
+		Repository: fmt.Sprintf("github.com/%s/%s", repo.Owner, repo.Repo),
+		Tag:        tag,
+		Commit:     commit,
+	}
+	return New(source, builderVersion, os.Getenv, time.Now()), nil
+}
+
+// JSON encodes the document as indented JSON
+func (d Document) JSON() ([]byte, error) {
+	return json.MarshalIndent(d, "", "  ")
+}
+
+// AppendToBody returns the release notes with the provenance block appended.
+// An existing provenance block is replaced.
+func AppendToBody(body string, d Document) (string, error) {
+	data, err := d.JSON()
+	if err != nil {
+		return "", err
+	}
+
+	if start := strings.Index(body, bodyStart); start >= 0 {
+		if end := strings.Index(body[start:], bodyEnd); end >= 0 {
+			body = body[:start] + body[start+end+len(bodyEnd):]
+		}
+	}
+	body = strings.TrimRight(body, "\n")
+
+	block := fmt.Sprintf("%s\n<details><summary>Build provenance</summary>\n\n```json\n%s\n```\n\n</details>\n%s",
+		bodyStart, data, bodyEnd)
+	if body == "" {
+		return block, nil
+	}
+	return body + "\n\n" + block, nil
+}
+
+// Attach generates the provenance of tag and attaches it to the GitHub release of the tag
+func Attach(target config2.ProvenanceTarget, tag, builderVersion string) (Document, error) {
+	token, err := config.GetPAT()
+	if err != nil {
+		return Document{}, err
+	}
+	repo, err := git.Current()
+	if err != nil {
+		return Document{}, err
+	}
+	doc, err := Generate(repo, tag, builderVersion)
+	if err != nil {
+		return Document{}, err
+	}
+	release, err := git.ReleaseByTag(repo, tag, token)
+	if err != nil {
+		return Document{}, err
+	}
+
+	switch target {
+	case config2.ProvenanceBody:
+		body, err := AppendToBody(release.Body, doc)
+		if err != nil {
+			return Document{}, err
+		}
+		if git.SkipInDryRun("appending the provenance to the notes of release", tag) {
+			return doc, nil
+		}
+		if _, err = git.UpdateRelease(repo, release.ID, github.ReleaseUpdate{Body: &body}, token); err != nil {
+			return Document{}, err
+		}
+	default:
+		data, err := doc.JSON()
+		if err != nil {
+			return Document{}, err
+		}
+		if err = git.UploadReleaseAsset(repo, release.ID, AssetName, "application/json", data, token); err != nil {
+			return Document{}, err
+		}
+	}
+
+	log.PluginPrint(log.Exec, "\uF00C Attached provenance to release %s", log.ColorText(log.ColorGreen, tag))
+	return doc, nil
+}
@@ -0,0 +1,225 @@
+package provenance
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nekoman-hq/neko-cli/plugin/release/internal/gittest"
+	config2 "github.com/nekoman-hq/neko-cli/plugin/release/pkg/config"
+)
+
+func TestNew(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.FixedZone("CET", 3600))
+	source := Source{Repository: "github.com/nekoman-hq/app", Tag: "v1.2.0", Commit: "abc1234"}
+
+	tests := []struct {
+		name        string
+		env         map[string]string
+		wantBuilder string
+		want        Invocation
+	}{
+		{"local", nil, "local", Invocation{}},
+		{
+			name: "github actions",
+			env: map[string]string{
+				"GITHUB_ACTIONS":    "true",
+				"GITHUB_RUN_ID":     "77",
+				"GITHUB_WORKFLOW":   "release",
+				"GITHUB_ACTOR":      "octocat",
+				"GITHUB_SERVER_URL": "https://github.com",
+				"GITHUB_REPOSITORY": "nekoman-hq/app",
+			},
+			wantBuilder: "github-actions",
+			want: Invocation{
+				RunID:    "77",
+				RunURL:   "https://github.com/nekoman-hq/app/actions/runs/77",
+				Workflow: "release",
+				Actor:    "octocat",
+			},
+		},
+		{
+			name:        "github actions without run url",
+			env:         map[string]string{"GITHUB_ACTIONS": "true", "GITHUB_RUN_ID": "77"},
+			wantBuilder: "github-actions",
+			want:        Invocation{RunID: "77"},
+		},
+		{
+			name: "gitlab ci",
+			env: map[string]string{
+				"GITLAB_CI":         "true",
+				"CI_PIPELINE_ID":    "9",
+				"CI_PIPELINE_URL":   "https://gitlab.com/app/-/pipelines/9",
+				"CI_JOB_NAME":       "release",
+				"GITLAB_USER_LOGIN": "neko",
+			},
+			wantBuilder: "gitlab-ci",
+			want: Invocation{
+				RunID:    "9",
+				RunURL:   "https://gitlab.com/app/-/pipelines/9",
+				Workflow: "release",
+				Actor:    "neko",
+			},
+		},
+		{"other ci", map[string]string{"CI": "1"}, "ci", Invocation{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := New(source, "1.0.0", func(key string) string { return tt.env[key] }, now)
+
+			if doc.Builder != (Builder{ID: tt.wantBuilder, Version: "1.0.0"}) {
+				t.Errorf("Builder = %+v, want %s", doc.Builder, tt.wantBuilder)
+			}
+			if doc.Invocation != tt.want {
+				t.Errorf("Invocation = %+v, want %+v", doc.Invocation, tt.want)
+			}
+			if doc.Source != source || doc.SchemaVersion != SchemaVersion {
+				t.Errorf("document = %+v, want the source %+v", doc, source)
+			}
+			if doc.Timestamp != "2026-03-01T11:00:00Z" {
+				t.Errorf("Timestamp = %q, want UTC", doc.Timestamp)
+			}
+		})
+	}
+}
+
+func TestAppendToBody(t *testing.T) {
+	doc := Document{SchemaVersion: SchemaVersion, Builder: Builder{ID: "local"}, Source: Source{Tag: "v1.2.0"}}
+	data, err := doc.JSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	block := bodyStart + "\n<details><summary>Build provenance</summary>\n\n```json\n" + string(data) + "\n```\n\n</details>\n" + bodyEnd
+	old := bodyStart + "\nstale\n" + bodyEnd
+
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{"empty notes", "", block},
+		{"appended", "## Features\n\n- history\n", "## Features\n\n- history\n\n" + block},
+		{"replaces earlier block", "## Features\n\n" + old + "\n", "## Features\n\n" + block},
+		{"only an earlier block", old, block},
+		{"keeps notes after the block", "Intro\n\n" + old + "\n\nOutro", "Intro\n\n\n\nOutro\n\n" + block},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := AppendToBody(tt.body, doc)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("AppendToBody =\n%s\nwant\n%s", got, tt.want)
+			}
+			if again, _ := AppendToBody(got, doc); again != got {
+				t.Errorf("attaching twice changed the notes:\n%s", again)
+			}
+		})
+	}
+}
+
+// releaseAPI answers the release lookup with a release holding notes and records the requests
+type releaseAPI struct {
+	requests []*http.Request
+	bodies   []string
+}
+
+func (api *releaseAPI) RoundTrip(req *http.Request) (*http.Response, error) {
+	body := ""
+	if req.Body != nil {
+		data, err := io.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		body = string(data)
+	}
+	api.requests = append(api.requests, req)
+	api.bodies = append(api.bodies, body)
+
+	status := http.StatusOK
+	if req.Method == http.MethodPost {
+		status = http.StatusCreated
+	}
+	release, _ := json.Marshal(map[string]any{"id": 42, "tag_name": "v1.2.0", "body": "## Features"})
+	return &http.Response{
+		StatusCode: status,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(bytes.NewReader(release)),
+		Request:    req,
+	}, nil
+}
+
+func TestAttach(t *testing.T) {
+	tests := []struct {
+		target     config2.ProvenanceTarget
+		wantMethod string
+		wantURL    string
+	}{
+		{config2.ProvenanceAsset, http.MethodPost, "https://uploads.github.com/repos/nekoman-hq/app/releases/42/assets?name=provenance.json"},
+		{config2.ProvenanceBody, http.MethodPatch, "https://api.github.com/repos/nekoman-hq/app/releases/42"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.target), func(t *testing.T) {
+			gittest.NewRepo(t)
+			gittest.Run(t, "remote", "add", "origin", "https://github.com/nekoman-hq/app.git")
+			commit := gittest.Commit(t, "feat: initial")
+			gittest.Run(t, "tag", "v1.2.0")
+			t.Setenv("GITHUB_TOKEN", "test-token")
+			t.Setenv("GITHUB_ACTIONS", "")
+			t.Setenv("GITLAB_CI", "")
+			t.Setenv("CI", "")
+
+			api := &releaseAPI{}
+			previous := http.DefaultClient.Transport
+			http.DefaultClient.Transport = api
+			t.Cleanup(func() { http.DefaultClient.Transport = previous })
+
+			doc, err := Attach(tt.target, "v1.2.0", "1.0.0")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if doc.Source != (Source{Repository: "github.com/nekoman-hq/app", Tag: "v1.2.0", Commit: commit}) {
+				t.Errorf("Source = %+v, want the tag at %s", doc.Source, commit)
+			}
+
+			if len(api.requests) != 2 {
+				t.Fatalf("sent %d API requests, want the release lookup and the attachment", len(api.requests))
+			}
+			attach := api.requests[1]
+			if attach.Method != tt.wantMethod || attach.URL.String() != tt.wantURL {
+				t.Errorf("attach request = %s %s, want %s %s", attach.Method, attach.URL, tt.wantMethod, tt.wantURL)
+			}
+			if got := attach.Header.Get("Authorization"); got != "Bearer test-token" {
+				t.Errorf("Authorization = %q", got)
+			}
+
+			switch tt.target {
+			case config2.ProvenanceAsset:
+				var uploaded Document
+				if err := json.Unmarshal([]byte(api.bodies[1]), &uploaded); err != nil {
+					t.Fatalf("asset %q: %v", api.bodies[1], err)
+				}
+				if uploaded != doc {
+					t.Errorf("uploaded %+v, want %+v", uploaded, doc)
+				}
+			case config2.ProvenanceBody:
+				var payload map[string]any
+				if err := json.Unmarshal([]byte(api.bodies[1]), &payload); err != nil {
+					t.Fatalf("update body %q: %v", api.bodies[1], err)
+				}
+				body, _ := payload["body"].(string)
+				if !strings.HasPrefix(body, "## Features\n\n"+bodyStart) || !strings.Contains(body, commit) {
+					t.Errorf("release body = %q, want the notes followed by the provenance", body)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,27 @@
+package release
+
+// releaseArgs are appended to the release command of the release system, resolved once per release
+var releaseArgs []string
+
+// SetReleaseArgs sets the extra arguments passed to the release system
+func SetReleaseArgs(args []string) {
+	releaseArgs = args
+}
+
+// ReleaseArgs returns the extra arguments passed to the release system
+func ReleaseArgs() []string {
+	return releaseArgs
+}
+
+// goReleaserSkipArgs are the --skip arguments built from goreleaser-skip, resolved once per release
+var goReleaserSkipArgs []string
+
+// SetGoReleaserSkipArgs sets the --skip arguments passed to goreleaser release
+func SetGoReleaserSkipArgs(args []string) {
+	goReleaserSkipArgs = args
+}
+
+// GoReleaserSkipArgs returns the --skip arguments passed to goreleaser release
+func GoReleaserSkipArgs() []string {
+	return goReleaserSkipArgs
+}
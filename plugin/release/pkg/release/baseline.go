@@ -0,0 +1,129 @@
+package release
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	config2 "github.com/nekoman-hq/neko-cli/plugin/release/pkg/config"
+	"github.com/nekoman-hq/neko-cli/plugin/release/pkg/git"
+)
+
+// Baseline is the starting point of "since the last release"
+type Baseline struct {
+	// Tag is the latest release tag, empty if nothing was released yet
+	Tag string
+	// Root is the root commit, used as starting point if there is no tag
+	Root string
+}
+
+// FirstRelease reports whether nothing was released yet, so the baseline covers the full history
+func (b Baseline) FirstRelease() bool {
+	return b.Tag == ""
+}
+
+// Ref returns the ref the baseline starts at
+func (b Baseline) Ref() string {
+	if b.Tag != "" {
+		return b.Tag
+	}
+	return b.Root
+}
+
+// Range returns the git revision range from the baseline to the ref.
+// Without a tag the range covers the whole history, including the root commit.
+func (b Baseline) Range(to string) string {
+	if b.Tag == "" {
+		return to
+	}
+	return b.Tag + ".." + to
+}
+
+// LastReleaseBaseline resolves the baseline shared by all "since the last release" commands.
+// The tag prefix comes from the config if there is one and is detected otherwise.
+func LastReleaseBaseline() (Baseline, error) {
+	tag, err := LatestReleaseTag()
+	if err != nil {
+		return Baseline{}, err
+	}
+	if tag != "" {
+		return Baseline{Tag: tag}, nil
+	}
+
+	root, err := git.RootCommit()
+	if err != nil {
+		return Baseline{}, err
+	}
+	return Baseline{Root: root}, nil
+}
+
+// LatestReleaseTag returns the tag of the highest release, honoring the configured or detected tag prefix,
+// or an empty string if nothing was released yet
+func LatestReleaseTag() (string, error) {
+	tags, err := git.Tags()
+	if err != nil {
+		return "", err
+	}
+
+	prefix, err := RepositoryTagPrefix(tags)
+	if err != nil {
+		return "", err
+	}
+	return LastReleaseTag(tags, prefix), nil
+}
+
+// RepositoryTagPrefix returns the tag prefix from the config if there is one and detects it from the tags otherwise
+func RepositoryTagPrefix(tags []string) (string, error) {
+	if !config2.Exists() {
+		return DetectTagPrefix(tags), nil
+	}
+	cfg, err := config2.LoadConfig()
+	if err != nil {
+		return "", err
+	}
+	return ResolveTagPrefix(cfg, tags), nil
+}
+
+// LastReleaseTag returns the tag of the highest version using the prefix, or an empty string if there is none
+func LastReleaseTag(tags []string, prefix string) string {
+	latest := ""
+	var latestVersion *semver.Version
+	for _, tag := range tags {
+		if !strings.HasPrefix(tag, prefix) {
+			continue
+		}
+		v, err := parseVersionWithPrefix(tag, prefix)
+		if err != nil {
+			continue
+		}
+		if latestVersion == nil || v.GreaterThan(latestVersion) {
+			latest, latestVersion = tag, v
+		}
+	}
+	return latest
+}
+
+// PreviousReleaseTag returns the tag of the highest version below tag using the prefix,
+// or an empty string if tag is the first release
+func PreviousReleaseTag(tags []string, prefix, tag string) (string, error) {
+	if !IsVersionTag(tag, prefix) {
+		return "", fmt.Errorf("%s is not a release tag with prefix %q", tag, prefix)
+	}
+	current, _ := parseVersionWithPrefix(tag, prefix)
+
+	previous := ""
+	var previousVersion *semver.Version
+	for _, t := range tags {
+		if !strings.HasPrefix(t, prefix) {
+			continue
+		}
+		v, err := parseVersionWithPrefix(t, prefix)
+		if err != nil || !v.LessThan(current) {
+			continue
+		}
+		if previousVersion == nil || v.GreaterThan(previousVersion) {
+			previous, previousVersion = t, v
+		}
+	}
+	return previous, nil
+}
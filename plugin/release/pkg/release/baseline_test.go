@@ -0,0 +1,175 @@
+package release
+
+import (
+	"os"
+	"testing"
+
+	"github.com/nekoman-hq/neko-cli/plugin/release/internal/gittest"
+	config2 "github.com/nekoman-hq/neko-cli/plugin/release/pkg/config"
+)
+
+func TestLatestReleaseTagBare(t *testing.T) {
+	gittest.NewRepo(t)
+	for _, tag := range []string{"1.2.3", "1.10.0", "1.9.0", "nightly"} {
+		gittest.Run(t, "commit", "-q", "--allow-empty", "-m", "feat: "+tag)
+		gittest.Run(t, "tag", tag)
+	}
+
+	got, err := LatestReleaseTag()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "1.10.0" {
+		t.Errorf("LatestReleaseTag() = %q, want 1.10.0", got)
+	}
+}
+
+func TestLatestReleaseTagConfiguredPrefix(t *testing.T) {
+	gittest.NewRepo(t)
+	for _, tag := range []string{"v3.0.0", "release-1.0.0", "release-1.1.0"} {
+		gittest.Run(t, "commit", "-q", "--allow-empty", "-m", "feat: "+tag)
+		gittest.Run(t, "tag", tag)
+	}
+	cfg := `{"project-type": "backend", "release-system": "goreleaser", "version": "1.1.0", "tag-prefix": "release-"}`
+	if err := os.WriteFile(config2.FileName, []byte(cfg), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := LatestReleaseTag()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "release-1.1.0" {
+		t.Errorf("LatestReleaseTag() = %q, want release-1.1.0", got)
+	}
+}
+
+func TestLatestReleaseTagNoTags(t *testing.T) {
+	gittest.NewRepo(t)
+	gittest.Run(t, "commit", "-q", "--allow-empty", "-m", "feat: initial")
+
+	got, err := LatestReleaseTag()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "" {
+		t.Errorf("LatestReleaseTag() = %q, want no tag", got)
+	}
+}
+
+func TestLastReleaseBaselineTag(t *testing.T) {
+	gittest.NewRepo(t)
+	gittest.Run(t, "commit", "-q", "--allow-empty", "-m", "feat: first")
+	gittest.Run(t, "tag", "v1.0.0")
+	gittest.Run(t, "commit", "-q", "--allow-empty", "-m", "feat: second")
+	gittest.Run(t, "tag", "v1.1.0")
+	gittest.Run(t, "commit", "-q", "--allow-empty", "-m", "fix: unreleased")
+
+	baseline, err := LastReleaseBaseline()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if baseline.FirstRelease() || baseline.Tag != "v1.1.0" {
+		t.Fatalf("baseline = %+v, want tag v1.1.0", baseline)
+	}
+	if got := baseline.Range("HEAD"); got != "v1.1.0..HEAD" {
+		t.Errorf("Range(HEAD) = %q, want v1.1.0..HEAD", got)
+	}
+	if got := gittest.Run(t, "rev-list", "--count", baseline.Range("HEAD")); got != "1" {
+		t.Errorf("range covers %s commits, want the unreleased one", got)
+	}
+}
+
+func TestLastReleaseBaselineNoTags(t *testing.T) {
+	gittest.NewRepo(t)
+	gittest.Run(t, "commit", "-q", "--allow-empty", "-m", "feat: first")
+	root := gittest.Run(t, "rev-parse", "HEAD")
+	gittest.Run(t, "commit", "-q", "--allow-empty", "-m", "feat: second")
+
+	baseline, err := LastReleaseBaseline()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !baseline.FirstRelease() || baseline.Root != root {
+		t.Fatalf("baseline = %+v, want the root commit %s", baseline, root)
+	}
+	if baseline.Ref() != root {
+		t.Errorf("Ref() = %q, want the root commit", baseline.Ref())
+	}
+	// The whole history including the root commit belongs to the first release
+	if got := gittest.Run(t, "rev-list", "--count", baseline.Range("HEAD")); got != "2" {
+		t.Errorf("range covers %s commits, want 2", got)
+	}
+}
+
+func TestRepositoryTagPrefix(t *testing.T) {
+	tests := []struct {
+		name   string
+		config string
+		tags   []string
+		want   string
+	}{
+		{"detected from tags", "", []string{"v1.0.0"}, "v"},
+		{"detected bare", "", []string{"1.0.0"}, ""},
+		{"configured", `{"project-type": "backend", "release-system": "goreleaser", "version": "1.0.0", "tag-prefix": "release-"}`, []string{"v1.0.0"}, "release-"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Chdir(t.TempDir())
+			if tt.config != "" {
+				if err := os.WriteFile(config2.FileName, []byte(tt.config), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			got, err := RepositoryTagPrefix(tt.tags)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("RepositoryTagPrefix(%q) = %q, want %q", tt.tags, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRepositoryTagPrefixInvalidConfig(t *testing.T) {
+	t.Chdir(t.TempDir())
+	if err := os.WriteFile(config2.FileName, []byte("{"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := RepositoryTagPrefix([]string{"v1.0.0"}); err == nil {
+		t.Error("RepositoryTagPrefix succeeded with an invalid config")
+	}
+}
+
+func TestPreviousReleaseTag(t *testing.T) {
+	tags := []string{"v1.0.0", "v1.10.0", "v1.9.0", "v2.0.0-rc.1", "nightly", "release-1.5.0"}
+
+	tests := []struct {
+		name    string
+		prefix  string
+		tag     string
+		want    string
+		wantErr bool
+	}{
+		{"semver order", "v", "v1.10.0", "v1.9.0", false},
+		{"prerelease follows its predecessor", "v", "v2.0.0-rc.1", "v1.10.0", false},
+		{"first release", "v", "v1.0.0", "", false},
+		{"other prefix", "release-", "release-1.5.0", "", false},
+		{"not a release tag", "v", "nightly", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := PreviousReleaseTag(tags, tt.prefix, tt.tag)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("PreviousReleaseTag(%q) error = %v, want error %t", tt.tag, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("PreviousReleaseTag(%q) = %q, want %q", tt.tag, got, tt.want)
+			}
+		})
+	}
+}
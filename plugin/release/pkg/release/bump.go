@@ -0,0 +1,68 @@
+package release
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+// DefaultVersionScheme is used when no version scheme is configured
+const DefaultVersionScheme = "semver"
+
+// BumpStrategy computes the next version for a release type.
+// Strategies are registered per version scheme, e.g. semver or calver.
+type BumpStrategy interface {
+	Next(current *semver.Version, t Type) semver.Version
+}
+
+// SemverStrategy increments major, minor or patch according to semantic versioning
+type SemverStrategy struct{}
+
+func (SemverStrategy) Next(current *semver.Version, t Type) semver.Version {
+	switch t {
+	case Major:
+		return current.IncMajor()
+	case Minor:
+		return current.IncMinor()
+	case Patch:
+		return current.IncPatch()
+	default:
+		return *current
+	}
+}
+
+var (
+	strategiesMu sync.RWMutex
+	strategies   = map[string]BumpStrategy{
+		DefaultVersionScheme: SemverStrategy{},
+	}
+)
+
+// RegisterStrategy registers a bump strategy for the given version scheme.
+// Registering a nil strategy or a scheme twice panics, like Register for release systems.
+func RegisterStrategy(scheme string, s BumpStrategy) {
+	strategiesMu.Lock()
+	defer strategiesMu.Unlock()
+	if s == nil {
+		panic("release: RegisterStrategy strategy is nil")
+	}
+	if existing, dup := strategies[scheme]; dup {
+		panic(fmt.Sprintf("release: RegisterStrategy called twice for version scheme %q (%T and %T)", scheme, existing, s))
+	}
+	strategies[scheme] = s
+}
+
+// GetStrategy returns the bump strategy for the given version scheme.
+// An empty scheme resolves to the default semver strategy.
+func GetStrategy(scheme string) (BumpStrategy, error) {
+	if scheme == "" {
+		scheme = DefaultVersionScheme
+	}
+	strategiesMu.RLock()
+	defer strategiesMu.RUnlock()
+	if s, ok := strategies[scheme]; ok {
+		return s, nil
+	}
+	return nil, fmt.Errorf("unknown version scheme: %s", scheme)
+}
@@ -0,0 +1,137 @@
+package release
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+// fixedStrategy always returns the same version
+type fixedStrategy struct{ version string }
+
+func (f fixedStrategy) Next(*semver.Version, Type) semver.Version {
+	return *semver.MustParse(f.version)
+}
+
+// unregisterStrategies removes test strategies from the global registry
+func unregisterStrategies(t *testing.T, schemes ...string) {
+	t.Cleanup(func() {
+		strategiesMu.Lock()
+		defer strategiesMu.Unlock()
+		for _, scheme := range schemes {
+			delete(strategies, scheme)
+		}
+	})
+}
+
+func TestSemverStrategyNext(t *testing.T) {
+	tests := []struct {
+		current string
+		t       Type
+		want    string
+	}{
+		{"1.2.3", Patch, "1.2.4"},
+		{"1.2.3", Minor, "1.3.0"},
+		{"1.2.3", Major, "2.0.0"},
+		{"0.9.9", Minor, "0.10.0"},
+		{"1.2.3", Type("nightly"), "1.2.3"},
+	}
+
+	for _, tt := range tests {
+		got := SemverStrategy{}.Next(semver.MustParse(tt.current), tt.t)
+		if got.String() != tt.want {
+			t.Errorf("Next(%s, %s) = %s, want %s", tt.current, tt.t, got.String(), tt.want)
+		}
+	}
+}
+
+func TestGetStrategy(t *testing.T) {
+	unregisterStrategies(t, "fixed-test")
+	RegisterStrategy("fixed-test", fixedStrategy{"9.9.9"})
+
+	tests := []struct {
+		scheme  string
+		want    BumpStrategy
+		wantErr bool
+	}{
+		{"", SemverStrategy{}, false},
+		{DefaultVersionScheme, SemverStrategy{}, false},
+		{"fixed-test", fixedStrategy{"9.9.9"}, false},
+		{"calver", nil, true},
+	}
+
+	for _, tt := range tests {
+		got, err := GetStrategy(tt.scheme)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("GetStrategy(%q) error = %v, want error %t", tt.scheme, err, tt.wantErr)
+		}
+		if got != tt.want {
+			t.Errorf("GetStrategy(%q) = %#v, want %#v", tt.scheme, got, tt.want)
+		}
+	}
+}
+
+func TestRegisterStrategyPanics(t *testing.T) {
+	unregisterStrategies(t, "dup-test")
+	RegisterStrategy("dup-test", fixedStrategy{"1.0.0"})
+
+	tests := []struct {
+		name     string
+		scheme   string
+		strategy BumpStrategy
+	}{
+		{"duplicate", "dup-test", fixedStrategy{"2.0.0"}},
+		{"default scheme", DefaultVersionScheme, fixedStrategy{"2.0.0"}},
+		{"nil", "nil-test", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("RegisterStrategy(%q) did not panic", tt.scheme)
+				}
+			}()
+			RegisterStrategy(tt.scheme, tt.strategy)
+		})
+	}
+
+	if s, _ := GetStrategy("dup-test"); s != (fixedStrategy{"1.0.0"}) {
+		t.Errorf("the duplicate replaced the registered strategy with %#v", s)
+	}
+}
+
+// Run with -race, RegisterStrategy and GetStrategy share the registry map
+func TestStrategyRegistryConcurrentAccess(t *testing.T) {
+	const workers = 16
+
+	schemes := make([]string, workers)
+	for i := range schemes {
+		schemes[i] = fmt.Sprintf("race-scheme-%d", i)
+	}
+	unregisterStrategies(t, schemes...)
+
+	var wg sync.WaitGroup
+	for _, scheme := range schemes {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			RegisterStrategy(scheme, SemverStrategy{})
+		}()
+		go func() {
+			defer wg.Done()
+			for range 100 {
+				_, _ = GetStrategy(scheme)
+			}
+		}()
+	}
+	wg.Wait()
+
+	for _, scheme := range schemes {
+		if _, err := GetStrategy(scheme); err != nil {
+			t.Errorf("GetStrategy(%q) after RegisterStrategy: %v", scheme, err)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package release
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	config2 "github.com/nekoman-hq/neko-cli/plugin/release/pkg/config"
+
+	"github.com/nekoman-hq/neko-cli/pkg/log"
+)
+
+// unreleasedHeading matches the "## [Unreleased]" section of a keep-a-changelog file
+var unreleasedHeading = regexp.MustCompile(`(?i)^#+\s*\[?unreleased\]?\s*$`)
+
+// CheckChangelog fails if the changelog neither has an entry for the version nor documents unreleased changes.
+// The check only runs if require-changelog is set in the config.
+func CheckChangelog(cfg *config2.NekoConfig, v *semver.Version) error {
+	if !cfg.RequireChangelog {
+		return nil
+	}
+
+	file := cfg.Changelog()
+	log.PluginV(log.Preflight, fmt.Sprintf("Checking %s for an entry of %s", file, v))
+
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return fmt.Errorf("changelog required but %s could not be read: %w", file, err)
+	}
+
+	if HasChangelogEntry(string(content), v) {
+		log.PluginV(log.Preflight, fmt.Sprintf("\uF00C %s documents %s", file, v))
+		return nil
+	}
+
+	return fmt.Errorf(
+		"%s has no entry for %s. Add a \"## [%s]\" section or list the changes under \"## [Unreleased]\" before releasing",
+		file, v, v,
+	)
+}
+
+// HasChangelogEntry reports whether the changelog has a heading for the version, with or without tag prefix,
+// or a non-empty Unreleased section
+func HasChangelogEntry(content string, v *semver.Version) bool {
+	versionHeading := regexp.MustCompile(fmt.Sprintf(`^#+\s*\[?(%s)?%s([\]\s)]|$)`,
+		regexp.QuoteMeta(tagPrefix), regexp.QuoteMeta(v.String())))
+
+	// unreleasedLevel is the heading level of the Unreleased section, 0 outside of it
+	unreleasedLevel := 0
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+
+		if strings.HasPrefix(line, "#") {
+			if versionHeading.MatchString(line) {
+				return true
+			}
+			level := len(line) - len(strings.TrimLeft(line, "#"))
+			switch {
+			case unreleasedHeading.MatchString(line):
+				unreleasedLevel = level
+			case level <= unreleasedLevel:
+				// Subheadings like "### Added" belong to the Unreleased section, the next release ends it
+				unreleasedLevel = 0
+			}
+			continue
+		}
+
+		// Any entry below the Unreleased heading documents the upcoming release
+		if unreleasedLevel > 0 && line != "" && !strings.HasPrefix(line, "[") {
+			return true
+		}
+	}
+	return false
+}
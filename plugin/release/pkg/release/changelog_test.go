@@ -0,0 +1,83 @@
+package release
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/nekoman-hq/neko-cli/plugin/release/internal/gittest"
+	config2 "github.com/nekoman-hq/neko-cli/plugin/release/pkg/config"
+)
+
+func TestHasChangelogEntry(t *testing.T) {
+	tests := []struct {
+		name    string
+		prefix  string
+		content string
+		want    bool
+	}{
+		{"keep-a-changelog heading", "v", "# Changelog\n\n## [1.3.0] - 2026-10-15\n- login\n", true},
+		{"plain heading", "v", "## 1.3.0\n", true},
+		{"prefixed heading", "v", "### v1.3.0 (2026-10-15)\n", true},
+		{"custom prefix", "release-", "## [release-1.3.0]\n", true},
+		{"other prefix", "v", "## [release-1.3.0]\n", false},
+		{"older version only", "v", "## [1.2.0]\n- login\n", false},
+		{"longer version", "v", "## [1.3.01]\n", false},
+		{"prerelease of the version", "v", "## [1.3.0-rc.1]\n", false},
+		{"version in text", "v", "Upgrade to 1.3.0 soon\n", false},
+		{"unreleased entries", "v", "## [Unreleased]\n\n- login\n\n## [1.2.0]\n", true},
+		{"unreleased lower case", "v", "## unreleased\n### Added\n- login\n", true},
+		{"unreleased subsection", "v", "## [Unreleased]\n\n### Added\n\n- login\n\n## [1.2.0]\n", true},
+		{"empty unreleased subsection", "v", "## [Unreleased]\n### Added\n\n## [1.2.0]\n- login\n", false},
+		{"entries after unreleased", "v", "## [Unreleased]\n## [1.2.0]\n### Added\n- login\n", false},
+		{"empty unreleased", "v", "## [Unreleased]\n\n## [1.2.0]\n- login\n", false},
+		{"unreleased link only", "v", "## [Unreleased]\n[Unreleased]: https://example.com/compare/v1.2.0...HEAD\n", false},
+		{"empty", "v", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetTagPrefix(tt.prefix)
+			t.Cleanup(func() { SetTagPrefix(DefaultTagPrefix) })
+
+			if got := HasChangelogEntry(tt.content, semver.MustParse("1.3.0")); got != tt.want {
+				t.Errorf("HasChangelogEntry(%q) = %t, want %t", tt.content, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckChangelog(t *testing.T) {
+	tests := []struct {
+		name    string
+		require bool
+		content string // empty leaves the changelog out
+		wantErr string
+	}{
+		{"not required", false, "", ""},
+		{"documented", true, "## [1.3.0]\n- login\n", ""},
+		{"missing entry", true, "## [1.2.0]\n- login\n", "has no entry for 1.3.0"},
+		{"missing file", true, "", "could not be read"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gittest.NewRepo(t)
+			if tt.content != "" {
+				gittest.WriteFile(t, "CHANGELOG.md", tt.content)
+			}
+
+			cfg := &config2.NekoConfig{RequireChangelog: tt.require}
+			err := CheckChangelog(cfg, semver.MustParse("1.3.0"))
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("CheckChangelog = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("CheckChangelog = %v, want an error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
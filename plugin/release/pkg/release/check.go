@@ -0,0 +1,54 @@
+package release
+
+import (
+	config2 "github.com/nekoman-hq/neko-cli/plugin/release/pkg/config"
+)
+
+// Check statuses of a ToolCheck
+const (
+	CheckOK     = "ok"
+	CheckFailed = "failed"
+)
+
+// ToolCheck is one entry of the requirement checklist of a release system
+type ToolCheck struct {
+	System string
+	Status string
+	Detail string
+}
+
+// CheckTools validates the requirements (binaries, config files, dependencies) of every configured
+// release system in one pass. Each missing requirement is reported as its own failed check.
+func CheckTools(cfg *config2.NekoConfig) []ToolCheck {
+	checks := make([]ToolCheck, 0)
+	for _, system := range cfg.Systems() {
+		name := string(system)
+
+		tool, err := Get(name)
+		if err != nil {
+			checks = append(checks, ToolCheck{System: name, Status: CheckFailed, Detail: err.Error()})
+			continue
+		}
+
+		problems := splitErrors(tool.Validate())
+		if len(problems) == 0 {
+			checks = append(checks, ToolCheck{System: name, Status: CheckOK, Detail: "all requirements met"})
+			continue
+		}
+		for _, problem := range problems {
+			checks = append(checks, ToolCheck{System: name, Status: CheckFailed, Detail: problem.Error()})
+		}
+	}
+	return checks
+}
+
+// splitErrors unpacks an errors.Join result into its errors
+func splitErrors(err error) []error {
+	if err == nil {
+		return nil
+	}
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		return joined.Unwrap()
+	}
+	return []error{err}
+}
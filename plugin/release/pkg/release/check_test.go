@@ -0,0 +1,77 @@
+package release
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+
+	config2 "github.com/nekoman-hq/neko-cli/plugin/release/pkg/config"
+)
+
+func TestSplitErrors(t *testing.T) {
+	a, b := errors.New("a"), errors.New("b")
+	wrapped := fmt.Errorf("tool: %w", a)
+
+	tests := []struct {
+		name string
+		err  error
+		want []error
+	}{
+		{"nil", nil, nil},
+		{"single", a, []error{a}},
+		{"joined", errors.Join(a, b), []error{a, b}},
+		{"wrapped single", wrapped, []error{wrapped}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := splitErrors(tt.err); !slices.Equal(got, tt.want) {
+				t.Errorf("splitErrors(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckTools(t *testing.T) {
+	unregister(t, "check-ok", "check-missing")
+	Register(&validatingTool{fakeTool: fakeTool{name: "check-ok"}})
+	Register(&validatingTool{
+		fakeTool: fakeTool{name: "check-missing"},
+		err: errors.Join(
+			errors.New("required dependency missing: npm"),
+			errors.New("package.json not found"),
+		),
+	})
+
+	tests := []struct {
+		name    string
+		systems []config2.ReleaseSystem
+		want    []ToolCheck
+	}{
+		{"requirements met", []config2.ReleaseSystem{"check-ok"}, []ToolCheck{
+			{System: "check-ok", Status: CheckOK, Detail: "all requirements met"},
+		}},
+		{"one row per missing requirement", []config2.ReleaseSystem{"check-ok", "check-missing"}, []ToolCheck{
+			{System: "check-ok", Status: CheckOK, Detail: "all requirements met"},
+			{System: "check-missing", Status: CheckFailed, Detail: "required dependency missing: npm"},
+			{System: "check-missing", Status: CheckFailed, Detail: "package.json not found"},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := CheckTools(&config2.NekoConfig{ReleaseSystems: tt.systems})
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("CheckTools = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckToolsUnknownSystem(t *testing.T) {
+	checks := CheckTools(&config2.NekoConfig{ReleaseSystem: "check-unknown"})
+	if len(checks) != 1 || checks[0].System != "check-unknown" || checks[0].Status != CheckFailed {
+		t.Errorf("CheckTools = %+v, want one failed check", checks)
+	}
+}
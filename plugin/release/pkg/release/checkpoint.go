@@ -0,0 +1,99 @@
+package release
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	config2 "github.com/nekoman-hq/neko-cli/plugin/release/pkg/config"
+	"github.com/nekoman-hq/neko-cli/plugin/release/pkg/git"
+
+	"github.com/nekoman-hq/neko-cli/pkg/errors"
+	"github.com/nekoman-hq/neko-cli/pkg/log"
+)
+
+// Checkpoint configures how the working tree is checked while a release is running
+type Checkpoint struct {
+	// ExpectedFiles are the files neko or the release tool are supposed to modify.
+	// A directory entry covers every file below it.
+	ExpectedFiles []string
+	Policy        config2.DirtyTreePolicy
+}
+
+// checkpoint is used by CreateReleaseCommit and Service.Run, resolved once per release
+var checkpoint Checkpoint
+
+// SetCheckpoint sets the checkpoint used during the release
+func SetCheckpoint(c Checkpoint) {
+	checkpoint = c
+}
+
+// CheckpointFrom returns the checkpoint for the config, expecting changes to the neko files and the changelog
+func CheckpointFrom(cfg *config2.NekoConfig) Checkpoint {
+	return Checkpoint{
+		ExpectedFiles: []string{config2.FileName, config2.LockFileName, cfg.Changelog()},
+		Policy:        cfg.DirtyTreePolicy(),
+	}
+}
+
+// Expect adds files the release tool is supposed to modify
+func (c *Checkpoint) Expect(files ...string) {
+	c.ExpectedFiles = append(c.ExpectedFiles, files...)
+}
+
+// UnexpectedChanges returns the changed files not covered by the expected files
+func UnexpectedChanges(changed, expected []string) []string {
+	var unexpected []string
+	for _, file := range changed {
+		if !isExpected(file, expected) {
+			unexpected = append(unexpected, file)
+		}
+	}
+	return unexpected
+}
+
+func isExpected(file string, expected []string) bool {
+	file = filepath.ToSlash(filepath.Clean(file))
+	for _, e := range expected {
+		e = filepath.ToSlash(filepath.Clean(e))
+		if file == e || strings.HasPrefix(file, e+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// checkWorkingTree detects files modified beyond the expected ones. With canStage set and
+// the stage policy, they are staged so they end up in the next commit; otherwise a warning is written.
+func checkWorkingTree(stage string, canStage bool) error {
+	changed, err := git.ChangedFiles()
+	if err != nil {
+		return err
+	}
+
+	unexpected := UnexpectedChanges(changed, checkpoint.ExpectedFiles)
+	if len(unexpected) == 0 {
+		log.PluginV(log.Guard, fmt.Sprintf("No unexpected changes %s", stage))
+		return nil
+	}
+
+	if canStage && checkpoint.Policy == config2.DirtyTreeStage {
+		if err := git.Stage(unexpected); err != nil {
+			return fmt.Errorf("failed to stage unexpected changes: %w", err)
+		}
+		log.PluginPrint(log.Guard, "Staged %d unexpected change(s) into the release commit: %s",
+			len(unexpected), log.ColorText(log.ColorCyan, strings.Join(unexpected, ", ")))
+		return nil
+	}
+
+	hint := fmt.Sprintf("Set \"dirty-tree\": %q in %s to include them in the release commit.",
+		config2.DirtyTreeStage, config2.FileName)
+	if !canStage {
+		hint = "They are not part of the release. Commit or discard them manually."
+	}
+	errors.WriteWarning(
+		"Working tree became dirty",
+		fmt.Sprintf("Unexpected changes %s:\n  %s\n%s", stage, strings.Join(unexpected, "\n  "), hint),
+	)
+	return nil
+}
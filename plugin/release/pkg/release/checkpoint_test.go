@@ -0,0 +1,83 @@
+package release
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/nekoman-hq/neko-cli/pkg/errors"
+	"github.com/nekoman-hq/neko-cli/plugin/release/internal/gittest"
+	config2 "github.com/nekoman-hq/neko-cli/plugin/release/pkg/config"
+)
+
+func TestUnexpectedChanges(t *testing.T) {
+	expected := []string{".release.neko.json", "CHANGELOG.md", "dist", "./docs/"}
+
+	tests := []struct {
+		changed []string
+		want    []string
+	}{
+		{[]string{"CHANGELOG.md", ".release.neko.json"}, nil},
+		{[]string{"dist/app.tar.gz", "docs/index.md"}, nil},
+		{[]string{"package-lock.json", "CHANGELOG.md"}, []string{"package-lock.json"}},
+		{[]string{"distribution.txt", "src/CHANGELOG.md"}, []string{"distribution.txt", "src/CHANGELOG.md"}},
+	}
+
+	for _, tt := range tests {
+		if got := UnexpectedChanges(tt.changed, expected); !slices.Equal(got, tt.want) {
+			t.Errorf("UnexpectedChanges(%v) = %v, want %v", tt.changed, got, tt.want)
+		}
+	}
+}
+
+// A release tool updating the changelog and, unexpectedly, writing a lock file and a log
+func TestCreateReleaseCommitToolChanges(t *testing.T) {
+	tests := []struct {
+		policy        config2.DirtyTreePolicy
+		wantCommitted []string
+		wantWarning   bool
+	}{
+		{config2.DirtyTreeWarn, []string{"CHANGELOG.md", "package-lock.json"}, true},
+		{config2.DirtyTreeStage, []string{"CHANGELOG.md", "package-lock.json", "release.log"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.policy), func(t *testing.T) {
+			gittest.NewRepo(t)
+			gittest.WriteFile(t, "CHANGELOG.md", "# Changelog\n")
+			gittest.WriteFile(t, "package-lock.json", "{}\n")
+			gittest.Run(t, "add", ".")
+			gittest.Run(t, "commit", "-q", "-m", "feat: initial")
+
+			SetCheckpoint(Checkpoint{ExpectedFiles: []string{"CHANGELOG.md"}, Policy: tt.policy})
+			t.Cleanup(func() { SetCheckpoint(Checkpoint{}) })
+
+			gittest.WriteFile(t, "CHANGELOG.md", "# Changelog\n\n## 1.1.0\n")
+			gittest.WriteFile(t, "package-lock.json", `{"version": "1.1.0"}`+"\n")
+			gittest.WriteFile(t, "release.log", "released\n")
+
+			before := len(errors.Warnings())
+			if err := (&ToolBase{}).CreateReleaseCommit(context.Background(), semver.MustParse("1.1.0")); err != nil {
+				t.Fatal(err)
+			}
+
+			committed := strings.Fields(gittest.Run(t, "show", "--name-only", "--format=", "HEAD"))
+			if !slices.Equal(committed, tt.wantCommitted) {
+				t.Errorf("release commit contains %v, want %v", committed, tt.wantCommitted)
+			}
+
+			warnings := errors.Warnings()[before:]
+			if (len(warnings) > 0) != tt.wantWarning {
+				t.Fatalf("warnings = %+v, want a warning %t", warnings, tt.wantWarning)
+			}
+			if tt.wantWarning {
+				msg := warnings[0].Message
+				if !strings.Contains(msg, "package-lock.json") || !strings.Contains(msg, "release.log") || strings.Contains(msg, "CHANGELOG.md") {
+					t.Errorf("warning %q does not list exactly the unexpected files", msg)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package release
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	config2 "github.com/nekoman-hq/neko-cli/plugin/release/pkg/config"
+	"github.com/nekoman-hq/neko-cli/plugin/release/pkg/git"
+)
+
+// CommitOptions configures the release commit
+type CommitOptions struct {
+	// Trailers like "Co-authored-by: Name <mail>" are appended as the last paragraph of the message
+	Trailers []string
+	// SignOff adds a Signed-off-by trailer via git commit --signoff
+	SignOff bool
+	// NoCommit skips the release commit, the current HEAD is tagged instead
+	NoCommit bool
+	// NoVerify skips the pre-commit and commit-msg hooks via git commit --no-verify
+	NoVerify bool
+	// AuthorName and AuthorEmail override the ambient git identity, e.g. for a CI bot
+	AuthorName  string
+	AuthorEmail string
+}
+
+// commitOptions is used by CreateReleaseCommit, resolved once per release
+var commitOptions CommitOptions
+
+// SetCommitOptions sets the options used for release commits
+func SetCommitOptions(o CommitOptions) {
+	commitOptions = o
+}
+
+// SetNoVerify skips the git hooks of the release commit, set per run by the --no-verify flag
+func SetNoVerify(noVerify bool) {
+	commitOptions.NoVerify = noVerify
+}
+
+// CommitOptionsFrom reads the release commit options from the config
+func CommitOptionsFrom(cfg *config2.NekoConfig) CommitOptions {
+	return CommitOptions{
+		Trailers: cfg.CommitTrailers,
+		SignOff:  cfg.SignOff,
+		NoCommit: !cfg.ShouldCreateReleaseCommit(),
+
+		AuthorName:  cfg.ReleaseCommitAuthor,
+		AuthorEmail: cfg.ReleaseCommitEmail,
+	}
+}
+
+// ReleaseCommitEnabled reports whether the release tools create a release commit
+func ReleaseCommitEnabled() bool {
+	return !commitOptions.NoCommit
+}
+
+// ReleaseCommitMessage returns the message of the chore commit for the release.
+// Git only recognizes trailers in the last paragraph, so they are separated by a blank line.
+func ReleaseCommitMessage(v *semver.Version) string {
+	subject := git.ReleaseCommitSubject(v.String())
+	if len(commitOptions.Trailers) == 0 {
+		return subject
+	}
+	return subject + "\n\n" + strings.Join(commitOptions.Trailers, "\n")
+}
+
+// ReleaseCommitArgs returns the git arguments used to create the release commit
+func ReleaseCommitArgs(v *semver.Version) []string {
+	args := []string{"commit", "--allow-empty", "-a"}
+	if commitOptions.SignOff {
+		args = append(args, "--signoff")
+	}
+	if commitOptions.NoVerify {
+		args = append(args, "--no-verify")
+	}
+	return append(args, "-m", ReleaseCommitMessage(v))
+}
+
+// ReleaseCommitEnv returns the environment of the release commit command.
+// The configured identity is set as author and committer, otherwise git uses the ambient config.
+func ReleaseCommitEnv(environ []string) []string {
+	env := append([]string{}, environ...)
+	if commitOptions.AuthorName != "" {
+		env = append(env, "GIT_AUTHOR_NAME="+commitOptions.AuthorName, "GIT_COMMITTER_NAME="+commitOptions.AuthorName)
+	}
+	if commitOptions.AuthorEmail != "" {
+		env = append(env, "GIT_AUTHOR_EMAIL="+commitOptions.AuthorEmail, "GIT_COMMITTER_EMAIL="+commitOptions.AuthorEmail)
+	}
+	return env
+}
+
+// PlannedCommitCommands returns the planned command creating the release commit, none if it is disabled
+func PlannedCommitCommands(v *semver.Version) []string {
+	if !ReleaseCommitEnabled() {
+		return nil
+	}
+	return []string{fmt.Sprintf("git commit --allow-empty -a -m \"%s\"", ReleaseCommitMessage(v))}
+}
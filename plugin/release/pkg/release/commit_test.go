@@ -0,0 +1,195 @@
+package release
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/nekoman-hq/neko-cli/plugin/release/internal/gittest"
+	config2 "github.com/nekoman-hq/neko-cli/plugin/release/pkg/config"
+	"github.com/nekoman-hq/neko-cli/plugin/release/pkg/git"
+)
+
+// withCommitOptions sets the release commit options for the test
+func withCommitOptions(t *testing.T, o CommitOptions) {
+	t.Helper()
+	SetCommitOptions(o)
+	t.Cleanup(func() { SetCommitOptions(CommitOptions{}) })
+}
+
+func TestReleaseCommitArgs(t *testing.T) {
+	subject := git.ReleaseCommitSubject("1.3.0")
+	trailers := []string{"Co-authored-by: Jane Doe <jane@example.com>", "Reviewed-by: John Doe <john@example.com>"}
+
+	tests := []struct {
+		name    string
+		options CommitOptions
+		want    []string
+	}{
+		{
+			name: "plain",
+			want: []string{"commit", "--allow-empty", "-a", "-m", subject},
+		},
+		{
+			name:    "trailers",
+			options: CommitOptions{Trailers: trailers},
+			want: []string{"commit", "--allow-empty", "-a", "-m",
+				subject + "\n\nCo-authored-by: Jane Doe <jane@example.com>\nReviewed-by: John Doe <john@example.com>"},
+		},
+		{
+			name:    "sign-off and no-verify",
+			options: CommitOptions{SignOff: true, NoVerify: true},
+			want:    []string{"commit", "--allow-empty", "-a", "--signoff", "--no-verify", "-m", subject},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			withCommitOptions(t, tt.options)
+			if got := ReleaseCommitArgs(semver.MustParse("1.3.0")); !slices.Equal(got, tt.want) {
+				t.Errorf("ReleaseCommitArgs = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// Git must parse the configured trailers and the sign-off from the commit it created
+func TestCreateReleaseCommitTrailers(t *testing.T) {
+	gittest.NewRepo(t)
+	gittest.Commit(t, "feat: initial")
+	withCommitOptions(t, CommitOptions{
+		Trailers: []string{"Co-authored-by: Jane Doe <jane@example.com>"},
+		SignOff:  true,
+	})
+
+	tb := &ToolBase{}
+	if err := tb.CreateReleaseCommit(context.Background(), semver.MustParse("1.3.0")); err != nil {
+		t.Fatal(err)
+	}
+
+	if subject := gittest.Run(t, "log", "-1", "--format=%s"); subject != git.ReleaseCommitSubject("1.3.0") {
+		t.Errorf("subject = %q", subject)
+	}
+	trailers := gittest.Run(t, "log", "-1", "--format=%(trailers:only,unfold)")
+	want := "Co-authored-by: Jane Doe <jane@example.com>\nSigned-off-by: neko <neko@example.com>"
+	if trailers != want {
+		t.Errorf("trailers = %q, want %q", trailers, want)
+	}
+}
+
+func TestCommitOptionsFromCreateReleaseCommit(t *testing.T) {
+	enabled, disabled := true, false
+	tests := []struct {
+		name    string
+		setting *bool
+		want    bool
+	}{
+		{"default", nil, true},
+		{"enabled", &enabled, true},
+		{"disabled", &disabled, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			withCommitOptions(t, CommitOptionsFrom(&config2.NekoConfig{CreateReleaseCommit: tt.setting}))
+			if got := ReleaseCommitEnabled(); got != tt.want {
+				t.Errorf("ReleaseCommitEnabled = %t, want %t", got, tt.want)
+			}
+
+			commands := PlannedCommitCommands(semver.MustParse("1.3.0"))
+			if planned := len(commands) == 1; planned != tt.want {
+				t.Errorf("PlannedCommitCommands = %q, want a commit planned: %t", commands, tt.want)
+			}
+		})
+	}
+}
+
+func TestReleaseCommitEnv(t *testing.T) {
+	tests := []struct {
+		name    string
+		options CommitOptions
+		want    []string
+	}{
+		{"ambient identity", CommitOptions{}, []string{"PATH=/bin"}},
+		{"name only", CommitOptions{AuthorName: "neko-bot"}, []string{
+			"PATH=/bin", "GIT_AUTHOR_NAME=neko-bot", "GIT_COMMITTER_NAME=neko-bot",
+		}},
+		{"name and email", CommitOptions{AuthorName: "neko-bot", AuthorEmail: "bot@example.com"}, []string{
+			"PATH=/bin", "GIT_AUTHOR_NAME=neko-bot", "GIT_COMMITTER_NAME=neko-bot",
+			"GIT_AUTHOR_EMAIL=bot@example.com", "GIT_COMMITTER_EMAIL=bot@example.com",
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			withCommitOptions(t, tt.options)
+			environ := []string{"PATH=/bin"}
+			if got := ReleaseCommitEnv(environ); !slices.Equal(got, tt.want) {
+				t.Errorf("ReleaseCommitEnv = %q, want %q", got, tt.want)
+			}
+			if len(environ) != 1 {
+				t.Errorf("ReleaseCommitEnv modified its input: %q", environ)
+			}
+		})
+	}
+}
+
+// The configured identity replaces the ambient git config as author and committer
+func TestCreateReleaseCommitIdentity(t *testing.T) {
+	gittest.NewRepo(t)
+	gittest.Commit(t, "feat: initial")
+	withCommitOptions(t, CommitOptionsFrom(&config2.NekoConfig{
+		ReleaseCommitAuthor: "neko-bot",
+		ReleaseCommitEmail:  "bot@example.com",
+	}))
+
+	if err := (&ToolBase{}).CreateReleaseCommit(context.Background(), semver.MustParse("1.3.0")); err != nil {
+		t.Fatal(err)
+	}
+
+	want := "neko-bot <bot@example.com>\nneko-bot <bot@example.com>"
+	if got := gittest.Run(t, "log", "-1", "--format=%an <%ae>%n%cn <%ce>"); got != want {
+		t.Errorf("author and committer = %q, want %q", got, want)
+	}
+}
+
+// A failing pre-commit hook blocks the release commit unless --no-verify is set
+func TestCreateReleaseCommitNoVerify(t *testing.T) {
+	tests := []struct {
+		name     string
+		noVerify bool
+		wantErr  bool
+	}{
+		{"hooks run", false, true},
+		{"hooks skipped", true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gittest.NewRepo(t)
+			gittest.Commit(t, "feat: initial")
+			hook := filepath.Join(".git", "hooks", "pre-commit")
+			if err := os.WriteFile(hook, []byte("#!/bin/sh\necho 'lint failed' >&2\nexit 1\n"), 0755); err != nil {
+				t.Fatal(err)
+			}
+
+			withCommitOptions(t, CommitOptions{SignOff: true})
+			SetNoVerify(tt.noVerify)
+			if !commitOptions.SignOff {
+				t.Error("SetNoVerify reset the other commit options")
+			}
+
+			err := (&ToolBase{}).CreateReleaseCommit(context.Background(), semver.MustParse("1.3.0"))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CreateReleaseCommit = %v, want error %t", err, tt.wantErr)
+			}
+			subject := gittest.Run(t, "log", "-1", "--format=%s")
+			if committed := subject == git.ReleaseCommitSubject("1.3.0"); committed == tt.wantErr {
+				t.Errorf("HEAD is %q, release committed: %t", subject, committed)
+			}
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package release
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/nekoman-hq/neko-cli/pkg/errors"
+	"github.com/nekoman-hq/neko-cli/pkg/log"
+	config2 "github.com/nekoman-hq/neko-cli/plugin/release/pkg/config"
+	"github.com/nekoman-hq/neko-cli/plugin/release/pkg/git"
+)
+
+// commitlintFiles are the JSON commitlint configs checked for scope rules.
+// JavaScript and YAML configs cannot be evaluated and are skipped.
+var commitlintFiles = []string{".commitlintrc", ".commitlintrc.json"}
+
+// commitlintRules holds the commitlint rules relevant for the release commit scope.
+// A rule is [level, "always"|"never", value], level 2 is an error and 1 a warning.
+type commitlintRules struct {
+	ScopeEnum  []json.RawMessage `json:"scope-enum"`
+	ScopeEmpty []json.RawMessage `json:"scope-empty"`
+}
+
+// loadCommitlintRules reads the scope rules from the first commitlint config found in the repository.
+// The second return value is the file the rules were read from, empty if there is none.
+func loadCommitlintRules() (*commitlintRules, string, error) {
+	for _, file := range commitlintFiles {
+		data, err := os.ReadFile(file)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, file, err
+		}
+		var cfg struct {
+			Rules commitlintRules `json:"rules"`
+		}
+		if err := json.Unmarshal(data, &cfg); err != nil {
+			return nil, file, fmt.Errorf("%s is not valid JSON: %w", file, err)
+		}
+		return &cfg.Rules, file, nil
+	}
+
+	data, err := os.ReadFile("package.json")
+	if err != nil {
+		return nil, "", nil
+	}
+	var pkg struct {
+		Commitlint *struct {
+			Rules commitlintRules `json:"rules"`
+		} `json:"commitlint"`
+	}
+	if err := json.Unmarshal(data, &pkg); err != nil || pkg.Commitlint == nil {
+		return nil, "", nil
+	}
+	return &pkg.Commitlint.Rules, "package.json", nil
+}
+
+// scopeViolation returns the level of the commitlint rule the scope breaks and why, level 0 if it is allowed
+func (r *commitlintRules) scopeViolation(scope string) (int, string) {
+	if level, when, ok := parseRule(r.ScopeEmpty); ok && level > 0 {
+		if scope == "" && when == "never" {
+			return level, "scope-empty requires a scope"
+		}
+		if scope != "" && when == "always" {
+			return level, "scope-empty forbids a scope"
+		}
+	}
+
+	if level, when, ok := parseRule(r.ScopeEnum); ok && level > 0 && scope != "" {
+		var allowed []string
+		if len(r.ScopeEnum) > 2 {
+			_ = json.Unmarshal(r.ScopeEnum[2], &allowed)
+		}
+		listed := slices.Contains(allowed, scope)
+		if when == "always" && !listed {
+			return level, fmt.Sprintf("scope-enum only allows %s", strings.Join(allowed, ", "))
+		}
+		if when == "never" && listed {
+			return level, fmt.Sprintf("scope-enum forbids %s", scope)
+		}
+	}
+	return 0, ""
+}
+
+// parseRule extracts the level and condition of a commitlint rule
+func parseRule(rule []json.RawMessage) (int, string, bool) {
+	if len(rule) < 2 {
+		return 0, "", false
+	}
+	var level int
+	var when string
+	if json.Unmarshal(rule[0], &level) != nil || json.Unmarshal(rule[1], &when) != nil {
+		return 0, "", false
+	}
+	return level, when, true
+}
+
+// checkCommitScope fails the release if the commitlint config of the repository rejects the
+// scope of the release commit, rules with warning level only produce a warning
+func checkCommitScope(cfg *config2.NekoConfig) {
+	// release-it creates its own commit from the commitMessage in .release-it.json
+	if !ReleaseCommitEnabled() || cfg.ReleaseSystem == config2.ReleaseTypeReleaseIt {
+		return
+	}
+
+	rules, file, err := loadCommitlintRules()
+	if err != nil {
+		log.PluginV(log.Preflight, fmt.Sprintf("Skipping commit scope check: %s", err.Error()))
+		return
+	}
+	if rules == nil {
+		return
+	}
+
+	scope := git.ReleaseCommitScope()
+	level, reason := rules.scopeViolation(scope)
+	if level == 0 {
+		return
+	}
+
+	message := fmt.Sprintf("the release commit '%s' breaks %s: %s. Set release-commit-scope in .release.neko.json",
+		git.ReleaseCommitSubject("x.y.z"), file, reason)
+	if level == 1 {
+		errors.WriteWarning("Release commit scope", message)
+		return
+	}
+	errors.WriteError("COMMIT_SCOPE_REJECTED", message)
+}
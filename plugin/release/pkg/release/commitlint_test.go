@@ -0,0 +1,142 @@
+package release
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/nekoman-hq/neko-cli/pkg/errors"
+	"github.com/nekoman-hq/neko-cli/plugin/release/internal/gittest"
+	config2 "github.com/nekoman-hq/neko-cli/plugin/release/pkg/config"
+	"github.com/nekoman-hq/neko-cli/plugin/release/pkg/git"
+)
+
+// withReleaseCommitScope configures the release commit scope for the test
+func withReleaseCommitScope(t *testing.T, scope string) {
+	t.Helper()
+	previous := git.ReleaseCommitScope()
+	git.SetReleaseCommitScope(&scope)
+	t.Cleanup(func() { git.SetReleaseCommitScope(&previous) })
+}
+
+// rules decodes the rules object of a commitlint config
+func rules(t *testing.T, config string) *commitlintRules {
+	t.Helper()
+	var r commitlintRules
+	if err := json.Unmarshal([]byte(config), &r); err != nil {
+		t.Fatal(err)
+	}
+	return &r
+}
+
+func TestScopeViolation(t *testing.T) {
+	tests := []struct {
+		name       string
+		rules      string
+		scope      string
+		wantLevel  int
+		wantReason string
+	}{
+		{"no rules", `{}`, "neko-release", 0, ""},
+		{"listed scope", `{"scope-enum": [2, "always", ["neko-release", "deps"]]}`, "neko-release", 0, ""},
+		{"unlisted scope", `{"scope-enum": [2, "always", ["deps", "ci"]]}`, "neko-release", 2, "scope-enum only allows deps, ci"},
+		{"unlisted scope warns", `{"scope-enum": [1, "always", ["deps"]]}`, "neko-release", 1, "scope-enum only allows deps"},
+		{"disabled rule", `{"scope-enum": [0, "always", ["deps"]]}`, "neko-release", 0, ""},
+		{"forbidden scope", `{"scope-enum": [2, "never", ["neko-release"]]}`, "neko-release", 2, "scope-enum forbids neko-release"},
+		{"enum ignores no scope", `{"scope-enum": [2, "always", ["deps"]]}`, "", 0, ""},
+		{"scope required", `{"scope-empty": [2, "never"]}`, "", 2, "scope-empty requires a scope"},
+		{"scope forbidden", `{"scope-empty": [2, "always"]}`, "release", 2, "scope-empty forbids a scope"},
+		{"malformed rule", `{"scope-enum": ["error"]}`, "neko-release", 0, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			level, reason := rules(t, tt.rules).scopeViolation(tt.scope)
+			if level != tt.wantLevel || reason != tt.wantReason {
+				t.Errorf("scopeViolation(%q) = %d, %q, want %d, %q", tt.scope, level, reason, tt.wantLevel, tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestLoadCommitlintRules(t *testing.T) {
+	const enum = `{"rules": {"scope-enum": [2, "always", ["deps"]]}}`
+
+	tests := []struct {
+		name     string
+		files    map[string]string
+		wantFile string
+		wantErr  bool
+	}{
+		{name: "no config"},
+		{name: ".commitlintrc", files: map[string]string{".commitlintrc": enum}, wantFile: ".commitlintrc"},
+		{name: ".commitlintrc.json", files: map[string]string{".commitlintrc.json": enum}, wantFile: ".commitlintrc.json"},
+		{
+			name:     "first config wins",
+			files:    map[string]string{".commitlintrc": enum, "package.json": `{"commitlint": {"rules": {}}}`},
+			wantFile: ".commitlintrc",
+		},
+		{name: "package.json", files: map[string]string{"package.json": `{"commitlint": ` + enum + `}`}, wantFile: "package.json"},
+		{name: "package.json without commitlint", files: map[string]string{"package.json": `{"name": "app"}`}},
+		{name: "invalid JSON", files: map[string]string{".commitlintrc": "extends: ['@commitlint/config-conventional']"}, wantFile: ".commitlintrc", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gittest.NewRepo(t)
+			for name, content := range tt.files {
+				gittest.WriteFile(t, name, content)
+			}
+
+			r, file, err := loadCommitlintRules()
+			if (err != nil) != tt.wantErr || file != tt.wantFile {
+				t.Fatalf("loadCommitlintRules = %q, %v, want %q, error %t", file, err, tt.wantFile, tt.wantErr)
+			}
+			if tt.wantErr || tt.wantFile == "" {
+				if r != nil {
+					t.Errorf("rules = %+v, want none", r)
+				}
+				return
+			}
+			if level, _ := r.scopeViolation("neko-release"); level != 2 {
+				t.Errorf("rules of %s not loaded: %+v", file, r)
+			}
+		})
+	}
+}
+
+// A warning level rule only warns about the release commit scope
+func TestCheckCommitScopeWarns(t *testing.T) {
+	tests := []struct {
+		name        string
+		scope       string
+		system      config2.ReleaseSystem
+		wantWarning bool
+	}{
+		{"rejected scope", "neko-release", config2.ReleaseTypeGoReleaser, true},
+		{"allowed scope", "release", config2.ReleaseTypeGoReleaser, false},
+		{"release-it commits itself", "neko-release", config2.ReleaseTypeReleaseIt, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gittest.NewRepo(t)
+			gittest.WriteFile(t, ".commitlintrc.json", `{"rules": {"scope-enum": [1, "always", ["release", "deps"]]}}`)
+			withReleaseCommitScope(t, tt.scope)
+
+			before := len(errors.Warnings())
+			checkCommitScope(&config2.NekoConfig{ReleaseSystem: tt.system})
+
+			warnings := errors.Warnings()[before:]
+			if !tt.wantWarning {
+				if len(warnings) != 0 {
+					t.Errorf("warnings = %+v, want none", warnings)
+				}
+				return
+			}
+			if len(warnings) != 1 || !strings.Contains(warnings[0].Message, "chore(neko-release): x.y.z") {
+				t.Errorf("warnings = %+v, want one naming the release commit", warnings)
+			}
+		})
+	}
+}
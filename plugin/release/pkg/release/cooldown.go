@@ -0,0 +1,67 @@
+package release
+
+import (
+	stderrors "errors"
+	"fmt"
+	"time"
+
+	"github.com/nekoman-hq/neko-cli/pkg/errors"
+	"github.com/nekoman-hq/neko-cli/pkg/log"
+	config2 "github.com/nekoman-hq/neko-cli/plugin/release/pkg/config"
+	"github.com/nekoman-hq/neko-cli/plugin/release/pkg/git"
+)
+
+// CooldownRemaining returns how long the configured min-release-interval still blocks a release
+// and the last release tag it is measured from. Zero means releasing is allowed.
+func CooldownRemaining(cfg *config2.NekoConfig, now time.Time) (time.Duration, string, error) {
+	interval, err := cfg.ReleaseInterval()
+	if err != nil || interval == 0 {
+		return 0, "", err
+	}
+
+	tag, err := latestBaselineTag(cfg)
+	if stderrors.Is(err, git.ErrNoTags) {
+		return 0, "", nil
+	}
+	if err != nil {
+		return 0, "", err
+	}
+
+	released, err := git.CommitTime(tag)
+	if err != nil {
+		return 0, "", err
+	}
+	return remainingCooldown(released, interval, now), tag, nil
+}
+
+// remainingCooldown returns the part of the interval after released that has not passed yet at now
+func remainingCooldown(released time.Time, interval time.Duration, now time.Time) time.Duration {
+	remaining := released.Add(interval).Sub(now)
+	if remaining < 0 {
+		return 0
+	}
+	return remaining
+}
+
+// CheckCooldown fails the release if the last release is more recent than min-release-interval.
+// With force the check only produces a warning.
+func CheckCooldown(cfg *config2.NekoConfig, force bool) {
+	remaining, tag, err := CooldownRemaining(cfg, time.Now())
+	if err != nil {
+		log.PluginV(log.Guard, fmt.Sprintf("Skipping release interval check: %s", err.Error()))
+		return
+	}
+	if remaining == 0 {
+		return
+	}
+
+	message := fmt.Sprintf("the last release %s is less than %s old (min-release-interval), the next release is allowed in %s",
+		tag, cfg.MinReleaseInterval, remaining.Round(time.Second))
+	if force {
+		errors.WriteWarning("Releasing within the release interval", message)
+		return
+	}
+	errors.WriteErrorWithDetails("RELEASE_COOLDOWN", message, map[string]any{
+		"hint": "Pass --force to release anyway",
+	})
+}
@@ -0,0 +1,132 @@
+package release
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nekoman-hq/neko-cli/pkg/errors"
+	"github.com/nekoman-hq/neko-cli/pkg/plugin"
+	"github.com/nekoman-hq/neko-cli/plugin/release/internal/gittest"
+	"github.com/nekoman-hq/neko-cli/plugin/release/pkg/config"
+)
+
+func TestRemainingCooldown(t *testing.T) {
+	released := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		interval time.Duration
+		now      time.Time
+		want     time.Duration
+	}{
+		{"just released", time.Hour, released, time.Hour},
+		{"within the interval", time.Hour, released.Add(20 * time.Minute), 40 * time.Minute},
+		{"interval passed", time.Hour, released.Add(time.Hour), 0},
+		{"long ago", time.Hour, released.Add(48 * time.Hour), 0},
+		{"no interval", 0, released, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := remainingCooldown(released, tt.interval, tt.now); got != tt.want {
+				t.Errorf("remainingCooldown = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+// cooldownRepo commits and tags v1.0.0 at released
+func cooldownRepo(t *testing.T, released time.Time) {
+	t.Helper()
+	gittest.NewRepo(t)
+	t.Setenv("GIT_COMMITTER_DATE", released.Format(time.RFC3339))
+	gittest.Commit(t, "feat: initial")
+	gittest.Run(t, "tag", "v1.0.0")
+}
+
+func TestCooldownRemaining(t *testing.T) {
+	released := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		interval string
+		tagged   bool
+		now      time.Time
+		want     time.Duration
+		wantTag  string
+	}{
+		{"no interval", "", true, released, 0, ""},
+		{"no release yet", "1h", false, released, 0, ""},
+		{"within the interval", "1h", true, released.Add(15 * time.Minute), 45 * time.Minute, "v1.0.0"},
+		{"interval passed", "1h", true, released.Add(2 * time.Hour), 0, "v1.0.0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.tagged {
+				cooldownRepo(t, released)
+			} else {
+				gittest.NewRepo(t)
+				gittest.Commit(t, "feat: initial")
+			}
+
+			got, tag, err := CooldownRemaining(&config.NekoConfig{MinReleaseInterval: tt.interval}, tt.now)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want || tag != tt.wantTag {
+				t.Errorf("CooldownRemaining = %s, %q, want %s, %q", got, tag, tt.want, tt.wantTag)
+			}
+		})
+	}
+}
+
+func TestCooldownRemainingInvalidInterval(t *testing.T) {
+	cooldownRepo(t, time.Now())
+
+	if _, _, err := CooldownRemaining(&config.NekoConfig{MinReleaseInterval: "soon"}, time.Now()); err == nil {
+		t.Error("CooldownRemaining accepted an invalid interval")
+	}
+}
+
+// --force downgrades the cooldown to a warning
+func TestCheckCooldownForce(t *testing.T) {
+	cooldownRepo(t, time.Now())
+
+	before := len(errors.Warnings())
+	CheckCooldown(&config.NekoConfig{MinReleaseInterval: "1h"}, true)
+
+	warnings := errors.Warnings()[before:]
+	if len(warnings) != 1 || warnings[0].Code != "Releasing within the release interval" ||
+		!strings.Contains(warnings[0].Message, "v1.0.0") {
+		t.Errorf("warnings = %+v, want one for v1.0.0", warnings)
+	}
+}
+
+// Without --force the release fails, WriteErrorWithDetails exits, so it runs in a subprocess
+func TestCheckCooldown(t *testing.T) {
+	if os.Getenv("NEKO_TEST_COOLDOWN") == "1" {
+		cooldownRepo(t, time.Now())
+		CheckCooldown(&config.NekoConfig{MinReleaseInterval: "1h"}, false)
+		return
+	}
+
+	cmd := exec.Command(os.Args[0], "-test.run=^TestCheckCooldown$")
+	cmd.Env = append(os.Environ(), "NEKO_TEST_COOLDOWN=1")
+	out, err := cmd.Output()
+	if exitErr, ok := err.(*exec.ExitError); !ok || exitErr.ExitCode() != 1 {
+		t.Fatalf("CheckCooldown exited with %v, want exit status 1", err)
+	}
+
+	var resp plugin.Response
+	if err := json.Unmarshal(out, &resp); err != nil {
+		t.Fatalf("CheckCooldown wrote %q: %v", out, err)
+	}
+	if resp.Error == nil || resp.Error.Code != "RELEASE_COOLDOWN" || !strings.Contains(resp.Error.Message, "v1.0.0") {
+		t.Errorf("error = %+v, want RELEASE_COOLDOWN for v1.0.0", resp.Error)
+	}
+}
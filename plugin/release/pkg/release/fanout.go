@@ -0,0 +1,95 @@
+package release
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+
+	"github.com/Masterminds/semver/v3"
+	config2 "github.com/nekoman-hq/neko-cli/plugin/release/pkg/config"
+
+	"github.com/nekoman-hq/neko-cli/pkg/log"
+)
+
+// sharedSteps run once for the first release system, the following systems publish onto their result
+var sharedSteps = []string{StepCommit, StepTag, StepPushCommit, StepPushTag}
+
+// getReleasers resolves the tools of the release systems in order
+func getReleasers(systems []config2.ReleaseSystem) ([]Tool, error) {
+	releasers := make([]Tool, 0, len(systems))
+	for _, system := range systems {
+		releaser, err := Get(string(system))
+		if err != nil {
+			return nil, fmt.Errorf(
+				"release System Not Found: %w", err,
+			)
+		}
+		releasers = append(releasers, releaser)
+	}
+	return releasers, nil
+}
+
+// validateReleasers checks the requirements of every release system before any git mutation
+func validateReleasers(releasers []Tool) error {
+	for _, releaser := range releasers {
+		if err := releaser.Validate(); err != nil {
+			return fmt.Errorf(
+				"release System Not Ready: %w", err,
+			)
+		}
+	}
+	return nil
+}
+
+// releaserNames returns the names of the release systems
+func releaserNames(releasers []Tool) []string {
+	names := make([]string, len(releasers))
+	for i, releaser := range releasers {
+		names[i] = releaser.Name()
+	}
+	return names
+}
+
+// runReleases runs the release systems in sequence, sharing one version bump, commit and tag.
+// Returns the number of systems that started, which are the ones to revert on failure.
+func runReleases(ctx context.Context, releasers []Tool, v *semver.Version, state *ReleaseState) (int, error) {
+	// release-args are meant for the primary release system only
+	args := ReleaseArgs()
+	defer SetReleaseArgs(args)
+
+	for i, releaser := range releasers {
+		if i > 0 {
+			SetReleaseArgs(nil)
+			log.PluginPrint(log.Exec, "Publishing %s with %s",
+				log.ColorText(log.ColorCyan, TagName(v)),
+				log.ColorText(log.ColorPurple, releaser.Name()))
+		}
+
+		if err := runRelease(ctx, releaser, v, state); err != nil {
+			if len(releasers) > 1 {
+				err = fmt.Errorf("%s: %w", releaser.Name(), err)
+			}
+			return i + 1, err
+		}
+
+		if len(releasers) > 1 {
+			state.MarkCompleted(sharedSteps...)
+		}
+	}
+	return len(releasers), nil
+}
+
+// revertReleases reverts the release systems in reverse order, so the system owning the commit and tag goes last
+func revertReleases(releasers []Tool) error {
+	if len(releasers) == 1 {
+		return releasers[0].RevertRelease()
+	}
+
+	var errs []error
+	for i := len(releasers) - 1; i >= 0; i-- {
+		if err := releasers[i].RevertRelease(); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", releasers[i].Name(), err))
+		}
+	}
+	return stderrors.Join(errs...)
+}
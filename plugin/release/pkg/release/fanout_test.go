@@ -0,0 +1,217 @@
+package release
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/nekoman-hq/neko-cli/plugin/release/internal/gittest"
+)
+
+// validatingTool fails Validate with err and records that it was validated
+type validatingTool struct {
+	fakeTool
+	err       error
+	validated bool
+}
+
+func (v *validatingTool) Validate() error {
+	v.validated = true
+	return v.err
+}
+
+func TestValidateReleasers(t *testing.T) {
+	missing := errors.New("required dependency missing: goreleaser")
+	tests := []struct {
+		name          string
+		errs          []error
+		wantErr       bool
+		wantValidated []bool
+	}{
+		{"all ready", []error{nil, nil}, false, []bool{true, true}},
+		{"first not ready", []error{missing, nil}, true, []bool{true, false}},
+		{"second not ready", []error{nil, missing}, true, []bool{true, true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tools := make([]*validatingTool, len(tt.errs))
+			releasers := make([]Tool, len(tt.errs))
+			for i, err := range tt.errs {
+				tools[i] = &validatingTool{err: err}
+				releasers[i] = tools[i]
+			}
+
+			err := validateReleasers(releasers)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("validateReleasers = %v, want error %t", err, tt.wantErr)
+			}
+			if tt.wantErr && !errors.Is(err, missing) {
+				t.Errorf("error %v does not wrap the tool's error", err)
+			}
+			for i, tool := range tools {
+				if tool.validated != tt.wantValidated[i] {
+					t.Errorf("tool %d validated = %t, want %t", i, tool.validated, tt.wantValidated[i])
+				}
+			}
+		})
+	}
+}
+
+// fanoutTool runs the shared steps plus its own publish step and records what it ran and reverted
+type fanoutTool struct {
+	fakeTool
+	ran      []string
+	args     []string
+	failing  string
+	reverted *[]string
+	revert   error
+}
+
+func (f *fanoutTool) Steps() []ReleaseStep {
+	var steps []ReleaseStep
+	for _, name := range append(slices.Clone(sharedSteps), f.name+"-publish") {
+		steps = append(steps, ReleaseStep{
+			Name: name,
+			Run: func(context.Context, *semver.Version) error {
+				f.ran = append(f.ran, name)
+				f.args = ReleaseArgs()
+				if name == f.failing {
+					return errors.New(name + " failed")
+				}
+				return nil
+			},
+		})
+	}
+	return steps
+}
+
+func (f *fanoutTool) RevertRelease() error {
+	*f.reverted = append(*f.reverted, f.name)
+	return f.revert
+}
+
+func TestRunReleases(t *testing.T) {
+	tests := []struct {
+		name        string
+		failing     map[string]string
+		wantStarted int
+		wantErr     string
+		wantRan     map[string][]string
+	}{
+		{
+			name:        "all systems",
+			wantStarted: 2,
+			wantRan: map[string][]string{
+				"release-it": {StepCommit, StepTag, StepPushCommit, StepPushTag, "release-it-publish"},
+				"goreleaser": {"goreleaser-publish"},
+			},
+		},
+		{
+			name:        "primary fails",
+			failing:     map[string]string{"release-it": StepPushTag},
+			wantStarted: 1,
+			wantErr:     "release-it: push-tag failed",
+			wantRan: map[string][]string{
+				"release-it": {StepCommit, StepTag, StepPushCommit, StepPushTag},
+			},
+		},
+		{
+			name:        "second fails",
+			failing:     map[string]string{"goreleaser": "goreleaser-publish"},
+			wantStarted: 2,
+			wantErr:     "goreleaser: goreleaser-publish failed",
+			wantRan: map[string][]string{
+				"release-it": {StepCommit, StepTag, StepPushCommit, StepPushTag, "release-it-publish"},
+				"goreleaser": {"goreleaser-publish"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gittest.NewRepo(t)
+			SetReleaseArgs([]string{"--no-npm.publish"})
+			t.Cleanup(func() { SetReleaseArgs(nil) })
+
+			var reverted []string
+			tools := []*fanoutTool{
+				{fakeTool: fakeTool{name: "release-it"}, failing: tt.failing["release-it"], reverted: &reverted},
+				{fakeTool: fakeTool{name: "goreleaser"}, failing: tt.failing["goreleaser"], reverted: &reverted},
+			}
+			releasers := []Tool{tools[0], tools[1]}
+			state := &ReleaseState{System: "release-it", Systems: releaserNames(releasers), Version: "1.3.0"}
+
+			started, err := runReleases(context.Background(), releasers, semver.MustParse("1.3.0"), state)
+			if started != tt.wantStarted {
+				t.Errorf("started = %d, want %d", started, tt.wantStarted)
+			}
+			if tt.wantErr == "" && err != nil {
+				t.Fatalf("runReleases = %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || err.Error() != tt.wantErr) {
+				t.Errorf("runReleases = %v, want %q", err, tt.wantErr)
+			}
+			for _, tool := range tools {
+				if !slices.Equal(tool.ran, tt.wantRan[tool.name]) {
+					t.Errorf("%s ran %v, want %v", tool.name, tool.ran, tt.wantRan[tool.name])
+				}
+			}
+
+			// release-args only reach the primary system and are restored afterwards
+			if !slices.Equal(tools[0].args, []string{"--no-npm.publish"}) {
+				t.Errorf("release-it got release args %q", tools[0].args)
+			}
+			if tools[1].ran != nil && tools[1].args != nil {
+				t.Errorf("goreleaser got release args %q", tools[1].args)
+			}
+			if args := ReleaseArgs(); !slices.Equal(args, []string{"--no-npm.publish"}) {
+				t.Errorf("release args after the fan-out = %q", args)
+			}
+		})
+	}
+}
+
+func TestRevertReleases(t *testing.T) {
+	failed := errors.New("delete release failed")
+
+	tests := []struct {
+		name         string
+		systems      []string
+		failing      string
+		wantReverted []string
+		wantErr      string
+	}{
+		{"single", []string{"goreleaser"}, "", []string{"goreleaser"}, ""},
+		{"single fails unwrapped", []string{"goreleaser"}, "goreleaser", []string{"goreleaser"}, "delete release failed"},
+		{"reverse order", []string{"release-it", "goreleaser"}, "", []string{"goreleaser", "release-it"}, ""},
+		{"keeps reverting", []string{"release-it", "goreleaser"}, "goreleaser", []string{"goreleaser", "release-it"}, "goreleaser: delete release failed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var reverted []string
+			releasers := make([]Tool, len(tt.systems))
+			for i, name := range tt.systems {
+				tool := &fanoutTool{fakeTool: fakeTool{name: name}, reverted: &reverted}
+				if name == tt.failing {
+					tool.revert = failed
+				}
+				releasers[i] = tool
+			}
+
+			err := revertReleases(releasers)
+			if !slices.Equal(reverted, tt.wantReverted) {
+				t.Errorf("reverted %v, want %v", reverted, tt.wantReverted)
+			}
+			if tt.wantErr == "" && err != nil {
+				t.Errorf("revertReleases = %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || err.Error() != tt.wantErr || !errors.Is(err, failed)) {
+				t.Errorf("revertReleases = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
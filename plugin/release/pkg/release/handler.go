@@ -8,11 +8,20 @@ package release
 */
 
 import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"slices"
+	"strings"
 	"time"
 
+	"github.com/Masterminds/semver/v3"
+
+	"github.com/nekoman-hq/neko-cli/pkg/errors"
 	"github.com/nekoman-hq/neko-cli/pkg/log"
 	"github.com/nekoman-hq/neko-cli/pkg/plugin"
 	"github.com/nekoman-hq/neko-cli/plugin/release/pkg/config"
+	"github.com/nekoman-hq/neko-cli/plugin/release/pkg/git"
 )
 
 const (
@@ -21,7 +30,7 @@ const (
 )
 
 // HandleRelease handles the patch, minor, major release commands
-func HandleRelease(req plugin.Request, releaseType Type) (*plugin.Response, error) {
+func HandleRelease(ctx context.Context, req plugin.Request, releaseType Type) (*plugin.Response, error) {
 	log.PluginPrint(log.Exec, "Starting %s release", string(releaseType))
 
 	// Load config
@@ -47,6 +56,31 @@ func HandleRelease(req plugin.Request, releaseType Type) (*plugin.Response, erro
 
 	// Create release service
 	svc := NewReleaseService(cfg)
+	svc.NoRevert = getFlagBool(req.Flags, "no-revert")
+	svc.SkipConnectivityCheck = getFlagBool(req.Flags, "skip-connectivity-check")
+	svc.ForceVersion = getFlagBool(req.Flags, "force-version")
+	svc.Strict = getFlagBool(req.Flags, "strict")
+	svc.Force = getFlagBool(req.Flags, "force")
+	SetNoVerify(getFlagBool(req.Flags, "no-verify"))
+
+	notesFile, _ := req.Flags["notes-file"].(string)
+	if notesFile != "" {
+		if svc.Notes, err = ReadNotesFile(notesFile); err != nil {
+			return &plugin.Response{
+				Status: "error",
+				Metadata: plugin.ResponseMetadata{
+					Plugin:    PluginName,
+					Version:   PluginVersion,
+					Command:   string(releaseType),
+					Timestamp: time.Now(),
+				},
+				Error: &plugin.ResponseError{
+					Code:    "INVALID_NOTES_FILE",
+					Message: err.Error(),
+				},
+			}, nil
+		}
+	}
 
 	// Get version info for response
 	oldVersion, newVersion, err := svc.GetNewVersion(releaseType)
@@ -66,10 +100,97 @@ func HandleRelease(req plugin.Request, releaseType Type) (*plugin.Response, erro
 		}, nil
 	}
 
-	// Check for dry-run flag
-	dryRun := getFlagBool(req.Flags, "dry-run")
-	if dryRun {
+	// Snapshot builds artifacts locally without committing, tagging or publishing
+	if getFlagBool(req.Flags, "snapshot") {
+		return handleSnapshot(cfg, releaseType, newVersion), nil
+	}
+
+	// The global --dry-run plans the release instead of running it
+	if req.Context.DryRun {
 		log.PluginPrint(log.Exec, "Dry run mode - no changes will be made")
+		// A dry run never releases, so the interval only warns
+		CheckCooldown(cfg, true)
+
+		firstRelease := IsFirstRelease(cfg)
+		currentVersion := oldVersion.String()
+		if firstRelease {
+			currentVersion = "none (first release)"
+		}
+
+		toolOutput := "<none>"
+		plan := Plan{Files: []string{config.FileName}, Commands: []string{}}
+		// Systems following the first one share its commit, tag and push commands
+		for _, system := range cfg.Systems() {
+			if releaser, err := Get(string(system)); err == nil {
+				if p, ok := releaser.(Planner); ok {
+					toolPlan := p.Plan(newVersion)
+					plan.Files = appendMissing(plan.Files, toolPlan.Files...)
+					plan.Commands = appendMissing(plan.Commands, toolPlan.Commands...)
+				}
+			}
+		}
+		if releaser, err := Get(string(cfg.ReleaseSystem)); err == nil {
+			if dr, ok := releaser.(DryRunner); ok {
+				out, err := dr.DryRun(newVersion)
+				if err != nil {
+					return &plugin.Response{
+						Status: "error",
+						Metadata: plugin.ResponseMetadata{
+							Plugin:    PluginName,
+							Version:   PluginVersion,
+							Command:   string(releaseType),
+							Timestamp: time.Now(),
+						},
+						Error: &plugin.ResponseError{
+							Code:    "DRY_RUN_FAILED",
+							Message: err.Error(),
+						},
+					}, nil
+				}
+				toolOutput = out
+			}
+		}
+
+		releaseNotes := "generated by " + systemsString(cfg)
+		if notesFile != "" {
+			releaseNotes = "from " + notesFile
+		}
+
+		remoteRelease, conflict := "not checked", false
+		if getFlagBool(req.Flags, "compare-remote") {
+			tag := TagName(newVersion)
+			existing, err := ExistingRelease(tag)
+			switch {
+			case err != nil:
+				remoteRelease = "unknown"
+				errors.WriteWarning("Could not compare with the remote", err.Error())
+			case existing != nil:
+				remoteRelease = fmt.Sprintf("would conflict with existing release %s (%s)", tag, existing.HTMLURL)
+				conflict = true
+			default:
+				remoteRelease = fmt.Sprintf("no release for %s yet", tag)
+			}
+		}
+		if conflict && svc.Strict {
+			return &plugin.Response{
+				Status: "error",
+				Metadata: plugin.ResponseMetadata{
+					Plugin:    PluginName,
+					Version:   PluginVersion,
+					Command:   string(releaseType),
+					Timestamp: time.Now(),
+				},
+				Error: &plugin.ResponseError{
+					Code:    "RELEASE_EXISTS",
+					Message: fmt.Sprintf("Release %s %s", newVersion, remoteRelease),
+					Details: map[string]any{"tag": TagName(newVersion)},
+				},
+			}, nil
+		}
+		if conflict {
+			errors.WriteWarning("Release already exists", remoteRelease)
+		}
+
 		return &plugin.Response{
 			Status: "success",
 			Metadata: plugin.ResponseMetadata{
@@ -86,7 +207,7 @@ func HandleRelease(req plugin.Request, releaseType Type) (*plugin.Response, erro
 					},
 					{
 						"property": "Current Version",
-						"value":    oldVersion.String(),
+						"value":    currentVersion,
 					},
 					{
 						"property": "New Version",
@@ -94,24 +215,51 @@ func HandleRelease(req plugin.Request, releaseType Type) (*plugin.Response, erro
 					},
 					{
 						"property": "Release System",
-						"value":    string(cfg.ReleaseSystem),
+						"value":    systemsString(cfg),
 					},
 					{
 						"property": "Dry Run",
 						"value":    "yes",
 					},
+					{
+						"property": "Tool Output",
+						"value":    toolOutput,
+					},
+					{
+						"property": "Release Notes",
+						"value":    releaseNotes,
+					},
+					{
+						"property": "Remote Release",
+						"value":    remoteRelease,
+					},
 					{
 						"property": "Status",
 						"value":    "Preview - no changes made",
 					},
 				},
+				"plan": map[string]any{
+					"current":          oldVersion.String(),
+					"next":             newVersion.String(),
+					"first_release":    firstRelease,
+					"type":             string(releaseType),
+					"system":           string(cfg.ReleaseSystem),
+					"systems":          cfg.Systems(),
+					"planned_files":    plan.Files,
+					"planned_commands": plan.Commands,
+					"remote_release":   remoteRelease,
+					"conflict":         conflict,
+					"notes_file":       notesFile,
+				},
 			},
 			RendererHint: "table",
+			ColumnOrder:  []string{"property", "value"},
 		}, nil
 	}
 
 	// Execute release
-	if err := svc.Run(releaseType); err != nil {
+	if err := svc.Run(ctx, releaseType); err != nil {
+		code, details := releaseErrorCode(err)
 		return &plugin.Response{
 			Status: "error",
 			Metadata: plugin.ResponseMetadata{
@@ -121,8 +269,9 @@ func HandleRelease(req plugin.Request, releaseType Type) (*plugin.Response, erro
 				Timestamp: time.Now(),
 			},
 			Error: &plugin.ResponseError{
-				Code:    "RELEASE_FAILED",
+				Code:    code,
 				Message: err.Error(),
+				Details: details,
 			},
 		}, nil
 	}
@@ -151,7 +300,7 @@ func HandleRelease(req plugin.Request, releaseType Type) (*plugin.Response, erro
 				},
 				{
 					"property": "Release System",
-					"value":    string(cfg.ReleaseSystem),
+					"value":    systemsString(cfg),
 				},
 				{
 					"property": "Status",
@@ -160,9 +309,84 @@ func HandleRelease(req plugin.Request, releaseType Type) (*plugin.Response, erro
 			},
 		},
 		RendererHint: "table",
+		ColumnOrder:  []string{"property", "value"},
 	}, nil
 }
 
+// handleSnapshot runs the snapshot-only mode of the configured release system
+func handleSnapshot(cfg *config.NekoConfig, releaseType Type, newVersion *semver.Version) *plugin.Response {
+	log.PluginPrint(log.Exec, "Snapshot mode - building artifacts without publishing")
+
+	errResp := func(code, message string) *plugin.Response {
+		return &plugin.Response{
+			Status: "error",
+			Metadata: plugin.ResponseMetadata{
+				Plugin:    PluginName,
+				Version:   PluginVersion,
+				Command:   string(releaseType),
+				Timestamp: time.Now(),
+			},
+			Error: &plugin.ResponseError{
+				Code:    code,
+				Message: message,
+			},
+		}
+	}
+
+	releaser, err := Get(string(cfg.ReleaseSystem))
+	if err != nil {
+		return errResp("RELEASE_SYSTEM_ERROR", err.Error())
+	}
+
+	snapshotter, ok := releaser.(Snapshotter)
+	if !ok {
+		return errResp("SNAPSHOT_UNSUPPORTED",
+			fmt.Sprintf("release system %s does not support snapshot builds", releaser.Name()))
+	}
+
+	artifacts, err := snapshotter.Snapshot(newVersion)
+	if err != nil {
+		return errResp("SNAPSHOT_FAILED", err.Error())
+	}
+
+	items := make([]map[string]any, 0, len(artifacts))
+	for _, a := range artifacts {
+		items = append(items, map[string]any{
+			"name": a.Name,
+			"path": a.Path,
+			"type": a.Type,
+		})
+	}
+
+	return &plugin.Response{
+		Status: "success",
+		Metadata: plugin.ResponseMetadata{
+			Plugin:    PluginName,
+			Version:   PluginVersion,
+			Command:   string(releaseType),
+			Timestamp: time.Now(),
+		},
+		Data: map[string]any{
+			"items": items,
+		},
+		RendererHint: "table",
+		ColumnOrder:  []string{"name", "type", "path"},
+	}
+}
+
+// releaseErrorCode maps a release error to its response code and details
+func releaseErrorCode(err error) (string, map[string]any) {
+	var rejected *git.PushRejectedError
+	if stderrors.As(err, &rejected) {
+		return "PUSH_REJECTED", map[string]any{
+			"ref":    rejected.Ref,
+			"reason": rejected.Reason,
+			"hint":   "The remote declined the push, e.g. a pre-receive hook. Fix the cause and release again",
+		}
+	}
+	return "RELEASE_FAILED", nil
+}
+
 func getFlagBool(flags map[string]any, name string) bool {
 	if v, ok := flags[name]; ok {
 		if b, ok := v.(bool); ok {
@@ -171,3 +395,22 @@ func getFlagBool(flags map[string]any, name string) bool {
 	}
 	return false
 }
+
+// appendMissing appends the values that are not in the slice yet
+func appendMissing(slice []string, values ...string) []string {
+	for _, v := range values {
+		if !slices.Contains(slice, v) {
+			slice = append(slice, v)
+		}
+	}
+	return slice
+}
+
+// systemsString returns the release systems of the config, e.g. "release-it, goreleaser"
+func systemsString(cfg *config.NekoConfig) string {
+	names := make([]string, 0, len(cfg.Systems()))
+	for _, system := range cfg.Systems() {
+		names = append(names, string(system))
+	}
+	return strings.Join(names, ", ")
+}
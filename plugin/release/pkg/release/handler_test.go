@@ -0,0 +1,227 @@
+package release
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/nekoman-hq/neko-cli/pkg/plugin"
+	"github.com/nekoman-hq/neko-cli/plugin/release/internal/gittest"
+	config2 "github.com/nekoman-hq/neko-cli/plugin/release/pkg/config"
+)
+
+// snapshotTool builds fixed artifacts or fails with err
+type snapshotTool struct {
+	fakeTool
+	artifacts []Artifact
+	err       error
+}
+
+func (s *snapshotTool) Snapshot(*semver.Version) ([]Artifact, error) {
+	return s.artifacts, s.err
+}
+
+func TestHandleSnapshot(t *testing.T) {
+	unregister(t, "snapshot-test", "snapshot-fail", "no-snapshot")
+	Register(&snapshotTool{
+		fakeTool:  fakeTool{name: "snapshot-test"},
+		artifacts: []Artifact{{Name: "app.tar.gz", Path: "dist/app.tar.gz", Type: "Archive"}},
+	})
+	Register(&snapshotTool{fakeTool: fakeTool{name: "snapshot-fail"}, err: errors.New("build failed")})
+	Register(&fakeTool{name: "no-snapshot"})
+
+	tests := []struct {
+		system   string
+		wantCode string
+	}{
+		{"snapshot-test", ""},
+		{"snapshot-fail", "SNAPSHOT_FAILED"},
+		{"no-snapshot", "SNAPSHOT_UNSUPPORTED"},
+		{"missing", "RELEASE_SYSTEM_ERROR"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.system, func(t *testing.T) {
+			cfg := &config2.NekoConfig{ReleaseSystem: config2.ReleaseSystem(tt.system)}
+			resp := handleSnapshot(cfg, Minor, semver.MustParse("1.2.0"))
+
+			if tt.wantCode != "" {
+				if resp.Error == nil || resp.Error.Code != tt.wantCode {
+					t.Errorf("error = %+v, want %s", resp.Error, tt.wantCode)
+				}
+				return
+			}
+			if resp.Status != "success" {
+				t.Fatalf("status = %s, error = %+v", resp.Status, resp.Error)
+			}
+			items := resp.Data["items"].([]map[string]any)
+			if len(items) != 1 || items[0]["name"] != "app.tar.gz" || items[0]["type"] != "Archive" {
+				t.Errorf("items = %v", items)
+			}
+		})
+	}
+}
+
+// planTool plans fixed files and commands and records whether it released
+type planTool struct {
+	fakeTool
+	released bool
+}
+
+func (p *planTool) Plan(v *semver.Version) Plan {
+	return Plan{
+		Files:    []string{"CHANGELOG.md"},
+		Commands: []string{"git tag " + TagName(v), "fake release " + v.String()},
+	}
+}
+
+func (p *planTool) Release(context.Context, *semver.Version) error {
+	p.released = true
+	return nil
+}
+
+// The plan object is what CI parses from `neko release minor --dry-run -o json`
+func TestHandleReleaseDryRunPlan(t *testing.T) {
+	gittest.NewRepo(t)
+	gittest.Commit(t, "feat: initial")
+	gittest.Run(t, "tag", "v1.2.0")
+	gittest.Commit(t, "feat: next")
+
+	unregister(t, string(config2.ReleaseTypeGoReleaser))
+	tool := &planTool{fakeTool: fakeTool{name: string(config2.ReleaseTypeGoReleaser)}}
+	Register(tool)
+
+	if err := config2.SaveConfig(config2.NekoConfig{
+		ProjectType:   config2.ProjectTypeBackend,
+		ReleaseSystem: config2.ReleaseTypeGoReleaser,
+		Version:       "1.2.0",
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	gittest.Run(t, "add", config2.FileName)
+	gittest.Run(t, "commit", "-q", "-m", "chore: config")
+	head, tags := gittest.Run(t, "rev-parse", "HEAD"), gittest.Run(t, "tag")
+
+	req := plugin.Request{Command: "minor", Context: plugin.Context{DryRun: true}}
+	resp, err := HandleRelease(context.Background(), req, Minor)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Status != "success" {
+		t.Fatalf("status = %s, error = %+v", resp.Status, resp.Error)
+	}
+	if tool.released {
+		t.Error("the dry run released")
+	}
+	// Nothing runs: no commit, no tag and no change to the config version
+	if got := gittest.Run(t, "rev-parse", "HEAD"); got != head {
+		t.Errorf("HEAD moved to %s in the dry run", got)
+	}
+	if got := gittest.Run(t, "tag"); got != tags {
+		t.Errorf("tags = %q after the dry run, want %q", got, tags)
+	}
+	if status := gittest.Run(t, "status", "--porcelain"); status != "" {
+		t.Errorf("the dry run changed the working tree:\n%s", status)
+	}
+
+	data, err := json.Marshal(resp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded struct {
+		Data struct {
+			Plan map[string]any `json:"plan"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	plan := decoded.Data.Plan
+
+	wantKeys := []string{
+		"current", "next", "first_release", "type", "system", "systems", "planned_files",
+		"planned_commands", "remote_release", "conflict", "notes_file",
+	}
+	if keys := slices.Sorted(maps.Keys(plan)); !slices.Equal(keys, slices.Sorted(slices.Values(wantKeys))) {
+		t.Errorf("plan keys = %v, want %v", keys, wantKeys)
+	}
+
+	want := map[string]any{
+		"current":          "1.2.0",
+		"next":             "1.3.0",
+		"first_release":    false,
+		"type":             "minor",
+		"system":           "goreleaser",
+		"systems":          []any{"goreleaser"},
+		"planned_files":    []any{config2.FileName, "CHANGELOG.md"},
+		"planned_commands": []any{"git tag v1.3.0", "fake release 1.3.0"},
+		"conflict":         false,
+	}
+	for key, value := range want {
+		got, _ := json.Marshal(plan[key])
+		wantJSON, _ := json.Marshal(value)
+		if string(got) != string(wantJSON) {
+			t.Errorf("plan[%q] = %s, want %s", key, got, wantJSON)
+		}
+	}
+}
+
+// Without a release tag the dry run announces the first release instead of a current version
+func TestHandleReleaseDryRunFirstRelease(t *testing.T) {
+	tests := []struct {
+		name        string
+		tags        []string
+		wantCurrent string
+		wantFirst   bool
+	}{
+		{"first release", nil, "none (first release)", true},
+		{"released before", []string{"v1.2.0"}, "1.2.0", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gittest.NewRepo(t)
+			gittest.Commit(t, "feat: initial")
+			for _, tag := range tt.tags {
+				gittest.Run(t, "tag", tag)
+			}
+
+			unregister(t, string(config2.ReleaseTypeGoReleaser))
+			Register(&planTool{fakeTool: fakeTool{name: string(config2.ReleaseTypeGoReleaser)}})
+			if err := config2.SaveConfig(config2.NekoConfig{
+				ProjectType:   config2.ProjectTypeBackend,
+				ReleaseSystem: config2.ReleaseTypeGoReleaser,
+				Version:       "1.2.0",
+			}); err != nil {
+				t.Fatal(err)
+			}
+
+			req := plugin.Request{Command: "minor", Context: plugin.Context{DryRun: true}}
+			resp, err := HandleRelease(context.Background(), req, Minor)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if resp.Status != "success" {
+				t.Fatalf("status = %s, error = %+v", resp.Status, resp.Error)
+			}
+
+			var current any
+			for _, item := range resp.Data["items"].([]map[string]any) {
+				if item["property"] == "Current Version" {
+					current = item["value"]
+				}
+			}
+			if current != tt.wantCurrent {
+				t.Errorf("Current Version = %v, want %q", current, tt.wantCurrent)
+			}
+			if plan := resp.Data["plan"].(map[string]any); plan["first_release"] != tt.wantFirst {
+				t.Errorf("plan first_release = %v, want %t", plan["first_release"], tt.wantFirst)
+			}
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package release
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/nekoman-hq/neko-cli/pkg/config"
+	"github.com/nekoman-hq/neko-cli/pkg/log"
+	"github.com/nekoman-hq/neko-cli/plugin/release/pkg/git"
+	"github.com/nekoman-hq/neko-cli/plugin/release/pkg/git/github"
+)
+
+// ReadNotesFile reads the release notes of --notes-file.
+// It runs before the release starts, so a missing or empty file fails before any git mutation.
+func ReadNotesFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read notes file: %w", err)
+	}
+	if strings.TrimSpace(string(data)) == "" {
+		return "", fmt.Errorf("notes file %s is empty", path)
+	}
+	return string(data), nil
+}
+
+// SetReleaseNotes replaces the body of the GitHub release of tag with notes,
+// e.g. the notes the release tool generated with a maintained RELEASE_NOTES.md
+func SetReleaseNotes(tag, notes string) error {
+	token, err := config.GetPAT()
+	if err != nil {
+		return err
+	}
+	repo, err := git.Current()
+	if err != nil {
+		return err
+	}
+	release, err := git.ReleaseByTag(repo, tag, token)
+	if err != nil {
+		return err
+	}
+
+	if git.SkipInDryRun("replacing the notes of release", tag) {
+		return nil
+	}
+	if _, err = git.UpdateRelease(repo, release.ID, github.ReleaseUpdate{Body: &notes}, token); err != nil {
+		return err
+	}
+
+	log.PluginPrint(log.Exec, "\uF00C Set the notes of release %s", log.ColorText(log.ColorGreen, tag))
+	return nil
+}
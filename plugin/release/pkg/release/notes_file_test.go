@@ -0,0 +1,113 @@
+package release
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nekoman-hq/neko-cli/plugin/release/internal/gittest"
+)
+
+// recordingTransport answers GitHub API requests with a release and records them
+type recordingTransport struct {
+	requests []*http.Request
+	bodies   []string
+}
+
+func (rt *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	body := ""
+	if req.Body != nil {
+		data, err := io.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		body = string(data)
+	}
+	rt.requests = append(rt.requests, req)
+	rt.bodies = append(rt.bodies, body)
+
+	release, _ := json.Marshal(map[string]any{"id": 42, "tag_name": "v1.2.0"})
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(bytes.NewReader(release)),
+		Request:    req,
+	}, nil
+}
+
+// fakeGitHubAPI routes the requests of http.DefaultClient to a recordingTransport
+func fakeGitHubAPI(t *testing.T) *recordingTransport {
+	t.Helper()
+	rt := &recordingTransport{}
+	previous := http.DefaultClient.Transport
+	http.DefaultClient.Transport = rt
+	t.Cleanup(func() { http.DefaultClient.Transport = previous })
+	return rt
+}
+
+func TestReadNotesFile(t *testing.T) {
+	dir := t.TempDir()
+	notes := filepath.Join(dir, "RELEASE_NOTES.md")
+	empty := filepath.Join(dir, "EMPTY.md")
+	if err := os.WriteFile(notes, []byte("## Highlights\n\n- Faster history\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(empty, []byte(" \n\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, err := ReadNotesFile(notes); err != nil || got != "## Highlights\n\n- Faster history\n" {
+		t.Errorf("ReadNotesFile = %q, %v", got, err)
+	}
+	if _, err := ReadNotesFile(empty); err == nil {
+		t.Error("ReadNotesFile accepted an empty file")
+	}
+	if _, err := ReadNotesFile(filepath.Join(dir, "missing.md")); err == nil {
+		t.Error("ReadNotesFile accepted a missing file")
+	}
+}
+
+func TestSetReleaseNotesSendsFileAsBody(t *testing.T) {
+	gittest.NewRepo(t)
+	gittest.Run(t, "remote", "add", "origin", "https://github.com/nekoman-hq/app.git")
+	t.Setenv("GITHUB_TOKEN", "test-token")
+	rt := fakeGitHubAPI(t)
+
+	if err := os.WriteFile("RELEASE_NOTES.md", []byte("## v1.2.0\n\nHand-written notes\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	notes, err := ReadNotesFile("RELEASE_NOTES.md")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := SetReleaseNotes("v1.2.0", notes); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(rt.requests) != 2 {
+		t.Fatalf("sent %d API requests, want the release lookup and the update", len(rt.requests))
+	}
+	update := rt.requests[1]
+	if update.Method != http.MethodPatch || update.URL.Path != "/repos/nekoman-hq/app/releases/42" {
+		t.Errorf("update request = %s %s, want PATCH /repos/nekoman-hq/app/releases/42", update.Method, update.URL.Path)
+	}
+	if got := update.Header.Get("Authorization"); got != "Bearer test-token" {
+		t.Errorf("Authorization = %q", got)
+	}
+
+	var payload map[string]any
+	if err := json.Unmarshal([]byte(rt.bodies[1]), &payload); err != nil {
+		t.Fatalf("update body %q: %v", rt.bodies[1], err)
+	}
+	if payload["body"] != "## v1.2.0\n\nHand-written notes\n" {
+		t.Errorf("release body = %q, want the notes file content", payload["body"])
+	}
+	if _, ok := payload["name"]; ok {
+		t.Error("the update changes the release title, only the body should change")
+	}
+}
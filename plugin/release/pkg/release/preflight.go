@@ -7,13 +7,50 @@ package release
 */
 
 import (
+	"strings"
+
+	"github.com/nekoman-hq/neko-cli/pkg/config"
 	"github.com/nekoman-hq/neko-cli/pkg/errors"
 	"github.com/nekoman-hq/neko-cli/pkg/log"
+	config2 "github.com/nekoman-hq/neko-cli/plugin/release/pkg/config"
 	"github.com/nekoman-hq/neko-cli/plugin/release/pkg/git"
 )
 
-func Preflight() {
+// Preflight checks the environment before any git mutation.
+// The GitHub connectivity check can be skipped for offline runs.
+// With strict a config that does not match the remote repository fails instead of warning.
+func Preflight(cfg *config2.NekoConfig, checkGitHub, strict bool) {
 	log.PluginV(log.Preflight, "Running pre-flight checks")
+	if _, err := config.GetPAT(); err != nil {
+		errors.WriteError(
+			"MISSING_GITHUB_TOKEN",
+			err.Error(),
+		)
+	}
+
+	if checkGitHub {
+		if err := git.CheckGitHub(git.GitHubAPI); err != nil {
+			errors.WriteError(
+				"GITHUB_UNREACHABLE",
+				err.Error(),
+			)
+		}
+	}
+
+	if _, err := git.Worktree(); err != nil {
+		errors.WriteError(
+			"NOT_A_GIT_REPOSITORY",
+			err.Error(),
+		)
+	}
+
+	if err := config2.VerifyLock(); err != nil {
+		errors.WriteError(
+			"CONFIG_LOCK_MISMATCH",
+			err.Error(),
+		)
+	}
+
 	if err := git.IsClean(); err != nil {
 		errors.WriteError(
 			"UNCOMMITTED_CHANGES",
@@ -28,11 +65,13 @@ func Preflight() {
 		)
 	}
 
-	if err := git.OnMainBranch(); err != nil {
-		errors.WriteError(
-			"INCORRECT_BRANCH",
-			err.Error(),
-		)
+	if !onPrereleaseBranch(cfg) {
+		if err := git.OnMainBranch(); err != nil {
+			errors.WriteError(
+				"INCORRECT_BRANCH",
+				err.Error(),
+			)
+		}
 	}
 
 	if err := git.HasUpstream(); err != nil {
@@ -49,5 +88,56 @@ func Preflight() {
 		)
 	}
 
+	checkRepository(cfg, strict)
+	checkCommitScope(cfg)
+	checkLFS()
+
 	log.PluginV(log.Preflight, "\uF00C Preflight checks succeeded!")
 }
+
+// checkRepository warns if the configured project does not match the remote, see CheckRepository
+func checkRepository(cfg *config2.NekoConfig, strict bool) {
+	err := CheckRepository(cfg)
+	if err == nil {
+		return
+	}
+
+	if strict {
+		errors.WriteError(
+			"REPOSITORY_MISMATCH",
+			err.Error(),
+		)
+		return
+	}
+	errors.WriteWarning("Repository mismatch", err.Error())
+}
+
+// checkLFS warns if the repository tracks files with git-lfs but git-lfs is missing or not initialized,
+// release tools would package the pointer files instead of the real content
+func checkLFS() {
+	patterns, err := git.LFSPatterns()
+	if err != nil {
+		log.PluginV(log.Preflight, "Skipping LFS check: %s", err.Error())
+		return
+	}
+	if len(patterns) == 0 {
+		return
+	}
+
+	if err := git.CheckLFS(); err != nil {
+		log.PluginPrint(log.Preflight, "\u26A0 Repository tracks %s with git-lfs: %s",
+			log.ColorText(log.ColorYellow, strings.Join(patterns, ", ")), err.Error())
+	}
+}
+
+// onPrereleaseBranch reports whether the current branch is a configured prerelease branch
+func onPrereleaseBranch(cfg *config2.NekoConfig) bool {
+	if len(cfg.PrereleaseBranches) == 0 {
+		return false
+	}
+	branch, err := git.CurrentBranch()
+	if err != nil {
+		return false
+	}
+	return PrereleaseIdentifier(branch, cfg.PrereleaseBranches) != ""
+}
@@ -0,0 +1,71 @@
+package release
+
+import (
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/nekoman-hq/neko-cli/plugin/release/pkg/config"
+)
+
+// PrereleaseIdentifier returns the prerelease identifier configured for the branch.
+// Returns an empty string if the branch produces stable releases.
+func PrereleaseIdentifier(branch string, branches []config.PrereleaseBranch) string {
+	for _, b := range branches {
+		if ok, _ := path.Match(b.Pattern, branch); ok {
+			return b.Identifier
+		}
+	}
+	return ""
+}
+
+// NextBranchVersion computes the next version honoring the branch prerelease identifier.
+// On a prerelease branch the result is <base>-<identifier>.N with N continuing the existing tags.
+// On a stable branch a pending prerelease is promoted to its stable version.
+// A bump past the pending version (e.g. major on 1.6.0-rc.2) starts a new prerelease series.
+func NextBranchVersion(strategy BumpStrategy, current *semver.Version, t Type, identifier string, tags []string) semver.Version {
+	base := strategy.Next(current, t)
+	if current.Prerelease() != "" {
+		// Continue (or promote) the pending prerelease unless the bump moves past it
+		pending, _ := current.SetPrerelease("")
+		if !base.GreaterThan(&pending) {
+			base = pending
+		}
+	}
+
+	if identifier == "" {
+		return base
+	}
+
+	n := nextPrereleaseNumber(&base, identifier, tags)
+	next, err := base.SetPrerelease(fmt.Sprintf("%s.%d", identifier, n))
+	if err != nil {
+		return base
+	}
+	return next
+}
+
+// nextPrereleaseNumber returns the next free prerelease number for base and identifier
+func nextPrereleaseNumber(base *semver.Version, identifier string, tags []string) int {
+	highest := 0
+	for _, tag := range tags {
+		v, err := ParseVersion(tag)
+		if err != nil {
+			continue
+		}
+		if v.Major() != base.Major() || v.Minor() != base.Minor() || v.Patch() != base.Patch() {
+			continue
+		}
+
+		num, found := strings.CutPrefix(v.Prerelease(), identifier+".")
+		if !found {
+			continue
+		}
+		if n, err := strconv.Atoi(num); err == nil && n > highest {
+			highest = n
+		}
+	}
+	return highest + 1
+}
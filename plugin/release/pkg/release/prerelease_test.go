@@ -0,0 +1,72 @@
+package release
+
+import (
+	"testing"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/nekoman-hq/neko-cli/plugin/release/pkg/config"
+)
+
+func TestPrereleaseIdentifier(t *testing.T) {
+	branches := []config.PrereleaseBranch{
+		{Pattern: "release/*", Identifier: "rc"},
+		{Pattern: "develop", Identifier: "beta"},
+	}
+
+	tests := []struct {
+		branch string
+		want   string
+	}{
+		{"release/1.6", "rc"},
+		{"develop", "beta"},
+		{"main", ""},
+		{"release/1.6/hotfix", ""},
+		{"feature/develop", ""},
+	}
+
+	for _, tt := range tests {
+		if got := PrereleaseIdentifier(tt.branch, branches); got != tt.want {
+			t.Errorf("PrereleaseIdentifier(%q) = %q, want %q", tt.branch, got, tt.want)
+		}
+	}
+}
+
+func TestNextBranchVersion(t *testing.T) {
+	tags := []string{"v1.5.0", "v1.6.0-rc.1", "v1.6.0-rc.2", "v1.6.0-beta.1", "v2.0.0-beta.4"}
+
+	tests := []struct {
+		name       string
+		current    string
+		t          Type
+		identifier string
+		want       string
+	}{
+		{"stable branch", "1.5.0", Minor, "", "1.6.0"},
+		{"stable branch promotes the prerelease", "1.6.0-rc.2", Patch, "", "1.6.0"},
+		{"stable branch major past the prerelease", "1.6.0-rc.2", Major, "", "2.0.0"},
+		{"first prerelease", "1.5.0", Minor, "rc", "1.6.0-rc.3"},
+		{"first prerelease of a new version", "1.5.0", Patch, "rc", "1.5.1-rc.1"},
+		{"continue the prerelease", "1.6.0-rc.2", Patch, "rc", "1.6.0-rc.3"},
+		{"other identifier on the same version", "1.6.0-rc.2", Patch, "beta", "1.6.0-beta.2"},
+		{"minor past the prerelease", "1.6.0-rc.2", Minor, "rc", "1.7.0-rc.1"},
+		{"major past the prerelease", "1.6.0-rc.2", Major, "rc", "2.0.0-rc.1"},
+		{"major continues existing tags", "1.6.0-rc.2", Major, "beta", "2.0.0-beta.5"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NextBranchVersion(SemverStrategy{}, semver.MustParse(tt.current), tt.t, tt.identifier, tags)
+			if got.String() != tt.want {
+				t.Errorf("NextBranchVersion(%s, %s, %q) = %s, want %s", tt.current, tt.t, tt.identifier, got.String(), tt.want)
+			}
+		})
+	}
+}
+
+// A strategy that does not move past the pending prerelease continues it
+func TestNextBranchVersionStrategyBelowPending(t *testing.T) {
+	got := NextBranchVersion(fixedStrategy{"1.5.9"}, semver.MustParse("1.6.0-rc.2"), Patch, "rc", []string{"v1.6.0-rc.2"})
+	if got.String() != "1.6.0-rc.3" {
+		t.Errorf("NextBranchVersion = %s, want 1.6.0-rc.3", got.String())
+	}
+}
@@ -6,17 +6,54 @@ package release
 @Since      18.12.2025
 */
 
-import "fmt"
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
 
-var tools = make(map[string]Tool)
+var (
+	toolsMu sync.RWMutex
+	tools   = make(map[string]Tool)
+)
 
+// Register makes a release system available by its name, it is called from the init of the tool packages.
+// Registering a nil tool or a name twice is a programming error and panics, like database/sql.Register.
 func Register(t Tool) {
-	tools[t.Name()] = t
+	toolsMu.Lock()
+	defer toolsMu.Unlock()
+	if t == nil {
+		panic("release: Register tool is nil")
+	}
+	name := t.Name()
+	if existing, dup := tools[name]; dup {
+		panic(fmt.Sprintf("release: Register called twice for release system %q (%T and %T)", name, existing, t))
+	}
+	tools[name] = t
 }
 
 func Get(name string) (Tool, error) {
+	toolsMu.RLock()
+	defer toolsMu.RUnlock()
 	if t, ok := tools[name]; ok {
 		return t, nil
 	}
-	return nil, fmt.Errorf("unknown release system: %s", name)
+	return nil, fmt.Errorf("unknown release system: %s; available: %v", name, registeredNames())
+}
+
+// Names returns the names of all registered release systems in sorted order
+func Names() []string {
+	toolsMu.RLock()
+	defer toolsMu.RUnlock()
+	return registeredNames()
+}
+
+// registeredNames expects toolsMu to be held by the caller
+func registeredNames() []string {
+	names := make([]string, 0, len(tools))
+	for name := range tools {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
 }
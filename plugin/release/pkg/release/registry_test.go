@@ -0,0 +1,97 @@
+package release
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/Masterminds/semver/v3"
+	config2 "github.com/nekoman-hq/neko-cli/plugin/release/pkg/config"
+)
+
+type fakeTool struct {
+	ToolBase
+	name string
+}
+
+func (f *fakeTool) Name() string                                   { return f.name }
+func (f *fakeTool) Init(*config2.NekoConfig) error                 { return nil }
+func (f *fakeTool) Release(context.Context, *semver.Version) error { return nil }
+func (f *fakeTool) RevertRelease() error                           { return nil }
+
+// unregister removes test tools from the global registry
+func unregister(t *testing.T, names ...string) {
+	t.Cleanup(func() {
+		toolsMu.Lock()
+		defer toolsMu.Unlock()
+		for _, name := range names {
+			delete(tools, name)
+		}
+	})
+}
+
+// Run with -race, Register and Get share the registry map
+func TestRegistryConcurrentAccess(t *testing.T) {
+	const workers = 32
+
+	names := make([]string, workers)
+	for i := range names {
+		names[i] = fmt.Sprintf("race-test-%d", i)
+	}
+	unregister(t, names...)
+
+	var wg sync.WaitGroup
+	for _, name := range names {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			Register(&fakeTool{name: name})
+		}()
+		go func() {
+			defer wg.Done()
+			for range 100 {
+				_, _ = Get(name)
+				_ = Names()
+			}
+		}()
+	}
+	wg.Wait()
+
+	for _, name := range names {
+		tool, err := Get(name)
+		if err != nil {
+			t.Fatalf("Get(%q) after Register: %v", name, err)
+		}
+		if tool.Name() != name {
+			t.Errorf("Get(%q) returned tool %q", name, tool.Name())
+		}
+	}
+}
+
+func TestGetUnknownListsAvailable(t *testing.T) {
+	unregister(t, "registry-test")
+	Register(&fakeTool{name: "registry-test"})
+
+	_, err := Get("does-not-exist")
+	if err == nil {
+		t.Fatal("expected an error for an unknown release system")
+	}
+	if !strings.Contains(err.Error(), "unknown release system: does-not-exist") ||
+		!strings.Contains(err.Error(), "registry-test") {
+		t.Errorf("error %q does not name the system and the available ones", err)
+	}
+}
+
+func TestRegisterTwicePanics(t *testing.T) {
+	unregister(t, "registry-dup")
+	Register(&fakeTool{name: "registry-dup"})
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected Register to panic on a duplicate name")
+		}
+	}()
+	Register(&fakeTool{name: "registry-dup"})
+}
@@ -0,0 +1,28 @@
+package release
+
+import (
+	stderrors "errors"
+
+	"github.com/nekoman-hq/neko-cli/pkg/config"
+	"github.com/nekoman-hq/neko-cli/plugin/release/pkg/git"
+	"github.com/nekoman-hq/neko-cli/plugin/release/pkg/git/github"
+)
+
+// ExistingRelease returns the GitHub release of tag, or nil if the tag has not been released yet.
+// Dry runs use it to catch a release that would conflict before anything is created.
+func ExistingRelease(tag string) (*github.Release, error) {
+	token, err := config.GetPAT()
+	if err != nil {
+		return nil, err
+	}
+	repo, err := git.Current()
+	if err != nil {
+		return nil, err
+	}
+
+	release, err := git.ReleaseByTag(repo, tag, token)
+	if stderrors.Is(err, git.ErrReleaseNotFound) {
+		return nil, nil
+	}
+	return release, err
+}
@@ -0,0 +1,153 @@
+package release
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/nekoman-hq/neko-cli/pkg/errors"
+	"github.com/nekoman-hq/neko-cli/pkg/plugin"
+	"github.com/nekoman-hq/neko-cli/plugin/release/internal/gittest"
+	config2 "github.com/nekoman-hq/neko-cli/plugin/release/pkg/config"
+)
+
+// releaseLookup answers the release lookups of http.DefaultClient with a fixed status and body
+type releaseLookup struct {
+	status int
+	body   string
+	paths  []string
+}
+
+func (rl *releaseLookup) RoundTrip(req *http.Request) (*http.Response, error) {
+	rl.paths = append(rl.paths, req.Method+" "+req.URL.Path)
+	return &http.Response{
+		StatusCode: rl.status,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(bytes.NewReader([]byte(rl.body))),
+		Request:    req,
+	}, nil
+}
+
+func TestHandleReleaseDryRunCompareRemote(t *testing.T) {
+	const existing = `{"id": 42, "tag_name": "v1.3.0", "html_url": "https://github.com/nekoman-hq/app/releases/tag/v1.3.0"}`
+
+	tests := []struct {
+		name        string
+		flags       map[string]any
+		status      int
+		body        string
+		wantCode    string
+		wantRemote  string
+		wantLookups int
+		wantWarning string
+	}{
+		{
+			name:       "not compared",
+			flags:      map[string]any{},
+			wantRemote: "not checked",
+		},
+		{
+			name:        "no release yet",
+			flags:       map[string]any{"compare-remote": true},
+			status:      http.StatusNotFound,
+			body:        `{"message": "Not Found"}`,
+			wantRemote:  "no release for v1.3.0 yet",
+			wantLookups: 1,
+		},
+		{
+			name:        "conflict",
+			flags:       map[string]any{"compare-remote": true},
+			status:      http.StatusOK,
+			body:        existing,
+			wantRemote:  "would conflict with existing release v1.3.0 (https://github.com/nekoman-hq/app/releases/tag/v1.3.0)",
+			wantLookups: 1,
+			wantWarning: "Release already exists",
+		},
+		{
+			name:        "conflict with --strict",
+			flags:       map[string]any{"compare-remote": true, "strict": true},
+			status:      http.StatusOK,
+			body:        existing,
+			wantCode:    "RELEASE_EXISTS",
+			wantLookups: 1,
+		},
+		{
+			name:        "lookup failed",
+			flags:       map[string]any{"compare-remote": true},
+			status:      http.StatusInternalServerError,
+			body:        `{"message": "Server Error"}`,
+			wantRemote:  "unknown",
+			wantLookups: 1,
+			wantWarning: "Could not compare with the remote",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gittest.NewRepo(t)
+			// Tags are fetched from the local remote, the repository is resolved from the push URL
+			gittest.NewRemote(t)
+			gittest.Run(t, "remote", "set-url", "--push", "origin", "https://github.com/nekoman-hq/app.git")
+			gittest.Commit(t, "feat: initial")
+			gittest.Run(t, "tag", "v1.2.0")
+			t.Setenv("GITHUB_TOKEN", "test-token")
+
+			unregister(t, string(config2.ReleaseTypeGoReleaser))
+			Register(&planTool{fakeTool: fakeTool{name: string(config2.ReleaseTypeGoReleaser)}})
+			if err := config2.SaveConfig(config2.NekoConfig{
+				ProjectType:   config2.ProjectTypeBackend,
+				ReleaseSystem: config2.ReleaseTypeGoReleaser,
+				Version:       "1.2.0",
+			}); err != nil {
+				t.Fatal(err)
+			}
+
+			api := &releaseLookup{status: tt.status, body: tt.body}
+			previous := http.DefaultClient.Transport
+			http.DefaultClient.Transport = api
+			t.Cleanup(func() { http.DefaultClient.Transport = previous })
+
+			before := len(errors.Warnings())
+			req := plugin.Request{Command: "minor", Flags: tt.flags, Context: plugin.Context{DryRun: true}}
+			resp, err := HandleRelease(context.Background(), req, Minor)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(api.paths) != tt.wantLookups {
+				t.Fatalf("API requests = %q, want %d lookups", api.paths, tt.wantLookups)
+			}
+			if tt.wantLookups > 0 && api.paths[0] != "GET /repos/nekoman-hq/app/releases/tags/v1.3.0" {
+				t.Errorf("lookup = %s, want the release of the next tag", api.paths[0])
+			}
+
+			if tt.wantCode != "" {
+				if resp.Error == nil || resp.Error.Code != tt.wantCode {
+					t.Fatalf("error = %+v, want %s", resp.Error, tt.wantCode)
+				}
+				return
+			}
+			if resp.Status != "success" {
+				t.Fatalf("status = %s, error = %+v", resp.Status, resp.Error)
+			}
+			plan := resp.Data["plan"].(map[string]any)
+			if plan["remote_release"] != tt.wantRemote {
+				t.Errorf("remote_release = %v, want %q", plan["remote_release"], tt.wantRemote)
+			}
+			if conflict := tt.wantWarning == "Release already exists"; plan["conflict"] != conflict {
+				t.Errorf("conflict = %v, want %t", plan["conflict"], conflict)
+			}
+
+			warnings := errors.Warnings()[before:]
+			if tt.wantWarning == "" {
+				if len(warnings) != 0 {
+					t.Errorf("warnings = %+v, want none", warnings)
+				}
+			} else if len(warnings) != 1 || warnings[0].Code != tt.wantWarning {
+				t.Errorf("warnings = %+v, want %q", warnings, tt.wantWarning)
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package release
+
+/*
+@Author     Benjamin Senekowitsch
+@Contact    senekowitsch@nekoman.at
+@Since      15.10.2026
+*/
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/nekoman-hq/neko-cli/pkg/log"
+	config2 "github.com/nekoman-hq/neko-cli/plugin/release/pkg/config"
+	"github.com/nekoman-hq/neko-cli/plugin/release/pkg/git"
+)
+
+// CheckRepository compares the configured project with the GitHub repository of the origin remote,
+// e.g. a fork would otherwise publish its releases to the upstream repository.
+// Returns nil if the remote cannot be resolved, there is nothing to compare against then.
+func CheckRepository(cfg *config2.NekoConfig) error {
+	remote, err := git.Current()
+	if err != nil {
+		log.PluginV(log.Config, fmt.Sprintf("Skipping repository check: %s", err.Error()))
+		return nil
+	}
+	return RepositoryMismatch(cfg, remote)
+}
+
+// RepositoryMismatch returns an error if project-owner or project-name differ from the remote.
+// GitHub names are case-insensitive, an empty owner or name is not compared.
+func RepositoryMismatch(cfg *config2.NekoConfig, remote *git.RepoInfo) error {
+	ownerMatches := cfg.ProjectOwner == "" || strings.EqualFold(cfg.ProjectOwner, remote.Owner)
+	nameMatches := cfg.ProjectName == "" || strings.EqualFold(cfg.ProjectName, remote.Repo)
+	if ownerMatches && nameMatches {
+		return nil
+	}
+
+	return fmt.Errorf(
+		"%s is configured for %s/%s but the remote is %s/%s.\nUpdate project-owner and project-name in %s, releases are published to the configured repository",
+		config2.FileName, cfg.ProjectOwner, cfg.ProjectName, remote.Owner, remote.Repo, config2.FileName,
+	)
+}
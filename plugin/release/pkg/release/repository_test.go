@@ -0,0 +1,59 @@
+package release
+
+import (
+	"testing"
+
+	"github.com/nekoman-hq/neko-cli/plugin/release/internal/gittest"
+	config2 "github.com/nekoman-hq/neko-cli/plugin/release/pkg/config"
+	"github.com/nekoman-hq/neko-cli/plugin/release/pkg/git"
+)
+
+func TestRepositoryMismatch(t *testing.T) {
+	remote := &git.RepoInfo{Owner: "nekoman-hq", Repo: "neko-cli"}
+	tests := []struct {
+		name         string
+		owner, repo  string
+		wantMismatch bool
+	}{
+		{"match", "nekoman-hq", "neko-cli", false},
+		{"case-insensitive", "Nekoman-HQ", "Neko-CLI", false},
+		{"empty fields", "", "", false},
+		{"only name set", "", "neko-cli", false},
+		{"fork owner", "someone", "neko-cli", true},
+		{"renamed repository", "nekoman-hq", "neko", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config2.NekoConfig{ProjectOwner: tt.owner, ProjectName: tt.repo}
+			if err := RepositoryMismatch(cfg, remote); (err != nil) != tt.wantMismatch {
+				t.Errorf("RepositoryMismatch = %v, want mismatch %t", err, tt.wantMismatch)
+			}
+		})
+	}
+}
+
+func TestCheckRepository(t *testing.T) {
+	cfg := &config2.NekoConfig{ProjectOwner: "nekoman-hq", ProjectName: "neko-cli"}
+	tests := []struct {
+		name         string
+		remote       string
+		wantMismatch bool
+	}{
+		{"no remote", "", false},
+		{"upstream", "git@github.com:nekoman-hq/neko-cli.git", false},
+		{"fork", "https://github.com/someone/neko-cli.git", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gittest.NewRepo(t)
+			if tt.remote != "" {
+				gittest.Run(t, "remote", "add", "origin", tt.remote)
+			}
+			if err := CheckRepository(cfg); (err != nil) != tt.wantMismatch {
+				t.Errorf("CheckRepository = %v, want mismatch %t", err, tt.wantMismatch)
+			}
+		})
+	}
+}
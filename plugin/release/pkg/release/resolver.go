@@ -24,9 +24,7 @@ const (
 )
 
 // ResolveReleaseType parses and validates the release type from the command argument
-func ResolveReleaseType(version *semver.Version, releaseType Type) (Type, error) {
-	newVer := NextVersion(version, releaseType)
-
+func ResolveReleaseType(version, newVer *semver.Version, releaseType Type) (Type, error) {
 	log.PluginPrint(log.Exec,
 		"Applying %s (%s \uF178 %s)",
 		log.ColorText(log.ColorPurple, string(releaseType)),
@@ -37,17 +35,9 @@ func ResolveReleaseType(version *semver.Version, releaseType Type) (Type, error)
 	return releaseType, nil
 }
 
+// NextVersion returns the next version using the default semver strategy
 func NextVersion(current *semver.Version, t Type) semver.Version {
-	switch t {
-	case Major:
-		return current.IncMajor()
-	case Minor:
-		return current.IncMinor()
-	case Patch:
-		return current.IncPatch()
-	default:
-		return *current
-	}
+	return SemverStrategy{}.Next(current, t)
 }
 
 func ParseReleaseType(input string) (Type, error) {
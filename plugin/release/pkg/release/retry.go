@@ -0,0 +1,101 @@
+package release
+
+import (
+	"context"
+	stderrors "errors"
+	"strings"
+	"time"
+
+	"github.com/nekoman-hq/neko-cli/pkg/log"
+	"github.com/nekoman-hq/neko-cli/pkg/plugin"
+	"github.com/nekoman-hq/neko-cli/plugin/release/pkg/config"
+)
+
+// HandleRetry resumes the last interrupted release from its failed step
+func HandleRetry(ctx context.Context) (*plugin.Response, error) {
+	log.PluginPrint(log.Exec, "Loading interrupted release")
+
+	errResp := func(code, message string, details map[string]any) *plugin.Response {
+		return &plugin.Response{
+			Status: "error",
+			Metadata: plugin.ResponseMetadata{
+				Plugin:    PluginName,
+				Version:   PluginVersion,
+				Command:   "retry",
+				Timestamp: time.Now(),
+			},
+			Error: &plugin.ResponseError{
+				Code:    code,
+				Message: message,
+				Details: details,
+			},
+		}
+	}
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return errResp("CONFIG_NOT_FOUND", err.Error(), map[string]any{
+			"hint": "Run 'neko release init' first to initialize the release configuration",
+		}), nil
+	}
+
+	state, err := LoadState()
+	if stderrors.Is(err, ErrNoState) {
+		return errResp("NO_RELEASE_STATE", err.Error(), map[string]any{
+			"hint": "Only releases that failed with --no-revert or could not be undone can be retried",
+		}), nil
+	}
+	if err != nil {
+		return errResp("RELEASE_STATE_ERROR", err.Error(), nil), nil
+	}
+
+	resumedFrom := state.Failed
+	skipped := strings.Join(state.Completed, ", ")
+
+	version, err := NewReleaseService(cfg).Retry(ctx, state)
+	if err != nil {
+		code, details := releaseErrorCode(err)
+		if details == nil {
+			details = map[string]any{}
+		}
+		details["failed_step"] = state.Failed
+		details["hint"] = "Fix the cause and run 'neko release retry' again"
+		return errResp(code, err.Error(), details), nil
+	}
+
+	return &plugin.Response{
+		Status: "success",
+		Metadata: plugin.ResponseMetadata{
+			Plugin:    PluginName,
+			Version:   PluginVersion,
+			Command:   "retry",
+			Timestamp: time.Now(),
+		},
+		Data: map[string]any{
+			"items": []map[string]any{
+				{
+					"property": "Release System",
+					"value":    state.System,
+				},
+				{
+					"property": "New Version",
+					"value":    version.String(),
+				},
+				{
+					"property": "Resumed From",
+					"value":    resumedFrom,
+				},
+				{
+					"property": "Skipped Steps",
+					"value":    skipped,
+				},
+				{
+					"property": "Status",
+					"value":    "Released successfully",
+				},
+			},
+		},
+		RendererHint: "table",
+		ColumnOrder:  []string{"property", "value"},
+	}, nil
+}
@@ -0,0 +1,193 @@
+package release
+
+import (
+	"context"
+	"errors"
+	"os/exec"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/nekoman-hq/neko-cli/plugin/release/internal/gittest"
+	config2 "github.com/nekoman-hq/neko-cli/plugin/release/pkg/config"
+)
+
+// stepTool releases in the shared steps plus a publish step and records the steps it ran
+type stepTool struct {
+	fakeTool
+	ran     []string
+	failing string
+}
+
+func (s *stepTool) Steps() []ReleaseStep {
+	var steps []ReleaseStep
+	for _, name := range []string{StepCommit, StepTag, StepPushCommit, StepPushTag, "publish"} {
+		steps = append(steps, ReleaseStep{
+			Name: name,
+			Run: func(context.Context, *semver.Version) error {
+				s.ran = append(s.ran, name)
+				if name == s.failing {
+					return errors.New(name + " failed")
+				}
+				return nil
+			},
+		})
+	}
+	return steps
+}
+
+func TestRunSteps(t *testing.T) {
+	tests := []struct {
+		name          string
+		completed     []string
+		failing       string
+		wantRan       []string
+		wantCompleted []string
+		wantFailed    string
+	}{
+		{
+			name:          "fresh release",
+			wantRan:       []string{StepCommit, StepTag, StepPushCommit, StepPushTag, "publish"},
+			wantCompleted: []string{StepCommit, StepTag, StepPushCommit, StepPushTag, "publish"},
+		},
+		{
+			name:          "resume after the push",
+			completed:     []string{StepCommit, StepTag, StepPushCommit, StepPushTag},
+			wantRan:       []string{"publish"},
+			wantCompleted: []string{StepCommit, StepTag, StepPushCommit, StepPushTag, "publish"},
+		},
+		{
+			name:          "failing step",
+			completed:     []string{StepCommit},
+			failing:       StepPushCommit,
+			wantRan:       []string{StepTag, StepPushCommit},
+			wantCompleted: []string{StepCommit, StepTag},
+			wantFailed:    StepPushCommit,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gittest.NewRepo(t)
+			tool := &stepTool{failing: tt.failing}
+			state := &ReleaseState{System: "steps", Version: "1.3.0", Completed: slices.Clone(tt.completed)}
+
+			err := RunSteps(context.Background(), tool.Steps(), semver.MustParse("1.3.0"), state)
+			if (err != nil) != (tt.failing != "") {
+				t.Fatalf("RunSteps error = %v, want failure at %q", err, tt.failing)
+			}
+			if !slices.Equal(tool.ran, tt.wantRan) {
+				t.Errorf("ran %v, want %v", tool.ran, tt.wantRan)
+			}
+
+			saved, err := LoadState()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !slices.Equal(saved.Completed, tt.wantCompleted) || saved.Failed != tt.wantFailed {
+				t.Errorf("saved state completed %v failed %q, want %v and %q",
+					saved.Completed, saved.Failed, tt.wantCompleted, tt.wantFailed)
+			}
+		})
+	}
+}
+
+func TestRunStepsCancelled(t *testing.T) {
+	gittest.NewRepo(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tool := &stepTool{}
+	state := &ReleaseState{System: "steps", Version: "1.3.0"}
+	if err := RunSteps(ctx, tool.Steps(), semver.MustParse("1.3.0"), state); !errors.Is(err, context.Canceled) {
+		t.Fatalf("RunSteps error = %v, want context.Canceled", err)
+	}
+	if len(tool.ran) != 0 || state.Failed != StepCommit {
+		t.Errorf("ran %v with failed step %q, want nothing run and %s failed", tool.ran, state.Failed, StepCommit)
+	}
+}
+
+func TestRunStepsCancelledDuringExec(t *testing.T) {
+	gittest.NewRepo(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	var ran []string
+	steps := []ReleaseStep{
+		{Name: "publish", Run: func(ctx context.Context, _ *semver.Version) error {
+			ran = append(ran, "publish")
+			return exec.CommandContext(ctx, "sleep", "30").Run()
+		}},
+		{Name: "announce", Run: func(context.Context, *semver.Version) error {
+			ran = append(ran, "announce")
+			return nil
+		}},
+	}
+	state := &ReleaseState{System: "steps", Version: "1.3.0"}
+
+	start := time.Now()
+	err := RunSteps(ctx, steps, semver.MustParse("1.3.0"), state)
+	if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Fatalf("RunSteps took %s, the cancelled context did not kill sleep", elapsed)
+	}
+	if err == nil {
+		t.Fatal("RunSteps succeeded, want the killed step to fail")
+	}
+	if !slices.Equal(ran, []string{"publish"}) || state.Failed != "publish" {
+		t.Errorf("ran %v with failed step %q, want only publish run and failed", ran, state.Failed)
+	}
+}
+
+// Retry resumes from the failed publish step of a release whose commit, tag and push succeeded
+func TestHandleRetry(t *testing.T) {
+	gittest.NewRepo(t)
+	gittest.Commit(t, "feat: initial")
+
+	unregister(t, string(config2.ReleaseTypeGoReleaser))
+	tool := &stepTool{fakeTool: fakeTool{name: string(config2.ReleaseTypeGoReleaser)}}
+	Register(tool)
+
+	if err := config2.SaveConfig(config2.NekoConfig{
+		ProjectType:   config2.ProjectTypeBackend,
+		ReleaseSystem: config2.ReleaseTypeGoReleaser,
+		Version:       "1.2.0",
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := HandleRetry(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Error == nil || resp.Error.Code != "NO_RELEASE_STATE" {
+		t.Fatalf("error = %+v, want NO_RELEASE_STATE", resp.Error)
+	}
+
+	if err := SaveState(&ReleaseState{
+		System:    string(config2.ReleaseTypeGoReleaser),
+		Version:   "1.3.0",
+		Completed: []string{StepCommit, StepTag, StepPushCommit, StepPushTag},
+		Failed:    "publish",
+		Error:     "goreleaser release failed",
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err = HandleRetry(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Status != "success" {
+		t.Fatalf("status = %s, error = %+v", resp.Status, resp.Error)
+	}
+	if !slices.Equal(tool.ran, []string{"publish"}) {
+		t.Errorf("retry ran %v, want only the failed publish step", tool.ran)
+	}
+	if _, err := LoadState(); !errors.Is(err, ErrNoState) {
+		t.Errorf("state after a successful retry: %v, want it removed", err)
+	}
+	if cfg, _ := config2.LoadConfig(); cfg == nil || cfg.Version != "1.3.0" {
+		t.Errorf("config = %+v, want version 1.3.0", cfg)
+	}
+}
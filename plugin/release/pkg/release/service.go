@@ -8,97 +8,290 @@ package release
 */
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/Masterminds/semver/v3"
 	config2 "github.com/nekoman-hq/neko-cli/plugin/release/pkg/config"
 	"github.com/nekoman-hq/neko-cli/plugin/release/pkg/git"
+	"github.com/nekoman-hq/neko-cli/plugin/release/pkg/provenance"
 
 	"github.com/nekoman-hq/neko-cli/pkg/errors"
 	"github.com/nekoman-hq/neko-cli/pkg/log"
 )
 
+// releaseSteps is the number of progress steps reported by Run
+const releaseSteps = 5
+
 type Service struct {
 	cfg *config2.NekoConfig
+
+	// NoRevert keeps completed steps on failure so the release can be resumed with retry
+	NoRevert bool
+
+	// ForceVersion releases from the config version even if it is smaller than the latest tag
+	ForceVersion bool
+
+	// SkipConnectivityCheck skips the GitHub connectivity check, e.g. for offline runs
+	SkipConnectivityCheck bool
+
+	// Force releases even if the last release is more recent than min-release-interval
+	Force bool
+
+	// Notes replace the release notes generated by the release tool, read from --notes-file
+	Notes string
+
+	// Strict fails the release if the configured repository does not match the remote
+	Strict bool
 }
 
 func NewReleaseService(cfg *config2.NekoConfig) *Service {
+	SetTagPrefix(ResolveTagPrefix(cfg, git.GetTags()))
+	SetCommitOptions(CommitOptionsFrom(cfg))
+	git.SetReleaseCommitScope(cfg.ReleaseCommitScope)
+	SetReleaseArgs(cfg.ReleaseArgs)
+	SetGoReleaserSkipArgs(cfg.GoReleaserSkipArgs())
 	return &Service{cfg: cfg}
 }
 
-// Run executes the release with the specified release type (patch, minor, major)
-func (rs *Service) Run(releaseType Type) error {
+// Run executes the release with the specified release type (patch, minor, major).
+// Cancelling the context kills the running release command and reverts like any other failure.
+func (rs *Service) Run(ctx context.Context, releaseType Type) error {
 	_, _ = git.Current()
 
-	Preflight()
-	version, err := VersionGuard(rs.cfg)
+	log.PluginProgress("Pre-flight checks", 1, releaseSteps)
+	Preflight(rs.cfg, !rs.SkipConnectivityCheck, rs.Strict)
+	log.PluginProgress("Version guard", 2, releaseSteps)
+	version, err := VersionGuard(rs.cfg, rs.ForceVersion)
 	if err != nil {
 		return err
 	}
+	CheckCooldown(rs.cfg, rs.Force)
 
-	releaser, err := Get(string(rs.cfg.ReleaseSystem))
+	releasers, err := getReleasers(rs.cfg.Systems())
 	if err != nil {
-		return fmt.Errorf(
-			"release System Not Found: %w", err,
+		return err
+	}
+
+	for _, releaser := range releasers {
+		log.PluginPrint(log.Exec,
+			"Release system detected: %s",
+			log.ColorText(log.ColorPurple, releaser.Name()),
 		)
 	}
 
-	log.PluginPrint(log.Exec,
-		"Release system detected: %s",
-		log.ColorText(log.ColorPurple, releaser.Name()),
-	)
+	log.PluginProgress("Validate release system", 3, releaseSteps)
+	if err := validateReleasers(releasers); err != nil {
+		return err
+	}
 
-	log.PluginPrint(log.Exec,
-		"Latest version tag extracted successfully \uF178 %s",
-		log.ColorText(log.ColorCyan, version.String()),
-	)
+	firstRelease := IsFirstRelease(rs.cfg)
+	if !firstRelease {
+		log.PluginPrint(log.Exec,
+			"Latest version tag extracted successfully \uF178 %s",
+			log.ColorText(log.ColorCyan, version.String()),
+		)
+	}
 
-	rt, err := ResolveReleaseType(version, releaseType)
+	newVersion, err := rs.nextVersion(version, releaseType)
 	if err != nil {
+		return err
+	}
+
+	if firstRelease {
+		log.PluginPrint(log.Exec, "Creating the first release %s",
+			log.ColorText(log.ColorCyan, TagName(&newVersion)))
+	}
+
+	if _, err = ResolveReleaseType(version, &newVersion, releaseType); err != nil {
 		return fmt.Errorf(
 			"invalid Release Type: %w", err,
 		)
 	}
 
-	log.PluginPrint(log.Guard, "\uF00C All checks have succeeded. %s", log.ColorText(log.ColorGreen, "Starting release now!"))
+	if err := CheckChangelog(rs.cfg, &newVersion); err != nil {
+		return err
+	}
+
+	rs.setCheckpoint(releasers, &newVersion)
 
-	newVersion := NextVersion(version, rt)
+	log.PluginPrint(log.Guard, "\uF00C All checks have succeeded. %s", log.ColorText(log.ColorGreen, "Starting release now!"))
 
-	if err := releaser.Release(&newVersion); err != nil {
+	log.PluginProgress("Release", 4, releaseSteps)
+	state := &ReleaseState{System: releasers[0].Name(), Version: newVersion.String()}
+	if len(releasers) > 1 {
+		state.Systems = releaserNames(releasers)
+	}
+	started, err := runReleases(ctx, releasers, &newVersion, state)
+	if err != nil {
 		releaseError := fmt.Errorf("release failed: %w", err)
 
+		if rs.NoRevert {
+			log.PluginPrint(log.Guard, "Keeping completed steps. Resume with %s",
+				log.ColorText(log.ColorGreen, "neko release retry"))
+			return releaseError
+		}
+
 		log.PluginPrint(log.Guard, "Encountered error while releasing. Trying to undo changes...")
-		if err := releaser.RevertRelease(); err != nil {
+		if err := revertReleases(releasers[:started]); err != nil {
 			return fmt.Errorf("%w: Failed undoing changes: %w", releaseError, err)
 		}
 		log.PluginPrint(log.Guard, "Successfully undid changes.")
+		rs.clearState()
 
 		return releaseError
 	}
 
-	if err := rs.updateConfig(&newVersion); err != nil {
+	if err := checkWorkingTree("after the release", false); err != nil {
+		log.PluginV(log.Guard, fmt.Sprintf("Skipping working tree check: %s", err.Error()))
+	}
+
+	rs.finish(&newVersion)
+	return nil
+}
+
+// setCheckpoint expects the configured files plus the files the release tools plan to modify
+func (rs *Service) setCheckpoint(releasers []Tool, v *semver.Version) {
+	cp := CheckpointFrom(rs.cfg)
+	for _, releaser := range releasers {
+		if planner, ok := releaser.(Planner); ok {
+			cp.Expect(planner.Plan(v).Files...)
+		}
+	}
+	SetCheckpoint(cp)
+}
+
+// Retry resumes an interrupted release, skipping the steps that already completed.
+// Preflight and version guard are skipped since the release commit and tag may already exist.
+func (rs *Service) Retry(ctx context.Context, state *ReleaseState) (*semver.Version, error) {
+	releasers, err := getReleasers(state.systems())
+	if err != nil {
+		return nil, err
+	}
+
+	for _, releaser := range releasers {
+		if _, ok := releaser.(Stepper); !ok {
+			return nil, fmt.Errorf("release system %s does not support resuming releases", releaser.Name())
+		}
+	}
+
+	if err := validateReleasers(releasers); err != nil {
+		return nil, err
+	}
+
+	version, err := ParseVersion(state.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	rs.setCheckpoint(releasers, version)
+
+	log.PluginPrint(log.Exec, "Resuming release %s from step %s",
+		log.ColorText(log.ColorCyan, version.String()),
+		log.ColorText(log.ColorCyan, state.Failed))
+
+	if _, err := runReleases(ctx, releasers, version, state); err != nil {
+		return nil, fmt.Errorf("release failed: %w", err)
+	}
+
+	rs.finish(version)
+	return version, nil
+}
+
+// runRelease runs the release step by step if the tool supports it, so it can be resumed later
+func runRelease(ctx context.Context, releaser Tool, v *semver.Version, state *ReleaseState) error {
+	if stepper, ok := releaser.(Stepper); ok {
+		return RunSteps(ctx, stepper.Steps(), v, state)
+	}
+	return releaser.Release(ctx, v)
+}
+
+// finish updates the config and removes the release state after a successful release.
+// The release is already published at this point, so failures of the follow-up steps only produce warnings.
+func (rs *Service) finish(newVersion *semver.Version) {
+	log.PluginProgress("Update config", 5, releaseSteps)
+	if err := rs.updateConfig(newVersion); err != nil {
 		errors.WriteWarning(
 			"Failed to update local config",
 			fmt.Sprintf("Updating version in .release.neko.json failed. Attempting to proceed with release: %s", err.Error()))
 	}
+	rs.clearState()
 
 	log.PluginPrint(log.Exec, "\uF00C Successfully released version %s",
 		log.ColorText(log.ColorCyan, newVersion.String()))
 
-	return nil
+	// The notes replace the body, so they are set before the provenance is appended to it
+	rs.setNotes(newVersion)
+	rs.attachProvenance(newVersion)
+}
+
+// setNotes replaces the generated release notes with the --notes-file notes
+func (rs *Service) setNotes(newVersion *semver.Version) {
+	if rs.Notes == "" {
+		return
+	}
+	if err := SetReleaseNotes(TagName(newVersion), rs.Notes); err != nil {
+		errors.WriteWarning(
+			"Failed to set the release notes",
+			fmt.Sprintf("Set them later with 'neko release amend %s --notes-file <path>': %s", TagName(newVersion), err.Error()))
+	}
+}
+
+// attachProvenance attaches the build provenance to the GitHub release if configured
+func (rs *Service) attachProvenance(newVersion *semver.Version) {
+	if rs.cfg.Provenance == "" {
+		return
+	}
+	if _, err := provenance.Attach(rs.cfg.Provenance, TagName(newVersion), PluginVersion); err != nil {
+		errors.WriteWarning(
+			"Failed to attach provenance",
+			fmt.Sprintf("Attach it later with 'neko release attach-provenance %s': %s", TagName(newVersion), err.Error()))
+	}
+}
+
+func (rs *Service) clearState() {
+	if err := ClearState(); err != nil {
+		errors.WriteWarning("Failed to remove release state", err.Error())
+	}
 }
 
 // GetNewVersion returns what the new version would be for a given release type
 func (rs *Service) GetNewVersion(releaseType Type) (*semver.Version, *semver.Version, error) {
-	version, err := VersionGuard(rs.cfg)
+	version, err := VersionGuard(rs.cfg, rs.ForceVersion)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	newVersion := NextVersion(version, releaseType)
+	newVersion, err := rs.nextVersion(version, releaseType)
+	if err != nil {
+		return nil, nil, err
+	}
 	return version, &newVersion, nil
 }
 
+// nextVersion resolves the configured bump strategy and applies the branch prerelease mapping
+func (rs *Service) nextVersion(current *semver.Version, releaseType Type) (semver.Version, error) {
+	strategy, err := GetStrategy(rs.cfg.VersionScheme)
+	if err != nil {
+		return semver.Version{}, err
+	}
+
+	identifier := ""
+	if len(rs.cfg.PrereleaseBranches) > 0 {
+		branch, err := git.CurrentBranch()
+		if err != nil {
+			return semver.Version{}, err
+		}
+		identifier = PrereleaseIdentifier(branch, rs.cfg.PrereleaseBranches)
+		if identifier != "" {
+			log.PluginV(log.Exec, fmt.Sprintf("Branch %s produces %s prereleases",
+				log.ColorText(log.ColorGreen, branch), log.ColorText(log.ColorCyan, identifier)))
+		}
+	}
+
+	return NextBranchVersion(strategy, current, releaseType, identifier, git.GetTags()), nil
+}
+
 func (rs *Service) updateConfig(newVersion *semver.Version) error {
 	rs.cfg.Version = newVersion.String()
 	return config2.SaveConfig(*rs.cfg)
@@ -0,0 +1,179 @@
+package release
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/Masterminds/semver/v3"
+	config2 "github.com/nekoman-hq/neko-cli/plugin/release/pkg/config"
+	"github.com/nekoman-hq/neko-cli/plugin/release/pkg/git"
+
+	"github.com/nekoman-hq/neko-cli/pkg/log"
+)
+
+// StateFileName is stored in the git dir so it never dirties the working tree
+const StateFileName = "neko-release-state.json"
+
+// ErrNoState is returned by LoadState if there is no interrupted release
+var ErrNoState = errors.New("no interrupted release found")
+
+// Step names shared by the release tools
+const (
+	StepCommit     = "commit"
+	StepTag        = "tag"
+	StepPushCommit = "push-commit"
+	StepPushTag    = "push-tag"
+)
+
+// ReleaseStep is a single resumable stage of a release
+type ReleaseStep struct {
+	Name string
+	Run  func(ctx context.Context, v *semver.Version) error
+}
+
+// Stepper is implemented by tools that split their release into resumable steps.
+// Completed steps are persisted so 'retry' can continue after the last failed step.
+type Stepper interface {
+	Steps() []ReleaseStep
+}
+
+// ReleaseState records the progress of a release in case it has to be resumed
+type ReleaseState struct {
+	System string `json:"system"`
+	// Systems lists all release systems in order if the release fans out to several
+	Systems   []string `json:"systems,omitempty"`
+	Version   string   `json:"version"`
+	Completed []string `json:"completed"`
+	Failed    string   `json:"failed,omitempty"`
+	Error     string   `json:"error,omitempty"`
+}
+
+// IsCompleted reports whether the step already ran successfully
+func (st *ReleaseState) IsCompleted(step string) bool {
+	return slices.Contains(st.Completed, step)
+}
+
+// MarkCompleted records steps as completed without running them
+func (st *ReleaseState) MarkCompleted(steps ...string) {
+	for _, step := range steps {
+		if !st.IsCompleted(step) {
+			st.Completed = append(st.Completed, step)
+		}
+	}
+}
+
+// systems returns the release systems of the release in order
+func (st *ReleaseState) systems() []config2.ReleaseSystem {
+	if len(st.Systems) == 0 {
+		return []config2.ReleaseSystem{config2.ReleaseSystem(st.System)}
+	}
+	systems := make([]config2.ReleaseSystem, len(st.Systems))
+	for i, name := range st.Systems {
+		systems[i] = config2.ReleaseSystem(name)
+	}
+	return systems
+}
+
+// RunSteps runs all steps that are not completed yet.
+// If st is nil the steps run without persisting their progress.
+// A cancelled context fails the next step before it starts, so it can be resumed with retry.
+func RunSteps(ctx context.Context, steps []ReleaseStep, v *semver.Version, st *ReleaseState) error {
+	for _, step := range steps {
+		if st != nil && st.IsCompleted(step.Name) {
+			log.PluginPrint(log.Exec, "Skipping completed step %s", log.ColorText(log.ColorCyan, step.Name))
+			continue
+		}
+
+		if err := runStep(ctx, step, v); err != nil {
+			if st != nil {
+				st.Failed = step.Name
+				st.Error = err.Error()
+				if serr := SaveState(st); serr != nil {
+					return fmt.Errorf("%w: %w", err, serr)
+				}
+			}
+			return err
+		}
+
+		if st != nil {
+			st.Completed = append(st.Completed, step.Name)
+			st.Failed, st.Error = "", ""
+			if err := SaveState(st); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// runStep runs the step unless the context is already cancelled
+func runStep(ctx context.Context, step ReleaseStep, v *semver.Version) error {
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("release cancelled before step %s: %w", step.Name, err)
+	}
+	return step.Run(ctx, v)
+}
+
+func statePath() (string, error) {
+	info, err := git.Worktree()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(info.GitDir, StateFileName), nil
+}
+
+// LoadState loads the state of an interrupted release
+func LoadState() (*ReleaseState, error) {
+	path, err := statePath()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, ErrNoState
+		}
+		return nil, fmt.Errorf("release state read error: %w", err)
+	}
+
+	var st ReleaseState
+	if err := json.Unmarshal(data, &st); err != nil {
+		return nil, fmt.Errorf("release state parse error: %w", err)
+	}
+	return &st, nil
+}
+
+// SaveState persists the release state
+func SaveState(st *ReleaseState) error {
+	path, err := statePath()
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(st, "", "  ")
+	if err != nil {
+		return fmt.Errorf("release state serialization failed: %w", err)
+	}
+	if err = os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("release state write failed: %w", err)
+	}
+	return nil
+}
+
+// ClearState removes the release state once a release finished or was reverted
+func ClearState() error {
+	path, err := statePath()
+	if err != nil {
+		return err
+	}
+	if err = os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("release state removal failed: %w", err)
+	}
+	return nil
+}
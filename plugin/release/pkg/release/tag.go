@@ -0,0 +1,84 @@
+package release
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/nekoman-hq/neko-cli/plugin/release/pkg/config"
+)
+
+// DefaultTagPrefix is used for new tags when no prefix is configured and no version tags exist yet
+const DefaultTagPrefix = "v"
+
+// tagPrefix is the prefix used by TagName, resolved once per release
+var tagPrefix = DefaultTagPrefix
+
+// versionTagPattern splits a version tag into its prefix and the version, e.g. release-1.2.3
+var versionTagPattern = regexp.MustCompile(`^(.*?)(\d+\.\d+\.\d+\S*)$`)
+
+// DetectTagPrefix returns the prefix used by most of the existing version tags.
+// Returns "v" for v1.2.3, "" for bare 1.2.3 and e.g. "release-" for release-1.2.3 tags,
+// or DefaultTagPrefix if no version tags exist. Ties are resolved in favor of DefaultTagPrefix,
+// then the bare style, then the alphabetically first prefix.
+func DetectTagPrefix(tags []string) string {
+	counts := map[string]int{}
+	for _, tag := range tags {
+		m := versionTagPattern.FindStringSubmatch(tag)
+		if m == nil {
+			continue
+		}
+		if _, err := semver.StrictNewVersion(m[2]); err != nil {
+			continue
+		}
+		counts[m[1]]++
+	}
+	if len(counts) == 0 {
+		return DefaultTagPrefix
+	}
+
+	prefixes := slices.SortedFunc(maps.Keys(counts), func(a, b string) int {
+		if c := cmp.Compare(counts[b], counts[a]); c != 0 {
+			return c
+		}
+		if rank := cmp.Compare(prefixRank(a), prefixRank(b)); rank != 0 {
+			return rank
+		}
+		return strings.Compare(a, b)
+	})
+	return prefixes[0]
+}
+
+// prefixRank orders prefixes with the same number of tags
+func prefixRank(prefix string) int {
+	switch prefix {
+	case DefaultTagPrefix:
+		return 0
+	case "":
+		return 1
+	default:
+		return 2
+	}
+}
+
+// ResolveTagPrefix returns the configured tag prefix or detects it from the existing tags
+func ResolveTagPrefix(cfg *config.NekoConfig, tags []string) string {
+	if cfg.TagPrefix != nil {
+		return *cfg.TagPrefix
+	}
+	return DetectTagPrefix(tags)
+}
+
+// SetTagPrefix sets the prefix used for tags created by the release tools
+func SetTagPrefix(prefix string) {
+	tagPrefix = prefix
+}
+
+// TagName returns the git tag name for the version
+func TagName(v *semver.Version) string {
+	return fmt.Sprintf("%s%s", tagPrefix, v)
+}
@@ -0,0 +1,31 @@
+package release
+
+import "testing"
+
+func TestDetectTagPrefix(t *testing.T) {
+	tests := []struct {
+		name string
+		tags []string
+		want string
+	}{
+		{"prefixed", []string{"v1.0.0", "v1.1.0", "latest"}, "v"},
+		{"bare", []string{"1.0.0", "1.1.0", "latest"}, ""},
+		{"mostly bare", []string{"v0.1.0", "1.0.0", "1.1.0"}, ""},
+		{"tie prefers prefixed", []string{"v1.0.0", "1.1.0"}, "v"},
+		{"custom prefix", []string{"release-1.0.0", "release-1.1.0", "v0.1.0"}, "release-"},
+		{"prefix with digits", []string{"app2-1.0.0", "app2-1.1.0-rc.1"}, "app2-"},
+		{"tie prefers bare over custom", []string{"1.0.0", "release-1.1.0"}, ""},
+		{"tie between custom prefixes", []string{"web-1.0.0", "api-1.0.0"}, "api-"},
+		{"invalid versions are ignored", []string{"release-1.0", "release-01.2.3", "v1.0.0"}, "v"},
+		{"no version tags", []string{"latest", "nightly"}, DefaultTagPrefix},
+		{"no tags", nil, DefaultTagPrefix},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DetectTagPrefix(tt.tags); got != tt.want {
+				t.Errorf("DetectTagPrefix(%v) = %q, want %q", tt.tags, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package release
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Placeholder syntaxes of the release tools, the first group captures the placeholder name
+var (
+	// MustacheSyntax matches {{name}} as used by jreleaser
+	MustacheSyntax = regexp.MustCompile(`\{\{\s*([^{}]*?)\s*\}\}`)
+	// DollarSyntax matches ${name} as used by release-it
+	DollarSyntax = regexp.MustCompile(`\$\{\s*([^{}]*?)\s*\}`)
+)
+
+// ValidateTemplate returns an error if the template uses a placeholder that is not known.
+// Known entries ending with "." allow any placeholder with that prefix, e.g. "Env.".
+func ValidateTemplate(field, template string, syntax *regexp.Regexp, known []string) error {
+	for _, m := range syntax.FindAllStringSubmatch(template, -1) {
+		name := m[1]
+		if isKnownPlaceholder(name, known) {
+			continue
+		}
+
+		msg := fmt.Sprintf("%s: unknown placeholder %q in %q", field, m[0], template)
+		if suggestion := suggestPlaceholder(name, known); suggestion != "" {
+			msg += fmt.Sprintf(", did you mean %q?", suggestion)
+		}
+		return fmt.Errorf("%s", msg)
+	}
+	return nil
+}
+
+func isKnownPlaceholder(name string, known []string) bool {
+	for _, k := range known {
+		if name == k || (strings.HasSuffix(k, ".") && strings.HasPrefix(name, k)) {
+			return true
+		}
+	}
+	return false
+}
+
+// suggestPlaceholder finds a known placeholder differing only in case, the most common typo
+func suggestPlaceholder(name string, known []string) string {
+	for _, k := range known {
+		if strings.EqualFold(name, k) {
+			return k
+		}
+	}
+	return ""
+}
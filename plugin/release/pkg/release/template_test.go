@@ -0,0 +1,44 @@
+package release
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestValidateTemplate(t *testing.T) {
+	known := []string{"version", "tagName", "repo."}
+
+	tests := []struct {
+		name     string
+		template string
+		syntax   *regexp.Regexp
+		wantErr  string
+	}{
+		{"empty", "", DollarSyntax, ""},
+		{"no placeholder", "release", DollarSyntax, ""},
+		{"known", "v${version}", DollarSyntax, ""},
+		{"spaces", "v${ version }", DollarSyntax, ""},
+		{"prefix", "${repo.repository} ${version}", DollarSyntax, ""},
+		{"unknown", "v${verison}", DollarSyntax, `unknown placeholder "${verison}"`},
+		{"case typo", "v${Version}", DollarSyntax, `did you mean "version"?`},
+		{"mustache known", "v{{tagName}}", MustacheSyntax, ""},
+		{"mustache unknown", "{{ projectName }}", MustacheSyntax, `unknown placeholder "{{ projectName }}"`},
+		{"other syntax is ignored", "{{projectName}}", DollarSyntax, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateTemplate("git.tagName", tt.template, tt.syntax, known)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("ValidateTemplate(%q) = %v", tt.template, err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) || !strings.HasPrefix(err.Error(), "git.tagName: ") {
+				t.Errorf("ValidateTemplate(%q) = %v, want an error containing %q", tt.template, err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,24 @@
+package release
+
+import (
+	"time"
+
+	"github.com/nekoman-hq/neko-cli/pkg/log"
+)
+
+// Took returns the time elapsed since start as suffix for completion log lines, e.g. " (42.1s)".
+// The duration is only shown in verbose mode, next to the logged command.
+func Took(start time.Time) string {
+	if !log.Verbose {
+		return ""
+	}
+	return " " + log.ColorText(log.ColorBrightBlack, "("+FormatDuration(time.Since(start))+")")
+}
+
+// FormatDuration rounds the duration for logs, to milliseconds below one second and to tenths of a second above
+func FormatDuration(d time.Duration) string {
+	if d < time.Second {
+		return d.Round(time.Millisecond).String()
+	}
+	return d.Round(100 * time.Millisecond).String()
+}
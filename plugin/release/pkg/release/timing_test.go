@@ -0,0 +1,135 @@
+package release
+
+import (
+	"context"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/nekoman-hq/neko-cli/pkg/log"
+	"github.com/nekoman-hq/neko-cli/plugin/release/internal/gittest"
+)
+
+// captureStderr returns what fn logs to stderr
+func captureStderr(t *testing.T, fn func()) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stderr := os.Stderr
+	os.Stderr = w
+	fn()
+	os.Stderr = stderr
+	_ = w.Close()
+
+	out, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(out)
+}
+
+// withVerbose sets log.Verbose for the test
+func withVerbose(t *testing.T, verbose bool) {
+	t.Helper()
+	previous := log.Verbose
+	log.Verbose = verbose
+	t.Cleanup(func() { log.Verbose = previous })
+}
+
+func TestFormatDuration(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{0, "0s"},
+		{1500 * time.Microsecond, "2ms"},
+		{999 * time.Millisecond, "999ms"},
+		{time.Second, "1s"},
+		{42*time.Second + 149*time.Millisecond, "42.1s"},
+		{42*time.Second + 150*time.Millisecond, "42.2s"},
+		{2*time.Minute + 3*time.Second, "2m3s"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := FormatDuration(tt.d); got != tt.want {
+				t.Errorf("FormatDuration(%s) = %q, want %q", tt.d, got, tt.want)
+			}
+		})
+	}
+}
+
+var tookPattern = regexp.MustCompile(`\(\d+(\.\d+)?m?s\)`)
+
+func TestTook(t *testing.T) {
+	tests := []struct {
+		name    string
+		verbose bool
+	}{
+		{"verbose", true},
+		{"quiet", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			withVerbose(t, tt.verbose)
+
+			got := Took(time.Now().Add(-1500 * time.Millisecond))
+			if !tt.verbose {
+				if got != "" {
+					t.Errorf("Took = %q, want nothing outside verbose mode", got)
+				}
+				return
+			}
+			if !strings.HasPrefix(got, " ") || !strings.Contains(got, "(1.5s)") {
+				t.Errorf("Took = %q, want \" (1.5s)\"", got)
+			}
+		})
+	}
+}
+
+// The completion line of a release step carries its duration in verbose mode only
+func TestCreateGitTagLogsDuration(t *testing.T) {
+	tests := []struct {
+		name    string
+		verbose bool
+	}{
+		{"verbose", true},
+		{"quiet", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gittest.NewRepo(t)
+			gittest.Commit(t, "feat: initial")
+			withVerbose(t, tt.verbose)
+
+			var err error
+			out := captureStderr(t, func() {
+				err = (&ToolBase{}).CreateGitTag(context.Background(), semver.MustParse("1.2.0"))
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var done string
+			for _, line := range strings.Split(out, "\n") {
+				if strings.Contains(line, "Created git tag") {
+					done = line
+				}
+			}
+			if done == "" {
+				t.Fatalf("no completion line in:\n%s", out)
+			}
+			if timed := tookPattern.MatchString(done); timed != tt.verbose {
+				t.Errorf("completion line %q shows a duration = %t, want %t", done, timed, tt.verbose)
+			}
+		})
+	}
+}
@@ -1,8 +1,13 @@
 package release
 
 import (
+	"context"
 	"fmt"
+	"os"
 	"os/exec"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/Masterminds/semver/v3"
 	"github.com/nekoman-hq/neko-cli/pkg/config"
@@ -21,11 +26,89 @@ import (
 type Tool interface {
 	Name() string
 	Init(cfg *config2.NekoConfig) error
-	Release(v *semver.Version) error
+	// Validate checks required config files and dependencies before any git mutation
+	Validate() error
+	// Release runs the whole release, the context cancels the commands it runs
+	Release(ctx context.Context, v *semver.Version) error
 	RevertRelease() error
 }
 
-type ToolBase struct{}
+// DryRunner is implemented by tools that support a native dry run.
+// The returned output is surfaced in the dry-run response.
+type DryRunner interface {
+	DryRun(v *semver.Version) (string, error)
+}
+
+// Artifact is a build artifact produced locally by a release tool
+type Artifact struct {
+	Name string `json:"name"`
+	Path string `json:"path"`
+	Type string `json:"type"`
+}
+
+// Snapshotter is implemented by tools that can build artifacts locally without publishing.
+// Unlike a dry run, a snapshot produces real artifacts.
+type Snapshotter interface {
+	Snapshot(v *semver.Version) ([]Artifact, error)
+}
+
+// Plan describes which files and commands a release would touch without executing it
+type Plan struct {
+	Files    []string
+	Commands []string
+}
+
+// Planner is implemented by tools that can describe their release steps for dry runs
+type Planner interface {
+	Plan(v *semver.Version) Plan
+}
+
+// ConfigFiler is implemented by tools with their own config files, e.g. to clean them up after migrate-tool
+type ConfigFiler interface {
+	ConfigFiles() []string
+}
+
+// InitFiler is implemented by tools that report the files their Init wrote, e.g. for init --commit
+type InitFiler interface {
+	InitFiles() []string
+}
+
+type ToolBase struct {
+	initFiles []string
+}
+
+// RecordInitFiles remembers files written by Init, see InitFiler.
+// Files that do not exist are skipped, external init commands do not always create all of them.
+func (tb *ToolBase) RecordInitFiles(files ...string) {
+	for _, f := range files {
+		if _, err := os.Stat(f); err == nil && !slices.Contains(tb.initFiles, f) {
+			tb.initFiles = append(tb.initFiles, f)
+		}
+	}
+}
+
+// InitFiles returns the files recorded by RecordInitFiles
+func (tb *ToolBase) InitFiles() []string {
+	return tb.initFiles
+}
+
+// Validate is a no-op by default, tools override it to check their requirements
+func (tb *ToolBase) Validate() error {
+	return nil
+}
+
+// RequireFile checks that at least one of the given files exists
+func (tb *ToolBase) RequireFile(names ...string) error {
+	for _, name := range names {
+		if _, err := os.Stat(name); err == nil {
+			return nil
+		}
+	}
+
+	return fmt.Errorf(
+		"required file missing: %s", strings.Join(names, " or "),
+	)
+}
 
 func (tb *ToolBase) RequireBinary(name string) error {
 	log.PluginV(log.Init,
@@ -38,7 +121,7 @@ func (tb *ToolBase) RequireBinary(name string) error {
 	path, err := exec.LookPath(name)
 	if err != nil {
 		return fmt.Errorf(
-			"required dependency missing: %s: %w", path, err,
+			"required dependency missing: %s: %w", name, err,
 		)
 	}
 
@@ -89,32 +172,11 @@ func (tb *ToolBase) RevertGitRelease(st GitReleaseState) error {
 		}
 	}
 
-	// Commits
+	// Commits. PushedCommit is only set after a successful push,
+	// so a push rejected by the remote falls through to the local hard reset
 	if st.ReleaseHead != "" {
-		if st.PushedCommit {
-			// empty commits cannot be reverted, ignore error
-			if err := git.RevertCommit(st.ReleaseHead); err != nil {
-				_ = git.CreateCommit(fmt.Sprintf("revert %s", st.ReleaseHead))
-			}
-
-			if err := tb.PushCommits(); err != nil {
-				return fmt.Errorf(
-					"rollback: failed pushing revert commit: %w",
-					err,
-				)
-			}
-		} else if st.PreHead != "" {
-			if err := git.HardResetTo(st.PreHead); err != nil {
-				return fmt.Errorf(
-					"rollback: failed hard reset to %s: %w",
-					st.PreHead,
-					err,
-				)
-			}
-		} else {
-			return fmt.Errorf(
-				"rollback: inconsistent state (release commit exists but pre-head missing)",
-			)
+		if err := tb.revertReleaseCommit(st); err != nil {
+			return err
 		}
 	}
 
@@ -129,6 +191,58 @@ func (tb *ToolBase) RevertGitRelease(st GitReleaseState) error {
 	return nil
 }
 
+// revertReleaseCommit undoes the release commit. An unpushed commit is reset away,
+// a pushed one is reverted, or recorded with an empty commit if it did not change anything.
+func (tb *ToolBase) revertReleaseCommit(st GitReleaseState) error {
+	if !st.PushedCommit {
+		if st.PreHead == "" {
+			return fmt.Errorf(
+				"rollback: inconsistent state (release commit exists but pre-head missing)",
+			)
+		}
+
+		log.PluginPrint(log.Guard, "Release commit %s was not pushed, resetting to %s",
+			log.ColorText(log.ColorCyan, st.ReleaseHead), log.ColorText(log.ColorCyan, st.PreHead))
+		if err := git.HardResetTo(st.PreHead); err != nil {
+			return fmt.Errorf(
+				"rollback: failed hard reset to %s: %w",
+				st.PreHead,
+				err,
+			)
+		}
+		return nil
+	}
+
+	empty, err := git.IsEmptyCommit(st.ReleaseHead)
+	if err != nil {
+		return fmt.Errorf("rollback: %w", err)
+	}
+
+	if empty {
+		// git revert refuses commits without changes, so the revert is recorded as an empty commit
+		log.PluginPrint(log.Guard, "Release commit %s is empty, recording the revert with an empty commit",
+			log.ColorText(log.ColorCyan, st.ReleaseHead))
+		if err := git.CreateCommit(fmt.Sprintf("revert %s", st.ReleaseHead)); err != nil {
+			return fmt.Errorf("rollback: failed creating revert commit: %w", err)
+		}
+	} else {
+		log.PluginPrint(log.Guard, "Reverting pushed release commit %s",
+			log.ColorText(log.ColorCyan, st.ReleaseHead))
+		if err := git.RevertCommit(st.ReleaseHead); err != nil {
+			return fmt.Errorf("rollback: %w", err)
+		}
+	}
+
+	// The rollback must complete even if the release itself was cancelled
+	if err := tb.PushCommits(context.Background()); err != nil {
+		return fmt.Errorf(
+			"rollback: failed pushing revert commit: %w",
+			err,
+		)
+	}
+	return nil
+}
+
 func (tb *ToolBase) DeleteGitHubRelease(tag string) error {
 	pat, err := config.GetPAT()
 	if err != nil {
@@ -139,13 +253,23 @@ func (tb *ToolBase) DeleteGitHubRelease(tag string) error {
 }
 
 // CreateReleaseCommit creates the chore commit for the release
-func (tb *ToolBase) CreateReleaseCommit(v *semver.Version) error {
-	commitMsg := fmt.Sprintf("chore(neko-release): %s", v)
+func (tb *ToolBase) CreateReleaseCommit(ctx context.Context, v *semver.Version) error {
+	if err := checkWorkingTree("before the release commit", true); err != nil {
+		return err
+	}
+
+	args := ReleaseCommitArgs(v)
+	commitMsg, _, _ := strings.Cut(ReleaseCommitMessage(v), "\n")
 
 	log.PluginV(log.Exec, fmt.Sprintf("Creating release commit: %s",
-		log.ColorText(log.ColorGreen, fmt.Sprintf("git commit --allow-empty -m \"%s\"", commitMsg))))
+		log.ColorText(log.ColorGreen, fmt.Sprintf("git %s", strings.Join(args, " ")))))
+	if git.SkipInDryRun(append([]string{"git"}, args...)...) {
+		return nil
+	}
 
-	cmd := exec.Command("git", "commit", "--allow-empty", "-a", "-m", commitMsg)
+	start := time.Now()
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Env = ReleaseCommitEnv(os.Environ())
 	output, err := cmd.CombinedOutput()
 	if err != nil {
 		return fmt.Errorf(
@@ -153,19 +277,23 @@ func (tb *ToolBase) CreateReleaseCommit(v *semver.Version) error {
 		)
 	}
 
-	log.PluginPrint(log.Exec, "\uF00C Created release commit: %s",
-		log.ColorText(log.ColorGreen, commitMsg))
+	log.PluginPrint(log.Exec, "\uF00C Created release commit: %s%s",
+		log.ColorText(log.ColorGreen, commitMsg), Took(start))
 	return nil
 }
 
 // CreateGitTag creates a git tag for the version
-func (tb *ToolBase) CreateGitTag(v *semver.Version) error {
-	tag := fmt.Sprintf("v%s", v)
+func (tb *ToolBase) CreateGitTag(ctx context.Context, v *semver.Version) error {
+	tag := TagName(v)
 
 	log.PluginV(log.Exec, fmt.Sprintf("Creating git tag: %s",
 		log.ColorText(log.ColorGreen, fmt.Sprintf("git tag %s", tag))))
+	if git.SkipInDryRun("git", "tag", tag) {
+		return nil
+	}
 
-	cmd := exec.Command("git", "tag", tag)
+	start := time.Now()
+	cmd := exec.CommandContext(ctx, "git", "tag", tag)
 	output, err := cmd.CombinedOutput()
 	if err != nil {
 		return fmt.Errorf(
@@ -173,45 +301,49 @@ func (tb *ToolBase) CreateGitTag(v *semver.Version) error {
 		)
 	}
 
-	log.PluginPrint(log.Exec, "\uF00C Created git tag: %s",
-		log.ColorText(log.ColorGreen, tag))
+	log.PluginPrint(log.Exec, "\uF00C Created git tag: %s%s",
+		log.ColorText(log.ColorGreen, tag), Took(start))
 	return nil
 }
 
 // PushCommits pushes the release commit to remote
-func (tb *ToolBase) PushCommits() error {
+func (tb *ToolBase) PushCommits(ctx context.Context) error {
 	log.PluginV(log.Exec, fmt.Sprintf("Pushing release commit: %s",
 		log.ColorText(log.ColorGreen, "git push origin HEAD")))
+	if git.SkipInDryRun("git", "push", "origin", "HEAD") {
+		return nil
+	}
 
-	cmd := exec.Command("git", "push", "origin", "HEAD")
-	output, err := cmd.CombinedOutput()
-	if err != nil {
+	start := time.Now()
+	if err := git.PushContext(ctx, "HEAD"); err != nil {
 		return fmt.Errorf(
-			"failed to push release commits: %s: %w", string(output), err,
+			"failed to push release commits: %w", err,
 		)
 	}
 
-	log.PluginPrint(log.Exec, "\uF00C Pushed release commit to %s",
-		log.ColorText(log.ColorGreen, "origin"))
+	log.PluginPrint(log.Exec, "\uF00C Pushed release commit to %s%s",
+		log.ColorText(log.ColorGreen, "origin"), Took(start))
 	return nil
 }
 
 // PushGitTag pushes the git tag to remote
-func (tb *ToolBase) PushGitTag(v *semver.Version) error {
-	tag := fmt.Sprintf("v%s", v)
+func (tb *ToolBase) PushGitTag(ctx context.Context, v *semver.Version) error {
+	tag := TagName(v)
 
 	log.PluginV(log.Exec, fmt.Sprintf("Pushing git tag: %s",
 		log.ColorText(log.ColorGreen, fmt.Sprintf("git push origin %s", tag))))
+	if git.SkipInDryRun("git", "push", "origin", tag) {
+		return nil
+	}
 
-	cmd := exec.Command("git", "push", "origin", tag)
-	output, err := cmd.CombinedOutput()
-	if err != nil {
+	start := time.Now()
+	if err := git.PushContext(ctx, tag); err != nil {
 		return fmt.Errorf(
-			"failed to push git tag: %s: %w", string(output), err,
+			"failed to push git tag: %w", err,
 		)
 	}
 
-	log.PluginPrint(log.Exec, "\uF00C Pushed git tag: %s",
-		log.ColorText(log.ColorGreen, tag))
+	log.PluginPrint(log.Exec, "\uF00C Pushed git tag: %s%s",
+		log.ColorText(log.ColorGreen, tag), Took(start))
 	return nil
 }
@@ -8,10 +8,14 @@ package goreleaser
 */
 
 import (
+	"context"
+	"encoding/json"
+	stderrors "errors"
 	"fmt"
 	"os"
 	"os/exec"
 	"strings"
+	"time"
 
 	"github.com/Masterminds/semver/v3"
 	"github.com/nekoman-hq/neko-cli/plugin/release/pkg/config"
@@ -49,12 +53,16 @@ func (g *GoReleaser) Name() string {
 	return "goreleaser"
 }
 
+func (g *GoReleaser) ConfigFiles() []string {
+	return []string{".goreleaser.yaml", ".goreleaser.yml"}
+}
+
 func (g *GoReleaser) Init(_ *config.NekoConfig) error {
 	if err := g.RequireBinary(g.Name()); err != nil {
 		return err
 	}
 
-	if err := runGoreleaserInit(); err != nil {
+	if err := g.runGoreleaserInit(); err != nil {
 		return err
 	}
 
@@ -65,48 +73,127 @@ func (g *GoReleaser) Init(_ *config.NekoConfig) error {
 	return nil
 }
 
-func (g *GoReleaser) Release(v *semver.Version) error {
-	pre, err := git.Head()
-	if err != nil {
-		return err
-	}
-	g.State.PreHead = pre
+func (g *GoReleaser) Validate() error {
+	return stderrors.Join(
+		g.RequireBinary(g.Name()),
+		g.RequireFile(".goreleaser.yaml", ".goreleaser.yml"),
+	)
+}
 
-	if err = g.CreateReleaseCommit(v); err != nil {
-		return err
+func (g *GoReleaser) Release(ctx context.Context, v *semver.Version) error {
+	return release2.RunSteps(ctx, g.Steps(), v, nil)
+}
+
+func (g *GoReleaser) Steps() []release2.ReleaseStep {
+	return []release2.ReleaseStep{
+		{Name: release2.StepCommit, Run: func(ctx context.Context, v *semver.Version) error {
+			pre, err := git.Head()
+			if err != nil {
+				return err
+			}
+			g.State.PreHead = pre
+
+			if !release2.ReleaseCommitEnabled() {
+				log.PluginPrint(log.Exec, "Skipping release commit, tagging %s directly",
+					log.ColorText(log.ColorCyan, pre))
+				return nil
+			}
+
+			if err = g.CreateReleaseCommit(ctx, v); err != nil {
+				return err
+			}
+
+			head, err := git.Head()
+			if err != nil {
+				return err
+			}
+			g.State.ReleaseCommitHash = head
+			return nil
+		}},
+		{Name: release2.StepTag, Run: func(ctx context.Context, v *semver.Version) error {
+			if err := g.CreateGitTag(ctx, v); err != nil {
+				return err
+			}
+			g.State.TagName = release2.TagName(v)
+			return nil
+		}},
+		{Name: release2.StepPushCommit, Run: func(ctx context.Context, _ *semver.Version) error {
+			if err := g.PushCommits(ctx); err != nil {
+				return err
+			}
+			g.State.PushedCommit = true
+			return nil
+		}},
+		{Name: release2.StepPushTag, Run: func(ctx context.Context, v *semver.Version) error {
+			if err := g.PushGitTag(ctx, v); err != nil {
+				return err
+			}
+			g.State.PushedTag = true
+			return nil
+		}},
+		{Name: "goreleaser-check", Run: func(ctx context.Context, _ *semver.Version) error {
+			return g.runGoReleaserDryRun(ctx)
+		}},
+		{Name: "goreleaser-release", Run: func(ctx context.Context, _ *semver.Version) error {
+			if err := g.runGoReleaserRelease(ctx); err != nil {
+				return err
+			}
+			g.State.RanGoRelease = true
+			return nil
+		}},
 	}
+}
 
-	head, err := git.Head()
+// Snapshot only builds the artifacts via goreleaser release --snapshot --clean.
+// No commit, tag, push or GitHub release is created.
+func (g *GoReleaser) Snapshot(_ *semver.Version) ([]release2.Artifact, error) {
+	log.PluginV(log.Exec, fmt.Sprintf("Running GoReleaser snapshot: %s",
+		log.ColorText(log.ColorGreen, "goreleaser release --snapshot --clean")))
+
+	start := time.Now()
+	cmd := exec.Command("goreleaser", "release", "--snapshot", "--clean")
+	output, err := cmd.CombinedOutput()
 	if err != nil {
-		return err
+		return nil, fmt.Errorf(
+			"GoReleaser snapshot failed: %s: %w", string(output), err,
+		)
 	}
-	g.State.ReleaseCommitHash = head
 
-	if err := g.CreateGitTag(v); err != nil {
-		return err
-	}
-	g.State.TagName = fmt.Sprintf("v%s", v.String())
+	log.PluginPrint(log.Exec, "\uF00C GoReleaser snapshot %s%s",
+		log.ColorText(log.ColorGreen, "successful"), release2.Took(start))
 
-	if err := g.PushCommits(); err != nil {
-		return err
-	}
-	g.State.PushedCommit = true
+	return loadArtifacts(artifactsFile)
+}
 
-	if err := g.PushGitTag(v); err != nil {
-		return err
-	}
-	g.State.PushedTag = true
+// artifactsFile is written by goreleaser and lists all built artifacts
+const artifactsFile = "dist/artifacts.json"
 
-	if err := g.runGoReleaserDryRun(); err != nil {
-		return err
+// loadArtifacts reads the artifacts goreleaser produced
+func loadArtifacts(path string) ([]release2.Artifact, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
 	}
 
-	if err := g.runGoReleaserRelease(); err != nil {
-		return err
+	var artifacts []release2.Artifact
+	if err := json.Unmarshal(data, &artifacts); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
 	}
-	g.State.RanGoRelease = true
+	return artifacts, nil
+}
 
-	return nil
+func (g *GoReleaser) Plan(v *semver.Version) release2.Plan {
+	tag := release2.TagName(v)
+	return release2.Plan{
+		Files: []string{},
+		Commands: append(release2.PlannedCommitCommands(v),
+			fmt.Sprintf("git tag %s", tag),
+			"git push origin HEAD",
+			fmt.Sprintf("git push origin %s", tag),
+			"goreleaser release --snapshot --clean",
+			strings.TrimSpace("goreleaser "+strings.Join(releaseArgs(), " ")),
+		),
+	}
 }
 
 func (g *GoReleaser) RevertRelease() error {
@@ -121,7 +208,8 @@ func (g *GoReleaser) RevertRelease() error {
 	})
 }
 
-func runGoreleaserInit() error {
+// runGoreleaserInit writes .goreleaser.yaml, goreleaser init also adds dist/ to .gitignore
+func (g *GoReleaser) runGoreleaserInit() error {
 	if _, err := os.Stat(".goreleaser.yaml"); err == nil {
 		log.PluginPrint(
 			log.Init,
@@ -149,6 +237,7 @@ func runGoreleaserInit() error {
 			"failed to initialize goreleaser: %s: %w", string(output), err,
 		)
 	}
+	g.RecordInitFiles(".goreleaser.yaml", ".gitignore")
 
 	log.PluginPrint(
 		log.Init,
@@ -184,11 +273,12 @@ func runGoreleaserCheck() error {
 }
 
 // runGoReleaserDryRun executes goreleaser in dry-run mode
-func (g *GoReleaser) runGoReleaserDryRun() error {
+func (g *GoReleaser) runGoReleaserDryRun(ctx context.Context) error {
 	log.PluginV(log.Exec, fmt.Sprintf("Running GoReleaser dry run: %s",
 		log.ColorText(log.ColorGreen, "goreleaser release --snapshot --clean")))
 
-	cmd := exec.Command("goreleaser", "release", "--snapshot", "--clean")
+	start := time.Now()
+	cmd := exec.CommandContext(ctx, "goreleaser", "release", "--snapshot", "--clean")
 	output, err := cmd.CombinedOutput()
 	if err != nil {
 		errors.WriteWarning(
@@ -199,17 +289,20 @@ func (g *GoReleaser) runGoReleaserDryRun() error {
 		return nil
 	}
 
-	log.PluginPrint(log.Exec, "\uF00C GoReleaser dry run %s",
-		log.ColorText(log.ColorGreen, "successful"))
+	log.PluginPrint(log.Exec, "\uF00C GoReleaser dry run %s%s",
+		log.ColorText(log.ColorGreen, "successful"), release2.Took(start))
 	return nil
 }
 
 // runGoReleaserRelease executes the full goreleaser release
-func (g *GoReleaser) runGoReleaserRelease() error {
+func (g *GoReleaser) runGoReleaserRelease(ctx context.Context) error {
+	args := releaseArgs()
+
 	log.PluginV(log.Exec, fmt.Sprintf("Running GoReleaser release: %s",
-		log.ColorText(log.ColorGreen, "goreleaser release --clean")))
+		log.ColorText(log.ColorGreen, "goreleaser "+strings.Join(args, " "))))
 
-	cmd := exec.Command("goreleaser", "release", "--clean")
+	start := time.Now()
+	cmd := exec.CommandContext(ctx, "goreleaser", args...)
 	output, err := cmd.CombinedOutput()
 	if err != nil {
 		return fmt.Errorf(
@@ -217,12 +310,18 @@ func (g *GoReleaser) runGoReleaserRelease() error {
 		)
 	}
 
-	log.PluginPrint(log.Exec, "\uF00C GoReleaser release %s",
-		log.ColorText(log.ColorGreen, "successful"),
+	log.PluginPrint(log.Exec, "\uF00C GoReleaser release %s%s",
+		log.ColorText(log.ColorGreen, "successful"), release2.Took(start),
 	)
 	return nil
 }
 
+// releaseArgs builds the goreleaser release arguments from goreleaser-skip and release-args
+func releaseArgs() []string {
+	args := append([]string{"release", "--clean"}, release2.GoReleaserSkipArgs()...)
+	return append(args, release2.ReleaseArgs()...)
+}
+
 func init() {
 	release2.Register(&GoReleaser{})
 }
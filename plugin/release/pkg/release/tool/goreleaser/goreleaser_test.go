@@ -0,0 +1,187 @@
+package goreleaser
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/nekoman-hq/neko-cli/plugin/release/internal/gittest"
+	"github.com/nekoman-hq/neko-cli/plugin/release/internal/testbin"
+	release2 "github.com/nekoman-hq/neko-cli/plugin/release/pkg/release"
+)
+
+const artifactsJSON = `[
+  {"name": "app_Linux_x86_64.tar.gz", "path": "dist/app_Linux_x86_64.tar.gz", "type": "Archive", "goos": "linux"},
+  {"name": "checksums.txt", "path": "dist/checksums.txt", "type": "Checksum"}
+]`
+
+func TestSnapshot(t *testing.T) {
+	gittest.NewRepo(t)
+	head := gittest.Commit(t, "feat: initial")
+	calls := testbin.Fake(t, "goreleaser", "mkdir -p dist && cat > dist/artifacts.json <<'EOF'\n"+artifactsJSON+"\nEOF")
+
+	artifacts, err := (&GoReleaser{}).Snapshot(semver.MustParse("1.2.0"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []release2.Artifact{
+		{Name: "app_Linux_x86_64.tar.gz", Path: "dist/app_Linux_x86_64.tar.gz", Type: "Archive"},
+		{Name: "checksums.txt", Path: "dist/checksums.txt", Type: "Checksum"},
+	}
+	if len(artifacts) != len(want) || artifacts[0] != want[0] || artifacts[1] != want[1] {
+		t.Errorf("artifacts = %+v, want %+v", artifacts, want)
+	}
+	if args := calls.Args(); len(args) != 1 || args[0] != "release --snapshot --clean" {
+		t.Errorf("goreleaser called with %q, want a single snapshot release", args)
+	}
+	if got := gittest.Run(t, "rev-parse", "HEAD"); got != head {
+		t.Error("the snapshot created a commit")
+	}
+	if tags := gittest.Run(t, "tag"); tags != "" {
+		t.Errorf("the snapshot created tags %q", tags)
+	}
+}
+
+func TestSnapshotFailure(t *testing.T) {
+	tests := []struct {
+		name   string
+		script string
+	}{
+		{"goreleaser fails", "echo 'git is in a dirty state'; exit 1"},
+		{"no artifacts", "mkdir -p dist"},
+		{"corrupt artifacts", "mkdir -p dist && echo '{' > dist/artifacts.json"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gittest.NewRepo(t)
+			testbin.Fake(t, "goreleaser", tt.script)
+
+			if _, err := (&GoReleaser{}).Snapshot(semver.MustParse("1.2.0")); err == nil {
+				t.Error("Snapshot succeeded")
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		binary  bool
+		config  string
+		wantErr bool
+	}{
+		{"ready", true, ".goreleaser.yaml", false},
+		{"yml config", true, ".goreleaser.yml", false},
+		{"missing config", true, "", true},
+		{"missing binary", false, ".goreleaser.yaml", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Chdir(t.TempDir())
+			t.Setenv("PATH", t.TempDir())
+			if tt.binary {
+				testbin.Fake(t, "goreleaser", "")
+			}
+			if tt.config != "" {
+				gittest.WriteFile(t, tt.config, "version: 2\n")
+			}
+
+			if err := (&GoReleaser{}).Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate = %v, want error %t", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// Without a release commit the tag goes on the current HEAD and the plan leaves out git commit
+func TestStepsWithoutReleaseCommit(t *testing.T) {
+	tests := []struct {
+		name       string
+		noCommit   bool
+		wantCommit bool
+	}{
+		{"release commit", false, true},
+		{"tag HEAD", true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gittest.NewRepo(t)
+			head := gittest.Commit(t, "feat: initial")
+			release2.SetCommitOptions(release2.CommitOptions{NoCommit: tt.noCommit})
+			t.Cleanup(func() { release2.SetCommitOptions(release2.CommitOptions{}) })
+
+			v := semver.MustParse("1.2.0")
+			g := &GoReleaser{}
+			for _, step := range g.Steps() {
+				if step.Name != release2.StepCommit && step.Name != release2.StepTag {
+					continue
+				}
+				if err := step.Run(context.Background(), v); err != nil {
+					t.Fatalf("%s: %v", step.Name, err)
+				}
+			}
+
+			tagged := gittest.Run(t, "rev-parse", "v1.2.0^{commit}")
+			if committed := tagged != head; committed != tt.wantCommit {
+				t.Errorf("release commit created = %t, want %t", committed, tt.wantCommit)
+			}
+			if g.State.PreHead == "" || !strings.HasPrefix(head, g.State.PreHead) {
+				t.Errorf("PreHead = %q, want %s", g.State.PreHead, head)
+			}
+			if (g.State.ReleaseCommitHash != "") != tt.wantCommit {
+				t.Errorf("ReleaseCommitHash = %q, want a hash only with a release commit", g.State.ReleaseCommitHash)
+			}
+
+			planned := slices.ContainsFunc(g.Plan(v).Commands, func(c string) bool {
+				return strings.HasPrefix(c, "git commit")
+			})
+			if planned != tt.wantCommit {
+				t.Errorf("plan contains git commit = %t, want %t", planned, tt.wantCommit)
+			}
+		})
+	}
+}
+
+func TestReleaseArgs(t *testing.T) {
+	tests := []struct {
+		name        string
+		skip        []string
+		releaseArgs []string
+		want        string
+	}{
+		{"defaults", nil, nil, "release --clean"},
+		{"skip", []string{"--skip=announce,docker"}, nil, "release --clean --skip=announce,docker"},
+		{"skip before release args", []string{"--skip=announce"}, []string{"--parallelism=2"}, "release --clean --skip=announce --parallelism=2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gittest.NewRepo(t)
+			release2.SetGoReleaserSkipArgs(tt.skip)
+			release2.SetReleaseArgs(tt.releaseArgs)
+			t.Cleanup(func() {
+				release2.SetGoReleaserSkipArgs(nil)
+				release2.SetReleaseArgs(nil)
+			})
+			calls := testbin.Fake(t, "goreleaser", "exit 0")
+
+			if err := (&GoReleaser{}).runGoReleaserRelease(context.Background()); err != nil {
+				t.Fatal(err)
+			}
+			if args := calls.Args(); len(args) != 1 || args[0] != tt.want {
+				t.Errorf("goreleaser called with %q, want %q", args, tt.want)
+			}
+
+			plan := (&GoReleaser{}).Plan(semver.MustParse("1.2.0"))
+			if !slices.Contains(plan.Commands, "goreleaser "+tt.want) {
+				t.Errorf("plan commands = %q, want goreleaser %s", plan.Commands, tt.want)
+			}
+		})
+	}
+}
@@ -7,9 +7,11 @@ package jreleaser
 */
 
 import (
+	stderrors "errors"
 	"fmt"
 	"os"
 
+	release2 "github.com/nekoman-hq/neko-cli/plugin/release/pkg/release"
 	"gopkg.in/yaml.v3"
 )
 
@@ -110,7 +112,28 @@ func LoadConfig() (*Config, error) {
 	return &cfg, nil
 }
 
+// knownPlaceholders are the jreleaser template variables accepted in tag and release names
+var knownPlaceholders = []string{
+	"projectName", "projectVersion", "projectEffectiveVersion", "projectVersionNumber",
+	"projectVersionMajor", "projectVersionMinor", "projectVersionPatch", "projectVersionTag",
+	"projectSnapshotLabel", "tagName", "previousTagName", "releaseName", "milestoneName",
+	"repoOwner", "repoName", "repoBranch", "commitShortHash", "commitFullHash", "Env.",
+}
+
+// ValidateTemplates checks the placeholders of the tag and release name
+func (c *Config) ValidateTemplates() error {
+	gh := c.Release.Github
+	return stderrors.Join(
+		release2.ValidateTemplate("release.github.tagName", gh.TagName, release2.MustacheSyntax, knownPlaceholders),
+		release2.ValidateTemplate("release.github.releaseName", gh.ReleaseName, release2.MustacheSyntax, knownPlaceholders),
+	)
+}
+
 func SaveConfig(cfg *Config) (err error) {
+	if err := cfg.ValidateTemplates(); err != nil {
+		return fmt.Errorf("invalid jreleaser.yml: %w", err)
+	}
+
 	file, err := os.Create("jreleaser.yml")
 	if err != nil {
 		return fmt.Errorf("create jreleaser.yml: %w", err)
@@ -0,0 +1,50 @@
+package jreleaser
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestConfigValidateTemplates(t *testing.T) {
+	tests := []struct {
+		name        string
+		tagName     string
+		releaseName string
+		wantErr     []string
+	}{
+		{"defaults", "v{{projectVersion}}", "Release {{tagName}}", nil},
+		{"env", "{{Env.TAG_PREFIX}}{{projectVersion}}", "{{projectName}} {{projectVersion}}", nil},
+		{"empty", "", "", nil},
+		{"bad tag", "v{{version}}", "Release {{tagName}}", []string{"release.github.tagName"}},
+		{"both bad", "v{{version}}", "{{ProjectName}}", []string{"release.github.tagName", `did you mean "projectName"?`}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{Release: Release{Github: GithubRelease{TagName: tt.tagName, ReleaseName: tt.releaseName}}}
+			err := cfg.ValidateTemplates()
+			if (err != nil) != (len(tt.wantErr) > 0) {
+				t.Fatalf("ValidateTemplates = %v, want errors for %v", err, tt.wantErr)
+			}
+			for _, want := range tt.wantErr {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("ValidateTemplates = %v, want it to contain %q", err, want)
+				}
+			}
+		})
+	}
+}
+
+// An invalid template must not leave a config behind
+func TestSaveConfigRejectsInvalidTemplate(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	err := SaveConfig(&Config{Release: Release{Github: GithubRelease{TagName: "v{{version}}"}}})
+	if err == nil {
+		t.Fatal("SaveConfig accepted an unknown placeholder")
+	}
+	if _, statErr := os.Stat("jreleaser.yml"); !os.IsNotExist(statErr) {
+		t.Errorf("SaveConfig wrote jreleaser.yml despite %v", err)
+	}
+}
@@ -8,6 +8,8 @@ package jreleaser
 */
 
 import (
+	"context"
+	stderrors "errors"
 	"fmt"
 	"os"
 	"os/exec"
@@ -41,6 +43,10 @@ func (j *JReleaser) Name() string {
 	return "jreleaser"
 }
 
+func (j *JReleaser) ConfigFiles() []string {
+	return []string{"jreleaser.yml"}
+}
+
 func (j *JReleaser) Init(cfg *config2.NekoConfig) error {
 	log.PluginV(log.Init, fmt.Sprintf("Initializing %s for project %s@%s",
 		log.ColorText(log.ColorGreen, j.Name()),
@@ -62,44 +68,84 @@ func (j *JReleaser) Init(cfg *config2.NekoConfig) error {
 	return nil
 }
 
-func (j *JReleaser) Release(v *semver.Version) error {
-	pre, err := git.Head()
-
-	if err != nil {
-		return err
-	}
-	j.State.PreHead = pre
-
-	if err = j.syncJReleaser(v); err != nil {
-		return err
-	}
-
-	if err = j.CreateReleaseCommit(v); err != nil {
-		return err
-	}
+func (j *JReleaser) Validate() error {
+	return stderrors.Join(
+		j.RequireBinary(j.Name()),
+		j.RequireFile("jreleaser.yml"),
+	)
+}
 
-	head, err := git.Head()
-	if err != nil {
-		return err
-	}
-	j.State.ReleaseCommitHash = head
+func (j *JReleaser) Release(ctx context.Context, v *semver.Version) error {
+	return release2.RunSteps(ctx, j.Steps(), v, nil)
+}
 
-	if err = j.PushCommits(); err != nil {
-		return err
+func (j *JReleaser) Steps() []release2.ReleaseStep {
+	return []release2.ReleaseStep{
+		{Name: release2.StepCommit, Run: func(ctx context.Context, v *semver.Version) error {
+			pre, err := git.Head()
+			if err != nil {
+				return err
+			}
+			j.State.PreHead = pre
+
+			if err = j.syncJReleaser(v); err != nil {
+				return err
+			}
+
+			if !release2.ReleaseCommitEnabled() {
+				log.PluginPrint(log.Exec, "Skipping release commit, tagging %s directly",
+					log.ColorText(log.ColorCyan, pre))
+				return nil
+			}
+
+			if err = j.CreateReleaseCommit(ctx, v); err != nil {
+				return err
+			}
+
+			head, err := git.Head()
+			if err != nil {
+				return err
+			}
+			j.State.ReleaseCommitHash = head
+			return nil
+		}},
+		{Name: release2.StepPushCommit, Run: func(ctx context.Context, _ *semver.Version) error {
+			if err := j.PushCommits(ctx); err != nil {
+				return err
+			}
+			j.State.PushedCommit = true
+			return nil
+		}},
+		{Name: "jreleaser-check", Run: func(ctx context.Context, _ *semver.Version) error {
+			return j.runJReleaserDryRun(ctx)
+		}},
+		{Name: "jreleaser-release", Run: func(ctx context.Context, v *semver.Version) error {
+			if err := j.runJReleaserRelease(ctx); err != nil {
+				return err
+			}
+			j.State.TagName = release2.TagName(v)
+			j.State.RanJRelease = true
+			return nil
+		}},
 	}
-	j.State.PushedCommit = true
+}
 
-	if err = j.runJReleaserDryRun(); err != nil {
-		return err
+func (j *JReleaser) Plan(v *semver.Version) release2.Plan {
+	files := []string{"jreleaser.yml"}
+	if jcfg, err := LoadConfig(); err == nil {
+		if app := jcfg.Release.Github.Changelog.Append; app != nil && app.Enabled && app.Target != "" {
+			files = append(files, app.Target)
+		}
 	}
 
-	if err = j.runJReleaserRelease(); err != nil {
-		return err
+	return release2.Plan{
+		Files: files,
+		Commands: append(release2.PlannedCommitCommands(v),
+			"git push origin HEAD",
+			"jreleaser full-release --dry-run",
+			strings.TrimSpace("jreleaser full-release "+strings.Join(release2.ReleaseArgs(), " ")),
+		),
 	}
-	j.State.TagName = fmt.Sprintf("v%s", v.String())
-	j.State.RanJRelease = true
-
-	return nil
 }
 
 func (j *JReleaser) RevertRelease() error {
@@ -149,7 +195,7 @@ func (j *JReleaser) runJReleaserInit(cfg *config2.NekoConfig) error {
 				Overwrite:   false,
 				Owner:       cfg.ProjectOwner,
 				Name:        cfg.ProjectName,
-				TagName:     "v{{projectVersion}}",
+				TagName:     release2.ResolveTagPrefix(cfg, git.GetTags()) + "{{projectVersion}}",
 				ReleaseName: fmt.Sprintf("%s@{{projectVersion}}", cfg.ProjectName),
 				Changelog: Changelog{
 					Enabled:          true,
@@ -163,7 +209,7 @@ func (j *JReleaser) runJReleaserInit(cfg *config2.NekoConfig) error {
 					Append: &ChangelogAppend{
 						Enabled: true,
 						Title:   "## [{{tagName}}]",
-						Target:  "CHANGELOG.md",
+						Target:  cfg.Changelog(),
 					},
 					IncludeLabels: &[]string{
 						"feature", "feat", "fix", "refactor", "improvement", "chore", "test", "docs", "hotfix",
@@ -199,6 +245,7 @@ func (j *JReleaser) runJReleaserInit(cfg *config2.NekoConfig) error {
 			"configuration write failed: %w", err,
 		)
 	}
+	j.RecordInitFiles("jreleaser.yml")
 	log.PluginPrint(log.Init, "\uF00C JReleaser configuration generated for %s", log.ColorText(log.ColorCyan, cfg.ProjectName))
 
 	return nil
@@ -210,7 +257,7 @@ func (j *JReleaser) runJReleaserCheck() error {
 		log.ColorText(log.ColorGreen, "jreleaser config"),
 	)
 
-	output, err := executeJReleaserCommand("config")
+	output, err := executeJReleaserCommand(context.Background(), "config")
 	if err != nil {
 		return fmt.Errorf(
 			"JReleaser configuration check failed: %s: %w", string(output), err,
@@ -251,6 +298,7 @@ func (j *JReleaser) syncJReleaser(v *semver.Version) error {
 			"configuration write failed: %w", err,
 		)
 	}
+	j.RecordInitFiles("jreleaser.yml")
 
 	log.PluginPrint(log.Exec,
 		"\uF00C JReleaser version updated to %s",
@@ -261,7 +309,7 @@ func (j *JReleaser) syncJReleaser(v *semver.Version) error {
 }
 
 // runJReleaserDryRun executes JReleaser in dry-run mode
-func (j *JReleaser) runJReleaserDryRun() error {
+func (j *JReleaser) runJReleaserDryRun(ctx context.Context) error {
 	action := "full-release --dry-run"
 
 	log.PluginV(
@@ -272,7 +320,8 @@ func (j *JReleaser) runJReleaserDryRun() error {
 		),
 	)
 
-	output, err := executeJReleaserCommand(action)
+	start := time.Now()
+	output, err := executeJReleaserCommand(ctx, action)
 	if err != nil {
 		errors.WriteWarning(
 			"JReleaser dry run failed",
@@ -287,15 +336,15 @@ func (j *JReleaser) runJReleaserDryRun() error {
 
 	log.PluginPrint(
 		log.Exec,
-		"\uF00C JReleaser dry run %s",
-		log.ColorText(log.ColorGreen, "successful"),
+		"\uF00C JReleaser dry run %s%s",
+		log.ColorText(log.ColorGreen, "successful"), release2.Took(start),
 	)
 	return nil
 }
 
 // runJReleaserRelease executes the full jreleaser release
-func (j *JReleaser) runJReleaserRelease() error {
-	action := "full-release"
+func (j *JReleaser) runJReleaserRelease(ctx context.Context) error {
+	action := strings.TrimSpace("full-release " + strings.Join(release2.ReleaseArgs(), " "))
 
 	log.PluginV(
 		log.Exec,
@@ -305,7 +354,8 @@ func (j *JReleaser) runJReleaserRelease() error {
 		),
 	)
 
-	output, err := executeJReleaserCommand(action)
+	start := time.Now()
+	output, err := executeJReleaserCommand(ctx, action)
 	if err != nil {
 		return fmt.Errorf(
 			"JReleaser release failed: %s: %w", string(output), err,
@@ -314,13 +364,13 @@ func (j *JReleaser) runJReleaserRelease() error {
 
 	log.PluginPrint(
 		log.Exec,
-		"\uF00C JReleaser release %s",
-		log.ColorText(log.ColorGreen, "successful"),
+		"\uF00C JReleaser release %s%s",
+		log.ColorText(log.ColorGreen, "successful"), release2.Took(start),
 	)
 	return nil
 }
 
-func executeJReleaserCommand(action string) ([]byte, error) {
+func executeJReleaserCommand(ctx context.Context, action string) ([]byte, error) {
 	pat, err := config.GetPAT()
 	if err != nil {
 		return nil, err
@@ -329,7 +379,7 @@ func executeJReleaserCommand(action string) ([]byte, error) {
 	maskedPat := strings.Repeat("*", 5)
 	log.PluginV(log.Init, fmt.Sprintf("Executing command: JRELEASER_GITHUB_TOKEN=%s jreleaser %s", maskedPat, action))
 
-	cmd := exec.Command("jreleaser", action)
+	cmd := exec.CommandContext(ctx, "jreleaser", strings.Fields(action)...)
 	cmd.Env = append(os.Environ(), "JRELEASER_GITHUB_TOKEN="+pat)
 
 	output, err := cmd.CombinedOutput()
@@ -0,0 +1,72 @@
+package jreleaser
+
+import (
+	"testing"
+
+	"github.com/nekoman-hq/neko-cli/plugin/release/internal/gittest"
+	"github.com/nekoman-hq/neko-cli/plugin/release/internal/testbin"
+	config2 "github.com/nekoman-hq/neko-cli/plugin/release/pkg/config"
+)
+
+func TestInitChangelogTarget(t *testing.T) {
+	tests := []struct {
+		changelogFile string
+		want          string
+	}{
+		{"", config2.DefaultChangelogFile},
+		{"docs/CHANGES.md", "docs/CHANGES.md"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			gittest.NewRepo(t)
+			cfg := &config2.NekoConfig{
+				ProjectName:   "app",
+				ProjectOwner:  "nekoman-hq",
+				Version:       "1.0.0",
+				ChangelogFile: tt.changelogFile,
+			}
+
+			if err := (&JReleaser{}).runJReleaserInit(cfg); err != nil {
+				t.Fatal(err)
+			}
+			jcfg, err := LoadConfig()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := jcfg.Release.Github.Changelog.Append.Target; got != tt.want {
+				t.Errorf("changelog target = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		binary  bool
+		config  bool
+		wantErr bool
+	}{
+		{"ready", true, true, false},
+		{"missing config", true, false, true},
+		{"missing binary", false, true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Chdir(t.TempDir())
+			t.Setenv("PATH", t.TempDir())
+			if tt.binary {
+				testbin.Fake(t, "jreleaser", "")
+			}
+			if tt.config {
+				gittest.WriteFile(t, "jreleaser.yml", "project: {}\n")
+			}
+
+			if err := (&JReleaser{}).Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate = %v, want error %t", err, tt.wantErr)
+			}
+		})
+	}
+}
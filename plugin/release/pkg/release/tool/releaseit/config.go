@@ -8,8 +8,12 @@ package releaseit
 
 import (
 	"encoding/json"
+	stderrors "errors"
 	"fmt"
 	"os"
+	"strings"
+
+	release2 "github.com/nekoman-hq/neko-cli/plugin/release/pkg/release"
 )
 
 type Config struct {
@@ -27,6 +31,7 @@ type GithubRelease struct {
 type GitConfig struct {
 	Changelog                                 string `json:"changelog,omitempty"`
 	CommitMessage                             string `json:"commitMessage,omitempty"`
+	TagName                                   string `json:"tagName,omitempty"`
 	Commit, Tag, Push, RequireCleanWorkingDir bool
 }
 
@@ -48,7 +53,29 @@ func LoadConfig() (*Config, error) {
 	return &cfg, nil
 }
 
+// knownPlaceholders are the release-it template variables accepted in tag and release names
+var knownPlaceholders = []string{
+	"version", "latestVersion", "latestTag", "tagName", "changelog", "name",
+	"branchName", "releaseUrl", "repo.",
+}
+
+// ValidateTemplates checks the placeholders of the tag and release name
+func (c *Config) ValidateTemplates() error {
+	var errs []error
+	if c.Github != nil {
+		errs = append(errs, release2.ValidateTemplate("github.releaseName", c.Github.ReleaseName, release2.DollarSyntax, knownPlaceholders))
+	}
+	if c.Git != nil {
+		errs = append(errs, release2.ValidateTemplate("git.tagName", c.Git.TagName, release2.DollarSyntax, knownPlaceholders))
+	}
+	return stderrors.Join(errs...)
+}
+
 func SaveConfig(cfg *Config) (err error) {
+	if err := cfg.ValidateTemplates(); err != nil {
+		return fmt.Errorf("invalid .release-it.json: %w", err)
+	}
+
 	file, err := os.Create(".release-it.json")
 	if err != nil {
 		return fmt.Errorf("create .release-it.json: %w", err)
@@ -69,7 +96,7 @@ func SaveConfig(cfg *Config) (err error) {
 	return nil
 }
 
-func InitDefaultConfig(projectName string) (*Config, error) {
+func InitDefaultConfig(projectName, changelogFile, tagPrefix string) (*Config, error) {
 	return &Config{
 		Schema: "https://unpkg.com/release-it/schema/release-it.json",
 		Github: &GithubRelease{
@@ -83,9 +110,19 @@ func InitDefaultConfig(projectName string) (*Config, error) {
 			RequireCleanWorkingDir: true,
 			Changelog:              "npx auto-changelog --stdout --commit-limit false -u --template https://raw.githubusercontent.com/release-it/release-it/main/templates/changelog-compact.hbs",
 			CommitMessage:          "chore(release): ${version}",
+			TagName:                tagPrefix + "${version}",
 		},
 		Hooks: &HooksConfig{
-			AfterBump: "npx auto-changelog -p",
+			AfterBump: "npx auto-changelog -p --output " + shellQuote(changelogFile),
 		},
 	}, nil
 }
+
+// shellQuote quotes s for the shell release-it runs its hooks in.
+// Plain paths like CHANGELOG.md or docs/CHANGES.md stay readable in the config.
+func shellQuote(s string) string {
+	if s != "" && strings.Trim(s, "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789._-/+@%:,") == "" {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,84 @@
+package releaseit
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+func TestInitDefaultConfigChangelogTarget(t *testing.T) {
+	tests := []struct {
+		file string
+		want string
+	}{
+		{"CHANGELOG.md", "npx auto-changelog -p --output CHANGELOG.md"},
+		{"docs/CHANGES.md", "npx auto-changelog -p --output docs/CHANGES.md"},
+		{"release notes.md", "npx auto-changelog -p --output 'release notes.md'"},
+		{"it's.md", `npx auto-changelog -p --output 'it'\''s.md'`},
+		{"$(touch pwned).md", "npx auto-changelog -p --output '$(touch pwned).md'"},
+	}
+
+	for _, tt := range tests {
+		cfg, err := InitDefaultConfig("app", tt.file, "v")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := cfg.Hooks.AfterBump; got != tt.want {
+			t.Errorf("after:bump hook for %q = %q, want %q", tt.file, got, tt.want)
+		}
+	}
+}
+
+// The quoted path must reach auto-changelog unchanged through the shell running the hook
+func TestShellQuoteRoundTrip(t *testing.T) {
+	for _, s := range []string{"CHANGELOG.md", "release notes.md", "it's.md", "$(touch pwned).md", "`id`.md", `a\b"c.md`, ""} {
+		out, err := exec.Command("sh", "-c", "printf %s "+shellQuote(s)).Output()
+		if err != nil {
+			t.Fatalf("sh -c for %q: %v", s, err)
+		}
+		if string(out) != s {
+			t.Errorf("the shell turned %q into %q", s, out)
+		}
+	}
+}
+
+func TestConfigValidateTemplates(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     Config
+		wantErr []string
+	}{
+		{"defaults", Config{Github: &GithubRelease{ReleaseName: "Release ${version}"}, Git: &GitConfig{TagName: "v${version}"}}, nil},
+		{"no sections", Config{}, nil},
+		{"bad tag", Config{Git: &GitConfig{TagName: "v${ver}"}}, []string{"git.tagName"}},
+		{"both bad", Config{Github: &GithubRelease{ReleaseName: "${Version}"}, Git: &GitConfig{TagName: "v${ver}"}}, []string{"github.releaseName", "git.tagName"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.ValidateTemplates()
+			if (err != nil) != (len(tt.wantErr) > 0) {
+				t.Fatalf("ValidateTemplates = %v, want errors for %v", err, tt.wantErr)
+			}
+			for _, field := range tt.wantErr {
+				if !strings.Contains(err.Error(), field) {
+					t.Errorf("ValidateTemplates = %v, want an error for %s", err, field)
+				}
+			}
+		})
+	}
+}
+
+// An invalid template must not leave a config behind
+func TestSaveConfigRejectsInvalidTemplate(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	err := SaveConfig(&Config{Git: &GitConfig{TagName: "v${ver}"}})
+	if err == nil {
+		t.Fatal("SaveConfig accepted an unknown placeholder")
+	}
+	if _, statErr := os.Stat(".release-it.json"); !os.IsNotExist(statErr) {
+		t.Errorf("SaveConfig wrote .release-it.json despite %v", err)
+	}
+}
@@ -2,9 +2,13 @@
 package releaseit
 
 import (
+	"context"
+	stderrors "errors"
 	"fmt"
 	"os"
 	"os/exec"
+	"strings"
+	"time"
 
 	"github.com/Masterminds/semver/v3"
 	"github.com/nekoman-hq/neko-cli/plugin/release/pkg/config"
@@ -36,6 +40,11 @@ func (r *ReleaseIt) Name() string {
 	return "release-it"
 }
 
+// ConfigFiles does not include package.json, it belongs to the project
+func (r *ReleaseIt) ConfigFiles() []string {
+	return []string{".release-it.json"}
+}
+
 func (r *ReleaseIt) ensurePackageManager() {
 	if r.packageManager == "" {
 		r.packageManager = r.detectPackageManager()
@@ -97,7 +106,27 @@ func (r *ReleaseIt) Init(cfg *config.NekoConfig) error {
 	return nil
 }
 
-func (r *ReleaseIt) Release(v *semver.Version) error {
+func (r *ReleaseIt) Validate() error {
+	r.ensurePackageManager()
+
+	return stderrors.Join(
+		r.RequireBinary(r.packageManager),
+		r.RequireFile("package.json"),
+	)
+}
+
+func (r *ReleaseIt) Release(ctx context.Context, v *semver.Version) error {
+	return release2.RunSteps(ctx, r.Steps(), v, nil)
+}
+
+// Steps is a single step since release-it commits, tags, pushes and publishes in one run
+func (r *ReleaseIt) Steps() []release2.ReleaseStep {
+	return []release2.ReleaseStep{
+		{Name: "release-it", Run: r.release},
+	}
+}
+
+func (r *ReleaseIt) release(ctx context.Context, v *semver.Version) error {
 	r.ensurePackageManager()
 
 	pre, err := git.Head()
@@ -106,26 +135,58 @@ func (r *ReleaseIt) Release(v *semver.Version) error {
 	}
 	r.State.PreHead = pre
 
-	if err = r.runReleaseItRelease(v); err != nil {
-		return err
-	}
-
-	head, err := git.Head()
-	if err != nil {
+	start := time.Now()
+	if _, err = r.runReleaseItRelease(ctx, v, false); err != nil {
 		return err
 	}
-	r.State.ReleaseCommitHash = head
-
-	r.State.TagName = fmt.Sprintf("v%s", v.String())
+	log.PluginPrint(log.Exec, "\uF00C release-it release %s%s",
+		log.ColorText(log.ColorGreen, "successful"), release2.Took(start))
 
-	r.State.PushedCommit = true
+	r.State.TagName = release2.TagName(v)
 	r.State.PushedTag = true
 
+	// Without a release commit the tag is on the existing HEAD, which must not be reverted
+	if release2.ReleaseCommitEnabled() {
+		head, err := git.Head()
+		if err != nil {
+			return err
+		}
+		r.State.ReleaseCommitHash = head
+		r.State.PushedCommit = true
+	}
+
 	r.State.CreatedGitHubRelease = true
 
 	return nil
 }
 
+// DryRun runs release-it with its native --dry-run flag and returns its output.
+// No release state is tracked since nothing is committed, tagged or pushed.
+func (r *ReleaseIt) DryRun(v *semver.Version) (string, error) {
+	r.ensurePackageManager()
+
+	start := time.Now()
+	output, err := r.runReleaseItRelease(context.Background(), v, true)
+	if err != nil {
+		return "", err
+	}
+
+	log.PluginPrint(log.Exec, "\uF00C release-it dry run %s%s",
+		log.ColorText(log.ColorGreen, "successful"), release2.Took(start))
+	return strings.TrimSpace(string(output)), nil
+}
+
+func (r *ReleaseIt) Plan(v *semver.Version) release2.Plan {
+	r.ensurePackageManager()
+
+	return release2.Plan{
+		Files: []string{"package.json"},
+		Commands: []string{
+			fmt.Sprintf("%s %s", r.getRunCommand(), strings.Join(releaseItArgs(v), " ")),
+		},
+	}
+}
+
 func (r *ReleaseIt) RevertRelease() error {
 	return r.RevertGitRelease(release2.GitReleaseState{
 		PreHead:              r.State.PreHead,
@@ -183,14 +244,20 @@ func (r *ReleaseIt) runReleaseItInit(cfg *config.NekoConfig) error {
 			"failed to initialize release-it: %s: %w", string(output), err,
 		)
 	}
+	if r.packageManager == "bun" {
+		r.RecordInitFiles("package.json", "bun.lock")
+	} else {
+		r.RecordInitFiles("package.json", "package-lock.json")
+	}
 
-	rcfg, err := InitDefaultConfig(cfg.ProjectName)
+	rcfg, err := InitDefaultConfig(cfg.ProjectName, cfg.Changelog(), release2.ResolveTagPrefix(cfg, git.GetTags()))
 	if err != nil {
 		return fmt.Errorf("failed to create default config: %w", err)
 	}
 	if err := SaveConfig(rcfg); err != nil {
 		return fmt.Errorf("failed to save .release-it.json: %w", err)
 	}
+	r.RecordInitFiles(".release-it.json")
 
 	log.PluginPrint(
 		log.Init,
@@ -228,23 +295,36 @@ func (r *ReleaseIt) runReleaseItCheck() error {
 	return nil
 }
 
-func (r *ReleaseIt) runReleaseItRelease(v *semver.Version) error {
-	versionStr := v.String()
+// releaseItArgs returns the release-it arguments for the version
+func releaseItArgs(v *semver.Version) []string {
+	args := []string{"release-it", v.String(), "--ci", "--no-git.requireCleanWorkingDir"}
+	if !release2.ReleaseCommitEnabled() {
+		args = append(args, "--no-git.commit")
+	}
+	return append(args, release2.ReleaseArgs()...)
+}
+
+func (r *ReleaseIt) runReleaseItRelease(ctx context.Context, v *semver.Version, dryRun bool) ([]byte, error) {
 	runCmd := r.getRunCommand()
-	releaseCmd := fmt.Sprintf("%s release-it %s --ci --no-git.requireCleanWorkingDir", runCmd, versionStr)
+	args := releaseItArgs(v)
+	if dryRun {
+		args = append(args, "--dry-run")
+	}
 
 	log.PluginV(log.Exec,
 		fmt.Sprintf("Running release-it: %s",
-			log.ColorText(log.ColorGreen, releaseCmd),
+			log.ColorText(log.ColorGreen, runCmd+" "+strings.Join(args, " ")),
 		),
 	)
 
-	cmd := exec.Command(runCmd, "release-it", versionStr, "--ci", "--no-git.requireCleanWorkingDir")
+	cmd := exec.CommandContext(ctx, runCmd, args...)
+	// release-it creates the release commit itself
+	cmd.Env = release2.ReleaseCommitEnv(os.Environ())
 	output, err := cmd.CombinedOutput()
 	if err != nil {
-		return fmt.Errorf("release failed: %s\nOutput: %s", err.Error(), string(output))
+		return output, fmt.Errorf("release failed: %s\nOutput: %s", err.Error(), string(output))
 	}
-	return nil
+	return output, nil
 }
 
 func init() {
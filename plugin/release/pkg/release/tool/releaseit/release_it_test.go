@@ -0,0 +1,108 @@
+package releaseit
+
+import (
+	"testing"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/nekoman-hq/neko-cli/plugin/release/internal/gittest"
+	"github.com/nekoman-hq/neko-cli/plugin/release/internal/testbin"
+	release2 "github.com/nekoman-hq/neko-cli/plugin/release/pkg/release"
+)
+
+func TestDryRunPassesDryRunFlag(t *testing.T) {
+	tests := []struct {
+		packageManager string
+		runner         string
+	}{
+		{"npm", "npx"},
+		{"bun", "bunx"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.packageManager, func(t *testing.T) {
+			calls := testbin.Fake(t, tt.runner, `echo "! release-it dry run"`)
+			r := &ReleaseIt{packageManager: tt.packageManager}
+
+			out, err := r.DryRun(semver.MustParse("1.2.0"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if out != "! release-it dry run" {
+				t.Errorf("output = %q, want the release-it output", out)
+			}
+
+			want := "release-it 1.2.0 --ci --no-git.requireCleanWorkingDir --dry-run"
+			if args := calls.Args(); len(args) != 1 || args[0] != want {
+				t.Errorf("%s called with %q, want %q", tt.runner, args, want)
+			}
+		})
+	}
+}
+
+func TestDryRunFailure(t *testing.T) {
+	testbin.Fake(t, "npx", "echo 'ERROR Not authenticated with GitHub'\nexit 1")
+	r := &ReleaseIt{packageManager: "npm"}
+
+	if _, err := r.DryRun(semver.MustParse("1.2.0")); err == nil {
+		t.Error("DryRun succeeded although release-it failed")
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		binary  string
+		files   []string
+		wantErr bool
+	}{
+		{"npm project", "npm", []string{"package.json", "package-lock.json"}, false},
+		{"bun project", "bun", []string{"package.json", "bun.lock"}, false},
+		{"bun project without bun", "npm", []string{"package.json", "bun.lock"}, true},
+		{"missing package.json", "npm", nil, true},
+		{"missing npm", "", []string{"package.json"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Chdir(t.TempDir())
+			t.Setenv("PATH", t.TempDir())
+			if tt.binary != "" {
+				testbin.Fake(t, tt.binary, "")
+			}
+			for _, f := range tt.files {
+				gittest.WriteFile(t, f, "{}\n")
+			}
+
+			if err := (&ReleaseIt{}).Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate = %v, want error %t", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// Release args from the config go to release-it after the fixed flags, before --dry-run
+func TestDryRunPassesReleaseArgs(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"none", nil, "release-it 1.2.0 --ci --no-git.requireCleanWorkingDir --dry-run"},
+		{"frontend default", []string{"--no-npm.publish"}, "release-it 1.2.0 --ci --no-git.requireCleanWorkingDir --no-npm.publish --dry-run"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			release2.SetReleaseArgs(tt.args)
+			t.Cleanup(func() { release2.SetReleaseArgs(nil) })
+			calls := testbin.Fake(t, "npx", "")
+
+			if _, err := (&ReleaseIt{packageManager: "npm"}).DryRun(semver.MustParse("1.2.0")); err != nil {
+				t.Fatal(err)
+			}
+			if args := calls.Args(); len(args) != 1 || args[0] != tt.want {
+				t.Errorf("npx called with %q, want %q", args, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,156 @@
+package release
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/nekoman-hq/neko-cli/plugin/release/internal/gittest"
+)
+
+// newTestRepoWithRemote creates a repository with one pushed commit on main and a bare origin
+func newTestRepoWithRemote(t *testing.T) (remote string) {
+	t.Helper()
+	gittest.NewRepo(t)
+	remote = gittest.NewRemote(t)
+
+	gittest.WriteFile(t, "version.txt", "1.0.0\n")
+	gittest.Run(t, "add", "version.txt")
+	gittest.Run(t, "commit", "-q", "-m", "feat: initial")
+	gittest.Run(t, "push", "-q", "origin", "main")
+	return remote
+}
+
+// remoteHead returns the commit main points at on the bare remote
+func remoteHead(t *testing.T, remote string) string {
+	t.Helper()
+	return gittest.Run(t, "--git-dir", remote, "rev-parse", "main")
+}
+
+func TestRevertReleaseCommitNotPushed(t *testing.T) {
+	remote := newTestRepoWithRemote(t)
+	preHead := gittest.Run(t, "rev-parse", "HEAD")
+
+	gittest.WriteFile(t, "version.txt", "1.1.0\n")
+	gittest.Run(t, "commit", "-q", "-am", "chore(neko-release): 1.1.0")
+	releaseHead := gittest.Run(t, "rev-parse", "HEAD")
+
+	tb := &ToolBase{}
+	err := tb.revertReleaseCommit(GitReleaseState{PreHead: preHead, ReleaseHead: releaseHead})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if head := gittest.Run(t, "rev-parse", "HEAD"); head != preHead {
+		t.Errorf("HEAD = %s, want the reset to the pre-release head %s", head, preHead)
+	}
+	if got := remoteHead(t, remote); got != preHead {
+		t.Errorf("remote main = %s, the rollback must not push an unpushed release", got)
+	}
+}
+
+func TestRevertReleaseCommitNotPushedWithoutPreHead(t *testing.T) {
+	newTestRepoWithRemote(t)
+	head := gittest.Run(t, "rev-parse", "HEAD")
+
+	tb := &ToolBase{}
+	if err := tb.revertReleaseCommit(GitReleaseState{ReleaseHead: head}); err == nil {
+		t.Fatal("expected an error for a release commit without pre-head")
+	}
+	if got := gittest.Run(t, "rev-parse", "HEAD"); got != head {
+		t.Errorf("HEAD moved to %s although the state was inconsistent", got)
+	}
+}
+
+func TestRevertReleaseCommitPushed(t *testing.T) {
+	remote := newTestRepoWithRemote(t)
+	preHead := gittest.Run(t, "rev-parse", "HEAD")
+
+	gittest.WriteFile(t, "version.txt", "1.1.0\n")
+	gittest.Run(t, "commit", "-q", "-am", "chore(neko-release): 1.1.0")
+	releaseHead := gittest.Run(t, "rev-parse", "HEAD")
+	gittest.Run(t, "push", "-q", "origin", "main")
+
+	tb := &ToolBase{}
+	err := tb.revertReleaseCommit(GitReleaseState{PreHead: preHead, ReleaseHead: releaseHead, PushedCommit: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Pushed history is never rewritten, the revert is a new commit on top of the release
+	head := gittest.Run(t, "rev-parse", "HEAD")
+	if parent := gittest.Run(t, "rev-parse", "HEAD^"); parent != releaseHead {
+		t.Errorf("revert commit parent = %s, want the release commit %s", parent, releaseHead)
+	}
+	if subject := gittest.Run(t, "log", "-1", "--format=%s"); !strings.HasPrefix(subject, "Revert ") {
+		t.Errorf("revert commit subject = %q, want a git revert", subject)
+	}
+	if tree, want := gittest.Run(t, "rev-parse", "HEAD^{tree}"), gittest.Run(t, "rev-parse", preHead+"^{tree}"); tree != want {
+		t.Error("the revert commit does not restore the pre-release tree")
+	}
+	if got := remoteHead(t, remote); got != head {
+		t.Errorf("remote main = %s, want the pushed revert %s", got, head)
+	}
+}
+
+func TestRevertReleaseCommitPushedEmpty(t *testing.T) {
+	remote := newTestRepoWithRemote(t)
+	preHead := gittest.Run(t, "rev-parse", "HEAD")
+
+	gittest.Run(t, "commit", "-q", "--allow-empty", "-m", "chore(neko-release): 1.1.0")
+	releaseHead := gittest.Run(t, "rev-parse", "HEAD")
+	gittest.Run(t, "push", "-q", "origin", "main")
+
+	tb := &ToolBase{}
+	err := tb.revertReleaseCommit(GitReleaseState{PreHead: preHead, ReleaseHead: releaseHead, PushedCommit: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	head := gittest.Run(t, "rev-parse", "HEAD")
+	if parent := gittest.Run(t, "rev-parse", "HEAD^"); parent != releaseHead {
+		t.Errorf("revert commit parent = %s, want the release commit %s", parent, releaseHead)
+	}
+	if subject := gittest.Run(t, "log", "-1", "--format=%s"); subject != "revert "+releaseHead {
+		t.Errorf("revert commit subject = %q, want the empty revert marker", subject)
+	}
+	if tree, want := gittest.Run(t, "rev-parse", "HEAD^{tree}"), gittest.Run(t, "rev-parse", releaseHead+"^{tree}"); tree != want {
+		t.Error("the empty revert commit changed the tree")
+	}
+	if got := remoteHead(t, remote); got != head {
+		t.Errorf("remote main = %s, want the pushed revert %s", got, head)
+	}
+}
+
+// A push declined by a pre-receive hook never reached the remote, so the rollback resets locally
+func TestPushRejectedRevertsLocally(t *testing.T) {
+	remote := newTestRepoWithRemote(t)
+	preHead := gittest.Run(t, "rev-parse", "HEAD")
+	gittest.RejectPushes(t, remote, "commit-lint: release commits need a scope")
+
+	tb := &ToolBase{}
+	if err := tb.CreateReleaseCommit(context.Background(), semver.MustParse("1.1.0")); err != nil {
+		t.Fatal(err)
+	}
+	releaseHead := gittest.Run(t, "rev-parse", "HEAD")
+
+	err := tb.PushCommits(context.Background())
+	if code, details := releaseErrorCode(err); code != "PUSH_REJECTED" ||
+		!strings.Contains(details["reason"].(string), "release commits need a scope") {
+		t.Fatalf("PushCommits = %v, code %s %v, want PUSH_REJECTED with the hook's message", err, code, details)
+	}
+
+	if err := tb.RevertGitRelease(GitReleaseState{PreHead: preHead, ReleaseHead: releaseHead}); err != nil {
+		t.Fatal(err)
+	}
+	if head := gittest.Run(t, "rev-parse", "HEAD"); head != preHead {
+		t.Errorf("HEAD = %s, want the reset to %s", head, preHead)
+	}
+	if revert := gittest.Run(t, "log", "--format=%s", "-1"); strings.HasPrefix(revert, "Revert") {
+		t.Errorf("the rollback created %q instead of resetting", revert)
+	}
+	if got := remoteHead(t, remote); got != preHead {
+		t.Errorf("remote main = %s, want it unchanged", got)
+	}
+}
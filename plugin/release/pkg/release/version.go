@@ -0,0 +1,37 @@
+package release
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+// ParseVersion parses a version string or tag into a strict semantic version.
+// The configured tag prefix and an optional "v" are stripped, so v1.2.3 and 1.2.3 parse the same.
+func ParseVersion(s string) (*semver.Version, error) {
+	return parseVersionWithPrefix(s, tagPrefix)
+}
+
+func parseVersionWithPrefix(s, prefix string) (*semver.Version, error) {
+	raw := strings.TrimSpace(s)
+	if prefix != "" {
+		raw = strings.TrimPrefix(raw, prefix)
+	}
+	raw = strings.TrimPrefix(raw, "v")
+
+	v, err := semver.StrictNewVersion(raw)
+	if err != nil {
+		return nil, fmt.Errorf("%s is not a valid semantic version: %w", s, err)
+	}
+	return v, nil
+}
+
+// IsVersionTag reports whether the tag is a semantic version with the prefix, e.g. v1.2.3
+func IsVersionTag(tag, prefix string) bool {
+	if !strings.HasPrefix(tag, prefix) {
+		return false
+	}
+	_, err := parseVersionWithPrefix(tag, prefix)
+	return err == nil
+}
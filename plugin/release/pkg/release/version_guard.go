@@ -7,6 +7,7 @@ package release
 */
 
 import (
+	stderrors "errors"
 	"fmt"
 
 	"github.com/Masterminds/semver/v3"
@@ -17,24 +18,137 @@ import (
 	"github.com/nekoman-hq/neko-cli/pkg/log"
 )
 
-func VersionGuard(cfg *config.NekoConfig) (*semver.Version, error) {
+// VersionGuard compares the config version against the latest tag.
+// With force a smaller config version only produces a warning.
+func VersionGuard(cfg *config.NekoConfig, force bool) (*semver.Version, error) {
 	log.PluginV(log.Guard, "Running Version Guard checks")
-	git2.Fetch()
+	if cfg.ShouldFetchTags() {
+		if err := git2.FetchTags(); err != nil {
+			errors.WriteWarning(
+				"Failed to fetch tags",
+				fmt.Sprintf("Comparing against local tags only: %s", err.Error()),
+			)
+		}
+	} else {
+		git2.Fetch()
+	}
+
+	latestTag, err := latestBaselineTag(cfg)
+	if stderrors.Is(err, git2.ErrNoTags) {
+		// Nothing to compare against, the config version is the baseline of the first release
+		log.PluginPrint(log.Guard, "No release tags yet, starting the first release from version %s in .release.neko.json",
+			log.ColorText(log.ColorCyan, cfg.Version))
+		return EnsureVersionIsValid(cfg, "", force)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest tag: %w", err)
+	}
+	checkTagDivergence(latestTag)
+
+	return EnsureVersionIsValid(cfg, latestTag, force)
+}
+
+// IsFirstRelease reports whether no release tag exists yet that the version guard would compare against
+func IsFirstRelease(cfg *config.NekoConfig) bool {
+	_, err := latestBaselineTag(cfg)
+	return stderrors.Is(err, git2.ErrNoTags)
+}
+
+// latestBaselineTag returns the highest release tag with the configured or detected tag prefix.
+// With ignore-prerelease-tags the highest stable version tag is used instead, so an rc tag does not block the stable release.
+func latestBaselineTag(cfg *config.NekoConfig) (string, error) {
+	if !cfg.IgnorePrereleaseTags {
+		tag, err := LatestReleaseTag()
+		if err != nil {
+			return "", err
+		}
+		if tag == "" {
+			return "", git2.ErrNoTags
+		}
+		log.PluginV(log.Guard, fmt.Sprintf("Latest release tag: %s", tag))
+		return tag, nil
+	}
+
+	tags, err := git2.MergedTags()
+	if err != nil {
+		return "", err
+	}
+
+	tag := LatestStableTag(tags)
+	if tag == "" {
+		return "", git2.ErrNoTags
+	}
+	log.PluginV(log.Guard, fmt.Sprintf("Latest stable tag: %s", tag))
+	return tag, nil
+}
+
+// LatestStableTag returns the tag of the highest version without prerelease, or an empty string if there is none
+func LatestStableTag(tags []string) string {
+	latest := ""
+	var latestVersion *semver.Version
+	for _, tag := range tags {
+		v, err := ParseVersion(tag)
+		if err != nil || v.Prerelease() != "" {
+			continue
+		}
+		if latestVersion == nil || v.GreaterThan(latestVersion) {
+			latest, latestVersion = tag, v
+		}
+	}
+	return latest
+}
+
+// checkTagDivergence warns if the local tag points at a different commit than the same tag on origin
+func checkTagDivergence(tag string) {
+	local, err := git2.TagCommit(tag)
+	if err != nil {
+		return
+	}
+	remote, err := git2.RemoteTagCommit(tag)
+	if err != nil {
+		log.PluginV(log.Guard, fmt.Sprintf("Skipping tag divergence check: %s", err.Error()))
+		return
+	}
 
-	latestTag := git2.LatestTag()
+	WarnTagDivergence(tag, local, remote)
+}
 
-	return EnsureVersionIsValid(cfg, latestTag)
+// WarnTagDivergence writes a warning if the local and remote commit of a tag differ.
+// Returns true if the tag diverged. A tag missing on origin is not considered diverged.
+func WarnTagDivergence(tag, local, remote string) bool {
+	if remote == "" || local == remote {
+		return false
+	}
+
+	errors.WriteWarning(
+		"Tag diverged from origin",
+		fmt.Sprintf("Local tag %s points at %s but origin has %s.\nRun 'git fetch --tags --force' to update the local tag.",
+			tag, shortHash(local), shortHash(remote)),
+	)
+	return true
 }
 
-func EnsureVersionIsValid(cfg *config.NekoConfig, latestTag string) (*semver.Version, error) {
-	localVer, err := semver.NewVersion(cfg.Version)
+func shortHash(hash string) string {
+	if len(hash) > 7 {
+		return hash[:7]
+	}
+	return hash
+}
+
+func EnsureVersionIsValid(cfg *config.NekoConfig, latestTag string, force bool) (*semver.Version, error) {
+	localVer, err := ParseVersion(cfg.Version)
 	if err != nil {
 		return nil, fmt.Errorf(
 			"version %s in .release.neko.json is not a valid semantic version", cfg.Version,
 		)
 	}
 
-	remoteVer, err := semver.NewVersion(latestTag)
+	// A fresh repository has no tag to compare against
+	if latestTag == "" {
+		return localVer, nil
+	}
+
+	remoteVer, err := ParseVersion(latestTag)
 	if err != nil {
 		errors.WriteWarning(
 			"Latest Git tag %s is not a valid semantic version, skipping comparison",
@@ -50,6 +164,15 @@ func EnsureVersionIsValid(cfg *config.NekoConfig, latestTag string) (*semver.Ver
 		return localVer, nil
 	}
 
+	if localVer.LessThan(remoteVer) && force {
+		log.PluginPrint(log.Guard, "\u26A0 %s",
+			log.ColorText(log.ColorYellow, fmt.Sprintf(
+				"--force-version: releasing from local version %s although it is smaller than latest tag %s",
+				localVer, remoteVer,
+			)))
+		return localVer, nil
+	}
+
 	if localVer.LessThan(remoteVer) {
 		return nil, fmt.Errorf(
 			"version violation: Local version %s is smaller than latest tag %s",
@@ -0,0 +1,292 @@
+package release
+
+import (
+	stderrors "errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/nekoman-hq/neko-cli/pkg/errors"
+	"github.com/nekoman-hq/neko-cli/plugin/release/internal/gittest"
+	"github.com/nekoman-hq/neko-cli/plugin/release/pkg/config"
+	"github.com/nekoman-hq/neko-cli/plugin/release/pkg/git"
+)
+
+func TestLatestBaselineTag(t *testing.T) {
+	tests := []struct {
+		name    string
+		tags    []string
+		cfg     config.NekoConfig
+		want    string
+		wantErr error
+	}{
+		{
+			name: "highest release tag",
+			tags: []string{"v1.0.0", "v1.2.0", "v1.1.0"},
+			want: "v1.2.0",
+		},
+		{
+			// git describe would return the deploy tag on HEAD
+			name: "ignores non-release tags",
+			tags: []string{"v1.0.0", "deploy-prod"},
+			want: "v1.0.0",
+		},
+		{
+			name: "custom prefix",
+			tags: []string{"release-1.0.0", "release-1.1.0", "latest"},
+			want: "release-1.1.0",
+		},
+		{
+			name: "prerelease is the baseline",
+			tags: []string{"v1.0.0", "v1.1.0-rc.1"},
+			want: "v1.1.0-rc.1",
+		},
+		{
+			name: "ignore prerelease tags",
+			tags: []string{"v1.0.0", "v1.1.0-rc.1"},
+			cfg:  config.NekoConfig{IgnorePrereleaseTags: true},
+			want: "v1.0.0",
+		},
+		{
+			name:    "no release tags",
+			tags:    []string{"nightly"},
+			wantErr: git.ErrNoTags,
+		},
+		{
+			name:    "only prerelease tags ignored",
+			tags:    []string{"v1.0.0-rc.1", "v1.0.0-rc.2"},
+			cfg:     config.NekoConfig{IgnorePrereleaseTags: true},
+			wantErr: git.ErrNoTags,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gittest.NewRepo(t)
+			for _, tag := range tt.tags {
+				gittest.Commit(t, "feat: "+tag)
+				gittest.Run(t, "tag", tag)
+			}
+
+			got, err := latestBaselineTag(&tt.cfg)
+			if !stderrors.Is(err, tt.wantErr) {
+				t.Fatalf("latestBaselineTag() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("latestBaselineTag() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// A failing git must not be mistaken for a repository without tags
+func TestLatestBaselineTagGitFailure(t *testing.T) {
+	t.Chdir(t.TempDir())
+	t.Setenv("GIT_CEILING_DIRECTORIES", filepath.Dir(t.TempDir()))
+
+	for _, cfg := range []config.NekoConfig{{}, {IgnorePrereleaseTags: true}} {
+		_, err := latestBaselineTag(&cfg)
+		if err == nil || stderrors.Is(err, git.ErrNoTags) {
+			t.Errorf("latestBaselineTag outside a repository with %+v = %v, want a git error", cfg, err)
+		}
+	}
+}
+
+func TestVersionGuardFirstRelease(t *testing.T) {
+	gittest.NewRepo(t)
+	gittest.Commit(t, "feat: initial")
+
+	version, err := VersionGuard(&config.NekoConfig{Version: "0.3.0"}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if version.String() != "0.3.0" {
+		t.Errorf("VersionGuard = %s, want the config version 0.3.0", version)
+	}
+}
+
+// --force-version downgrades a config version behind the latest tag to a warning
+func TestVersionGuardForceVersion(t *testing.T) {
+	gittest.NewRepo(t)
+	gittest.Commit(t, "feat: initial")
+	gittest.Run(t, "tag", "v1.2.0")
+	cfg := &config.NekoConfig{Version: "1.1.0"}
+
+	if _, err := VersionGuard(cfg, false); err == nil {
+		t.Error("VersionGuard accepted a version behind the latest tag")
+	}
+	version, err := VersionGuard(cfg, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if version.String() != "1.1.0" {
+		t.Errorf("forced VersionGuard = %s, want the config version 1.1.0", version)
+	}
+}
+
+func TestVersionGuardGitFailure(t *testing.T) {
+	t.Chdir(t.TempDir())
+	t.Setenv("GIT_CEILING_DIRECTORIES", filepath.Dir(t.TempDir()))
+
+	if version, err := VersionGuard(&config.NekoConfig{Version: "0.3.0"}, false); err == nil {
+		t.Errorf("VersionGuard outside a repository = %s, want an error", version)
+	}
+}
+
+func TestWarnTagDivergence(t *testing.T) {
+	tests := []struct {
+		name          string
+		local, remote string
+		want          bool
+	}{
+		{"same commit", "abc1234def", "abc1234def", false},
+		{"missing on origin", "abc1234def", "", false},
+		{"diverged", "abc1234def", "0987654fed", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := len(errors.Warnings())
+			if got := WarnTagDivergence("v1.0.0", tt.local, tt.remote); got != tt.want {
+				t.Errorf("WarnTagDivergence = %t, want %t", got, tt.want)
+			}
+			if warned := len(errors.Warnings()) > before; warned != tt.want {
+				t.Errorf("warning written = %t, want %t", warned, tt.want)
+			}
+		})
+	}
+}
+
+// A tag force-moved locally must be reported against the commit origin still has
+func TestCheckTagDivergence(t *testing.T) {
+	gittest.NewRepo(t)
+	gittest.NewRemote(t)
+	released := gittest.Commit(t, "feat: initial")
+	gittest.Run(t, "tag", "v1.0.0")
+	gittest.Run(t, "push", "-q", "--tags", "origin", "main")
+
+	before := len(errors.Warnings())
+	checkTagDivergence("v1.0.0")
+	if n := len(errors.Warnings()) - before; n != 0 {
+		t.Fatalf("%d warnings for a tag matching origin", n)
+	}
+
+	moved := gittest.Commit(t, "feat: moved")
+	gittest.Run(t, "tag", "-f", "v1.0.0")
+	checkTagDivergence("v1.0.0")
+
+	warnings := errors.Warnings()
+	if len(warnings) != before+1 {
+		t.Fatalf("got %d warnings, want one for the moved tag", len(warnings)-before)
+	}
+	w := warnings[len(warnings)-1]
+	if w.Code != "Tag diverged from origin" ||
+		!strings.Contains(w.Message, shortHash(moved)) || !strings.Contains(w.Message, shortHash(released)) {
+		t.Errorf("warning = %+v, want the local %s and remote %s commit", w, shortHash(moved), shortHash(released))
+	}
+}
+
+func TestLatestStableTag(t *testing.T) {
+	tests := []struct {
+		name string
+		tags []string
+		want string
+	}{
+		{"highest stable", []string{"v1.0.0", "v1.2.0", "v1.1.0"}, "v1.2.0"},
+		{"semver order", []string{"v1.9.0", "v1.10.0"}, "v1.10.0"},
+		{"skips prereleases", []string{"v1.0.0", "v1.1.0-rc.1", "v2.0.0-beta.1"}, "v1.0.0"},
+		{"skips non-version tags", []string{"latest", "v1.0.0", "deploy-prod"}, "v1.0.0"},
+		{"only prereleases", []string{"v1.0.0-rc.1"}, ""},
+		{"no tags", nil, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := LatestStableTag(tt.tags); got != tt.want {
+				t.Errorf("LatestStableTag(%q) = %q, want %q", tt.tags, got, tt.want)
+			}
+		})
+	}
+}
+
+// A stable tag on an unmerged branch is not the baseline of HEAD
+func TestLatestBaselineTagIgnoresUnmergedTags(t *testing.T) {
+	gittest.NewRepo(t)
+	gittest.Commit(t, "feat: initial")
+	gittest.Run(t, "tag", "v1.0.0")
+	gittest.Run(t, "checkout", "-q", "-b", "hotfix")
+	gittest.Commit(t, "fix: hotfix")
+	gittest.Run(t, "tag", "v1.0.1")
+	gittest.Run(t, "checkout", "-q", "main")
+
+	got, err := latestBaselineTag(&config.NekoConfig{IgnorePrereleaseTags: true})
+	if err != nil || got != "v1.0.0" {
+		t.Errorf("latestBaselineTag = %q, %v, want v1.0.0", got, err)
+	}
+}
+
+func TestEnsureVersionIsValid(t *testing.T) {
+	tests := []struct {
+		name      string
+		version   string
+		latestTag string
+		force     bool
+		want      string
+		wantErr   string
+	}{
+		{"no tags", "0.1.0", "", false, "0.1.0", ""},
+		{"equal", "1.2.0", "v1.2.0", false, "1.2.0", ""},
+		{"ahead", "1.3.0", "v1.2.0", false, "1.3.0", ""},
+		{"behind", "1.1.0", "v1.2.0", false, "", "version violation"},
+		{"behind forced", "1.1.0", "v1.2.0", true, "1.1.0", ""},
+		{"invalid tag", "1.1.0", "nightly", false, "1.1.0", ""},
+		{"invalid version", "one", "v1.2.0", true, "", "not a valid semantic version"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := EnsureVersionIsValid(&config.NekoConfig{Version: tt.version}, tt.latestTag, tt.force)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("EnsureVersionIsValid = %v, %v, want %q", got, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.String() != tt.want {
+				t.Errorf("EnsureVersionIsValid = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsFirstRelease(t *testing.T) {
+	tests := []struct {
+		name string
+		tags []string
+		cfg  config.NekoConfig
+		want bool
+	}{
+		{"no tags", nil, config.NekoConfig{}, true},
+		{"only non-release tags", []string{"nightly"}, config.NekoConfig{}, true},
+		{"released", []string{"v1.0.0"}, config.NekoConfig{}, false},
+		{"prerelease", []string{"v1.0.0-rc.1"}, config.NekoConfig{}, false},
+		{"only ignored prereleases", []string{"v1.0.0-rc.1"}, config.NekoConfig{IgnorePrereleaseTags: true}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gittest.NewRepo(t)
+			gittest.Commit(t, "feat: initial")
+			for _, tag := range tt.tags {
+				gittest.Run(t, "tag", tag)
+			}
+
+			if got := IsFirstRelease(&tt.cfg); got != tt.want {
+				t.Errorf("IsFirstRelease = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
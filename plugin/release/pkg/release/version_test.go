@@ -0,0 +1,61 @@
+package release
+
+import "testing"
+
+func TestParseVersion(t *testing.T) {
+	tests := []struct {
+		name    string
+		prefix  string
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{"bare", "v", "1.2.3", "1.2.3", false},
+		{"prefixed", "v", "v1.2.3", "1.2.3", false},
+		{"whitespace", "v", " v1.2.3\n", "1.2.3", false},
+		{"prerelease", "v", "v1.2.3-rc.1", "1.2.3-rc.1", false},
+		{"custom prefix", "release-", "release-1.2.3", "1.2.3", false},
+		{"custom prefix and v", "release-", "release-v1.2.3", "1.2.3", false},
+		{"custom prefix accepts v", "release-", "v1.2.3", "1.2.3", false},
+		{"no prefix", "", "1.2.3", "1.2.3", false},
+		{"missing patch", "v", "v1.2", "", true},
+		{"leading zero", "v", "01.2.3", "", true},
+		{"other prefix", "v", "release-1.2.3", "", true},
+		{"empty", "v", "", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetTagPrefix(tt.prefix)
+			t.Cleanup(func() { SetTagPrefix(DefaultTagPrefix) })
+
+			got, err := ParseVersion(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseVersion(%q) error = %v, want error %t", tt.input, err, tt.wantErr)
+			}
+			if err == nil && got.String() != tt.want {
+				t.Errorf("ParseVersion(%q) = %s, want %s", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsVersionTag(t *testing.T) {
+	tests := []struct {
+		tag, prefix string
+		want        bool
+	}{
+		{"v1.2.3", "v", true},
+		{"1.2.3", "v", false},
+		{"1.2.3", "", true},
+		{"release-1.2.3", "release-", true},
+		{"v1.2.3", "release-", false},
+		{"vnext", "v", false},
+	}
+
+	for _, tt := range tests {
+		if got := IsVersionTag(tt.tag, tt.prefix); got != tt.want {
+			t.Errorf("IsVersionTag(%q, %q) = %t, want %t", tt.tag, tt.prefix, got, tt.want)
+		}
+	}
+}
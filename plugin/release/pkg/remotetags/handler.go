@@ -0,0 +1,142 @@
+// Package remotetags includes the ls-remote-tags command handler that compares local and remote tags
+package remotetags
+
+import (
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/nekoman-hq/neko-cli/pkg/log"
+	"github.com/nekoman-hq/neko-cli/pkg/plugin"
+	"github.com/nekoman-hq/neko-cli/plugin/release/pkg/git"
+)
+
+const (
+	PluginName    = "release"
+	PluginVersion = "1.0.0"
+)
+
+// Tag statuses reported by ls-remote-tags
+const (
+	StatusInSync     = "in-sync"
+	StatusLocalOnly  = "local-only"
+	StatusRemoteOnly = "remote-only"
+	StatusDiverged   = "diverged"
+)
+
+// TagDiff compares a tag between the local repository and origin.
+// Local or Remote is empty if the tag is missing there.
+type TagDiff struct {
+	Tag    string
+	Local  string
+	Remote string
+	Status string
+}
+
+// HandleLsRemoteTags lists local and remote tags side by side and flags the discrepancies
+func HandleLsRemoteTags(_ plugin.Request) (*plugin.Response, error) {
+	log.PluginPrint(log.Exec, "Comparing local and remote tags")
+
+	local, err := git.LocalTagCommits()
+	if err != nil {
+		return errorResponse("NOT_A_GIT_REPOSITORY", err.Error()), nil
+	}
+	remote, err := git.RemoteTagCommits()
+	if err != nil {
+		return errorResponse("REMOTE_UNREACHABLE", err.Error()), nil
+	}
+
+	diffs := CompareTags(local, remote)
+
+	drift := 0
+	items := make([]map[string]any, 0, len(diffs))
+	for _, d := range diffs {
+		if d.Status != StatusInSync {
+			drift++
+		}
+		items = append(items, map[string]any{
+			"tag":    d.Tag,
+			"local":  shortHash(d.Local),
+			"remote": shortHash(d.Remote),
+			"status": d.Status,
+		})
+	}
+
+	if drift == 0 {
+		log.PluginPrint(log.Exec, "\uF00C Local and remote tags are in sync")
+	} else {
+		log.PluginPrint(log.Exec, "\u26A0 %s of %d tags differ from origin, run %s to update local tags",
+			log.ColorText(log.ColorYellow, strconv.Itoa(drift)), len(diffs),
+			log.ColorText(log.ColorCyan, "git fetch --tags --force"))
+	}
+
+	return &plugin.Response{
+		Status: "success",
+		Metadata: plugin.ResponseMetadata{
+			Plugin:    PluginName,
+			Version:   PluginVersion,
+			Command:   "ls-remote-tags",
+			Timestamp: time.Now(),
+		},
+		Data: map[string]any{
+			"items": items,
+			"drift": drift,
+		},
+		RendererHint: "table",
+		ColumnOrder:  []string{"tag", "local", "remote", "status"},
+	}, nil
+}
+
+// CompareTags compares the local and remote tag commits, sorted by tag name
+func CompareTags(local, remote map[string]string) []TagDiff {
+	names := make([]string, 0, len(local)+len(remote))
+	for tag := range local {
+		names = append(names, tag)
+	}
+	for tag := range remote {
+		if _, ok := local[tag]; !ok {
+			names = append(names, tag)
+		}
+	}
+	sort.Strings(names)
+
+	diffs := make([]TagDiff, 0, len(names))
+	for _, tag := range names {
+		d := TagDiff{Tag: tag, Local: local[tag], Remote: remote[tag]}
+		switch {
+		case d.Remote == "":
+			d.Status = StatusLocalOnly
+		case d.Local == "":
+			d.Status = StatusRemoteOnly
+		case d.Local != d.Remote:
+			d.Status = StatusDiverged
+		default:
+			d.Status = StatusInSync
+		}
+		diffs = append(diffs, d)
+	}
+	return diffs
+}
+
+func shortHash(hash string) string {
+	if len(hash) > 7 {
+		return hash[:7]
+	}
+	return hash
+}
+
+func errorResponse(code, message string) *plugin.Response {
+	return &plugin.Response{
+		Status: "error",
+		Metadata: plugin.ResponseMetadata{
+			Plugin:    PluginName,
+			Version:   PluginVersion,
+			Command:   "ls-remote-tags",
+			Timestamp: time.Now(),
+		},
+		Error: &plugin.ResponseError{
+			Code:    code,
+			Message: message,
+		},
+	}
+}
@@ -0,0 +1,123 @@
+package remotetags
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/nekoman-hq/neko-cli/pkg/plugin"
+	"github.com/nekoman-hq/neko-cli/plugin/release/internal/gittest"
+)
+
+func TestCompareTags(t *testing.T) {
+	tests := []struct {
+		name   string
+		local  map[string]string
+		remote map[string]string
+		want   []TagDiff
+	}{
+		{"none", nil, nil, []TagDiff{}},
+		{"in sync", map[string]string{"v1.0.0": "a"}, map[string]string{"v1.0.0": "a"}, []TagDiff{
+			{Tag: "v1.0.0", Local: "a", Remote: "a", Status: StatusInSync},
+		}},
+		{"all statuses sorted by tag", map[string]string{"v1.2.0": "c", "v1.0.0": "a", "v1.1.0": "b"}, map[string]string{"v1.0.0": "a", "v1.1.0": "x", "v0.9.0": "z"}, []TagDiff{
+			{Tag: "v0.9.0", Remote: "z", Status: StatusRemoteOnly},
+			{Tag: "v1.0.0", Local: "a", Remote: "a", Status: StatusInSync},
+			{Tag: "v1.1.0", Local: "b", Remote: "x", Status: StatusDiverged},
+			{Tag: "v1.2.0", Local: "c", Status: StatusLocalOnly},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CompareTags(tt.local, tt.remote); !slices.Equal(got, tt.want) {
+				t.Errorf("CompareTags = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+// lsRemoteRepo has v1.0.0 in sync, v1.1.0 moved locally, v1.2.0 local-only and v0.9.0 remote-only
+func lsRemoteRepo(t *testing.T) {
+	t.Helper()
+	gittest.NewRepo(t)
+	gittest.NewRemote(t)
+	gittest.Commit(t, "feat: initial")
+	gittest.Run(t, "tag", "v0.9.0")
+	gittest.Run(t, "tag", "v1.0.0")
+	gittest.Run(t, "tag", "-a", "-m", "annotated", "v1.1.0")
+	gittest.Run(t, "push", "-q", "origin", "main", "--tags")
+
+	gittest.Run(t, "tag", "-d", "v0.9.0")
+	gittest.Commit(t, "feat: next")
+	gittest.Run(t, "tag", "-f", "-a", "-m", "moved", "v1.1.0")
+	gittest.Run(t, "tag", "v1.2.0")
+}
+
+func TestHandleLsRemoteTags(t *testing.T) {
+	lsRemoteRepo(t)
+
+	resp, err := HandleLsRemoteTags(plugin.Request{Command: "ls-remote-tags"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Status != "success" {
+		t.Fatalf("status = %s, error = %+v", resp.Status, resp.Error)
+	}
+
+	statuses := make([]string, 0)
+	for _, item := range resp.Data["items"].([]map[string]any) {
+		statuses = append(statuses, item["tag"].(string)+" "+item["status"].(string))
+		if local := item["local"].(string); len(local) > 7 {
+			t.Errorf("local commit %q is not shortened", local)
+		}
+	}
+	want := []string{
+		"v0.9.0 " + StatusRemoteOnly,
+		"v1.0.0 " + StatusInSync,
+		"v1.1.0 " + StatusDiverged,
+		"v1.2.0 " + StatusLocalOnly,
+	}
+	if !slices.Equal(statuses, want) {
+		t.Errorf("statuses = %q, want %q", statuses, want)
+	}
+	if resp.Data["drift"] != 3 {
+		t.Errorf("drift = %v, want 3", resp.Data["drift"])
+	}
+}
+
+func TestHandleLsRemoteTagsErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		setup    func(t *testing.T)
+		wantCode string
+	}{
+		{"no origin", func(t *testing.T) {
+			gittest.NewRepo(t)
+			gittest.Commit(t, "feat: initial")
+		}, "REMOTE_UNREACHABLE"},
+		{"no tags", func(t *testing.T) {
+			gittest.NewRepo(t)
+			gittest.NewRemote(t)
+			gittest.Commit(t, "feat: initial")
+		}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup(t)
+			resp, err := HandleLsRemoteTags(plugin.Request{Command: "ls-remote-tags"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.wantCode == "" {
+				if resp.Status != "success" || resp.Data["drift"] != 0 {
+					t.Errorf("response = %+v, want an empty comparison", resp)
+				}
+				return
+			}
+			if resp.Error == nil || resp.Error.Code != tt.wantCode {
+				t.Errorf("error = %+v, want %s", resp.Error, tt.wantCode)
+			}
+		})
+	}
+}
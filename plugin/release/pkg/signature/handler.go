@@ -0,0 +1,100 @@
+// Package signature includes the verify-tag-signature command handler
+package signature
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/nekoman-hq/neko-cli/pkg/log"
+	"github.com/nekoman-hq/neko-cli/pkg/plugin"
+	"github.com/nekoman-hq/neko-cli/plugin/release/pkg/git"
+)
+
+const (
+	PluginName    = "release"
+	PluginVersion = "1.0.0"
+)
+
+// HandleVerifyTagSignature verifies the GPG or SSH signature of a release tag.
+// Only a valid signature succeeds, so the command can gate deployments in CI.
+func HandleVerifyTagSignature(req plugin.Request) (*plugin.Response, error) {
+	if len(req.Args) == 0 {
+		return errorResponse("MISSING_TAG", "No release tag given", map[string]any{
+			"hint": "Usage: neko release verify-tag-signature <tag>",
+		}), nil
+	}
+	tag := req.Args[0]
+
+	if !git.RefExists("refs/tags/" + tag) {
+		return errorResponse("TAG_NOT_FOUND", fmt.Sprintf("Tag %s does not exist", tag), map[string]any{"tag": tag}), nil
+	}
+
+	sig, err := git.VerifyTag(tag)
+	if err != nil {
+		return errorResponse("VERIFY_FAILED", err.Error(), map[string]any{"tag": tag}), nil
+	}
+
+	details := map[string]any{
+		"tag":    tag,
+		"status": string(sig.Status),
+		"signer": sig.Signer,
+		"key":    sig.Key,
+		"output": sig.Output,
+	}
+
+	switch sig.Status {
+	case git.SignatureUnsigned:
+		return errorResponse("TAG_UNSIGNED", fmt.Sprintf("Tag %s is not signed", tag), details), nil
+	case git.SignatureBad:
+		return errorResponse("BAD_SIGNATURE",
+			fmt.Sprintf("Tag %s has a BAD signature, the tag was modified after signing", tag), details), nil
+	case git.SignatureUnverified:
+		details["hint"] = "Import the signer's GPG key or add it to gpg.ssh.allowedSignersFile for SSH signatures"
+		return errorResponse("SIGNATURE_UNVERIFIED",
+			fmt.Sprintf("Tag %s is signed, but the signature could not be verified", tag), details), nil
+	}
+
+	log.PluginPrint(log.Exec, "\uF00C Tag %s has a valid signature by %s",
+		log.ColorText(log.ColorCyan, tag), log.ColorText(log.ColorGreen, sig.Signer))
+
+	return &plugin.Response{
+		Status: "success",
+		Metadata: plugin.ResponseMetadata{
+			Plugin:    PluginName,
+			Version:   PluginVersion,
+			Command:   "verify-tag-signature",
+			Timestamp: time.Now(),
+		},
+		Data: map[string]any{
+			"items": []map[string]any{
+				{"property": "Tag", "value": tag},
+				{"property": "Signature", "value": string(sig.Status)},
+				{"property": "Signer", "value": sig.Signer},
+				{"property": "Key", "value": sig.Key},
+			},
+			"tag":    tag,
+			"status": string(sig.Status),
+			"signer": sig.Signer,
+			"key":    sig.Key,
+		},
+		RendererHint: "table",
+		ColumnOrder:  []string{"property", "value"},
+	}, nil
+}
+
+func errorResponse(code, message string, details map[string]any) *plugin.Response {
+	return &plugin.Response{
+		Status: "error",
+		Metadata: plugin.ResponseMetadata{
+			Plugin:    PluginName,
+			Version:   PluginVersion,
+			Command:   "verify-tag-signature",
+			Timestamp: time.Now(),
+		},
+		Error: &plugin.ResponseError{
+			Code:    code,
+			Message: message,
+			Details: details,
+		},
+	}
+}
@@ -0,0 +1,100 @@
+package signature
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/nekoman-hq/neko-cli/pkg/plugin"
+	"github.com/nekoman-hq/neko-cli/plugin/release/internal/gittest"
+)
+
+// signedRepo creates a repository with tags signed by a fresh SSH key:
+// v1.0.0 signed, v1.1.0 annotated, v1.2.0 lightweight and v1.3.0 signed but modified afterwards
+func signedRepo(t *testing.T) {
+	t.Helper()
+	if _, err := exec.LookPath("ssh-keygen"); err != nil {
+		t.Skip("ssh-keygen not installed")
+	}
+	gittest.NewRepo(t)
+	key := filepath.Join(t.TempDir(), "key")
+	if out, err := exec.Command("ssh-keygen", "-q", "-t", "ed25519", "-N", "", "-C", "neko@example.com", "-f", key).CombinedOutput(); err != nil {
+		t.Fatalf("ssh-keygen: %v: %s", err, out)
+	}
+	gittest.Run(t, "config", "gpg.format", "ssh")
+	gittest.Run(t, "config", "user.signingkey", key)
+
+	gittest.Commit(t, "feat: initial")
+	gittest.Run(t, "tag", "-s", "-m", "release 1.0.0", "v1.0.0")
+	gittest.Run(t, "tag", "-a", "-m", "release 1.1.0", "v1.1.0")
+	gittest.Run(t, "tag", "v1.2.0")
+
+	tampered := strings.Replace(gittest.Run(t, "cat-file", "tag", "v1.0.0"), "release 1.0.0", "release 1.3.0", 1)
+	cmd := exec.Command("git", "mktag")
+	cmd.Stdin = strings.NewReader(tampered + "\n")
+	out, err := cmd.Output()
+	if err != nil {
+		t.Fatalf("git mktag: %v", err)
+	}
+	gittest.Run(t, "tag", "v1.3.0", strings.TrimSpace(string(out)))
+}
+
+// trustKey adds the signing key to the allowed signers so its signatures verify
+func trustKey(t *testing.T) {
+	t.Helper()
+	pub, err := os.ReadFile(gittest.Run(t, "config", "user.signingkey") + ".pub")
+	if err != nil {
+		t.Fatal(err)
+	}
+	allowed := filepath.Join(t.TempDir(), "allowed_signers")
+	if err := os.WriteFile(allowed, []byte("neko@example.com "+string(pub)), 0644); err != nil {
+		t.Fatal(err)
+	}
+	gittest.Run(t, "config", "gpg.ssh.allowedSignersFile", allowed)
+}
+
+func TestHandleVerifyTagSignature(t *testing.T) {
+	tests := []struct {
+		name       string
+		args       []string
+		trusted    bool
+		wantCode   string
+		wantSigner string
+	}{
+		{name: "missing tag", wantCode: "MISSING_TAG"},
+		{name: "unknown tag", args: []string{"v9.9.9"}, wantCode: "TAG_NOT_FOUND"},
+		{name: "valid", args: []string{"v1.0.0"}, trusted: true, wantSigner: "neko@example.com"},
+		{name: "untrusted key", args: []string{"v1.0.0"}, wantCode: "SIGNATURE_UNVERIFIED"},
+		{name: "annotated tag", args: []string{"v1.1.0"}, trusted: true, wantCode: "TAG_UNSIGNED"},
+		{name: "lightweight tag", args: []string{"v1.2.0"}, trusted: true, wantCode: "TAG_UNSIGNED"},
+		{name: "modified tag", args: []string{"v1.3.0"}, trusted: true, wantCode: "BAD_SIGNATURE"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			signedRepo(t)
+			if tt.trusted {
+				trustKey(t)
+			}
+
+			resp, err := HandleVerifyTagSignature(plugin.Request{Command: "verify-tag-signature", Args: tt.args})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.wantCode != "" {
+				if resp.Error == nil || resp.Error.Code != tt.wantCode {
+					t.Errorf("error = %+v, want %s", resp.Error, tt.wantCode)
+				}
+				return
+			}
+			if resp.Status != "success" {
+				t.Fatalf("status = %s, error = %+v", resp.Status, resp.Error)
+			}
+			if resp.Data["signer"] != tt.wantSigner {
+				t.Errorf("signer = %v, want %s", resp.Data["signer"], tt.wantSigner)
+			}
+		})
+	}
+}
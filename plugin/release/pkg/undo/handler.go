@@ -0,0 +1,153 @@
+// Package undo includes the undo-last-tag command handler
+package undo
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/nekoman-hq/neko-cli/pkg/log"
+	"github.com/nekoman-hq/neko-cli/pkg/plugin"
+	"github.com/nekoman-hq/neko-cli/plugin/release/pkg/config"
+	"github.com/nekoman-hq/neko-cli/plugin/release/pkg/git"
+	"github.com/nekoman-hq/neko-cli/plugin/release/pkg/release"
+)
+
+const (
+	PluginName    = "release"
+	PluginVersion = "1.0.0"
+)
+
+// HandleUndoLastTag deletes the latest release tag locally and on origin.
+// Commits and GitHub releases are left untouched.
+func HandleUndoLastTag(req plugin.Request) (*plugin.Response, error) {
+	log.PluginPrint(log.Exec, "Resolving latest release tag")
+
+	tag, err := release.LatestReleaseTag()
+	if err != nil {
+		return errorResponse("TAG_LOOKUP_FAILED", err.Error(), nil), nil
+	}
+	if tag == "" {
+		return errorResponse("NO_TAGS", "No release tags found in this repository", nil), nil
+	}
+
+	subject, err := git.CommitSubject(tag)
+	if err != nil {
+		return errorResponse("TAG_LOOKUP_FAILED", err.Error(), nil), nil
+	}
+
+	if config.Exists() {
+		if cfg, err := config.LoadConfig(); err == nil {
+			git.SetReleaseCommitScope(cfg.ReleaseCommitScope)
+		}
+	}
+
+	if err = EnsureCreatedByNeko(tag, subject); err != nil {
+		return errorResponse("TAG_NOT_CREATED_BY_NEKO", err.Error(), map[string]any{
+			"tag":    tag,
+			"commit": subject,
+		}), nil
+	}
+
+	onRemote, err := git.RemoteTagExists(tag)
+	if err != nil {
+		return errorResponse("REMOTE_LOOKUP_FAILED", err.Error(), nil), nil
+	}
+
+	if !req.Confirmed() {
+		resp := errorResponse("CONFIRMATION_REQUIRED",
+			fmt.Sprintf("Deleting tag %s requires confirmation", tag),
+			map[string]any{
+				"tag":    tag,
+				"commit": subject,
+				"remote": onRemote,
+				"hint":   "Re-run with --yes to delete the tag",
+			})
+		resp.Data = deletePlan(tag, subject, onRemote).ConfirmationData()
+		return resp, nil
+	}
+
+	if err = git.DeleteLocalTag(tag); err != nil {
+		return errorResponse("TAG_DELETE_FAILED", err.Error(), nil), nil
+	}
+	log.PluginPrint(log.Exec, "\uF00C Deleted local tag %s", log.ColorText(log.ColorGreen, tag))
+
+	if onRemote {
+		if err = git.DeleteRemoteTag(tag); err != nil {
+			return errorResponse("TAG_DELETE_FAILED", err.Error(), nil), nil
+		}
+		log.PluginPrint(log.Exec, "\uF00C Deleted remote tag %s", log.ColorText(log.ColorGreen, tag))
+	}
+
+	return &plugin.Response{
+		Status: "success",
+		Metadata: plugin.ResponseMetadata{
+			Plugin:    PluginName,
+			Version:   PluginVersion,
+			Command:   "undo-last-tag",
+			Timestamp: time.Now(),
+		},
+		Data: map[string]any{
+			"items": []map[string]any{
+				{
+					"property": "Tag",
+					"value":    tag,
+				},
+				{
+					"property": "Commit",
+					"value":    subject,
+				},
+				{
+					"property": "Deleted Remote",
+					"value":    onRemote,
+				},
+				{
+					"property": "Status",
+					"value":    "Tag deleted",
+				},
+			},
+		},
+		RendererHint: "table",
+		ColumnOrder:  []string{"property", "value"},
+	}, nil
+}
+
+// deletePlan describes the deletion of the release tag for the confirmation summary
+func deletePlan(tag, subject string, onRemote bool) plugin.Plan {
+	plan := plugin.Plan{
+		Summary:  fmt.Sprintf("Delete tag %s (%s)", tag, subject),
+		Commands: []string{"git tag -d " + tag},
+	}
+	if onRemote {
+		plan.Remote = "origin"
+		plan.Commands = append(plan.Commands, "git push origin --delete "+tag)
+	}
+	return plan
+}
+
+// EnsureCreatedByNeko guards against deleting tags that do not point at a neko release commit
+func EnsureCreatedByNeko(tag, subject string) error {
+	if !git.IsReleaseCommit(subject) {
+		return fmt.Errorf(
+			"tag %s points at '%s', which is not a release commit like '%s'. Refusing to delete a tag neko did not create",
+			tag, subject, git.ReleaseCommitSubject("x.y.z"),
+		)
+	}
+	return nil
+}
+
+func errorResponse(code, message string, details map[string]any) *plugin.Response {
+	return &plugin.Response{
+		Status: "error",
+		Metadata: plugin.ResponseMetadata{
+			Plugin:    PluginName,
+			Version:   PluginVersion,
+			Command:   "undo-last-tag",
+			Timestamp: time.Now(),
+		},
+		Error: &plugin.ResponseError{
+			Code:    code,
+			Message: message,
+			Details: details,
+		},
+	}
+}
@@ -0,0 +1,121 @@
+package undo
+
+import (
+	"testing"
+
+	"github.com/nekoman-hq/neko-cli/pkg/plugin"
+	"github.com/nekoman-hq/neko-cli/plugin/release/internal/gittest"
+	"github.com/nekoman-hq/neko-cli/plugin/release/pkg/git"
+)
+
+func undoRequest(yes bool) plugin.Request {
+	return plugin.Request{
+		Command: "undo-last-tag",
+		Flags:   map[string]any{},
+		Context: plugin.Context{AssumeYes: yes},
+	}
+}
+
+// releasedRepo creates a repository with the release commits and tags 1.0.0 and 1.1.0 pushed to origin
+func releasedRepo(t *testing.T) string {
+	t.Helper()
+	gittest.NewRepo(t)
+	remote := gittest.NewRemote(t)
+	gittest.Commit(t, "feat: initial")
+	for _, version := range []string{"1.0.0", "1.1.0"} {
+		gittest.Commit(t, git.ReleaseCommitSubject(version))
+		gittest.Run(t, "tag", "v"+version)
+	}
+	gittest.Run(t, "push", "-q", "--tags", "origin", "main")
+	return remote
+}
+
+func tagExists(t *testing.T, dir, tag string) bool {
+	t.Helper()
+	return gittest.RunIn(t, dir, "tag", "--list", tag) != ""
+}
+
+func TestHandleUndoLastTag(t *testing.T) {
+	remote := releasedRepo(t)
+
+	resp, err := HandleUndoLastTag(undoRequest(false))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Error == nil || resp.Error.Code != "CONFIRMATION_REQUIRED" {
+		t.Fatalf("error = %+v, want CONFIRMATION_REQUIRED", resp.Error)
+	}
+	if !tagExists(t, "", "v1.1.0") {
+		t.Fatal("v1.1.0 was deleted without confirmation")
+	}
+
+	resp, err = HandleUndoLastTag(undoRequest(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Status != "success" {
+		t.Fatalf("status = %s, error = %+v", resp.Status, resp.Error)
+	}
+	if tagExists(t, "", "v1.1.0") || tagExists(t, remote, "v1.1.0") {
+		t.Error("v1.1.0 was not deleted locally and on origin")
+	}
+	if !tagExists(t, "", "v1.0.0") || !tagExists(t, remote, "v1.0.0") {
+		t.Error("the previous release tag v1.0.0 was deleted")
+	}
+}
+
+func TestHandleUndoLastTagErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		setup func(t *testing.T)
+		want  string
+	}{
+		{
+			name:  "no tags",
+			setup: func(t *testing.T) { gittest.Commit(t, "feat: initial") },
+			want:  "NO_TAGS",
+		},
+		{
+			name: "not a release commit",
+			setup: func(t *testing.T) {
+				gittest.Commit(t, "feat: hand-made tag")
+				gittest.Run(t, "tag", "v2.0.0")
+			},
+			want: "TAG_NOT_CREATED_BY_NEKO",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gittest.NewRepo(t)
+			tt.setup(t)
+
+			resp, err := HandleUndoLastTag(undoRequest(true))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if resp.Error == nil || resp.Error.Code != tt.want {
+				t.Errorf("error = %+v, want %s", resp.Error, tt.want)
+			}
+		})
+	}
+}
+
+func TestHandleUndoLastTagLocalOnly(t *testing.T) {
+	gittest.NewRepo(t)
+	remote := gittest.NewRemote(t)
+	gittest.Commit(t, git.ReleaseCommitSubject("1.0.0"))
+	gittest.Run(t, "push", "-q", "origin", "main")
+	gittest.Run(t, "tag", "v1.0.0")
+
+	resp, _ := HandleUndoLastTag(undoRequest(true))
+	if resp.Status != "success" {
+		t.Fatalf("status = %s, error = %+v", resp.Status, resp.Error)
+	}
+	if tagExists(t, "", "v1.0.0") {
+		t.Error("the local tag was not deleted")
+	}
+	if tagExists(t, remote, "v1.0.0") {
+		t.Error("the tag showed up on origin")
+	}
+}
@@ -0,0 +1,123 @@
+package validate
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/nekoman-hq/neko-cli/pkg/log"
+	"github.com/nekoman-hq/neko-cli/pkg/plugin"
+	"github.com/nekoman-hq/neko-cli/plugin/release/pkg/config"
+)
+
+// Result is the validation outcome of a single configuration file
+type Result struct {
+	Config string
+	Err    error
+}
+
+// handleValidateAll validates every config matched by the request args.
+// Without --keep-going validation stops at the first invalid config.
+func handleValidateAll(req plugin.Request) *plugin.Response {
+	paths, err := ExpandConfigPaths(req.Args)
+	if err != nil {
+		return &plugin.Response{
+			Status: "error",
+			Metadata: plugin.ResponseMetadata{
+				Plugin:    PluginName,
+				Version:   PluginVersion,
+				Command:   "validate",
+				Timestamp: time.Now(),
+			},
+			Error: &plugin.ResponseError{
+				Code:    "INVALID_PATTERN",
+				Message: err.Error(),
+			},
+		}
+	}
+
+	log.PluginPrint(log.Config, "Validating %d release configurations", len(paths))
+	results := ValidateAll(paths, getFlagBool(req.Flags, "keep-going"))
+
+	failed := 0
+	items := make([]map[string]any, 0, len(results))
+	for _, r := range results {
+		item := map[string]any{
+			"config": r.Config,
+			"status": "valid",
+			"error":  "",
+		}
+		if r.Err != nil {
+			failed++
+			item["status"] = "invalid"
+			item["error"] = r.Err.Error()
+		}
+		items = append(items, item)
+	}
+
+	resp := &plugin.Response{
+		Status: "success",
+		Metadata: plugin.ResponseMetadata{
+			Plugin:    PluginName,
+			Version:   PluginVersion,
+			Command:   "validate",
+			Timestamp: time.Now(),
+		},
+		Data: map[string]any{
+			"items": items,
+		},
+		RendererHint: "table",
+		ColumnOrder:  []string{"config", "status", "error"},
+	}
+
+	if failed > 0 {
+		resp.Status = "error"
+		resp.Error = &plugin.ResponseError{
+			Code:    "VALIDATION_FAILED",
+			Message: fmt.Sprintf("%d of %d configurations are invalid", failed, len(results)),
+		}
+	}
+	return resp
+}
+
+// ExpandConfigPaths resolves glob patterns to config paths.
+// Patterns without matches are kept as-is so they are reported as missing.
+func ExpandConfigPaths(patterns []string) ([]string, error) {
+	var paths []string
+	seen := make(map[string]bool)
+
+	for _, pattern := range patterns {
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid config pattern %s: %w", pattern, err)
+		}
+		if len(matches) == 0 {
+			matches = []string{pattern}
+		}
+
+		for _, m := range matches {
+			if !seen[m] {
+				seen[m] = true
+				paths = append(paths, m)
+			}
+		}
+	}
+	return paths, nil
+}
+
+// ValidateAll validates each config and collects the results.
+// If keepGoing is false, validation stops after the first invalid config.
+func ValidateAll(paths []string, keepGoing bool) []Result {
+	results := make([]Result, 0, len(paths))
+	for _, path := range paths {
+		log.PluginV(log.Config, fmt.Sprintf("Validating %s", log.ColorText(log.ColorCyan, path)))
+
+		_, err := config.LoadConfigFrom(path)
+		results = append(results, Result{Config: path, Err: err})
+
+		if err != nil && !keepGoing {
+			break
+		}
+	}
+	return results
+}
@@ -0,0 +1,120 @@
+package validate
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/nekoman-hq/neko-cli/pkg/plugin"
+	"github.com/nekoman-hq/neko-cli/plugin/release/internal/gittest"
+)
+
+const (
+	validConfig   = `{"project-type": "backend", "release-system": "goreleaser", "version": "1.2.0"}`
+	invalidConfig = `{"project-type": "backend", "release-system": "goreleaser", "version": "one"}`
+)
+
+// monorepo writes a mix of valid and invalid configs into the working directory
+func monorepo(t *testing.T) {
+	t.Helper()
+	t.Chdir(t.TempDir())
+	gittest.WriteFile(t, "api/.release.neko.json", validConfig)
+	gittest.WriteFile(t, "web/.release.neko.json", invalidConfig)
+	gittest.WriteFile(t, "worker/.release.neko.json", validConfig)
+}
+
+func TestExpandConfigPaths(t *testing.T) {
+	monorepo(t)
+
+	paths, err := ExpandConfigPaths([]string{"*/.release.neko.json", "api/.release.neko.json", "missing.json"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		filepath.Join("api", ".release.neko.json"),
+		filepath.Join("web", ".release.neko.json"),
+		filepath.Join("worker", ".release.neko.json"),
+		"missing.json",
+	}
+	if !slices.Equal(paths, want) {
+		t.Errorf("paths = %v, want %v", paths, want)
+	}
+
+	if _, err := ExpandConfigPaths([]string{"[invalid"}); err == nil {
+		t.Error("an invalid pattern was accepted")
+	}
+}
+
+func TestValidateAll(t *testing.T) {
+	monorepo(t)
+	paths := []string{"api/.release.neko.json", "web/.release.neko.json", "worker/.release.neko.json", "missing.json"}
+
+	tests := []struct {
+		keepGoing bool
+		want      []bool
+	}{
+		{true, []bool{true, false, true, false}},
+		{false, []bool{true, false}},
+	}
+
+	for _, tt := range tests {
+		results := ValidateAll(paths, tt.keepGoing)
+		var valid []bool
+		for _, r := range results {
+			valid = append(valid, r.Err == nil)
+		}
+		if !slices.Equal(valid, tt.want) {
+			t.Errorf("ValidateAll keep-going %t valid = %v, want %v", tt.keepGoing, valid, tt.want)
+		}
+	}
+}
+
+func TestHandleValidateAll(t *testing.T) {
+	tests := []struct {
+		name       string
+		args       []string
+		keepGoing  bool
+		wantStatus []string
+		wantCode   string
+	}{
+		{"all valid", []string{"api/.release.neko.json", "worker/.release.neko.json"}, false, []string{"valid", "valid"}, ""},
+		{"keep going", []string{"*/.release.neko.json"}, true, []string{"valid", "invalid", "valid"}, "VALIDATION_FAILED"},
+		{"stop at first failure", []string{"*/.release.neko.json"}, false, []string{"valid", "invalid"}, "VALIDATION_FAILED"},
+		{"invalid pattern", []string{"[invalid"}, false, nil, "INVALID_PATTERN"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			monorepo(t)
+			resp, err := HandleValidate(plugin.Request{
+				Command: "validate",
+				Args:    tt.args,
+				Flags:   map[string]any{"keep-going": tt.keepGoing},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if tt.wantCode == "" && resp.Status != "success" {
+				t.Fatalf("status = %s, error = %+v", resp.Status, resp.Error)
+			}
+			if tt.wantCode != "" && (resp.Error == nil || resp.Error.Code != tt.wantCode) {
+				t.Fatalf("error = %+v, want %s", resp.Error, tt.wantCode)
+			}
+			if tt.wantStatus == nil {
+				return
+			}
+
+			var status []string
+			for _, item := range resp.Data["items"].([]map[string]any) {
+				status = append(status, item["status"].(string))
+				if (item["status"] == "invalid") != (item["error"] != "") {
+					t.Errorf("item %v has no matching error message", item)
+				}
+			}
+			if !slices.Equal(status, tt.wantStatus) {
+				t.Errorf("status = %v, want %v", status, tt.wantStatus)
+			}
+		})
+	}
+}
@@ -10,9 +10,11 @@ package validate
 import (
 	"time"
 
+	"github.com/nekoman-hq/neko-cli/pkg/errors"
 	"github.com/nekoman-hq/neko-cli/pkg/log"
 	"github.com/nekoman-hq/neko-cli/pkg/plugin"
 	"github.com/nekoman-hq/neko-cli/plugin/release/pkg/config"
+	"github.com/nekoman-hq/neko-cli/plugin/release/pkg/release"
 )
 
 const (
@@ -22,6 +24,11 @@ const (
 
 // HandleValidate validates the release configuration
 func HandleValidate(req plugin.Request) (*plugin.Response, error) {
+	// Explicit config paths or globs validate several configs at once, e.g. in a monorepo
+	if len(req.Args) > 0 {
+		return handleValidateAll(req), nil
+	}
+
 	log.PluginPrint(log.Config, "Validating release configuration")
 
 	// Check if config exists
@@ -79,6 +86,26 @@ func HandleValidate(req plugin.Request) (*plugin.Response, error) {
 		}, nil
 	}
 
+	// A config copied into a fork still points at the upstream repository
+	if err := release.CheckRepository(cfg); err != nil {
+		if getFlagBool(req.Flags, "strict") {
+			return &plugin.Response{
+				Status: "error",
+				Metadata: plugin.ResponseMetadata{
+					Plugin:    PluginName,
+					Version:   PluginVersion,
+					Command:   "validate",
+					Timestamp: time.Now(),
+				},
+				Error: &plugin.ResponseError{
+					Code:    "REPOSITORY_MISMATCH",
+					Message: err.Error(),
+				},
+			}, nil
+		}
+		errors.WriteWarning("Repository mismatch", err.Error())
+	}
+
 	log.PluginPrint(log.Config, "Configuration is valid")
 
 	// Check if --show flag is set
@@ -117,11 +144,12 @@ func HandleValidate(req plugin.Request) (*plugin.Response, error) {
 					},
 					{
 						"property": "Status",
-						"value":    "✓ Valid",
+						"value":    log.Sanitize("✓ Valid"),
 					},
 				},
 			},
 			RendererHint: "table",
+			ColumnOrder:  []string{"property", "value"},
 		}, nil
 	}
 
@@ -142,11 +170,12 @@ func HandleValidate(req plugin.Request) (*plugin.Response, error) {
 				},
 				{
 					"property": "Status",
-					"value":    "✓ Valid",
+					"value":    log.Sanitize("✓ Valid"),
 				},
 			},
 		},
 		RendererHint: "table",
+		ColumnOrder:  []string{"property", "value"},
 	}, nil
 }
 
@@ -0,0 +1,50 @@
+package validate
+
+import (
+	"testing"
+
+	"github.com/nekoman-hq/neko-cli/pkg/errors"
+	"github.com/nekoman-hq/neko-cli/pkg/plugin"
+	"github.com/nekoman-hq/neko-cli/plugin/release/internal/gittest"
+)
+
+func TestHandleValidateRepository(t *testing.T) {
+	const config = `{"project-owner": "nekoman-hq", "project-name": "neko-cli", "project-type": "backend", "release-system": "goreleaser", "version": "1.2.0"}`
+	tests := []struct {
+		name     string
+		remote   string
+		strict   bool
+		wantCode string
+		wantWarn bool
+	}{
+		{"match", "git@github.com:nekoman-hq/neko-cli.git", true, "", false},
+		{"fork warns", "git@github.com:someone/neko-cli.git", false, "", true},
+		{"fork strict", "git@github.com:someone/neko-cli.git", true, "REPOSITORY_MISMATCH", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gittest.NewRepo(t)
+			gittest.Run(t, "remote", "add", "origin", tt.remote)
+			gittest.WriteFile(t, ".release.neko.json", config)
+
+			warnings := len(errors.Warnings())
+			resp, err := HandleValidate(plugin.Request{Command: "validate", Flags: map[string]any{"strict": tt.strict}})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if warned := len(errors.Warnings()) > warnings; warned != tt.wantWarn {
+				t.Errorf("warned = %t, want %t", warned, tt.wantWarn)
+			}
+			if tt.wantCode == "" {
+				if resp.Status != "success" {
+					t.Errorf("status = %s, error = %+v", resp.Status, resp.Error)
+				}
+				return
+			}
+			if resp.Error == nil || resp.Error.Code != tt.wantCode {
+				t.Errorf("error = %+v, want %s", resp.Error, tt.wantCode)
+			}
+		})
+	}
+}